@@ -4,28 +4,35 @@ import LexUnicode;
 
 // Lexer rules
 fragment HAS: [Hh][Aa][Ss];
-fragment IS: [Ii][Ss];
+fragment RESULTS: [Rr][Ee][Ss][Uu][Ll][Tt][Ss];
 fragment PROPTYPE: (UnicodeLetter)+;
 fragment PROPKEY: (UnicodeLetter | UnicodeDigit | '_')+;
 
 LPAREN: '(';
 RPAREN: ')';
+COMMA: ',';
+MINUS: '-';
 AND: [Aa][Nn][Dd];
 OR: [Oo][Rr];
-COMPARATOR: (
-		'='
-		| '!='
-		| '~'
-		| '>='
-		| '<='
-		| '>'
-		| '<'
-		| HAS
-		| IS
-	);
+NOT: [Nn][Oo][Tt];
+IN: [Ii][Nn];
+BETWEEN: [Bb][Ee][Tt][Ww][Ee][Ee][Nn];
+IS: [Ii][Ss];
+SET: [Ss][Ee][Tt];
+COMPARATOR: ('=' | '!=' | '~' | '>=' | '<=' | '>' | '<' | HAS);
 STRING: '"' (~["] | '\\"')* '"';
-PROPERTY: (PROPTYPE '.')? PROPKEY;
+PROPERTY: (PROPTYPE '.')? PROPKEY | RESULTS '.' PROPKEY '.' PROPKEY ('.' PROPKEY)?;
 TEXT: (
+		UnicodeLetter
+		| UnicodeDigit
+		| '_'
+		| '.'
+		| '+'
+		| '/'
+		| '\''
+		| '@'
+		| ':'
+	) (
 		UnicodeLetter
 		| UnicodeDigit
 		| '_'
@@ -36,7 +43,7 @@ TEXT: (
 		| '\''
 		| '@'
 		| ':'
-	)+;
+	)*;
 
 WS: [ \t\n\r]+ -> skip; // ignore whitespace
 
@@ -50,10 +57,19 @@ expression:
 	| expression expression			# combinationImpicitAnd
 	| expression OR expression		# combinationOr
 	| LPAREN expression RPAREN		# expressionGrouping
-	| PROPERTY COMPARATOR literal	# condition
+	| negation						# negatedExpression
+	| condition						# explicitCondition
 	| literal						# implicitCondition;
 
+// a negation only applies to a condition or grouping, so free text like "not bad" or "-bob" is still a name search
+negation: (NOT | MINUS) (LPAREN expression RPAREN | negation | condition);
+
+condition:
+	PROPERTY IS NOT? SET								# setCondition
+	| PROPERTY (COMPARATOR | IS) literal				# comparisonCondition
+	| PROPERTY IN LPAREN literal (COMMA literal)* RPAREN	# listCondition
+	| PROPERTY BETWEEN literal AND literal				# rangeCondition;
+
 literal:
-	PROPERTY	# textLiteral // it's not really a property, just indistinguishable by lexer
-	| TEXT		# textLiteral
-	| STRING	# stringLiteral;
\ No newline at end of file
+	MINUS* (PROPERTY | TEXT | NOT | IN | BETWEEN | SET | MINUS)	# textLiteral // property and keywords are just text here
+	| STRING													# stringLiteral;
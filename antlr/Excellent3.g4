@@ -3,10 +3,13 @@ grammar Excellent3;
 import LexUnicode;
 
 COMMA: ',';
+COLON: ':';
 LPAREN: '(';
 RPAREN: ')';
 LBRACK: '[';
 RBRACK: ']';
+LBRACE: '{';
+RBRACE: '}';
 
 DOT: '.';
 ARROW: '=>';
@@ -27,6 +30,13 @@ GT: '>';
 
 AMPERSAND: '&';
 
+AND: '&&';
+OR: '||';
+NOT: '!';
+
+COALESCE: '??';
+QUESTION: '?';
+
 TEXT: '"' (~["] | '\\"')* '"';
 INTEGER: [0-9]+;
 DECIMAL: [0-9]+ '.' [0-9]+;
@@ -44,20 +54,25 @@ ERROR: .;
 parse: expression EOF;
 
 expression:
-	atom												# atomReference
-	| MINUS expression									# negation
-	| expression EXPONENT expression					# exponent
-	| expression op = (TIMES | DIVIDE) expression		# multiplicationOrDivision
-	| expression op = (PLUS | MINUS) expression			# additionOrSubtraction
-	| expression op = (LTE | LT | GTE | GT) expression	# comparison
-	| expression op = (EQ | NEQ) expression				# equality
-	| expression AMPERSAND expression					# concatenation
-	| LPAREN nameList RPAREN ARROW expression			# anonFunction
-	| TEXT												# textLiteral
-	| (INTEGER | DECIMAL)								# numberLiteral
-	| TRUE												# true
-	| FALSE												# false
-	| NULL												# null;
+	atom													# atomReference
+	| MINUS expression										# negation
+	| NOT expression										# not
+	| expression EXPONENT expression						# exponent
+	| expression op = (TIMES | DIVIDE) expression			# multiplicationOrDivision
+	| expression op = (PLUS | MINUS) expression				# additionOrSubtraction
+	| expression op = (LTE | LT | GTE | GT) expression		# comparison
+	| expression op = (EQ | NEQ) expression					# equality
+	| expression AMPERSAND expression						# concatenation
+	| expression AND expression								# and
+	| expression OR expression								# or
+	| expression COALESCE expression						# coalesce
+	| <assoc = right> expression QUESTION expression COLON expression	# ternary
+	| LPAREN nameList RPAREN ARROW expression				# anonFunction
+	| TEXT													# textLiteral
+	| (INTEGER | DECIMAL)									# numberLiteral
+	| TRUE													# true
+	| FALSE													# false
+	| NULL													# null;
 
 // a subset of expressions which can be followed by (), [] or .
 atom:
@@ -65,8 +80,14 @@ atom:
 	| atom DOT (NAME | INTEGER)		# dotLookup
 	| atom LBRACK expression RBRACK	# arrayLookup
 	| LPAREN expression RPAREN		# parentheses
+	| LBRACK parameters? RBRACK		# arrayLiteral
+	| LBRACE properties? RBRACE		# objectLiteral
 	| NAME							# contextReference;
 
 parameters: expression (COMMA expression)* # functionParameters;
 
+properties: property (COMMA property)*;
+
+property: TEXT COLON expression;
+
 nameList: NAME (COMMA NAME)*;
\ No newline at end of file
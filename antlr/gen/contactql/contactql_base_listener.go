@@ -32,11 +32,11 @@ func (s *BaseContactQLListener) EnterImplicitCondition(ctx *ImplicitConditionCon
 // ExitImplicitCondition is called when production implicitCondition is exited.
 func (s *BaseContactQLListener) ExitImplicitCondition(ctx *ImplicitConditionContext) {}
 
-// EnterCondition is called when production condition is entered.
-func (s *BaseContactQLListener) EnterCondition(ctx *ConditionContext) {}
+// EnterExplicitCondition is called when production explicitCondition is entered.
+func (s *BaseContactQLListener) EnterExplicitCondition(ctx *ExplicitConditionContext) {}
 
-// ExitCondition is called when production condition is exited.
-func (s *BaseContactQLListener) ExitCondition(ctx *ConditionContext) {}
+// ExitExplicitCondition is called when production explicitCondition is exited.
+func (s *BaseContactQLListener) ExitExplicitCondition(ctx *ExplicitConditionContext) {}
 
 // EnterCombinationAnd is called when production combinationAnd is entered.
 func (s *BaseContactQLListener) EnterCombinationAnd(ctx *CombinationAndContext) {}
@@ -44,6 +44,12 @@ func (s *BaseContactQLListener) EnterCombinationAnd(ctx *CombinationAndContext)
 // ExitCombinationAnd is called when production combinationAnd is exited.
 func (s *BaseContactQLListener) ExitCombinationAnd(ctx *CombinationAndContext) {}
 
+// EnterNegatedExpression is called when production negatedExpression is entered.
+func (s *BaseContactQLListener) EnterNegatedExpression(ctx *NegatedExpressionContext) {}
+
+// ExitNegatedExpression is called when production negatedExpression is exited.
+func (s *BaseContactQLListener) ExitNegatedExpression(ctx *NegatedExpressionContext) {}
+
 // EnterCombinationImpicitAnd is called when production combinationImpicitAnd is entered.
 func (s *BaseContactQLListener) EnterCombinationImpicitAnd(ctx *CombinationImpicitAndContext) {}
 
@@ -62,6 +68,36 @@ func (s *BaseContactQLListener) EnterExpressionGrouping(ctx *ExpressionGroupingC
 // ExitExpressionGrouping is called when production expressionGrouping is exited.
 func (s *BaseContactQLListener) ExitExpressionGrouping(ctx *ExpressionGroupingContext) {}
 
+// EnterNegation is called when production negation is entered.
+func (s *BaseContactQLListener) EnterNegation(ctx *NegationContext) {}
+
+// ExitNegation is called when production negation is exited.
+func (s *BaseContactQLListener) ExitNegation(ctx *NegationContext) {}
+
+// EnterSetCondition is called when production setCondition is entered.
+func (s *BaseContactQLListener) EnterSetCondition(ctx *SetConditionContext) {}
+
+// ExitSetCondition is called when production setCondition is exited.
+func (s *BaseContactQLListener) ExitSetCondition(ctx *SetConditionContext) {}
+
+// EnterComparisonCondition is called when production comparisonCondition is entered.
+func (s *BaseContactQLListener) EnterComparisonCondition(ctx *ComparisonConditionContext) {}
+
+// ExitComparisonCondition is called when production comparisonCondition is exited.
+func (s *BaseContactQLListener) ExitComparisonCondition(ctx *ComparisonConditionContext) {}
+
+// EnterListCondition is called when production listCondition is entered.
+func (s *BaseContactQLListener) EnterListCondition(ctx *ListConditionContext) {}
+
+// ExitListCondition is called when production listCondition is exited.
+func (s *BaseContactQLListener) ExitListCondition(ctx *ListConditionContext) {}
+
+// EnterRangeCondition is called when production rangeCondition is entered.
+func (s *BaseContactQLListener) EnterRangeCondition(ctx *RangeConditionContext) {}
+
+// ExitRangeCondition is called when production rangeCondition is exited.
+func (s *BaseContactQLListener) ExitRangeCondition(ctx *RangeConditionContext) {}
+
 // EnterTextLiteral is called when production textLiteral is entered.
 func (s *BaseContactQLListener) EnterTextLiteral(ctx *TextLiteralContext) {}
 
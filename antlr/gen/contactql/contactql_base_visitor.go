@@ -15,7 +15,7 @@ func (v *BaseContactQLVisitor) VisitImplicitCondition(ctx *ImplicitConditionCont
 	return v.VisitChildren(ctx)
 }
 
-func (v *BaseContactQLVisitor) VisitCondition(ctx *ConditionContext) interface{} {
+func (v *BaseContactQLVisitor) VisitExplicitCondition(ctx *ExplicitConditionContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
@@ -23,6 +23,10 @@ func (v *BaseContactQLVisitor) VisitCombinationAnd(ctx *CombinationAndContext) i
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseContactQLVisitor) VisitNegatedExpression(ctx *NegatedExpressionContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseContactQLVisitor) VisitCombinationImpicitAnd(ctx *CombinationImpicitAndContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -35,6 +39,26 @@ func (v *BaseContactQLVisitor) VisitExpressionGrouping(ctx *ExpressionGroupingCo
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseContactQLVisitor) VisitNegation(ctx *NegationContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseContactQLVisitor) VisitSetCondition(ctx *SetConditionContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseContactQLVisitor) VisitComparisonCondition(ctx *ComparisonConditionContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseContactQLVisitor) VisitListCondition(ctx *ListConditionContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseContactQLVisitor) VisitRangeCondition(ctx *RangeConditionContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseContactQLVisitor) VisitTextLiteral(ctx *TextLiteralContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -13,12 +13,15 @@ type ContactQLListener interface {
 	// EnterImplicitCondition is called when entering the implicitCondition production.
 	EnterImplicitCondition(c *ImplicitConditionContext)
 
-	// EnterCondition is called when entering the condition production.
-	EnterCondition(c *ConditionContext)
+	// EnterExplicitCondition is called when entering the explicitCondition production.
+	EnterExplicitCondition(c *ExplicitConditionContext)
 
 	// EnterCombinationAnd is called when entering the combinationAnd production.
 	EnterCombinationAnd(c *CombinationAndContext)
 
+	// EnterNegatedExpression is called when entering the negatedExpression production.
+	EnterNegatedExpression(c *NegatedExpressionContext)
+
 	// EnterCombinationImpicitAnd is called when entering the combinationImpicitAnd production.
 	EnterCombinationImpicitAnd(c *CombinationImpicitAndContext)
 
@@ -28,6 +31,21 @@ type ContactQLListener interface {
 	// EnterExpressionGrouping is called when entering the expressionGrouping production.
 	EnterExpressionGrouping(c *ExpressionGroupingContext)
 
+	// EnterNegation is called when entering the negation production.
+	EnterNegation(c *NegationContext)
+
+	// EnterSetCondition is called when entering the setCondition production.
+	EnterSetCondition(c *SetConditionContext)
+
+	// EnterComparisonCondition is called when entering the comparisonCondition production.
+	EnterComparisonCondition(c *ComparisonConditionContext)
+
+	// EnterListCondition is called when entering the listCondition production.
+	EnterListCondition(c *ListConditionContext)
+
+	// EnterRangeCondition is called when entering the rangeCondition production.
+	EnterRangeCondition(c *RangeConditionContext)
+
 	// EnterTextLiteral is called when entering the textLiteral production.
 	EnterTextLiteral(c *TextLiteralContext)
 
@@ -40,12 +58,15 @@ type ContactQLListener interface {
 	// ExitImplicitCondition is called when exiting the implicitCondition production.
 	ExitImplicitCondition(c *ImplicitConditionContext)
 
-	// ExitCondition is called when exiting the condition production.
-	ExitCondition(c *ConditionContext)
+	// ExitExplicitCondition is called when exiting the explicitCondition production.
+	ExitExplicitCondition(c *ExplicitConditionContext)
 
 	// ExitCombinationAnd is called when exiting the combinationAnd production.
 	ExitCombinationAnd(c *CombinationAndContext)
 
+	// ExitNegatedExpression is called when exiting the negatedExpression production.
+	ExitNegatedExpression(c *NegatedExpressionContext)
+
 	// ExitCombinationImpicitAnd is called when exiting the combinationImpicitAnd production.
 	ExitCombinationImpicitAnd(c *CombinationImpicitAndContext)
 
@@ -55,6 +76,21 @@ type ContactQLListener interface {
 	// ExitExpressionGrouping is called when exiting the expressionGrouping production.
 	ExitExpressionGrouping(c *ExpressionGroupingContext)
 
+	// ExitNegation is called when exiting the negation production.
+	ExitNegation(c *NegationContext)
+
+	// ExitSetCondition is called when exiting the setCondition production.
+	ExitSetCondition(c *SetConditionContext)
+
+	// ExitComparisonCondition is called when exiting the comparisonCondition production.
+	ExitComparisonCondition(c *ComparisonConditionContext)
+
+	// ExitListCondition is called when exiting the listCondition production.
+	ExitListCondition(c *ListConditionContext)
+
+	// ExitRangeCondition is called when exiting the rangeCondition production.
+	ExitRangeCondition(c *RangeConditionContext)
+
 	// ExitTextLiteral is called when exiting the textLiteral production.
 	ExitTextLiteral(c *TextLiteralContext)
 
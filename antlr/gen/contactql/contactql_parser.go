@@ -32,33 +32,55 @@ var ContactQLParserStaticData struct {
 func contactqlParserInit() {
 	staticData := &ContactQLParserStaticData
 	staticData.LiteralNames = []string{
-		"", "'('", "')'",
+		"", "'('", "')'", "','", "'-'",
 	}
 	staticData.SymbolicNames = []string{
-		"", "LPAREN", "RPAREN", "AND", "OR", "COMPARATOR", "STRING", "PROPERTY",
-		"TEXT", "WS", "ERROR",
+		"", "LPAREN", "RPAREN", "COMMA", "MINUS", "AND", "OR", "NOT", "IN",
+		"BETWEEN", "IS", "SET", "COMPARATOR", "STRING", "PROPERTY", "TEXT", "WS",
+		"ERROR",
 	}
 	staticData.RuleNames = []string{
-		"parse", "expression", "literal",
+		"parse", "expression", "negation", "condition", "literal",
 	}
 	staticData.PredictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 1, 10, 39, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 1, 0, 1, 0, 1, 0, 1,
-		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 19, 8, 1, 1, 1,
-		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 5, 1, 29, 8, 1, 10, 1, 12, 1,
-		32, 9, 1, 1, 2, 1, 2, 1, 2, 3, 2, 37, 8, 2, 1, 2, 0, 1, 2, 3, 0, 2, 4,
-		0, 0, 42, 0, 6, 1, 0, 0, 0, 2, 18, 1, 0, 0, 0, 4, 36, 1, 0, 0, 0, 6, 7,
-		3, 2, 1, 0, 7, 8, 5, 0, 0, 1, 8, 1, 1, 0, 0, 0, 9, 10, 6, 1, -1, 0, 10,
-		11, 5, 1, 0, 0, 11, 12, 3, 2, 1, 0, 12, 13, 5, 2, 0, 0, 13, 19, 1, 0, 0,
-		0, 14, 15, 5, 7, 0, 0, 15, 16, 5, 5, 0, 0, 16, 19, 3, 4, 2, 0, 17, 19,
-		3, 4, 2, 0, 18, 9, 1, 0, 0, 0, 18, 14, 1, 0, 0, 0, 18, 17, 1, 0, 0, 0,
-		19, 30, 1, 0, 0, 0, 20, 21, 10, 6, 0, 0, 21, 22, 5, 3, 0, 0, 22, 29, 3,
-		2, 1, 7, 23, 24, 10, 5, 0, 0, 24, 29, 3, 2, 1, 6, 25, 26, 10, 4, 0, 0,
-		26, 27, 5, 4, 0, 0, 27, 29, 3, 2, 1, 5, 28, 20, 1, 0, 0, 0, 28, 23, 1,
-		0, 0, 0, 28, 25, 1, 0, 0, 0, 29, 32, 1, 0, 0, 0, 30, 28, 1, 0, 0, 0, 30,
-		31, 1, 0, 0, 0, 31, 3, 1, 0, 0, 0, 32, 30, 1, 0, 0, 0, 33, 37, 5, 7, 0,
-		0, 34, 37, 5, 8, 0, 0, 35, 37, 5, 6, 0, 0, 36, 33, 1, 0, 0, 0, 36, 34,
-		1, 0, 0, 0, 36, 35, 1, 0, 0, 0, 37, 5, 1, 0, 0, 0, 4, 18, 28, 30, 36,
+		4, 1, 17, 86, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
+		4, 1, 0, 1, 0, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3,
+		1, 22, 8, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 5, 1, 32,
+		8, 1, 10, 1, 12, 1, 35, 9, 1, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2,
+		3, 2, 44, 8, 2, 1, 3, 1, 3, 1, 3, 3, 3, 49, 8, 3, 1, 3, 1, 3, 1, 3, 1,
+		3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 5, 3, 61, 8, 3, 10, 3, 12, 3, 64,
+		9, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 3, 3, 74, 8, 3, 1,
+		4, 5, 4, 77, 8, 4, 10, 4, 12, 4, 80, 9, 4, 1, 4, 1, 4, 3, 4, 84, 8, 4,
+		1, 4, 0, 1, 2, 5, 0, 2, 4, 6, 8, 0, 3, 2, 0, 4, 4, 7, 7, 2, 0, 10, 10,
+		12, 12, 4, 0, 4, 4, 7, 9, 11, 11, 14, 15, 95, 0, 10, 1, 0, 0, 0, 2, 21,
+		1, 0, 0, 0, 4, 36, 1, 0, 0, 0, 6, 73, 1, 0, 0, 0, 8, 83, 1, 0, 0, 0, 10,
+		11, 3, 2, 1, 0, 11, 12, 5, 0, 0, 1, 12, 1, 1, 0, 0, 0, 13, 14, 6, 1, -1,
+		0, 14, 15, 5, 1, 0, 0, 15, 16, 3, 2, 1, 0, 16, 17, 5, 2, 0, 0, 17, 22,
+		1, 0, 0, 0, 18, 22, 3, 4, 2, 0, 19, 22, 3, 6, 3, 0, 20, 22, 3, 8, 4, 0,
+		21, 13, 1, 0, 0, 0, 21, 18, 1, 0, 0, 0, 21, 19, 1, 0, 0, 0, 21, 20, 1,
+		0, 0, 0, 22, 33, 1, 0, 0, 0, 23, 24, 10, 7, 0, 0, 24, 25, 5, 5, 0, 0, 25,
+		32, 3, 2, 1, 8, 26, 27, 10, 6, 0, 0, 27, 32, 3, 2, 1, 7, 28, 29, 10, 5,
+		0, 0, 29, 30, 5, 6, 0, 0, 30, 32, 3, 2, 1, 6, 31, 23, 1, 0, 0, 0, 31, 26,
+		1, 0, 0, 0, 31, 28, 1, 0, 0, 0, 32, 35, 1, 0, 0, 0, 33, 31, 1, 0, 0, 0,
+		33, 34, 1, 0, 0, 0, 34, 3, 1, 0, 0, 0, 35, 33, 1, 0, 0, 0, 36, 43, 7, 0,
+		0, 0, 37, 38, 5, 1, 0, 0, 38, 39, 3, 2, 1, 0, 39, 40, 5, 2, 0, 0, 40, 44,
+		1, 0, 0, 0, 41, 44, 3, 4, 2, 0, 42, 44, 3, 6, 3, 0, 43, 37, 1, 0, 0, 0,
+		43, 41, 1, 0, 0, 0, 43, 42, 1, 0, 0, 0, 44, 5, 1, 0, 0, 0, 45, 46, 5, 14,
+		0, 0, 46, 48, 5, 10, 0, 0, 47, 49, 5, 7, 0, 0, 48, 47, 1, 0, 0, 0, 48,
+		49, 1, 0, 0, 0, 49, 50, 1, 0, 0, 0, 50, 74, 5, 11, 0, 0, 51, 52, 5, 14,
+		0, 0, 52, 53, 7, 1, 0, 0, 53, 74, 3, 8, 4, 0, 54, 55, 5, 14, 0, 0, 55,
+		56, 5, 8, 0, 0, 56, 57, 5, 1, 0, 0, 57, 62, 3, 8, 4, 0, 58, 59, 5, 3, 0,
+		0, 59, 61, 3, 8, 4, 0, 60, 58, 1, 0, 0, 0, 61, 64, 1, 0, 0, 0, 62, 60,
+		1, 0, 0, 0, 62, 63, 1, 0, 0, 0, 63, 65, 1, 0, 0, 0, 64, 62, 1, 0, 0, 0,
+		65, 66, 5, 2, 0, 0, 66, 74, 1, 0, 0, 0, 67, 68, 5, 14, 0, 0, 68, 69, 5,
+		9, 0, 0, 69, 70, 3, 8, 4, 0, 70, 71, 5, 5, 0, 0, 71, 72, 3, 8, 4, 0, 72,
+		74, 1, 0, 0, 0, 73, 45, 1, 0, 0, 0, 73, 51, 1, 0, 0, 0, 73, 54, 1, 0, 0,
+		0, 73, 67, 1, 0, 0, 0, 74, 7, 1, 0, 0, 0, 75, 77, 5, 4, 0, 0, 76, 75, 1,
+		0, 0, 0, 77, 80, 1, 0, 0, 0, 78, 76, 1, 0, 0, 0, 78, 79, 1, 0, 0, 0, 79,
+		81, 1, 0, 0, 0, 80, 78, 1, 0, 0, 0, 81, 84, 7, 2, 0, 0, 82, 84, 5, 13,
+		0, 0, 83, 78, 1, 0, 0, 0, 83, 82, 1, 0, 0, 0, 84, 9, 1, 0, 0, 0, 9, 21,
+		31, 33, 43, 48, 62, 73, 78, 83,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -99,21 +121,30 @@ const (
 	ContactQLParserEOF        = antlr.TokenEOF
 	ContactQLParserLPAREN     = 1
 	ContactQLParserRPAREN     = 2
-	ContactQLParserAND        = 3
-	ContactQLParserOR         = 4
-	ContactQLParserCOMPARATOR = 5
-	ContactQLParserSTRING     = 6
-	ContactQLParserPROPERTY   = 7
-	ContactQLParserTEXT       = 8
-	ContactQLParserWS         = 9
-	ContactQLParserERROR      = 10
+	ContactQLParserCOMMA      = 3
+	ContactQLParserMINUS      = 4
+	ContactQLParserAND        = 5
+	ContactQLParserOR         = 6
+	ContactQLParserNOT        = 7
+	ContactQLParserIN         = 8
+	ContactQLParserBETWEEN    = 9
+	ContactQLParserIS         = 10
+	ContactQLParserSET        = 11
+	ContactQLParserCOMPARATOR = 12
+	ContactQLParserSTRING     = 13
+	ContactQLParserPROPERTY   = 14
+	ContactQLParserTEXT       = 15
+	ContactQLParserWS         = 16
+	ContactQLParserERROR      = 17
 )
 
 // ContactQLParser rules.
 const (
 	ContactQLParserRULE_parse      = 0
 	ContactQLParserRULE_expression = 1
-	ContactQLParserRULE_literal    = 2
+	ContactQLParserRULE_negation   = 2
+	ContactQLParserRULE_condition  = 3
+	ContactQLParserRULE_literal    = 4
 )
 
 // IParseContext is an interface to support dynamic dispatch.
@@ -218,11 +249,11 @@ func (p *ContactQLParser) Parse() (localctx IParseContext) {
 	p.EnterRule(localctx, 0, ContactQLParserRULE_parse)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(6)
+		p.SetState(10)
 		p.expression(0)
 	}
 	{
-		p.SetState(7)
+		p.SetState(11)
 		p.Match(ContactQLParserEOF)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -353,12 +384,12 @@ func (s *ImplicitConditionContext) Accept(visitor antlr.ParseTreeVisitor) interf
 	}
 }
 
-type ConditionContext struct {
+type ExplicitConditionContext struct {
 	ExpressionContext
 }
 
-func NewConditionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ConditionContext {
-	var p = new(ConditionContext)
+func NewExplicitConditionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExplicitConditionContext {
+	var p = new(ExplicitConditionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -367,22 +398,14 @@ func NewConditionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *Cond
 	return p
 }
 
-func (s *ConditionContext) GetRuleContext() antlr.RuleContext {
+func (s *ExplicitConditionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ConditionContext) PROPERTY() antlr.TerminalNode {
-	return s.GetToken(ContactQLParserPROPERTY, 0)
-}
-
-func (s *ConditionContext) COMPARATOR() antlr.TerminalNode {
-	return s.GetToken(ContactQLParserCOMPARATOR, 0)
-}
-
-func (s *ConditionContext) Literal() ILiteralContext {
+func (s *ExplicitConditionContext) Condition() IConditionContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(ILiteralContext); ok {
+		if _, ok := ctx.(IConditionContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -392,25 +415,25 @@ func (s *ConditionContext) Literal() ILiteralContext {
 		return nil
 	}
 
-	return t.(ILiteralContext)
+	return t.(IConditionContext)
 }
 
-func (s *ConditionContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ExplicitConditionContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(ContactQLListener); ok {
-		listenerT.EnterCondition(s)
+		listenerT.EnterExplicitCondition(s)
 	}
 }
 
-func (s *ConditionContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ExplicitConditionContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(ContactQLListener); ok {
-		listenerT.ExitCondition(s)
+		listenerT.ExitExplicitCondition(s)
 	}
 }
 
-func (s *ConditionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *ExplicitConditionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case ContactQLVisitor:
-		return t.VisitCondition(s)
+		return t.VisitExplicitCondition(s)
 
 	default:
 		return t.VisitChildren(s)
@@ -502,6 +525,62 @@ func (s *CombinationAndContext) Accept(visitor antlr.ParseTreeVisitor) interface
 	}
 }
 
+type NegatedExpressionContext struct {
+	ExpressionContext
+}
+
+func NewNegatedExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *NegatedExpressionContext {
+	var p = new(NegatedExpressionContext)
+
+	InitEmptyExpressionContext(&p.ExpressionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *NegatedExpressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *NegatedExpressionContext) Negation() INegationContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(INegationContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(INegationContext)
+}
+
+func (s *NegatedExpressionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ContactQLListener); ok {
+		listenerT.EnterNegatedExpression(s)
+	}
+}
+
+func (s *NegatedExpressionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ContactQLListener); ok {
+		listenerT.ExitNegatedExpression(s)
+	}
+}
+
+func (s *NegatedExpressionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case ContactQLVisitor:
+		return t.VisitNegatedExpression(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
 type CombinationImpicitAndContext struct {
 	ExpressionContext
 }
@@ -748,7 +827,7 @@ func (p *ContactQLParser) expression(_p int) (localctx IExpressionContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(18)
+	p.SetState(21)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -761,7 +840,7 @@ func (p *ContactQLParser) expression(_p int) (localctx IExpressionContext) {
 		_prevctx = localctx
 
 		{
-			p.SetState(10)
+			p.SetState(14)
 			p.Match(ContactQLParserLPAREN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -769,11 +848,11 @@ func (p *ContactQLParser) expression(_p int) (localctx IExpressionContext) {
 			}
 		}
 		{
-			p.SetState(11)
+			p.SetState(15)
 			p.expression(0)
 		}
 		{
-			p.SetState(12)
+			p.SetState(16)
 			p.Match(ContactQLParserRPAREN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -782,36 +861,29 @@ func (p *ContactQLParser) expression(_p int) (localctx IExpressionContext) {
 		}
 
 	case 2:
-		localctx = NewConditionContext(p, localctx)
+		localctx = NewNegatedExpressionContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(14)
-			p.Match(ContactQLParserPROPERTY)
-			if p.HasError() {
-				// Recognition error - abort rule
-				goto errorExit
-			}
-		}
-		{
-			p.SetState(15)
-			p.Match(ContactQLParserCOMPARATOR)
-			if p.HasError() {
-				// Recognition error - abort rule
-				goto errorExit
-			}
+			p.SetState(18)
+			p.Negation()
 		}
+
+	case 3:
+		localctx = NewExplicitConditionContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
 		{
-			p.SetState(16)
-			p.Literal()
+			p.SetState(19)
+			p.Condition()
 		}
 
-	case 3:
+	case 4:
 		localctx = NewImplicitConditionContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(17)
+			p.SetState(20)
 			p.Literal()
 		}
 
@@ -819,7 +891,7 @@ func (p *ContactQLParser) expression(_p int) (localctx IExpressionContext) {
 		goto errorExit
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(30)
+	p.SetState(33)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -834,7 +906,7 @@ func (p *ContactQLParser) expression(_p int) (localctx IExpressionContext) {
 				p.TriggerExitRuleEvent()
 			}
 			_prevctx = localctx
-			p.SetState(28)
+			p.SetState(31)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -844,14 +916,14 @@ func (p *ContactQLParser) expression(_p int) (localctx IExpressionContext) {
 			case 1:
 				localctx = NewCombinationAndContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, ContactQLParserRULE_expression)
-				p.SetState(20)
+				p.SetState(23)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 6)) {
-					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 6)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 7)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 7)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(21)
+					p.SetState(24)
 					p.Match(ContactQLParserAND)
 					if p.HasError() {
 						// Recognition error - abort rule
@@ -859,35 +931,35 @@ func (p *ContactQLParser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(22)
-					p.expression(7)
+					p.SetState(25)
+					p.expression(8)
 				}
 
 			case 2:
 				localctx = NewCombinationImpicitAndContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, ContactQLParserRULE_expression)
-				p.SetState(23)
+				p.SetState(26)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 5)) {
-					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 5)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 6)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 6)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(24)
-					p.expression(6)
+					p.SetState(27)
+					p.expression(7)
 				}
 
 			case 3:
 				localctx = NewCombinationOrContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, ContactQLParserRULE_expression)
-				p.SetState(25)
+				p.SetState(28)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 4)) {
-					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 4)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 5)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 5)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(26)
+					p.SetState(29)
 					p.Match(ContactQLParserOR)
 					if p.HasError() {
 						// Recognition error - abort rule
@@ -895,8 +967,8 @@ func (p *ContactQLParser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(27)
-					p.expression(5)
+					p.SetState(30)
+					p.expression(6)
 				}
 
 			case antlr.ATNInvalidAltNumber:
@@ -904,7 +976,7 @@ func (p *ContactQLParser) expression(_p int) (localctx IExpressionContext) {
 			}
 
 		}
-		p.SetState(32)
+		p.SetState(35)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -928,196 +1000,208 @@ errorExit:
 	goto errorExit // Trick to prevent compiler error if the label is not used
 }
 
-// ILiteralContext is an interface to support dynamic dispatch.
-type ILiteralContext interface {
+// INegationContext is an interface to support dynamic dispatch.
+type INegationContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
-	// IsLiteralContext differentiates from other interfaces.
-	IsLiteralContext()
+
+	// Getter signatures
+	NOT() antlr.TerminalNode
+	MINUS() antlr.TerminalNode
+	LPAREN() antlr.TerminalNode
+	Expression() IExpressionContext
+	RPAREN() antlr.TerminalNode
+	Negation() INegationContext
+	Condition() IConditionContext
+
+	// IsNegationContext differentiates from other interfaces.
+	IsNegationContext()
 }
 
-type LiteralContext struct {
+type NegationContext struct {
 	antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyLiteralContext() *LiteralContext {
-	var p = new(LiteralContext)
+func NewEmptyNegationContext() *NegationContext {
+	var p = new(NegationContext)
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = ContactQLParserRULE_literal
+	p.RuleIndex = ContactQLParserRULE_negation
 	return p
 }
 
-func InitEmptyLiteralContext(p *LiteralContext) {
+func InitEmptyNegationContext(p *NegationContext) {
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = ContactQLParserRULE_literal
+	p.RuleIndex = ContactQLParserRULE_negation
 }
 
-func (*LiteralContext) IsLiteralContext() {}
+func (*NegationContext) IsNegationContext() {}
 
-func NewLiteralContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *LiteralContext {
-	var p = new(LiteralContext)
+func NewNegationContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *NegationContext {
+	var p = new(NegationContext)
 
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = ContactQLParserRULE_literal
+	p.RuleIndex = ContactQLParserRULE_negation
 
 	return p
 }
 
-func (s *LiteralContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *LiteralContext) CopyAll(ctx *LiteralContext) {
-	s.CopyFrom(&ctx.BaseParserRuleContext)
-}
+func (s *NegationContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *LiteralContext) GetRuleContext() antlr.RuleContext {
-	return s
+func (s *NegationContext) NOT() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserNOT, 0)
 }
 
-func (s *LiteralContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
+func (s *NegationContext) MINUS() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserMINUS, 0)
 }
 
-type StringLiteralContext struct {
-	LiteralContext
+func (s *NegationContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserLPAREN, 0)
 }
 
-func NewStringLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *StringLiteralContext {
-	var p = new(StringLiteralContext)
-
-	InitEmptyLiteralContext(&p.LiteralContext)
-	p.parser = parser
-	p.CopyAll(ctx.(*LiteralContext))
-
-	return p
-}
+func (s *NegationContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
 
-func (s *StringLiteralContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
+	if t == nil {
+		return nil
+	}
 
-func (s *StringLiteralContext) STRING() antlr.TerminalNode {
-	return s.GetToken(ContactQLParserSTRING, 0)
+	return t.(IExpressionContext)
 }
 
-func (s *StringLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(ContactQLListener); ok {
-		listenerT.EnterStringLiteral(s)
-	}
+func (s *NegationContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserRPAREN, 0)
 }
 
-func (s *StringLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(ContactQLListener); ok {
-		listenerT.ExitStringLiteral(s)
+func (s *NegationContext) Negation() INegationContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(INegationContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
 	}
-}
-
-func (s *StringLiteralContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
-	switch t := visitor.(type) {
-	case ContactQLVisitor:
-		return t.VisitStringLiteral(s)
 
-	default:
-		return t.VisitChildren(s)
+	if t == nil {
+		return nil
 	}
-}
 
-type TextLiteralContext struct {
-	LiteralContext
+	return t.(INegationContext)
 }
 
-func NewTextLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *TextLiteralContext {
-	var p = new(TextLiteralContext)
+func (s *NegationContext) Condition() IConditionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IConditionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
 
-	InitEmptyLiteralContext(&p.LiteralContext)
-	p.parser = parser
-	p.CopyAll(ctx.(*LiteralContext))
+	if t == nil {
+		return nil
+	}
 
-	return p
+	return t.(IConditionContext)
 }
 
-func (s *TextLiteralContext) GetRuleContext() antlr.RuleContext {
+func (s *NegationContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *TextLiteralContext) PROPERTY() antlr.TerminalNode {
-	return s.GetToken(ContactQLParserPROPERTY, 0)
-}
-
-func (s *TextLiteralContext) TEXT() antlr.TerminalNode {
-	return s.GetToken(ContactQLParserTEXT, 0)
+func (s *NegationContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *TextLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *NegationContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(ContactQLListener); ok {
-		listenerT.EnterTextLiteral(s)
+		listenerT.EnterNegation(s)
 	}
 }
 
-func (s *TextLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *NegationContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(ContactQLListener); ok {
-		listenerT.ExitTextLiteral(s)
+		listenerT.ExitNegation(s)
 	}
 }
 
-func (s *TextLiteralContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *NegationContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case ContactQLVisitor:
-		return t.VisitTextLiteral(s)
+		return t.VisitNegation(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-func (p *ContactQLParser) Literal() (localctx ILiteralContext) {
-	localctx = NewLiteralContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 4, ContactQLParserRULE_literal)
-	p.SetState(36)
+func (p *ContactQLParser) Negation() (localctx INegationContext) {
+	localctx = NewNegationContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 4, ContactQLParserRULE_negation)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(36)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == ContactQLParserMINUS || _la == ContactQLParserNOT) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+	p.SetState(43)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
 	switch p.GetTokenStream().LA(1) {
-	case ContactQLParserPROPERTY:
-		localctx = NewTextLiteralContext(p, localctx)
-		p.EnterOuterAlt(localctx, 1)
+	case ContactQLParserLPAREN:
 		{
-			p.SetState(33)
-			p.Match(ContactQLParserPROPERTY)
+			p.SetState(37)
+			p.Match(ContactQLParserLPAREN)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
-
-	case ContactQLParserTEXT:
-		localctx = NewTextLiteralContext(p, localctx)
-		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(34)
-			p.Match(ContactQLParserTEXT)
+			p.SetState(38)
+			p.expression(0)
+		}
+		{
+			p.SetState(39)
+			p.Match(ContactQLParserRPAREN)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
 
-	case ContactQLParserSTRING:
-		localctx = NewStringLiteralContext(p, localctx)
-		p.EnterOuterAlt(localctx, 3)
+	case ContactQLParserMINUS, ContactQLParserNOT:
 		{
-			p.SetState(35)
-			p.Match(ContactQLParserSTRING)
-			if p.HasError() {
-				// Recognition error - abort rule
-				goto errorExit
-			}
+			p.SetState(41)
+			p.Negation()
+		}
+
+	case ContactQLParserPROPERTY:
+		{
+			p.SetState(42)
+			p.Condition()
 		}
 
 	default:
@@ -1138,30 +1222,872 @@ errorExit:
 	goto errorExit // Trick to prevent compiler error if the label is not used
 }
 
-func (p *ContactQLParser) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex int) bool {
-	switch ruleIndex {
-	case 1:
-		var t *ExpressionContext = nil
-		if localctx != nil {
-			t = localctx.(*ExpressionContext)
-		}
-		return p.Expression_Sempred(t, predIndex)
+// IConditionContext is an interface to support dynamic dispatch.
+type IConditionContext interface {
+	antlr.ParserRuleContext
 
-	default:
-		panic("No predicate with index: " + fmt.Sprint(ruleIndex))
-	}
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+	// IsConditionContext differentiates from other interfaces.
+	IsConditionContext()
 }
 
-func (p *ContactQLParser) Expression_Sempred(localctx antlr.RuleContext, predIndex int) bool {
-	switch predIndex {
-	case 0:
-		return p.Precpred(p.GetParserRuleContext(), 6)
-
-	case 1:
-		return p.Precpred(p.GetParserRuleContext(), 5)
+type ConditionContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyConditionContext() *ConditionContext {
+	var p = new(ConditionContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = ContactQLParserRULE_condition
+	return p
+}
+
+func InitEmptyConditionContext(p *ConditionContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = ContactQLParserRULE_condition
+}
+
+func (*ConditionContext) IsConditionContext() {}
+
+func NewConditionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ConditionContext {
+	var p = new(ConditionContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ContactQLParserRULE_condition
+
+	return p
+}
+
+func (s *ConditionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ConditionContext) CopyAll(ctx *ConditionContext) {
+	s.CopyFrom(&ctx.BaseParserRuleContext)
+}
+
+func (s *ConditionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ConditionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+type SetConditionContext struct {
+	ConditionContext
+}
+
+func NewSetConditionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SetConditionContext {
+	var p = new(SetConditionContext)
+
+	InitEmptyConditionContext(&p.ConditionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ConditionContext))
+
+	return p
+}
+
+func (s *SetConditionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SetConditionContext) PROPERTY() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserPROPERTY, 0)
+}
+
+func (s *SetConditionContext) IS() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserIS, 0)
+}
+
+func (s *SetConditionContext) SET() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserSET, 0)
+}
+
+func (s *SetConditionContext) NOT() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserNOT, 0)
+}
+
+func (s *SetConditionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ContactQLListener); ok {
+		listenerT.EnterSetCondition(s)
+	}
+}
+
+func (s *SetConditionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ContactQLListener); ok {
+		listenerT.ExitSetCondition(s)
+	}
+}
+
+func (s *SetConditionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case ContactQLVisitor:
+		return t.VisitSetCondition(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+type RangeConditionContext struct {
+	ConditionContext
+}
+
+func NewRangeConditionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *RangeConditionContext {
+	var p = new(RangeConditionContext)
+
+	InitEmptyConditionContext(&p.ConditionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ConditionContext))
+
+	return p
+}
+
+func (s *RangeConditionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *RangeConditionContext) PROPERTY() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserPROPERTY, 0)
+}
+
+func (s *RangeConditionContext) BETWEEN() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserBETWEEN, 0)
+}
+
+func (s *RangeConditionContext) AllLiteral() []ILiteralContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(ILiteralContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]ILiteralContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(ILiteralContext); ok {
+			tst[i] = t.(ILiteralContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *RangeConditionContext) Literal(i int) ILiteralContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ILiteralContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILiteralContext)
+}
+
+func (s *RangeConditionContext) AND() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserAND, 0)
+}
+
+func (s *RangeConditionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ContactQLListener); ok {
+		listenerT.EnterRangeCondition(s)
+	}
+}
+
+func (s *RangeConditionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ContactQLListener); ok {
+		listenerT.ExitRangeCondition(s)
+	}
+}
+
+func (s *RangeConditionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case ContactQLVisitor:
+		return t.VisitRangeCondition(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+type ComparisonConditionContext struct {
+	ConditionContext
+}
+
+func NewComparisonConditionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ComparisonConditionContext {
+	var p = new(ComparisonConditionContext)
+
+	InitEmptyConditionContext(&p.ConditionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ConditionContext))
+
+	return p
+}
+
+func (s *ComparisonConditionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ComparisonConditionContext) PROPERTY() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserPROPERTY, 0)
+}
+
+func (s *ComparisonConditionContext) Literal() ILiteralContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ILiteralContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILiteralContext)
+}
+
+func (s *ComparisonConditionContext) COMPARATOR() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserCOMPARATOR, 0)
+}
+
+func (s *ComparisonConditionContext) IS() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserIS, 0)
+}
+
+func (s *ComparisonConditionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ContactQLListener); ok {
+		listenerT.EnterComparisonCondition(s)
+	}
+}
+
+func (s *ComparisonConditionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ContactQLListener); ok {
+		listenerT.ExitComparisonCondition(s)
+	}
+}
+
+func (s *ComparisonConditionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case ContactQLVisitor:
+		return t.VisitComparisonCondition(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+type ListConditionContext struct {
+	ConditionContext
+}
+
+func NewListConditionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ListConditionContext {
+	var p = new(ListConditionContext)
+
+	InitEmptyConditionContext(&p.ConditionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ConditionContext))
+
+	return p
+}
+
+func (s *ListConditionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ListConditionContext) PROPERTY() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserPROPERTY, 0)
+}
+
+func (s *ListConditionContext) IN() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserIN, 0)
+}
+
+func (s *ListConditionContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserLPAREN, 0)
+}
+
+func (s *ListConditionContext) AllLiteral() []ILiteralContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(ILiteralContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]ILiteralContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(ILiteralContext); ok {
+			tst[i] = t.(ILiteralContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *ListConditionContext) Literal(i int) ILiteralContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ILiteralContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILiteralContext)
+}
+
+func (s *ListConditionContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserRPAREN, 0)
+}
+
+func (s *ListConditionContext) AllCOMMA() []antlr.TerminalNode {
+	return s.GetTokens(ContactQLParserCOMMA)
+}
+
+func (s *ListConditionContext) COMMA(i int) antlr.TerminalNode {
+	return s.GetToken(ContactQLParserCOMMA, i)
+}
+
+func (s *ListConditionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ContactQLListener); ok {
+		listenerT.EnterListCondition(s)
+	}
+}
+
+func (s *ListConditionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ContactQLListener); ok {
+		listenerT.ExitListCondition(s)
+	}
+}
+
+func (s *ListConditionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case ContactQLVisitor:
+		return t.VisitListCondition(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *ContactQLParser) Condition() (localctx IConditionContext) {
+	localctx = NewConditionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 6, ContactQLParserRULE_condition)
+	var _la int
+
+	p.SetState(73)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 6, p.GetParserRuleContext()) {
+	case 1:
+		localctx = NewSetConditionContext(p, localctx)
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(45)
+			p.Match(ContactQLParserPROPERTY)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(46)
+			p.Match(ContactQLParserIS)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(48)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == ContactQLParserNOT {
+			{
+				p.SetState(47)
+				p.Match(ContactQLParserNOT)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+
+		}
+		{
+			p.SetState(50)
+			p.Match(ContactQLParserSET)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case 2:
+		localctx = NewComparisonConditionContext(p, localctx)
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(51)
+			p.Match(ContactQLParserPROPERTY)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(52)
+			_la = p.GetTokenStream().LA(1)
+
+			if !(_la == ContactQLParserIS || _la == ContactQLParserCOMPARATOR) {
+				p.GetErrorHandler().RecoverInline(p)
+			} else {
+				p.GetErrorHandler().ReportMatch(p)
+				p.Consume()
+			}
+		}
+		{
+			p.SetState(53)
+			p.Literal()
+		}
+
+	case 3:
+		localctx = NewListConditionContext(p, localctx)
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(54)
+			p.Match(ContactQLParserPROPERTY)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(55)
+			p.Match(ContactQLParserIN)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(56)
+			p.Match(ContactQLParserLPAREN)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(57)
+			p.Literal()
+		}
+		p.SetState(62)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		for _la == ContactQLParserCOMMA {
+			{
+				p.SetState(58)
+				p.Match(ContactQLParserCOMMA)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+			{
+				p.SetState(59)
+				p.Literal()
+			}
+
+			p.SetState(64)
+			p.GetErrorHandler().Sync(p)
+			if p.HasError() {
+				goto errorExit
+			}
+			_la = p.GetTokenStream().LA(1)
+		}
+		{
+			p.SetState(65)
+			p.Match(ContactQLParserRPAREN)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case 4:
+		localctx = NewRangeConditionContext(p, localctx)
+		p.EnterOuterAlt(localctx, 4)
+		{
+			p.SetState(67)
+			p.Match(ContactQLParserPROPERTY)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(68)
+			p.Match(ContactQLParserBETWEEN)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(69)
+			p.Literal()
+		}
+		{
+			p.SetState(70)
+			p.Match(ContactQLParserAND)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(71)
+			p.Literal()
+		}
+
+	case antlr.ATNInvalidAltNumber:
+		goto errorExit
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// ILiteralContext is an interface to support dynamic dispatch.
+type ILiteralContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+	// IsLiteralContext differentiates from other interfaces.
+	IsLiteralContext()
+}
+
+type LiteralContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyLiteralContext() *LiteralContext {
+	var p = new(LiteralContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = ContactQLParserRULE_literal
+	return p
+}
+
+func InitEmptyLiteralContext(p *LiteralContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = ContactQLParserRULE_literal
+}
+
+func (*LiteralContext) IsLiteralContext() {}
+
+func NewLiteralContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *LiteralContext {
+	var p = new(LiteralContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ContactQLParserRULE_literal
+
+	return p
+}
+
+func (s *LiteralContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *LiteralContext) CopyAll(ctx *LiteralContext) {
+	s.CopyFrom(&ctx.BaseParserRuleContext)
+}
+
+func (s *LiteralContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *LiteralContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+type StringLiteralContext struct {
+	LiteralContext
+}
+
+func NewStringLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *StringLiteralContext {
+	var p = new(StringLiteralContext)
+
+	InitEmptyLiteralContext(&p.LiteralContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*LiteralContext))
+
+	return p
+}
+
+func (s *StringLiteralContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *StringLiteralContext) STRING() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserSTRING, 0)
+}
+
+func (s *StringLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ContactQLListener); ok {
+		listenerT.EnterStringLiteral(s)
+	}
+}
+
+func (s *StringLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ContactQLListener); ok {
+		listenerT.ExitStringLiteral(s)
+	}
+}
+
+func (s *StringLiteralContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case ContactQLVisitor:
+		return t.VisitStringLiteral(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+type TextLiteralContext struct {
+	LiteralContext
+}
+
+func NewTextLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *TextLiteralContext {
+	var p = new(TextLiteralContext)
+
+	InitEmptyLiteralContext(&p.LiteralContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*LiteralContext))
+
+	return p
+}
+
+func (s *TextLiteralContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *TextLiteralContext) PROPERTY() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserPROPERTY, 0)
+}
+
+func (s *TextLiteralContext) TEXT() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserTEXT, 0)
+}
+
+func (s *TextLiteralContext) NOT() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserNOT, 0)
+}
+
+func (s *TextLiteralContext) IN() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserIN, 0)
+}
+
+func (s *TextLiteralContext) BETWEEN() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserBETWEEN, 0)
+}
+
+func (s *TextLiteralContext) SET() antlr.TerminalNode {
+	return s.GetToken(ContactQLParserSET, 0)
+}
+
+func (s *TextLiteralContext) AllMINUS() []antlr.TerminalNode {
+	return s.GetTokens(ContactQLParserMINUS)
+}
+
+func (s *TextLiteralContext) MINUS(i int) antlr.TerminalNode {
+	return s.GetToken(ContactQLParserMINUS, i)
+}
+
+func (s *TextLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ContactQLListener); ok {
+		listenerT.EnterTextLiteral(s)
+	}
+}
+
+func (s *TextLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ContactQLListener); ok {
+		listenerT.ExitTextLiteral(s)
+	}
+}
+
+func (s *TextLiteralContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case ContactQLVisitor:
+		return t.VisitTextLiteral(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *ContactQLParser) Literal() (localctx ILiteralContext) {
+	localctx = NewLiteralContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 8, ContactQLParserRULE_literal)
+	var _la int
+
+	var _alt int
+
+	p.SetState(83)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetTokenStream().LA(1) {
+	case ContactQLParserMINUS, ContactQLParserNOT, ContactQLParserIN, ContactQLParserBETWEEN, ContactQLParserSET, ContactQLParserPROPERTY, ContactQLParserTEXT:
+		localctx = NewTextLiteralContext(p, localctx)
+		p.EnterOuterAlt(localctx, 1)
+		p.SetState(78)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 7, p.GetParserRuleContext())
+		if p.HasError() {
+			goto errorExit
+		}
+		for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+			if _alt == 1 {
+				{
+					p.SetState(75)
+					p.Match(ContactQLParserMINUS)
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+
+			}
+			p.SetState(80)
+			p.GetErrorHandler().Sync(p)
+			if p.HasError() {
+				goto errorExit
+			}
+			_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 7, p.GetParserRuleContext())
+			if p.HasError() {
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(81)
+			_la = p.GetTokenStream().LA(1)
+
+			if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&52112) != 0) {
+				p.GetErrorHandler().RecoverInline(p)
+			} else {
+				p.GetErrorHandler().ReportMatch(p)
+				p.Consume()
+			}
+		}
+
+	case ContactQLParserSTRING:
+		localctx = NewStringLiteralContext(p, localctx)
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(82)
+			p.Match(ContactQLParserSTRING)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	default:
+		p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		goto errorExit
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+func (p *ContactQLParser) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex int) bool {
+	switch ruleIndex {
+	case 1:
+		var t *ExpressionContext = nil
+		if localctx != nil {
+			t = localctx.(*ExpressionContext)
+		}
+		return p.Expression_Sempred(t, predIndex)
+
+	default:
+		panic("No predicate with index: " + fmt.Sprint(ruleIndex))
+	}
+}
+
+func (p *ContactQLParser) Expression_Sempred(localctx antlr.RuleContext, predIndex int) bool {
+	switch predIndex {
+	case 0:
+		return p.Precpred(p.GetParserRuleContext(), 7)
+
+	case 1:
+		return p.Precpred(p.GetParserRuleContext(), 6)
 
 	case 2:
-		return p.Precpred(p.GetParserRuleContext(), 4)
+		return p.Precpred(p.GetParserRuleContext(), 5)
 
 	default:
 		panic("No predicate with index: " + fmt.Sprint(predIndex))
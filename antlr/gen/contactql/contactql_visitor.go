@@ -13,12 +13,15 @@ type ContactQLVisitor interface {
 	// Visit a parse tree produced by ContactQLParser#implicitCondition.
 	VisitImplicitCondition(ctx *ImplicitConditionContext) interface{}
 
-	// Visit a parse tree produced by ContactQLParser#condition.
-	VisitCondition(ctx *ConditionContext) interface{}
+	// Visit a parse tree produced by ContactQLParser#explicitCondition.
+	VisitExplicitCondition(ctx *ExplicitConditionContext) interface{}
 
 	// Visit a parse tree produced by ContactQLParser#combinationAnd.
 	VisitCombinationAnd(ctx *CombinationAndContext) interface{}
 
+	// Visit a parse tree produced by ContactQLParser#negatedExpression.
+	VisitNegatedExpression(ctx *NegatedExpressionContext) interface{}
+
 	// Visit a parse tree produced by ContactQLParser#combinationImpicitAnd.
 	VisitCombinationImpicitAnd(ctx *CombinationImpicitAndContext) interface{}
 
@@ -28,6 +31,21 @@ type ContactQLVisitor interface {
 	// Visit a parse tree produced by ContactQLParser#expressionGrouping.
 	VisitExpressionGrouping(ctx *ExpressionGroupingContext) interface{}
 
+	// Visit a parse tree produced by ContactQLParser#negation.
+	VisitNegation(ctx *NegationContext) interface{}
+
+	// Visit a parse tree produced by ContactQLParser#setCondition.
+	VisitSetCondition(ctx *SetConditionContext) interface{}
+
+	// Visit a parse tree produced by ContactQLParser#comparisonCondition.
+	VisitComparisonCondition(ctx *ComparisonConditionContext) interface{}
+
+	// Visit a parse tree produced by ContactQLParser#listCondition.
+	VisitListCondition(ctx *ListConditionContext) interface{}
+
+	// Visit a parse tree produced by ContactQLParser#rangeCondition.
+	VisitRangeCondition(ctx *RangeConditionContext) interface{}
+
 	// Visit a parse tree produced by ContactQLParser#textLiteral.
 	VisitTextLiteral(ctx *TextLiteralContext) interface{}
 
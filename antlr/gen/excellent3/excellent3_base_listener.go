@@ -38,12 +38,24 @@ func (s *BaseExcellent3Listener) EnterComparison(ctx *ComparisonContext) {}
 // ExitComparison is called when production comparison is exited.
 func (s *BaseExcellent3Listener) ExitComparison(ctx *ComparisonContext) {}
 
+// EnterOr is called when production or is entered.
+func (s *BaseExcellent3Listener) EnterOr(ctx *OrContext) {}
+
+// ExitOr is called when production or is exited.
+func (s *BaseExcellent3Listener) ExitOr(ctx *OrContext) {}
+
 // EnterFalse is called when production false is entered.
 func (s *BaseExcellent3Listener) EnterFalse(ctx *FalseContext) {}
 
 // ExitFalse is called when production false is exited.
 func (s *BaseExcellent3Listener) ExitFalse(ctx *FalseContext) {}
 
+// EnterCoalesce is called when production coalesce is entered.
+func (s *BaseExcellent3Listener) EnterCoalesce(ctx *CoalesceContext) {}
+
+// ExitCoalesce is called when production coalesce is exited.
+func (s *BaseExcellent3Listener) ExitCoalesce(ctx *CoalesceContext) {}
+
 // EnterAdditionOrSubtraction is called when production additionOrSubtraction is entered.
 func (s *BaseExcellent3Listener) EnterAdditionOrSubtraction(ctx *AdditionOrSubtractionContext) {}
 
@@ -56,6 +68,12 @@ func (s *BaseExcellent3Listener) EnterTextLiteral(ctx *TextLiteralContext) {}
 // ExitTextLiteral is called when production textLiteral is exited.
 func (s *BaseExcellent3Listener) ExitTextLiteral(ctx *TextLiteralContext) {}
 
+// EnterNot is called when production not is entered.
+func (s *BaseExcellent3Listener) EnterNot(ctx *NotContext) {}
+
+// ExitNot is called when production not is exited.
+func (s *BaseExcellent3Listener) ExitNot(ctx *NotContext) {}
+
 // EnterConcatenation is called when production concatenation is entered.
 func (s *BaseExcellent3Listener) EnterConcatenation(ctx *ConcatenationContext) {}
 
@@ -68,6 +86,12 @@ func (s *BaseExcellent3Listener) EnterNull(ctx *NullContext) {}
 // ExitNull is called when production null is exited.
 func (s *BaseExcellent3Listener) ExitNull(ctx *NullContext) {}
 
+// EnterAnd is called when production and is entered.
+func (s *BaseExcellent3Listener) EnterAnd(ctx *AndContext) {}
+
+// ExitAnd is called when production and is exited.
+func (s *BaseExcellent3Listener) ExitAnd(ctx *AndContext) {}
+
 // EnterMultiplicationOrDivision is called when production multiplicationOrDivision is entered.
 func (s *BaseExcellent3Listener) EnterMultiplicationOrDivision(ctx *MultiplicationOrDivisionContext) {
 }
@@ -99,6 +123,12 @@ func (s *BaseExcellent3Listener) EnterEquality(ctx *EqualityContext) {}
 // ExitEquality is called when production equality is exited.
 func (s *BaseExcellent3Listener) ExitEquality(ctx *EqualityContext) {}
 
+// EnterTernary is called when production ternary is entered.
+func (s *BaseExcellent3Listener) EnterTernary(ctx *TernaryContext) {}
+
+// ExitTernary is called when production ternary is exited.
+func (s *BaseExcellent3Listener) ExitTernary(ctx *TernaryContext) {}
+
 // EnterNumberLiteral is called when production numberLiteral is entered.
 func (s *BaseExcellent3Listener) EnterNumberLiteral(ctx *NumberLiteralContext) {}
 
@@ -123,6 +153,18 @@ func (s *BaseExcellent3Listener) EnterDotLookup(ctx *DotLookupContext) {}
 // ExitDotLookup is called when production dotLookup is exited.
 func (s *BaseExcellent3Listener) ExitDotLookup(ctx *DotLookupContext) {}
 
+// EnterObjectLiteral is called when production objectLiteral is entered.
+func (s *BaseExcellent3Listener) EnterObjectLiteral(ctx *ObjectLiteralContext) {}
+
+// ExitObjectLiteral is called when production objectLiteral is exited.
+func (s *BaseExcellent3Listener) ExitObjectLiteral(ctx *ObjectLiteralContext) {}
+
+// EnterArrayLiteral is called when production arrayLiteral is entered.
+func (s *BaseExcellent3Listener) EnterArrayLiteral(ctx *ArrayLiteralContext) {}
+
+// ExitArrayLiteral is called when production arrayLiteral is exited.
+func (s *BaseExcellent3Listener) ExitArrayLiteral(ctx *ArrayLiteralContext) {}
+
 // EnterFunctionCall is called when production functionCall is entered.
 func (s *BaseExcellent3Listener) EnterFunctionCall(ctx *FunctionCallContext) {}
 
@@ -147,6 +189,18 @@ func (s *BaseExcellent3Listener) EnterFunctionParameters(ctx *FunctionParameters
 // ExitFunctionParameters is called when production functionParameters is exited.
 func (s *BaseExcellent3Listener) ExitFunctionParameters(ctx *FunctionParametersContext) {}
 
+// EnterProperties is called when production properties is entered.
+func (s *BaseExcellent3Listener) EnterProperties(ctx *PropertiesContext) {}
+
+// ExitProperties is called when production properties is exited.
+func (s *BaseExcellent3Listener) ExitProperties(ctx *PropertiesContext) {}
+
+// EnterProperty is called when production property is entered.
+func (s *BaseExcellent3Listener) EnterProperty(ctx *PropertyContext) {}
+
+// ExitProperty is called when production property is exited.
+func (s *BaseExcellent3Listener) ExitProperty(ctx *PropertyContext) {}
+
 // EnterNameList is called when production nameList is entered.
 func (s *BaseExcellent3Listener) EnterNameList(ctx *NameListContext) {}
 
@@ -19,10 +19,18 @@ func (v *BaseExcellent3Visitor) VisitComparison(ctx *ComparisonContext) interfac
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseExcellent3Visitor) VisitOr(ctx *OrContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseExcellent3Visitor) VisitFalse(ctx *FalseContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseExcellent3Visitor) VisitCoalesce(ctx *CoalesceContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseExcellent3Visitor) VisitAdditionOrSubtraction(ctx *AdditionOrSubtractionContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -31,6 +39,10 @@ func (v *BaseExcellent3Visitor) VisitTextLiteral(ctx *TextLiteralContext) interf
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseExcellent3Visitor) VisitNot(ctx *NotContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseExcellent3Visitor) VisitConcatenation(ctx *ConcatenationContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -39,6 +51,10 @@ func (v *BaseExcellent3Visitor) VisitNull(ctx *NullContext) interface{} {
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseExcellent3Visitor) VisitAnd(ctx *AndContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseExcellent3Visitor) VisitMultiplicationOrDivision(ctx *MultiplicationOrDivisionContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -59,6 +75,10 @@ func (v *BaseExcellent3Visitor) VisitEquality(ctx *EqualityContext) interface{}
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseExcellent3Visitor) VisitTernary(ctx *TernaryContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseExcellent3Visitor) VisitNumberLiteral(ctx *NumberLiteralContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -75,6 +95,14 @@ func (v *BaseExcellent3Visitor) VisitDotLookup(ctx *DotLookupContext) interface{
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseExcellent3Visitor) VisitObjectLiteral(ctx *ObjectLiteralContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseExcellent3Visitor) VisitArrayLiteral(ctx *ArrayLiteralContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseExcellent3Visitor) VisitFunctionCall(ctx *FunctionCallContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -91,6 +119,14 @@ func (v *BaseExcellent3Visitor) VisitFunctionParameters(ctx *FunctionParametersC
 	return v.VisitChildren(ctx)
 }
 
+func (v *BaseExcellent3Visitor) VisitProperties(ctx *PropertiesContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
+func (v *BaseExcellent3Visitor) VisitProperty(ctx *PropertyContext) interface{} {
+	return v.VisitChildren(ctx)
+}
+
 func (v *BaseExcellent3Visitor) VisitNameList(ctx *NameListContext) interface{} {
 	return v.VisitChildren(ctx)
 }
@@ -43,82 +43,89 @@ func excellent3lexerLexerInit() {
 		"DEFAULT_MODE",
 	}
 	staticData.LiteralNames = []string{
-		"", "','", "'('", "')'", "'['", "']'", "'.'", "'=>'", "'+'", "'-'",
-		"'*'", "'/'", "'^'", "'='", "'!='", "'<='", "'<'", "'>='", "'>'", "'&'",
+		"", "','", "':'", "'('", "')'", "'['", "']'", "'{'", "'}'", "'.'", "'=>'",
+		"'+'", "'-'", "'*'", "'/'", "'^'", "'='", "'!='", "'<='", "'<'", "'>='",
+		"'>'", "'&'", "'&&'", "'||'", "'!'", "'??'", "'?'",
 	}
 	staticData.SymbolicNames = []string{
-		"", "COMMA", "LPAREN", "RPAREN", "LBRACK", "RBRACK", "DOT", "ARROW",
-		"PLUS", "MINUS", "TIMES", "DIVIDE", "EXPONENT", "EQ", "NEQ", "LTE",
-		"LT", "GTE", "GT", "AMPERSAND", "TEXT", "INTEGER", "DECIMAL", "TRUE",
-		"FALSE", "NULL", "NAME", "WS", "ERROR",
+		"", "COMMA", "COLON", "LPAREN", "RPAREN", "LBRACK", "RBRACK", "LBRACE",
+		"RBRACE", "DOT", "ARROW", "PLUS", "MINUS", "TIMES", "DIVIDE", "EXPONENT",
+		"EQ", "NEQ", "LTE", "LT", "GTE", "GT", "AMPERSAND", "AND", "OR", "NOT",
+		"COALESCE", "QUESTION", "TEXT", "INTEGER", "DECIMAL", "TRUE", "FALSE",
+		"NULL", "NAME", "WS", "ERROR",
 	}
 	staticData.RuleNames = []string{
-		"COMMA", "LPAREN", "RPAREN", "LBRACK", "RBRACK", "DOT", "ARROW", "PLUS",
-		"MINUS", "TIMES", "DIVIDE", "EXPONENT", "EQ", "NEQ", "LTE", "LT", "GTE",
-		"GT", "AMPERSAND", "TEXT", "INTEGER", "DECIMAL", "TRUE", "FALSE", "NULL",
-		"NAME", "WS", "ERROR", "UnicodeLetter", "UnicodeClass_LU", "UnicodeClass_LL",
+		"COMMA", "COLON", "LPAREN", "RPAREN", "LBRACK", "RBRACK", "LBRACE",
+		"RBRACE", "DOT", "ARROW", "PLUS", "MINUS", "TIMES", "DIVIDE", "EXPONENT",
+		"EQ", "NEQ", "LTE", "LT", "GTE", "GT", "AMPERSAND", "AND", "OR", "NOT",
+		"COALESCE", "QUESTION", "TEXT", "INTEGER", "DECIMAL", "TRUE", "FALSE",
+		"NULL", "NAME", "WS", "ERROR", "UnicodeLetter", "UnicodeClass_LU", "UnicodeClass_LL",
 		"UnicodeClass_LT", "UnicodeClass_LM", "UnicodeClass_LO", "UnicodeDigit",
 	}
 	staticData.PredictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 0, 28, 198, 6, -1, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2,
+		4, 0, 36, 233, 6, -1, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2,
 		4, 7, 4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2,
 		10, 7, 10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15,
 		7, 15, 2, 16, 7, 16, 2, 17, 7, 17, 2, 18, 7, 18, 2, 19, 7, 19, 2, 20, 7,
 		20, 2, 21, 7, 21, 2, 22, 7, 22, 2, 23, 7, 23, 2, 24, 7, 24, 2, 25, 7, 25,
 		2, 26, 7, 26, 2, 27, 7, 27, 2, 28, 7, 28, 2, 29, 7, 29, 2, 30, 7, 30, 2,
-		31, 7, 31, 2, 32, 7, 32, 2, 33, 7, 33, 2, 34, 7, 34, 1, 0, 1, 0, 1, 1,
-		1, 1, 1, 2, 1, 2, 1, 3, 1, 3, 1, 4, 1, 4, 1, 5, 1, 5, 1, 6, 1, 6, 1, 6,
-		1, 7, 1, 7, 1, 8, 1, 8, 1, 9, 1, 9, 1, 10, 1, 10, 1, 11, 1, 11, 1, 12,
-		1, 12, 1, 13, 1, 13, 1, 13, 1, 14, 1, 14, 1, 14, 1, 15, 1, 15, 1, 16, 1,
-		16, 1, 16, 1, 17, 1, 17, 1, 18, 1, 18, 1, 19, 1, 19, 1, 19, 1, 19, 5, 19,
-		118, 8, 19, 10, 19, 12, 19, 121, 9, 19, 1, 19, 1, 19, 1, 20, 4, 20, 126,
-		8, 20, 11, 20, 12, 20, 127, 1, 21, 4, 21, 131, 8, 21, 11, 21, 12, 21, 132,
-		1, 21, 1, 21, 4, 21, 137, 8, 21, 11, 21, 12, 21, 138, 1, 22, 1, 22, 1,
-		22, 1, 22, 1, 22, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23, 1, 23, 1, 24, 1, 24,
-		1, 24, 1, 24, 1, 24, 1, 25, 1, 25, 4, 25, 159, 8, 25, 11, 25, 12, 25, 160,
-		1, 25, 1, 25, 1, 25, 5, 25, 166, 8, 25, 10, 25, 12, 25, 169, 9, 25, 1,
-		26, 4, 26, 172, 8, 26, 11, 26, 12, 26, 173, 1, 26, 1, 26, 1, 27, 1, 27,
-		1, 28, 1, 28, 1, 28, 1, 28, 1, 28, 3, 28, 185, 8, 28, 1, 29, 1, 29, 1,
-		30, 1, 30, 1, 31, 1, 31, 1, 32, 1, 32, 1, 33, 1, 33, 1, 34, 1, 34, 0, 0,
-		35, 1, 1, 3, 2, 5, 3, 7, 4, 9, 5, 11, 6, 13, 7, 15, 8, 17, 9, 19, 10, 21,
-		11, 23, 12, 25, 13, 27, 14, 29, 15, 31, 16, 33, 17, 35, 18, 37, 19, 39,
-		20, 41, 21, 43, 22, 45, 23, 47, 24, 49, 25, 51, 26, 53, 27, 55, 28, 57,
-		0, 59, 0, 61, 0, 63, 0, 65, 0, 67, 0, 69, 0, 1, 0, 18, 1, 0, 34, 34, 1,
-		0, 48, 57, 2, 0, 84, 84, 116, 116, 2, 0, 82, 82, 114, 114, 2, 0, 85, 85,
-		117, 117, 2, 0, 69, 69, 101, 101, 2, 0, 70, 70, 102, 102, 2, 0, 65, 65,
-		97, 97, 2, 0, 76, 76, 108, 108, 2, 0, 83, 83, 115, 115, 2, 0, 78, 78, 110,
-		110, 3, 0, 9, 10, 13, 13, 32, 32, 82, 0, 65, 90, 192, 214, 216, 222, 256,
-		310, 313, 327, 330, 381, 385, 386, 388, 395, 398, 401, 403, 404, 406, 408,
-		412, 413, 415, 416, 418, 425, 428, 435, 437, 444, 452, 461, 463, 475, 478,
-		494, 497, 500, 502, 504, 506, 562, 570, 571, 573, 574, 577, 582, 584, 590,
-		880, 882, 886, 895, 902, 906, 908, 929, 931, 939, 975, 980, 984, 1006,
-		1012, 1015, 1017, 1018, 1021, 1071, 1120, 1152, 1162, 1229, 1232, 1326,
-		1329, 1366, 4256, 4293, 4295, 4301, 7680, 7828, 7838, 7934, 7944, 7951,
-		7960, 7965, 7976, 7983, 7992, 7999, 8008, 8013, 8025, 8031, 8040, 8047,
-		8120, 8123, 8136, 8139, 8152, 8155, 8168, 8172, 8184, 8187, 8450, 8455,
-		8459, 8461, 8464, 8466, 8469, 8477, 8484, 8493, 8496, 8499, 8510, 8511,
-		8517, 8579, 11264, 11310, 11360, 11364, 11367, 11376, 11378, 11381, 11390,
-		11392, 11394, 11490, 11499, 11501, 11506, 42560, 42562, 42604, 42624, 42650,
-		42786, 42798, 42802, 42862, 42873, 42886, 42891, 42893, 42896, 42898, 42902,
-		42925, 42928, 42929, 65313, 65338, 81, 0, 97, 122, 181, 246, 248, 255,
-		257, 375, 378, 384, 387, 389, 392, 402, 405, 411, 414, 417, 419, 421, 424,
-		429, 432, 436, 438, 447, 454, 460, 462, 499, 501, 505, 507, 569, 572, 578,
-		583, 659, 661, 687, 881, 883, 887, 893, 912, 974, 976, 977, 981, 983, 985,
-		1011, 1013, 1119, 1121, 1153, 1163, 1215, 1218, 1327, 1377, 1415, 7424,
-		7467, 7531, 7543, 7545, 7578, 7681, 7837, 7839, 7943, 7952, 7957, 7968,
-		7975, 7984, 7991, 8000, 8005, 8016, 8023, 8032, 8039, 8048, 8061, 8064,
-		8071, 8080, 8087, 8096, 8103, 8112, 8116, 8118, 8119, 8126, 8132, 8134,
-		8135, 8144, 8147, 8150, 8151, 8160, 8167, 8178, 8180, 8182, 8183, 8458,
-		8467, 8495, 8505, 8508, 8509, 8518, 8521, 8526, 8580, 11312, 11358, 11361,
-		11372, 11377, 11387, 11393, 11500, 11502, 11507, 11520, 11557, 11559, 11565,
-		42561, 42605, 42625, 42651, 42787, 42801, 42803, 42872, 42874, 42876, 42879,
-		42887, 42892, 42894, 42897, 42901, 42903, 42921, 43002, 43866, 43876, 43877,
-		64256, 64262, 64275, 64279, 65345, 65370, 6, 0, 453, 459, 498, 8079, 8088,
-		8095, 8104, 8111, 8124, 8140, 8188, 8188, 33, 0, 688, 705, 710, 721, 736,
-		740, 748, 750, 884, 890, 1369, 1600, 1765, 1766, 2036, 2037, 2042, 2074,
-		2084, 2088, 2417, 3654, 3782, 4348, 6103, 6211, 6823, 7293, 7468, 7530,
-		7544, 7615, 8305, 8319, 8336, 8348, 11388, 11389, 11631, 11823, 12293,
+		31, 7, 31, 2, 32, 7, 32, 2, 33, 7, 33, 2, 34, 7, 34, 2, 35, 7, 35, 2, 36,
+		7, 36, 2, 37, 7, 37, 2, 38, 7, 38, 2, 39, 7, 39, 2, 40, 7, 40, 2, 41, 7,
+		41, 2, 42, 7, 42, 1, 0, 1, 0, 1, 1, 1, 1, 1, 2, 1, 2, 1, 3, 1, 3, 1, 4,
+		1, 4, 1, 5, 1, 5, 1, 6, 1, 6, 1, 7, 1, 7, 1, 8, 1, 8, 1, 9, 1, 9, 1, 9,
+		1, 10, 1, 10, 1, 11, 1, 11, 1, 12, 1, 12, 1, 13, 1, 13, 1, 14, 1, 14, 1,
+		15, 1, 15, 1, 16, 1, 16, 1, 16, 1, 17, 1, 17, 1, 17, 1, 18, 1, 18, 1, 19,
+		1, 19, 1, 19, 1, 20, 1, 20, 1, 21, 1, 21, 1, 22, 1, 22, 1, 22, 1, 23, 1,
+		23, 1, 23, 1, 24, 1, 24, 1, 25, 1, 25, 1, 25, 1, 26, 1, 26, 1, 27, 1, 27,
+		1, 27, 1, 27, 5, 27, 153, 8, 27, 10, 27, 12, 27, 156, 9, 27, 1, 27, 1,
+		27, 1, 28, 4, 28, 161, 8, 28, 11, 28, 12, 28, 162, 1, 29, 4, 29, 166, 8,
+		29, 11, 29, 12, 29, 167, 1, 29, 1, 29, 4, 29, 172, 8, 29, 11, 29, 12, 29,
+		173, 1, 30, 1, 30, 1, 30, 1, 30, 1, 30, 1, 31, 1, 31, 1, 31, 1, 31, 1,
+		31, 1, 31, 1, 32, 1, 32, 1, 32, 1, 32, 1, 32, 1, 33, 1, 33, 4, 33, 194,
+		8, 33, 11, 33, 12, 33, 195, 1, 33, 1, 33, 1, 33, 5, 33, 201, 8, 33, 10,
+		33, 12, 33, 204, 9, 33, 1, 34, 4, 34, 207, 8, 34, 11, 34, 12, 34, 208,
+		1, 34, 1, 34, 1, 35, 1, 35, 1, 36, 1, 36, 1, 36, 1, 36, 1, 36, 3, 36, 220,
+		8, 36, 1, 37, 1, 37, 1, 38, 1, 38, 1, 39, 1, 39, 1, 40, 1, 40, 1, 41, 1,
+		41, 1, 42, 1, 42, 0, 0, 43, 1, 1, 3, 2, 5, 3, 7, 4, 9, 5, 11, 6, 13, 7,
+		15, 8, 17, 9, 19, 10, 21, 11, 23, 12, 25, 13, 27, 14, 29, 15, 31, 16, 33,
+		17, 35, 18, 37, 19, 39, 20, 41, 21, 43, 22, 45, 23, 47, 24, 49, 25, 51,
+		26, 53, 27, 55, 28, 57, 29, 59, 30, 61, 31, 63, 32, 65, 33, 67, 34, 69,
+		35, 71, 36, 73, 0, 75, 0, 77, 0, 79, 0, 81, 0, 83, 0, 85, 0, 1, 0, 18,
+		1, 0, 34, 34, 1, 0, 48, 57, 2, 0, 84, 84, 116, 116, 2, 0, 82, 82, 114,
+		114, 2, 0, 85, 85, 117, 117, 2, 0, 69, 69, 101, 101, 2, 0, 70, 70, 102,
+		102, 2, 0, 65, 65, 97, 97, 2, 0, 76, 76, 108, 108, 2, 0, 83, 83, 115, 115,
+		2, 0, 78, 78, 110, 110, 3, 0, 9, 10, 13, 13, 32, 32, 82, 0, 65, 90, 192,
+		214, 216, 222, 256, 310, 313, 327, 330, 381, 385, 386, 388, 395, 398, 401,
+		403, 404, 406, 408, 412, 413, 415, 416, 418, 425, 428, 435, 437, 444, 452,
+		461, 463, 475, 478, 494, 497, 500, 502, 504, 506, 562, 570, 571, 573, 574,
+		577, 582, 584, 590, 880, 882, 886, 895, 902, 906, 908, 929, 931, 939, 975,
+		980, 984, 1006, 1012, 1015, 1017, 1018, 1021, 1071, 1120, 1152, 1162, 1229,
+		1232, 1326, 1329, 1366, 4256, 4293, 4295, 4301, 7680, 7828, 7838, 7934,
+		7944, 7951, 7960, 7965, 7976, 7983, 7992, 7999, 8008, 8013, 8025, 8031,
+		8040, 8047, 8120, 8123, 8136, 8139, 8152, 8155, 8168, 8172, 8184, 8187,
+		8450, 8455, 8459, 8461, 8464, 8466, 8469, 8477, 8484, 8493, 8496, 8499,
+		8510, 8511, 8517, 8579, 11264, 11310, 11360, 11364, 11367, 11376, 11378,
+		11381, 11390, 11392, 11394, 11490, 11499, 11501, 11506, 42560, 42562, 42604,
+		42624, 42650, 42786, 42798, 42802, 42862, 42873, 42886, 42891, 42893, 42896,
+		42898, 42902, 42925, 42928, 42929, 65313, 65338, 81, 0, 97, 122, 181, 246,
+		248, 255, 257, 375, 378, 384, 387, 389, 392, 402, 405, 411, 414, 417, 419,
+		421, 424, 429, 432, 436, 438, 447, 454, 460, 462, 499, 501, 505, 507, 569,
+		572, 578, 583, 659, 661, 687, 881, 883, 887, 893, 912, 974, 976, 977, 981,
+		983, 985, 1011, 1013, 1119, 1121, 1153, 1163, 1215, 1218, 1327, 1377, 1415,
+		7424, 7467, 7531, 7543, 7545, 7578, 7681, 7837, 7839, 7943, 7952, 7957,
+		7968, 7975, 7984, 7991, 8000, 8005, 8016, 8023, 8032, 8039, 8048, 8061,
+		8064, 8071, 8080, 8087, 8096, 8103, 8112, 8116, 8118, 8119, 8126, 8132,
+		8134, 8135, 8144, 8147, 8150, 8151, 8160, 8167, 8178, 8180, 8182, 8183,
+		8458, 8467, 8495, 8505, 8508, 8509, 8518, 8521, 8526, 8580, 11312, 11358,
+		11361, 11372, 11377, 11387, 11393, 11500, 11502, 11507, 11520, 11557, 11559,
+		11565, 42561, 42605, 42625, 42651, 42787, 42801, 42803, 42872, 42874, 42876,
+		42879, 42887, 42892, 42894, 42897, 42901, 42903, 42921, 43002, 43866, 43876,
+		43877, 64256, 64262, 64275, 64279, 65345, 65370, 6, 0, 453, 459, 498, 8079,
+		8088, 8095, 8104, 8111, 8124, 8140, 8188, 8188, 33, 0, 688, 705, 710, 721,
+		736, 740, 748, 750, 884, 890, 1369, 1600, 1765, 1766, 2036, 2037, 2042,
+		2074, 2084, 2088, 2417, 3654, 3782, 4348, 6103, 6211, 6823, 7293, 7468,
+		7530, 7544, 7615, 8305, 8319, 8336, 8348, 11388, 11389, 11631, 11823, 12293,
 		12341, 12347, 12542, 40981, 42237, 42508, 42623, 42652, 42653, 42775, 42783,
 		42864, 42888, 43000, 43001, 43471, 43494, 43632, 43741, 43763, 43764, 43868,
 		43871, 65392, 65439, 234, 0, 170, 186, 443, 451, 660, 1514, 1520, 1522,
@@ -167,7 +174,7 @@ func excellent3lexerLexerInit() {
 		3673, 3792, 3801, 3872, 3881, 4160, 4169, 4240, 4249, 6112, 6121, 6160,
 		6169, 6470, 6479, 6608, 6617, 6784, 6793, 6800, 6809, 6992, 7001, 7088,
 		7097, 7232, 7241, 7248, 7257, 42528, 42537, 43216, 43225, 43264, 43273,
-		43472, 43481, 43504, 43513, 43600, 43609, 44016, 44025, 65296, 65305, 205,
+		43472, 43481, 43504, 43513, 43600, 43609, 44016, 44025, 65296, 65305, 240,
 		0, 1, 1, 0, 0, 0, 0, 3, 1, 0, 0, 0, 0, 5, 1, 0, 0, 0, 0, 7, 1, 0, 0, 0,
 		0, 9, 1, 0, 0, 0, 0, 11, 1, 0, 0, 0, 0, 13, 1, 0, 0, 0, 0, 15, 1, 0, 0,
 		0, 0, 17, 1, 0, 0, 0, 0, 19, 1, 0, 0, 0, 0, 21, 1, 0, 0, 0, 0, 23, 1, 0,
@@ -175,60 +182,71 @@ func excellent3lexerLexerInit() {
 		0, 0, 0, 0, 33, 1, 0, 0, 0, 0, 35, 1, 0, 0, 0, 0, 37, 1, 0, 0, 0, 0, 39,
 		1, 0, 0, 0, 0, 41, 1, 0, 0, 0, 0, 43, 1, 0, 0, 0, 0, 45, 1, 0, 0, 0, 0,
 		47, 1, 0, 0, 0, 0, 49, 1, 0, 0, 0, 0, 51, 1, 0, 0, 0, 0, 53, 1, 0, 0, 0,
-		0, 55, 1, 0, 0, 0, 1, 71, 1, 0, 0, 0, 3, 73, 1, 0, 0, 0, 5, 75, 1, 0, 0,
-		0, 7, 77, 1, 0, 0, 0, 9, 79, 1, 0, 0, 0, 11, 81, 1, 0, 0, 0, 13, 83, 1,
-		0, 0, 0, 15, 86, 1, 0, 0, 0, 17, 88, 1, 0, 0, 0, 19, 90, 1, 0, 0, 0, 21,
-		92, 1, 0, 0, 0, 23, 94, 1, 0, 0, 0, 25, 96, 1, 0, 0, 0, 27, 98, 1, 0, 0,
-		0, 29, 101, 1, 0, 0, 0, 31, 104, 1, 0, 0, 0, 33, 106, 1, 0, 0, 0, 35, 109,
-		1, 0, 0, 0, 37, 111, 1, 0, 0, 0, 39, 113, 1, 0, 0, 0, 41, 125, 1, 0, 0,
-		0, 43, 130, 1, 0, 0, 0, 45, 140, 1, 0, 0, 0, 47, 145, 1, 0, 0, 0, 49, 151,
-		1, 0, 0, 0, 51, 158, 1, 0, 0, 0, 53, 171, 1, 0, 0, 0, 55, 177, 1, 0, 0,
-		0, 57, 184, 1, 0, 0, 0, 59, 186, 1, 0, 0, 0, 61, 188, 1, 0, 0, 0, 63, 190,
-		1, 0, 0, 0, 65, 192, 1, 0, 0, 0, 67, 194, 1, 0, 0, 0, 69, 196, 1, 0, 0,
-		0, 71, 72, 5, 44, 0, 0, 72, 2, 1, 0, 0, 0, 73, 74, 5, 40, 0, 0, 74, 4,
-		1, 0, 0, 0, 75, 76, 5, 41, 0, 0, 76, 6, 1, 0, 0, 0, 77, 78, 5, 91, 0, 0,
-		78, 8, 1, 0, 0, 0, 79, 80, 5, 93, 0, 0, 80, 10, 1, 0, 0, 0, 81, 82, 5,
-		46, 0, 0, 82, 12, 1, 0, 0, 0, 83, 84, 5, 61, 0, 0, 84, 85, 5, 62, 0, 0,
-		85, 14, 1, 0, 0, 0, 86, 87, 5, 43, 0, 0, 87, 16, 1, 0, 0, 0, 88, 89, 5,
-		45, 0, 0, 89, 18, 1, 0, 0, 0, 90, 91, 5, 42, 0, 0, 91, 20, 1, 0, 0, 0,
-		92, 93, 5, 47, 0, 0, 93, 22, 1, 0, 0, 0, 94, 95, 5, 94, 0, 0, 95, 24, 1,
-		0, 0, 0, 96, 97, 5, 61, 0, 0, 97, 26, 1, 0, 0, 0, 98, 99, 5, 33, 0, 0,
-		99, 100, 5, 61, 0, 0, 100, 28, 1, 0, 0, 0, 101, 102, 5, 60, 0, 0, 102,
-		103, 5, 61, 0, 0, 103, 30, 1, 0, 0, 0, 104, 105, 5, 60, 0, 0, 105, 32,
-		1, 0, 0, 0, 106, 107, 5, 62, 0, 0, 107, 108, 5, 61, 0, 0, 108, 34, 1, 0,
-		0, 0, 109, 110, 5, 62, 0, 0, 110, 36, 1, 0, 0, 0, 111, 112, 5, 38, 0, 0,
-		112, 38, 1, 0, 0, 0, 113, 119, 5, 34, 0, 0, 114, 118, 8, 0, 0, 0, 115,
-		116, 5, 92, 0, 0, 116, 118, 5, 34, 0, 0, 117, 114, 1, 0, 0, 0, 117, 115,
-		1, 0, 0, 0, 118, 121, 1, 0, 0, 0, 119, 117, 1, 0, 0, 0, 119, 120, 1, 0,
-		0, 0, 120, 122, 1, 0, 0, 0, 121, 119, 1, 0, 0, 0, 122, 123, 5, 34, 0, 0,
-		123, 40, 1, 0, 0, 0, 124, 126, 7, 1, 0, 0, 125, 124, 1, 0, 0, 0, 126, 127,
-		1, 0, 0, 0, 127, 125, 1, 0, 0, 0, 127, 128, 1, 0, 0, 0, 128, 42, 1, 0,
-		0, 0, 129, 131, 7, 1, 0, 0, 130, 129, 1, 0, 0, 0, 131, 132, 1, 0, 0, 0,
-		132, 130, 1, 0, 0, 0, 132, 133, 1, 0, 0, 0, 133, 134, 1, 0, 0, 0, 134,
-		136, 5, 46, 0, 0, 135, 137, 7, 1, 0, 0, 136, 135, 1, 0, 0, 0, 137, 138,
-		1, 0, 0, 0, 138, 136, 1, 0, 0, 0, 138, 139, 1, 0, 0, 0, 139, 44, 1, 0,
-		0, 0, 140, 141, 7, 2, 0, 0, 141, 142, 7, 3, 0, 0, 142, 143, 7, 4, 0, 0,
-		143, 144, 7, 5, 0, 0, 144, 46, 1, 0, 0, 0, 145, 146, 7, 6, 0, 0, 146, 147,
-		7, 7, 0, 0, 147, 148, 7, 8, 0, 0, 148, 149, 7, 9, 0, 0, 149, 150, 7, 5,
-		0, 0, 150, 48, 1, 0, 0, 0, 151, 152, 7, 10, 0, 0, 152, 153, 7, 4, 0, 0,
-		153, 154, 7, 8, 0, 0, 154, 155, 7, 8, 0, 0, 155, 50, 1, 0, 0, 0, 156, 159,
-		3, 57, 28, 0, 157, 159, 5, 95, 0, 0, 158, 156, 1, 0, 0, 0, 158, 157, 1,
-		0, 0, 0, 159, 160, 1, 0, 0, 0, 160, 158, 1, 0, 0, 0, 160, 161, 1, 0, 0,
-		0, 161, 167, 1, 0, 0, 0, 162, 166, 3, 57, 28, 0, 163, 166, 3, 69, 34, 0,
-		164, 166, 5, 95, 0, 0, 165, 162, 1, 0, 0, 0, 165, 163, 1, 0, 0, 0, 165,
-		164, 1, 0, 0, 0, 166, 169, 1, 0, 0, 0, 167, 165, 1, 0, 0, 0, 167, 168,
-		1, 0, 0, 0, 168, 52, 1, 0, 0, 0, 169, 167, 1, 0, 0, 0, 170, 172, 7, 11,
-		0, 0, 171, 170, 1, 0, 0, 0, 172, 173, 1, 0, 0, 0, 173, 171, 1, 0, 0, 0,
-		173, 174, 1, 0, 0, 0, 174, 175, 1, 0, 0, 0, 175, 176, 6, 26, 0, 0, 176,
-		54, 1, 0, 0, 0, 177, 178, 9, 0, 0, 0, 178, 56, 1, 0, 0, 0, 179, 185, 3,
-		59, 29, 0, 180, 185, 3, 61, 30, 0, 181, 185, 3, 63, 31, 0, 182, 185, 3,
-		65, 32, 0, 183, 185, 3, 67, 33, 0, 184, 179, 1, 0, 0, 0, 184, 180, 1, 0,
-		0, 0, 184, 181, 1, 0, 0, 0, 184, 182, 1, 0, 0, 0, 184, 183, 1, 0, 0, 0,
-		185, 58, 1, 0, 0, 0, 186, 187, 7, 12, 0, 0, 187, 60, 1, 0, 0, 0, 188, 189,
-		7, 13, 0, 0, 189, 62, 1, 0, 0, 0, 190, 191, 7, 14, 0, 0, 191, 64, 1, 0,
-		0, 0, 192, 193, 7, 15, 0, 0, 193, 66, 1, 0, 0, 0, 194, 195, 7, 16, 0, 0,
-		195, 68, 1, 0, 0, 0, 196, 197, 7, 17, 0, 0, 197, 70, 1, 0, 0, 0, 12, 0,
-		117, 119, 127, 132, 138, 158, 160, 165, 167, 173, 184, 1, 6, 0, 0,
+		0, 55, 1, 0, 0, 0, 0, 57, 1, 0, 0, 0, 0, 59, 1, 0, 0, 0, 0, 61, 1, 0, 0,
+		0, 0, 63, 1, 0, 0, 0, 0, 65, 1, 0, 0, 0, 0, 67, 1, 0, 0, 0, 0, 69, 1, 0,
+		0, 0, 0, 71, 1, 0, 0, 0, 1, 87, 1, 0, 0, 0, 3, 89, 1, 0, 0, 0, 5, 91, 1,
+		0, 0, 0, 7, 93, 1, 0, 0, 0, 9, 95, 1, 0, 0, 0, 11, 97, 1, 0, 0, 0, 13,
+		99, 1, 0, 0, 0, 15, 101, 1, 0, 0, 0, 17, 103, 1, 0, 0, 0, 19, 105, 1, 0,
+		0, 0, 21, 108, 1, 0, 0, 0, 23, 110, 1, 0, 0, 0, 25, 112, 1, 0, 0, 0, 27,
+		114, 1, 0, 0, 0, 29, 116, 1, 0, 0, 0, 31, 118, 1, 0, 0, 0, 33, 120, 1,
+		0, 0, 0, 35, 123, 1, 0, 0, 0, 37, 126, 1, 0, 0, 0, 39, 128, 1, 0, 0, 0,
+		41, 131, 1, 0, 0, 0, 43, 133, 1, 0, 0, 0, 45, 135, 1, 0, 0, 0, 47, 138,
+		1, 0, 0, 0, 49, 141, 1, 0, 0, 0, 51, 143, 1, 0, 0, 0, 53, 146, 1, 0, 0,
+		0, 55, 148, 1, 0, 0, 0, 57, 160, 1, 0, 0, 0, 59, 165, 1, 0, 0, 0, 61, 175,
+		1, 0, 0, 0, 63, 180, 1, 0, 0, 0, 65, 186, 1, 0, 0, 0, 67, 193, 1, 0, 0,
+		0, 69, 206, 1, 0, 0, 0, 71, 212, 1, 0, 0, 0, 73, 219, 1, 0, 0, 0, 75, 221,
+		1, 0, 0, 0, 77, 223, 1, 0, 0, 0, 79, 225, 1, 0, 0, 0, 81, 227, 1, 0, 0,
+		0, 83, 229, 1, 0, 0, 0, 85, 231, 1, 0, 0, 0, 87, 88, 5, 44, 0, 0, 88, 2,
+		1, 0, 0, 0, 89, 90, 5, 58, 0, 0, 90, 4, 1, 0, 0, 0, 91, 92, 5, 40, 0, 0,
+		92, 6, 1, 0, 0, 0, 93, 94, 5, 41, 0, 0, 94, 8, 1, 0, 0, 0, 95, 96, 5, 91,
+		0, 0, 96, 10, 1, 0, 0, 0, 97, 98, 5, 93, 0, 0, 98, 12, 1, 0, 0, 0, 99,
+		100, 5, 123, 0, 0, 100, 14, 1, 0, 0, 0, 101, 102, 5, 125, 0, 0, 102, 16,
+		1, 0, 0, 0, 103, 104, 5, 46, 0, 0, 104, 18, 1, 0, 0, 0, 105, 106, 5, 61,
+		0, 0, 106, 107, 5, 62, 0, 0, 107, 20, 1, 0, 0, 0, 108, 109, 5, 43, 0, 0,
+		109, 22, 1, 0, 0, 0, 110, 111, 5, 45, 0, 0, 111, 24, 1, 0, 0, 0, 112, 113,
+		5, 42, 0, 0, 113, 26, 1, 0, 0, 0, 114, 115, 5, 47, 0, 0, 115, 28, 1, 0,
+		0, 0, 116, 117, 5, 94, 0, 0, 117, 30, 1, 0, 0, 0, 118, 119, 5, 61, 0, 0,
+		119, 32, 1, 0, 0, 0, 120, 121, 5, 33, 0, 0, 121, 122, 5, 61, 0, 0, 122,
+		34, 1, 0, 0, 0, 123, 124, 5, 60, 0, 0, 124, 125, 5, 61, 0, 0, 125, 36,
+		1, 0, 0, 0, 126, 127, 5, 60, 0, 0, 127, 38, 1, 0, 0, 0, 128, 129, 5, 62,
+		0, 0, 129, 130, 5, 61, 0, 0, 130, 40, 1, 0, 0, 0, 131, 132, 5, 62, 0, 0,
+		132, 42, 1, 0, 0, 0, 133, 134, 5, 38, 0, 0, 134, 44, 1, 0, 0, 0, 135, 136,
+		5, 38, 0, 0, 136, 137, 5, 38, 0, 0, 137, 46, 1, 0, 0, 0, 138, 139, 5, 124,
+		0, 0, 139, 140, 5, 124, 0, 0, 140, 48, 1, 0, 0, 0, 141, 142, 5, 33, 0,
+		0, 142, 50, 1, 0, 0, 0, 143, 144, 5, 63, 0, 0, 144, 145, 5, 63, 0, 0, 145,
+		52, 1, 0, 0, 0, 146, 147, 5, 63, 0, 0, 147, 54, 1, 0, 0, 0, 148, 154, 5,
+		34, 0, 0, 149, 153, 8, 0, 0, 0, 150, 151, 5, 92, 0, 0, 151, 153, 5, 34,
+		0, 0, 152, 149, 1, 0, 0, 0, 152, 150, 1, 0, 0, 0, 153, 156, 1, 0, 0, 0,
+		154, 152, 1, 0, 0, 0, 154, 155, 1, 0, 0, 0, 155, 157, 1, 0, 0, 0, 156,
+		154, 1, 0, 0, 0, 157, 158, 5, 34, 0, 0, 158, 56, 1, 0, 0, 0, 159, 161,
+		7, 1, 0, 0, 160, 159, 1, 0, 0, 0, 161, 162, 1, 0, 0, 0, 162, 160, 1, 0,
+		0, 0, 162, 163, 1, 0, 0, 0, 163, 58, 1, 0, 0, 0, 164, 166, 7, 1, 0, 0,
+		165, 164, 1, 0, 0, 0, 166, 167, 1, 0, 0, 0, 167, 165, 1, 0, 0, 0, 167,
+		168, 1, 0, 0, 0, 168, 169, 1, 0, 0, 0, 169, 171, 5, 46, 0, 0, 170, 172,
+		7, 1, 0, 0, 171, 170, 1, 0, 0, 0, 172, 173, 1, 0, 0, 0, 173, 171, 1, 0,
+		0, 0, 173, 174, 1, 0, 0, 0, 174, 60, 1, 0, 0, 0, 175, 176, 7, 2, 0, 0,
+		176, 177, 7, 3, 0, 0, 177, 178, 7, 4, 0, 0, 178, 179, 7, 5, 0, 0, 179,
+		62, 1, 0, 0, 0, 180, 181, 7, 6, 0, 0, 181, 182, 7, 7, 0, 0, 182, 183, 7,
+		8, 0, 0, 183, 184, 7, 9, 0, 0, 184, 185, 7, 5, 0, 0, 185, 64, 1, 0, 0,
+		0, 186, 187, 7, 10, 0, 0, 187, 188, 7, 4, 0, 0, 188, 189, 7, 8, 0, 0, 189,
+		190, 7, 8, 0, 0, 190, 66, 1, 0, 0, 0, 191, 194, 3, 73, 36, 0, 192, 194,
+		5, 95, 0, 0, 193, 191, 1, 0, 0, 0, 193, 192, 1, 0, 0, 0, 194, 195, 1, 0,
+		0, 0, 195, 193, 1, 0, 0, 0, 195, 196, 1, 0, 0, 0, 196, 202, 1, 0, 0, 0,
+		197, 201, 3, 73, 36, 0, 198, 201, 3, 85, 42, 0, 199, 201, 5, 95, 0, 0,
+		200, 197, 1, 0, 0, 0, 200, 198, 1, 0, 0, 0, 200, 199, 1, 0, 0, 0, 201,
+		204, 1, 0, 0, 0, 202, 200, 1, 0, 0, 0, 202, 203, 1, 0, 0, 0, 203, 68, 1,
+		0, 0, 0, 204, 202, 1, 0, 0, 0, 205, 207, 7, 11, 0, 0, 206, 205, 1, 0, 0,
+		0, 207, 208, 1, 0, 0, 0, 208, 206, 1, 0, 0, 0, 208, 209, 1, 0, 0, 0, 209,
+		210, 1, 0, 0, 0, 210, 211, 6, 34, 0, 0, 211, 70, 1, 0, 0, 0, 212, 213,
+		9, 0, 0, 0, 213, 72, 1, 0, 0, 0, 214, 220, 3, 75, 37, 0, 215, 220, 3, 77,
+		38, 0, 216, 220, 3, 79, 39, 0, 217, 220, 3, 81, 40, 0, 218, 220, 3, 83,
+		41, 0, 219, 214, 1, 0, 0, 0, 219, 215, 1, 0, 0, 0, 219, 216, 1, 0, 0, 0,
+		219, 217, 1, 0, 0, 0, 219, 218, 1, 0, 0, 0, 220, 74, 1, 0, 0, 0, 221, 222,
+		7, 12, 0, 0, 222, 76, 1, 0, 0, 0, 223, 224, 7, 13, 0, 0, 224, 78, 1, 0,
+		0, 0, 225, 226, 7, 14, 0, 0, 226, 80, 1, 0, 0, 0, 227, 228, 7, 15, 0, 0,
+		228, 82, 1, 0, 0, 0, 229, 230, 7, 16, 0, 0, 230, 84, 1, 0, 0, 0, 231, 232,
+		7, 17, 0, 0, 232, 86, 1, 0, 0, 0, 12, 0, 152, 154, 162, 167, 173, 193,
+		195, 200, 202, 208, 219, 1, 6, 0, 0,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -270,31 +288,39 @@ func NewExcellent3Lexer(input antlr.CharStream) *Excellent3Lexer {
 // Excellent3Lexer tokens.
 const (
 	Excellent3LexerCOMMA     = 1
-	Excellent3LexerLPAREN    = 2
-	Excellent3LexerRPAREN    = 3
-	Excellent3LexerLBRACK    = 4
-	Excellent3LexerRBRACK    = 5
-	Excellent3LexerDOT       = 6
-	Excellent3LexerARROW     = 7
-	Excellent3LexerPLUS      = 8
-	Excellent3LexerMINUS     = 9
-	Excellent3LexerTIMES     = 10
-	Excellent3LexerDIVIDE    = 11
-	Excellent3LexerEXPONENT  = 12
-	Excellent3LexerEQ        = 13
-	Excellent3LexerNEQ       = 14
-	Excellent3LexerLTE       = 15
-	Excellent3LexerLT        = 16
-	Excellent3LexerGTE       = 17
-	Excellent3LexerGT        = 18
-	Excellent3LexerAMPERSAND = 19
-	Excellent3LexerTEXT      = 20
-	Excellent3LexerINTEGER   = 21
-	Excellent3LexerDECIMAL   = 22
-	Excellent3LexerTRUE      = 23
-	Excellent3LexerFALSE     = 24
-	Excellent3LexerNULL      = 25
-	Excellent3LexerNAME      = 26
-	Excellent3LexerWS        = 27
-	Excellent3LexerERROR     = 28
+	Excellent3LexerCOLON     = 2
+	Excellent3LexerLPAREN    = 3
+	Excellent3LexerRPAREN    = 4
+	Excellent3LexerLBRACK    = 5
+	Excellent3LexerRBRACK    = 6
+	Excellent3LexerLBRACE    = 7
+	Excellent3LexerRBRACE    = 8
+	Excellent3LexerDOT       = 9
+	Excellent3LexerARROW     = 10
+	Excellent3LexerPLUS      = 11
+	Excellent3LexerMINUS     = 12
+	Excellent3LexerTIMES     = 13
+	Excellent3LexerDIVIDE    = 14
+	Excellent3LexerEXPONENT  = 15
+	Excellent3LexerEQ        = 16
+	Excellent3LexerNEQ       = 17
+	Excellent3LexerLTE       = 18
+	Excellent3LexerLT        = 19
+	Excellent3LexerGTE       = 20
+	Excellent3LexerGT        = 21
+	Excellent3LexerAMPERSAND = 22
+	Excellent3LexerAND       = 23
+	Excellent3LexerOR        = 24
+	Excellent3LexerNOT       = 25
+	Excellent3LexerCOALESCE  = 26
+	Excellent3LexerQUESTION  = 27
+	Excellent3LexerTEXT      = 28
+	Excellent3LexerINTEGER   = 29
+	Excellent3LexerDECIMAL   = 30
+	Excellent3LexerTRUE      = 31
+	Excellent3LexerFALSE     = 32
+	Excellent3LexerNULL      = 33
+	Excellent3LexerNAME      = 34
+	Excellent3LexerWS        = 35
+	Excellent3LexerERROR     = 36
 )
@@ -16,21 +16,33 @@ type Excellent3Listener interface {
 	// EnterComparison is called when entering the comparison production.
 	EnterComparison(c *ComparisonContext)
 
+	// EnterOr is called when entering the or production.
+	EnterOr(c *OrContext)
+
 	// EnterFalse is called when entering the false production.
 	EnterFalse(c *FalseContext)
 
+	// EnterCoalesce is called when entering the coalesce production.
+	EnterCoalesce(c *CoalesceContext)
+
 	// EnterAdditionOrSubtraction is called when entering the additionOrSubtraction production.
 	EnterAdditionOrSubtraction(c *AdditionOrSubtractionContext)
 
 	// EnterTextLiteral is called when entering the textLiteral production.
 	EnterTextLiteral(c *TextLiteralContext)
 
+	// EnterNot is called when entering the not production.
+	EnterNot(c *NotContext)
+
 	// EnterConcatenation is called when entering the concatenation production.
 	EnterConcatenation(c *ConcatenationContext)
 
 	// EnterNull is called when entering the null production.
 	EnterNull(c *NullContext)
 
+	// EnterAnd is called when entering the and production.
+	EnterAnd(c *AndContext)
+
 	// EnterMultiplicationOrDivision is called when entering the multiplicationOrDivision production.
 	EnterMultiplicationOrDivision(c *MultiplicationOrDivisionContext)
 
@@ -46,6 +58,9 @@ type Excellent3Listener interface {
 	// EnterEquality is called when entering the equality production.
 	EnterEquality(c *EqualityContext)
 
+	// EnterTernary is called when entering the ternary production.
+	EnterTernary(c *TernaryContext)
+
 	// EnterNumberLiteral is called when entering the numberLiteral production.
 	EnterNumberLiteral(c *NumberLiteralContext)
 
@@ -58,6 +73,12 @@ type Excellent3Listener interface {
 	// EnterDotLookup is called when entering the dotLookup production.
 	EnterDotLookup(c *DotLookupContext)
 
+	// EnterObjectLiteral is called when entering the objectLiteral production.
+	EnterObjectLiteral(c *ObjectLiteralContext)
+
+	// EnterArrayLiteral is called when entering the arrayLiteral production.
+	EnterArrayLiteral(c *ArrayLiteralContext)
+
 	// EnterFunctionCall is called when entering the functionCall production.
 	EnterFunctionCall(c *FunctionCallContext)
 
@@ -70,6 +91,12 @@ type Excellent3Listener interface {
 	// EnterFunctionParameters is called when entering the functionParameters production.
 	EnterFunctionParameters(c *FunctionParametersContext)
 
+	// EnterProperties is called when entering the properties production.
+	EnterProperties(c *PropertiesContext)
+
+	// EnterProperty is called when entering the property production.
+	EnterProperty(c *PropertyContext)
+
 	// EnterNameList is called when entering the nameList production.
 	EnterNameList(c *NameListContext)
 
@@ -82,21 +109,33 @@ type Excellent3Listener interface {
 	// ExitComparison is called when exiting the comparison production.
 	ExitComparison(c *ComparisonContext)
 
+	// ExitOr is called when exiting the or production.
+	ExitOr(c *OrContext)
+
 	// ExitFalse is called when exiting the false production.
 	ExitFalse(c *FalseContext)
 
+	// ExitCoalesce is called when exiting the coalesce production.
+	ExitCoalesce(c *CoalesceContext)
+
 	// ExitAdditionOrSubtraction is called when exiting the additionOrSubtraction production.
 	ExitAdditionOrSubtraction(c *AdditionOrSubtractionContext)
 
 	// ExitTextLiteral is called when exiting the textLiteral production.
 	ExitTextLiteral(c *TextLiteralContext)
 
+	// ExitNot is called when exiting the not production.
+	ExitNot(c *NotContext)
+
 	// ExitConcatenation is called when exiting the concatenation production.
 	ExitConcatenation(c *ConcatenationContext)
 
 	// ExitNull is called when exiting the null production.
 	ExitNull(c *NullContext)
 
+	// ExitAnd is called when exiting the and production.
+	ExitAnd(c *AndContext)
+
 	// ExitMultiplicationOrDivision is called when exiting the multiplicationOrDivision production.
 	ExitMultiplicationOrDivision(c *MultiplicationOrDivisionContext)
 
@@ -112,6 +151,9 @@ type Excellent3Listener interface {
 	// ExitEquality is called when exiting the equality production.
 	ExitEquality(c *EqualityContext)
 
+	// ExitTernary is called when exiting the ternary production.
+	ExitTernary(c *TernaryContext)
+
 	// ExitNumberLiteral is called when exiting the numberLiteral production.
 	ExitNumberLiteral(c *NumberLiteralContext)
 
@@ -124,6 +166,12 @@ type Excellent3Listener interface {
 	// ExitDotLookup is called when exiting the dotLookup production.
 	ExitDotLookup(c *DotLookupContext)
 
+	// ExitObjectLiteral is called when exiting the objectLiteral production.
+	ExitObjectLiteral(c *ObjectLiteralContext)
+
+	// ExitArrayLiteral is called when exiting the arrayLiteral production.
+	ExitArrayLiteral(c *ArrayLiteralContext)
+
 	// ExitFunctionCall is called when exiting the functionCall production.
 	ExitFunctionCall(c *FunctionCallContext)
 
@@ -136,6 +184,12 @@ type Excellent3Listener interface {
 	// ExitFunctionParameters is called when exiting the functionParameters production.
 	ExitFunctionParameters(c *FunctionParametersContext)
 
+	// ExitProperties is called when exiting the properties production.
+	ExitProperties(c *PropertiesContext)
+
+	// ExitProperty is called when exiting the property production.
+	ExitProperty(c *PropertyContext)
+
 	// ExitNameList is called when exiting the nameList production.
 	ExitNameList(c *NameListContext)
 }
@@ -32,63 +32,86 @@ var Excellent3ParserStaticData struct {
 func excellent3ParserInit() {
 	staticData := &Excellent3ParserStaticData
 	staticData.LiteralNames = []string{
-		"", "','", "'('", "')'", "'['", "']'", "'.'", "'=>'", "'+'", "'-'",
-		"'*'", "'/'", "'^'", "'='", "'!='", "'<='", "'<'", "'>='", "'>'", "'&'",
+		"", "','", "':'", "'('", "')'", "'['", "']'", "'{'", "'}'", "'.'", "'=>'",
+		"'+'", "'-'", "'*'", "'/'", "'^'", "'='", "'!='", "'<='", "'<'", "'>='",
+		"'>'", "'&'", "'&&'", "'||'", "'!'", "'??'", "'?'",
 	}
 	staticData.SymbolicNames = []string{
-		"", "COMMA", "LPAREN", "RPAREN", "LBRACK", "RBRACK", "DOT", "ARROW",
-		"PLUS", "MINUS", "TIMES", "DIVIDE", "EXPONENT", "EQ", "NEQ", "LTE",
-		"LT", "GTE", "GT", "AMPERSAND", "TEXT", "INTEGER", "DECIMAL", "TRUE",
-		"FALSE", "NULL", "NAME", "WS", "ERROR",
+		"", "COMMA", "COLON", "LPAREN", "RPAREN", "LBRACK", "RBRACK", "LBRACE",
+		"RBRACE", "DOT", "ARROW", "PLUS", "MINUS", "TIMES", "DIVIDE", "EXPONENT",
+		"EQ", "NEQ", "LTE", "LT", "GTE", "GT", "AMPERSAND", "AND", "OR", "NOT",
+		"COALESCE", "QUESTION", "TEXT", "INTEGER", "DECIMAL", "TRUE", "FALSE",
+		"NULL", "NAME", "WS", "ERROR",
 	}
 	staticData.RuleNames = []string{
-		"parse", "expression", "atom", "parameters", "nameList",
+		"parse", "expression", "atom", "parameters", "properties", "property",
+		"nameList",
 	}
 	staticData.PredictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 1, 28, 97, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
-		4, 1, 0, 1, 0, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 29, 8, 1, 1, 1, 1, 1, 1, 1,
+		4, 1, 36, 140, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
+		4, 2, 5, 7, 5, 2, 6, 7, 6, 1, 0, 1, 0, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-		1, 1, 1, 1, 1, 1, 5, 1, 49, 8, 1, 10, 1, 12, 1, 52, 9, 1, 1, 2, 1, 2, 1,
-		2, 1, 2, 1, 2, 1, 2, 3, 2, 60, 8, 2, 1, 2, 1, 2, 1, 2, 3, 2, 65, 8, 2,
-		1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 5, 2, 76, 8, 2, 10,
-		2, 12, 2, 79, 9, 2, 1, 3, 1, 3, 1, 3, 5, 3, 84, 8, 3, 10, 3, 12, 3, 87,
-		9, 3, 1, 4, 1, 4, 1, 4, 5, 4, 92, 8, 4, 10, 4, 12, 4, 95, 9, 4, 1, 4, 0,
-		2, 2, 4, 5, 0, 2, 4, 6, 8, 0, 6, 1, 0, 21, 22, 1, 0, 10, 11, 1, 0, 8, 9,
-		1, 0, 15, 18, 1, 0, 13, 14, 2, 0, 21, 21, 26, 26, 111, 0, 10, 1, 0, 0,
-		0, 2, 28, 1, 0, 0, 0, 4, 59, 1, 0, 0, 0, 6, 80, 1, 0, 0, 0, 8, 88, 1, 0,
-		0, 0, 10, 11, 3, 2, 1, 0, 11, 12, 5, 0, 0, 1, 12, 1, 1, 0, 0, 0, 13, 14,
-		6, 1, -1, 0, 14, 29, 3, 4, 2, 0, 15, 16, 5, 9, 0, 0, 16, 29, 3, 2, 1, 13,
-		17, 18, 5, 2, 0, 0, 18, 19, 3, 8, 4, 0, 19, 20, 5, 3, 0, 0, 20, 21, 5,
-		7, 0, 0, 21, 22, 3, 2, 1, 6, 22, 29, 1, 0, 0, 0, 23, 29, 5, 20, 0, 0, 24,
-		29, 7, 0, 0, 0, 25, 29, 5, 23, 0, 0, 26, 29, 5, 24, 0, 0, 27, 29, 5, 25,
-		0, 0, 28, 13, 1, 0, 0, 0, 28, 15, 1, 0, 0, 0, 28, 17, 1, 0, 0, 0, 28, 23,
-		1, 0, 0, 0, 28, 24, 1, 0, 0, 0, 28, 25, 1, 0, 0, 0, 28, 26, 1, 0, 0, 0,
-		28, 27, 1, 0, 0, 0, 29, 50, 1, 0, 0, 0, 30, 31, 10, 12, 0, 0, 31, 32, 5,
-		12, 0, 0, 32, 49, 3, 2, 1, 13, 33, 34, 10, 11, 0, 0, 34, 35, 7, 1, 0, 0,
-		35, 49, 3, 2, 1, 12, 36, 37, 10, 10, 0, 0, 37, 38, 7, 2, 0, 0, 38, 49,
-		3, 2, 1, 11, 39, 40, 10, 9, 0, 0, 40, 41, 7, 3, 0, 0, 41, 49, 3, 2, 1,
-		10, 42, 43, 10, 8, 0, 0, 43, 44, 7, 4, 0, 0, 44, 49, 3, 2, 1, 9, 45, 46,
-		10, 7, 0, 0, 46, 47, 5, 19, 0, 0, 47, 49, 3, 2, 1, 8, 48, 30, 1, 0, 0,
-		0, 48, 33, 1, 0, 0, 0, 48, 36, 1, 0, 0, 0, 48, 39, 1, 0, 0, 0, 48, 42,
-		1, 0, 0, 0, 48, 45, 1, 0, 0, 0, 49, 52, 1, 0, 0, 0, 50, 48, 1, 0, 0, 0,
-		50, 51, 1, 0, 0, 0, 51, 3, 1, 0, 0, 0, 52, 50, 1, 0, 0, 0, 53, 54, 6, 2,
-		-1, 0, 54, 55, 5, 2, 0, 0, 55, 56, 3, 2, 1, 0, 56, 57, 5, 3, 0, 0, 57,
-		60, 1, 0, 0, 0, 58, 60, 5, 26, 0, 0, 59, 53, 1, 0, 0, 0, 59, 58, 1, 0,
-		0, 0, 60, 77, 1, 0, 0, 0, 61, 62, 10, 5, 0, 0, 62, 64, 5, 2, 0, 0, 63,
-		65, 3, 6, 3, 0, 64, 63, 1, 0, 0, 0, 64, 65, 1, 0, 0, 0, 65, 66, 1, 0, 0,
-		0, 66, 76, 5, 3, 0, 0, 67, 68, 10, 4, 0, 0, 68, 69, 5, 6, 0, 0, 69, 76,
-		7, 5, 0, 0, 70, 71, 10, 3, 0, 0, 71, 72, 5, 4, 0, 0, 72, 73, 3, 2, 1, 0,
-		73, 74, 5, 5, 0, 0, 74, 76, 1, 0, 0, 0, 75, 61, 1, 0, 0, 0, 75, 67, 1,
-		0, 0, 0, 75, 70, 1, 0, 0, 0, 76, 79, 1, 0, 0, 0, 77, 75, 1, 0, 0, 0, 77,
-		78, 1, 0, 0, 0, 78, 5, 1, 0, 0, 0, 79, 77, 1, 0, 0, 0, 80, 85, 3, 2, 1,
-		0, 81, 82, 5, 1, 0, 0, 82, 84, 3, 2, 1, 0, 83, 81, 1, 0, 0, 0, 84, 87,
-		1, 0, 0, 0, 85, 83, 1, 0, 0, 0, 85, 86, 1, 0, 0, 0, 86, 7, 1, 0, 0, 0,
-		87, 85, 1, 0, 0, 0, 88, 93, 5, 26, 0, 0, 89, 90, 5, 1, 0, 0, 90, 92, 5,
-		26, 0, 0, 91, 89, 1, 0, 0, 0, 92, 95, 1, 0, 0, 0, 93, 91, 1, 0, 0, 0, 93,
-		94, 1, 0, 0, 0, 94, 9, 1, 0, 0, 0, 95, 93, 1, 0, 0, 0, 9, 28, 48, 50, 59,
-		64, 75, 77, 85, 93,
+		1, 3, 1, 35, 8, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		5, 1, 70, 8, 1, 10, 1, 12, 1, 73, 9, 1, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1,
+		2, 1, 2, 3, 2, 82, 8, 2, 1, 2, 1, 2, 1, 2, 3, 2, 87, 8, 2, 1, 2, 1, 2,
+		3, 2, 91, 8, 2, 1, 2, 1, 2, 1, 2, 3, 2, 96, 8, 2, 1, 2, 1, 2, 1, 2, 1,
+		2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 5, 2, 107, 8, 2, 10, 2, 12, 2, 110, 9,
+		2, 1, 3, 1, 3, 1, 3, 5, 3, 115, 8, 3, 10, 3, 12, 3, 118, 9, 3, 1, 4, 1,
+		4, 1, 4, 5, 4, 123, 8, 4, 10, 4, 12, 4, 126, 9, 4, 1, 5, 1, 5, 1, 5, 1,
+		5, 1, 6, 1, 6, 1, 6, 5, 6, 135, 8, 6, 10, 6, 12, 6, 138, 9, 6, 1, 6, 0,
+		2, 2, 4, 7, 0, 2, 4, 6, 8, 10, 12, 0, 6, 1, 0, 29, 30, 1, 0, 13, 14, 1,
+		0, 11, 12, 1, 0, 18, 21, 1, 0, 16, 17, 2, 0, 29, 29, 34, 34, 162, 0, 14,
+		1, 0, 0, 0, 2, 34, 1, 0, 0, 0, 4, 90, 1, 0, 0, 0, 6, 111, 1, 0, 0, 0, 8,
+		119, 1, 0, 0, 0, 10, 127, 1, 0, 0, 0, 12, 131, 1, 0, 0, 0, 14, 15, 3, 2,
+		1, 0, 15, 16, 5, 0, 0, 1, 16, 1, 1, 0, 0, 0, 17, 18, 6, 1, -1, 0, 18, 35,
+		3, 4, 2, 0, 19, 20, 5, 12, 0, 0, 20, 35, 3, 2, 1, 18, 21, 22, 5, 25, 0,
+		0, 22, 35, 3, 2, 1, 17, 23, 24, 5, 3, 0, 0, 24, 25, 3, 12, 6, 0, 25, 26,
+		5, 4, 0, 0, 26, 27, 5, 10, 0, 0, 27, 28, 3, 2, 1, 6, 28, 35, 1, 0, 0, 0,
+		29, 35, 5, 28, 0, 0, 30, 35, 7, 0, 0, 0, 31, 35, 5, 31, 0, 0, 32, 35, 5,
+		32, 0, 0, 33, 35, 5, 33, 0, 0, 34, 17, 1, 0, 0, 0, 34, 19, 1, 0, 0, 0,
+		34, 21, 1, 0, 0, 0, 34, 23, 1, 0, 0, 0, 34, 29, 1, 0, 0, 0, 34, 30, 1,
+		0, 0, 0, 34, 31, 1, 0, 0, 0, 34, 32, 1, 0, 0, 0, 34, 33, 1, 0, 0, 0, 35,
+		71, 1, 0, 0, 0, 36, 37, 10, 16, 0, 0, 37, 38, 5, 15, 0, 0, 38, 70, 3, 2,
+		1, 17, 39, 40, 10, 15, 0, 0, 40, 41, 7, 1, 0, 0, 41, 70, 3, 2, 1, 16, 42,
+		43, 10, 14, 0, 0, 43, 44, 7, 2, 0, 0, 44, 70, 3, 2, 1, 15, 45, 46, 10,
+		13, 0, 0, 46, 47, 7, 3, 0, 0, 47, 70, 3, 2, 1, 14, 48, 49, 10, 12, 0, 0,
+		49, 50, 7, 4, 0, 0, 50, 70, 3, 2, 1, 13, 51, 52, 10, 11, 0, 0, 52, 53,
+		5, 22, 0, 0, 53, 70, 3, 2, 1, 12, 54, 55, 10, 10, 0, 0, 55, 56, 5, 23,
+		0, 0, 56, 70, 3, 2, 1, 11, 57, 58, 10, 9, 0, 0, 58, 59, 5, 24, 0, 0, 59,
+		70, 3, 2, 1, 10, 60, 61, 10, 8, 0, 0, 61, 62, 5, 26, 0, 0, 62, 70, 3, 2,
+		1, 9, 63, 64, 10, 7, 0, 0, 64, 65, 5, 27, 0, 0, 65, 66, 3, 2, 1, 0, 66,
+		67, 5, 2, 0, 0, 67, 68, 3, 2, 1, 7, 68, 70, 1, 0, 0, 0, 69, 36, 1, 0, 0,
+		0, 69, 39, 1, 0, 0, 0, 69, 42, 1, 0, 0, 0, 69, 45, 1, 0, 0, 0, 69, 48,
+		1, 0, 0, 0, 69, 51, 1, 0, 0, 0, 69, 54, 1, 0, 0, 0, 69, 57, 1, 0, 0, 0,
+		69, 60, 1, 0, 0, 0, 69, 63, 1, 0, 0, 0, 70, 73, 1, 0, 0, 0, 71, 69, 1,
+		0, 0, 0, 71, 72, 1, 0, 0, 0, 72, 3, 1, 0, 0, 0, 73, 71, 1, 0, 0, 0, 74,
+		75, 6, 2, -1, 0, 75, 76, 5, 3, 0, 0, 76, 77, 3, 2, 1, 0, 77, 78, 5, 4,
+		0, 0, 78, 91, 1, 0, 0, 0, 79, 81, 5, 5, 0, 0, 80, 82, 3, 6, 3, 0, 81, 80,
+		1, 0, 0, 0, 81, 82, 1, 0, 0, 0, 82, 83, 1, 0, 0, 0, 83, 91, 5, 6, 0, 0,
+		84, 86, 5, 7, 0, 0, 85, 87, 3, 8, 4, 0, 86, 85, 1, 0, 0, 0, 86, 87, 1,
+		0, 0, 0, 87, 88, 1, 0, 0, 0, 88, 91, 5, 8, 0, 0, 89, 91, 5, 34, 0, 0, 90,
+		74, 1, 0, 0, 0, 90, 79, 1, 0, 0, 0, 90, 84, 1, 0, 0, 0, 90, 89, 1, 0, 0,
+		0, 91, 108, 1, 0, 0, 0, 92, 93, 10, 7, 0, 0, 93, 95, 5, 3, 0, 0, 94, 96,
+		3, 6, 3, 0, 95, 94, 1, 0, 0, 0, 95, 96, 1, 0, 0, 0, 96, 97, 1, 0, 0, 0,
+		97, 107, 5, 4, 0, 0, 98, 99, 10, 6, 0, 0, 99, 100, 5, 9, 0, 0, 100, 107,
+		7, 5, 0, 0, 101, 102, 10, 5, 0, 0, 102, 103, 5, 5, 0, 0, 103, 104, 3, 2,
+		1, 0, 104, 105, 5, 6, 0, 0, 105, 107, 1, 0, 0, 0, 106, 92, 1, 0, 0, 0,
+		106, 98, 1, 0, 0, 0, 106, 101, 1, 0, 0, 0, 107, 110, 1, 0, 0, 0, 108, 106,
+		1, 0, 0, 0, 108, 109, 1, 0, 0, 0, 109, 5, 1, 0, 0, 0, 110, 108, 1, 0, 0,
+		0, 111, 116, 3, 2, 1, 0, 112, 113, 5, 1, 0, 0, 113, 115, 3, 2, 1, 0, 114,
+		112, 1, 0, 0, 0, 115, 118, 1, 0, 0, 0, 116, 114, 1, 0, 0, 0, 116, 117,
+		1, 0, 0, 0, 117, 7, 1, 0, 0, 0, 118, 116, 1, 0, 0, 0, 119, 124, 3, 10,
+		5, 0, 120, 121, 5, 1, 0, 0, 121, 123, 3, 10, 5, 0, 122, 120, 1, 0, 0, 0,
+		123, 126, 1, 0, 0, 0, 124, 122, 1, 0, 0, 0, 124, 125, 1, 0, 0, 0, 125,
+		9, 1, 0, 0, 0, 126, 124, 1, 0, 0, 0, 127, 128, 5, 28, 0, 0, 128, 129, 5,
+		2, 0, 0, 129, 130, 3, 2, 1, 0, 130, 11, 1, 0, 0, 0, 131, 136, 5, 34, 0,
+		0, 132, 133, 5, 1, 0, 0, 133, 135, 5, 34, 0, 0, 134, 132, 1, 0, 0, 0, 135,
+		138, 1, 0, 0, 0, 136, 134, 1, 0, 0, 0, 136, 137, 1, 0, 0, 0, 137, 13, 1,
+		0, 0, 0, 138, 136, 1, 0, 0, 0, 12, 34, 69, 71, 81, 86, 90, 95, 106, 108,
+		116, 124, 136,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -128,33 +151,41 @@ func NewExcellent3Parser(input antlr.TokenStream) *Excellent3Parser {
 const (
 	Excellent3ParserEOF       = antlr.TokenEOF
 	Excellent3ParserCOMMA     = 1
-	Excellent3ParserLPAREN    = 2
-	Excellent3ParserRPAREN    = 3
-	Excellent3ParserLBRACK    = 4
-	Excellent3ParserRBRACK    = 5
-	Excellent3ParserDOT       = 6
-	Excellent3ParserARROW     = 7
-	Excellent3ParserPLUS      = 8
-	Excellent3ParserMINUS     = 9
-	Excellent3ParserTIMES     = 10
-	Excellent3ParserDIVIDE    = 11
-	Excellent3ParserEXPONENT  = 12
-	Excellent3ParserEQ        = 13
-	Excellent3ParserNEQ       = 14
-	Excellent3ParserLTE       = 15
-	Excellent3ParserLT        = 16
-	Excellent3ParserGTE       = 17
-	Excellent3ParserGT        = 18
-	Excellent3ParserAMPERSAND = 19
-	Excellent3ParserTEXT      = 20
-	Excellent3ParserINTEGER   = 21
-	Excellent3ParserDECIMAL   = 22
-	Excellent3ParserTRUE      = 23
-	Excellent3ParserFALSE     = 24
-	Excellent3ParserNULL      = 25
-	Excellent3ParserNAME      = 26
-	Excellent3ParserWS        = 27
-	Excellent3ParserERROR     = 28
+	Excellent3ParserCOLON     = 2
+	Excellent3ParserLPAREN    = 3
+	Excellent3ParserRPAREN    = 4
+	Excellent3ParserLBRACK    = 5
+	Excellent3ParserRBRACK    = 6
+	Excellent3ParserLBRACE    = 7
+	Excellent3ParserRBRACE    = 8
+	Excellent3ParserDOT       = 9
+	Excellent3ParserARROW     = 10
+	Excellent3ParserPLUS      = 11
+	Excellent3ParserMINUS     = 12
+	Excellent3ParserTIMES     = 13
+	Excellent3ParserDIVIDE    = 14
+	Excellent3ParserEXPONENT  = 15
+	Excellent3ParserEQ        = 16
+	Excellent3ParserNEQ       = 17
+	Excellent3ParserLTE       = 18
+	Excellent3ParserLT        = 19
+	Excellent3ParserGTE       = 20
+	Excellent3ParserGT        = 21
+	Excellent3ParserAMPERSAND = 22
+	Excellent3ParserAND       = 23
+	Excellent3ParserOR        = 24
+	Excellent3ParserNOT       = 25
+	Excellent3ParserCOALESCE  = 26
+	Excellent3ParserQUESTION  = 27
+	Excellent3ParserTEXT      = 28
+	Excellent3ParserINTEGER   = 29
+	Excellent3ParserDECIMAL   = 30
+	Excellent3ParserTRUE      = 31
+	Excellent3ParserFALSE     = 32
+	Excellent3ParserNULL      = 33
+	Excellent3ParserNAME      = 34
+	Excellent3ParserWS        = 35
+	Excellent3ParserERROR     = 36
 )
 
 // Excellent3Parser rules.
@@ -163,7 +194,9 @@ const (
 	Excellent3ParserRULE_expression = 1
 	Excellent3ParserRULE_atom       = 2
 	Excellent3ParserRULE_parameters = 3
-	Excellent3ParserRULE_nameList   = 4
+	Excellent3ParserRULE_properties = 4
+	Excellent3ParserRULE_property   = 5
+	Excellent3ParserRULE_nameList   = 6
 )
 
 // IParseContext is an interface to support dynamic dispatch.
@@ -268,11 +301,11 @@ func (p *Excellent3Parser) Parse() (localctx IParseContext) {
 	p.EnterRule(localctx, 0, Excellent3ParserRULE_parse)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(10)
+		p.SetState(14)
 		p.expression(0)
 	}
 	{
-		p.SetState(11)
+		p.SetState(15)
 		p.Match(Excellent3ParserEOF)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -509,6 +542,91 @@ func (s *ComparisonContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	}
 }
 
+type OrContext struct {
+	ExpressionContext
+}
+
+func NewOrContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *OrContext {
+	var p = new(OrContext)
+
+	InitEmptyExpressionContext(&p.ExpressionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *OrContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *OrContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *OrContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *OrContext) OR() antlr.TerminalNode {
+	return s.GetToken(Excellent3ParserOR, 0)
+}
+
+func (s *OrContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent3Listener); ok {
+		listenerT.EnterOr(s)
+	}
+}
+
+func (s *OrContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent3Listener); ok {
+		listenerT.ExitOr(s)
+	}
+}
+
+func (s *OrContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case Excellent3Visitor:
+		return t.VisitOr(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
 type FalseContext struct {
 	ExpressionContext
 }
@@ -553,6 +671,91 @@ func (s *FalseContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	}
 }
 
+type CoalesceContext struct {
+	ExpressionContext
+}
+
+func NewCoalesceContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *CoalesceContext {
+	var p = new(CoalesceContext)
+
+	InitEmptyExpressionContext(&p.ExpressionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *CoalesceContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CoalesceContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *CoalesceContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *CoalesceContext) COALESCE() antlr.TerminalNode {
+	return s.GetToken(Excellent3ParserCOALESCE, 0)
+}
+
+func (s *CoalesceContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent3Listener); ok {
+		listenerT.EnterCoalesce(s)
+	}
+}
+
+func (s *CoalesceContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent3Listener); ok {
+		listenerT.ExitCoalesce(s)
+	}
+}
+
+func (s *CoalesceContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case Excellent3Visitor:
+		return t.VisitCoalesce(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
 type AdditionOrSubtractionContext struct {
 	ExpressionContext
 	op antlr.Token
@@ -691,6 +894,66 @@ func (s *TextLiteralContext) Accept(visitor antlr.ParseTreeVisitor) interface{}
 	}
 }
 
+type NotContext struct {
+	ExpressionContext
+}
+
+func NewNotContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *NotContext {
+	var p = new(NotContext)
+
+	InitEmptyExpressionContext(&p.ExpressionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *NotContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *NotContext) NOT() antlr.TerminalNode {
+	return s.GetToken(Excellent3ParserNOT, 0)
+}
+
+func (s *NotContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *NotContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent3Listener); ok {
+		listenerT.EnterNot(s)
+	}
+}
+
+func (s *NotContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent3Listener); ok {
+		listenerT.ExitNot(s)
+	}
+}
+
+func (s *NotContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case Excellent3Visitor:
+		return t.VisitNot(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
 type ConcatenationContext struct {
 	ExpressionContext
 }
@@ -820,13 +1083,12 @@ func (s *NullContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	}
 }
 
-type MultiplicationOrDivisionContext struct {
+type AndContext struct {
 	ExpressionContext
-	op antlr.Token
 }
 
-func NewMultiplicationOrDivisionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *MultiplicationOrDivisionContext {
-	var p = new(MultiplicationOrDivisionContext)
+func NewAndContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *AndContext {
+	var p = new(AndContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -835,15 +1097,11 @@ func NewMultiplicationOrDivisionContext(parser antlr.Parser, ctx antlr.ParserRul
 	return p
 }
 
-func (s *MultiplicationOrDivisionContext) GetOp() antlr.Token { return s.op }
-
-func (s *MultiplicationOrDivisionContext) SetOp(v antlr.Token) { s.op = v }
-
-func (s *MultiplicationOrDivisionContext) GetRuleContext() antlr.RuleContext {
+func (s *AndContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *MultiplicationOrDivisionContext) AllExpression() []IExpressionContext {
+func (s *AndContext) AllExpression() []IExpressionContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
@@ -864,7 +1122,7 @@ func (s *MultiplicationOrDivisionContext) AllExpression() []IExpressionContext {
 	return tst
 }
 
-func (s *MultiplicationOrDivisionContext) Expression(i int) IExpressionContext {
+func (s *AndContext) Expression(i int) IExpressionContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
@@ -884,42 +1142,39 @@ func (s *MultiplicationOrDivisionContext) Expression(i int) IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-func (s *MultiplicationOrDivisionContext) TIMES() antlr.TerminalNode {
-	return s.GetToken(Excellent3ParserTIMES, 0)
-}
-
-func (s *MultiplicationOrDivisionContext) DIVIDE() antlr.TerminalNode {
-	return s.GetToken(Excellent3ParserDIVIDE, 0)
+func (s *AndContext) AND() antlr.TerminalNode {
+	return s.GetToken(Excellent3ParserAND, 0)
 }
 
-func (s *MultiplicationOrDivisionContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *AndContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(Excellent3Listener); ok {
-		listenerT.EnterMultiplicationOrDivision(s)
+		listenerT.EnterAnd(s)
 	}
 }
 
-func (s *MultiplicationOrDivisionContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *AndContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(Excellent3Listener); ok {
-		listenerT.ExitMultiplicationOrDivision(s)
+		listenerT.ExitAnd(s)
 	}
 }
 
-func (s *MultiplicationOrDivisionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+func (s *AndContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	switch t := visitor.(type) {
 	case Excellent3Visitor:
-		return t.VisitMultiplicationOrDivision(s)
+		return t.VisitAnd(s)
 
 	default:
 		return t.VisitChildren(s)
 	}
 }
 
-type TrueContext struct {
+type MultiplicationOrDivisionContext struct {
 	ExpressionContext
+	op antlr.Token
 }
 
-func NewTrueContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *TrueContext {
-	var p = new(TrueContext)
+func NewMultiplicationOrDivisionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *MultiplicationOrDivisionContext {
+	var p = new(MultiplicationOrDivisionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -928,28 +1183,121 @@ func NewTrueContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *TrueConte
 	return p
 }
 
-func (s *TrueContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
+func (s *MultiplicationOrDivisionContext) GetOp() antlr.Token { return s.op }
 
-func (s *TrueContext) TRUE() antlr.TerminalNode {
-	return s.GetToken(Excellent3ParserTRUE, 0)
+func (s *MultiplicationOrDivisionContext) SetOp(v antlr.Token) { s.op = v }
+
+func (s *MultiplicationOrDivisionContext) GetRuleContext() antlr.RuleContext {
+	return s
 }
 
-func (s *TrueContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(Excellent3Listener); ok {
-		listenerT.EnterTrue(s)
+func (s *MultiplicationOrDivisionContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
 	}
-}
 
-func (s *TrueContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(Excellent3Listener); ok {
-		listenerT.ExitTrue(s)
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
 	}
+
+	return tst
 }
 
-func (s *TrueContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
-	switch t := visitor.(type) {
+func (s *MultiplicationOrDivisionContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *MultiplicationOrDivisionContext) TIMES() antlr.TerminalNode {
+	return s.GetToken(Excellent3ParserTIMES, 0)
+}
+
+func (s *MultiplicationOrDivisionContext) DIVIDE() antlr.TerminalNode {
+	return s.GetToken(Excellent3ParserDIVIDE, 0)
+}
+
+func (s *MultiplicationOrDivisionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent3Listener); ok {
+		listenerT.EnterMultiplicationOrDivision(s)
+	}
+}
+
+func (s *MultiplicationOrDivisionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent3Listener); ok {
+		listenerT.ExitMultiplicationOrDivision(s)
+	}
+}
+
+func (s *MultiplicationOrDivisionContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case Excellent3Visitor:
+		return t.VisitMultiplicationOrDivision(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+type TrueContext struct {
+	ExpressionContext
+}
+
+func NewTrueContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *TrueContext {
+	var p = new(TrueContext)
+
+	InitEmptyExpressionContext(&p.ExpressionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *TrueContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *TrueContext) TRUE() antlr.TerminalNode {
+	return s.GetToken(Excellent3ParserTRUE, 0)
+}
+
+func (s *TrueContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent3Listener); ok {
+		listenerT.EnterTrue(s)
+	}
+}
+
+func (s *TrueContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent3Listener); ok {
+		listenerT.ExitTrue(s)
+	}
+}
+
+func (s *TrueContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
 	case Excellent3Visitor:
 		return t.VisitTrue(s)
 
@@ -1192,6 +1540,95 @@ func (s *EqualityContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	}
 }
 
+type TernaryContext struct {
+	ExpressionContext
+}
+
+func NewTernaryContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *TernaryContext {
+	var p = new(TernaryContext)
+
+	InitEmptyExpressionContext(&p.ExpressionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *TernaryContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *TernaryContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *TernaryContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *TernaryContext) QUESTION() antlr.TerminalNode {
+	return s.GetToken(Excellent3ParserQUESTION, 0)
+}
+
+func (s *TernaryContext) COLON() antlr.TerminalNode {
+	return s.GetToken(Excellent3ParserCOLON, 0)
+}
+
+func (s *TernaryContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent3Listener); ok {
+		listenerT.EnterTernary(s)
+	}
+}
+
+func (s *TernaryContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent3Listener); ok {
+		listenerT.ExitTernary(s)
+	}
+}
+
+func (s *TernaryContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case Excellent3Visitor:
+		return t.VisitTernary(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
 type NumberLiteralContext struct {
 	ExpressionContext
 }
@@ -1343,7 +1780,7 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(28)
+	p.SetState(34)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -1356,7 +1793,7 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 		_prevctx = localctx
 
 		{
-			p.SetState(14)
+			p.SetState(18)
 			p.atom(0)
 		}
 
@@ -1365,7 +1802,7 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(15)
+			p.SetState(19)
 			p.Match(Excellent3ParserMINUS)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -1373,16 +1810,33 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 			}
 		}
 		{
-			p.SetState(16)
-			p.expression(13)
+			p.SetState(20)
+			p.expression(18)
 		}
 
 	case 3:
+		localctx = NewNotContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(21)
+			p.Match(Excellent3ParserNOT)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(22)
+			p.expression(17)
+		}
+
+	case 4:
 		localctx = NewAnonFunctionContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(17)
+			p.SetState(23)
 			p.Match(Excellent3ParserLPAREN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -1390,11 +1844,11 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 			}
 		}
 		{
-			p.SetState(18)
+			p.SetState(24)
 			p.NameList()
 		}
 		{
-			p.SetState(19)
+			p.SetState(25)
 			p.Match(Excellent3ParserRPAREN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -1402,7 +1856,7 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 			}
 		}
 		{
-			p.SetState(20)
+			p.SetState(26)
 			p.Match(Excellent3ParserARROW)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -1410,16 +1864,16 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 			}
 		}
 		{
-			p.SetState(21)
+			p.SetState(27)
 			p.expression(6)
 		}
 
-	case 4:
+	case 5:
 		localctx = NewTextLiteralContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(23)
+			p.SetState(29)
 			p.Match(Excellent3ParserTEXT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -1427,12 +1881,12 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 			}
 		}
 
-	case 5:
+	case 6:
 		localctx = NewNumberLiteralContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(24)
+			p.SetState(30)
 			_la = p.GetTokenStream().LA(1)
 
 			if !(_la == Excellent3ParserINTEGER || _la == Excellent3ParserDECIMAL) {
@@ -1443,12 +1897,12 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 			}
 		}
 
-	case 6:
+	case 7:
 		localctx = NewTrueContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(25)
+			p.SetState(31)
 			p.Match(Excellent3ParserTRUE)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -1456,12 +1910,12 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 			}
 		}
 
-	case 7:
+	case 8:
 		localctx = NewFalseContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(26)
+			p.SetState(32)
 			p.Match(Excellent3ParserFALSE)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -1469,12 +1923,12 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 			}
 		}
 
-	case 8:
+	case 9:
 		localctx = NewNullContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(27)
+			p.SetState(33)
 			p.Match(Excellent3ParserNULL)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -1486,7 +1940,7 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 		goto errorExit
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(50)
+	p.SetState(71)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -1501,7 +1955,7 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 				p.TriggerExitRuleEvent()
 			}
 			_prevctx = localctx
-			p.SetState(48)
+			p.SetState(69)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -1511,14 +1965,14 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 			case 1:
 				localctx = NewExponentContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, Excellent3ParserRULE_expression)
-				p.SetState(30)
+				p.SetState(36)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 12)) {
-					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 12)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 16)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 16)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(31)
+					p.SetState(37)
 					p.Match(Excellent3ParserEXPONENT)
 					if p.HasError() {
 						// Recognition error - abort rule
@@ -1526,21 +1980,21 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(32)
-					p.expression(13)
+					p.SetState(38)
+					p.expression(17)
 				}
 
 			case 2:
 				localctx = NewMultiplicationOrDivisionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, Excellent3ParserRULE_expression)
-				p.SetState(33)
+				p.SetState(39)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 11)) {
-					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 11)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 15)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 15)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(34)
+					p.SetState(40)
 
 					var _lt = p.GetTokenStream().LT(1)
 
@@ -1558,21 +2012,21 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(35)
-					p.expression(12)
+					p.SetState(41)
+					p.expression(16)
 				}
 
 			case 3:
 				localctx = NewAdditionOrSubtractionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, Excellent3ParserRULE_expression)
-				p.SetState(36)
+				p.SetState(42)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 10)) {
-					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 10)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 14)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 14)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(37)
+					p.SetState(43)
 
 					var _lt = p.GetTokenStream().LT(1)
 
@@ -1590,21 +2044,21 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(38)
-					p.expression(11)
+					p.SetState(44)
+					p.expression(15)
 				}
 
 			case 4:
 				localctx = NewComparisonContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, Excellent3ParserRULE_expression)
-				p.SetState(39)
+				p.SetState(45)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 9)) {
-					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 9)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 13)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 13)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(40)
+					p.SetState(46)
 
 					var _lt = p.GetTokenStream().LT(1)
 
@@ -1612,7 +2066,7 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 
 					_la = p.GetTokenStream().LA(1)
 
-					if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&491520) != 0) {
+					if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&3932160) != 0) {
 						var _ri = p.GetErrorHandler().RecoverInline(p)
 
 						localctx.(*ComparisonContext).op = _ri
@@ -1622,21 +2076,21 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(41)
-					p.expression(10)
+					p.SetState(47)
+					p.expression(14)
 				}
 
 			case 5:
 				localctx = NewEqualityContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, Excellent3ParserRULE_expression)
-				p.SetState(42)
+				p.SetState(48)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 8)) {
-					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 8)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 12)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 12)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(43)
+					p.SetState(49)
 
 					var _lt = p.GetTokenStream().LT(1)
 
@@ -1654,21 +2108,21 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(44)
-					p.expression(9)
+					p.SetState(50)
+					p.expression(13)
 				}
 
 			case 6:
 				localctx = NewConcatenationContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, Excellent3ParserRULE_expression)
-				p.SetState(45)
+				p.SetState(51)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 7)) {
-					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 7)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 11)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 11)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(46)
+					p.SetState(52)
 					p.Match(Excellent3ParserAMPERSAND)
 					if p.HasError() {
 						// Recognition error - abort rule
@@ -1676,22 +2130,122 @@ func (p *Excellent3Parser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(47)
-					p.expression(8)
+					p.SetState(53)
+					p.expression(12)
 				}
 
-			case antlr.ATNInvalidAltNumber:
-				goto errorExit
-			}
+			case 7:
+				localctx = NewAndContext(p, NewExpressionContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, Excellent3ParserRULE_expression)
+				p.SetState(54)
 
-		}
-		p.SetState(52)
-		p.GetErrorHandler().Sync(p)
-		if p.HasError() {
-			goto errorExit
-		}
-		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 2, p.GetParserRuleContext())
-		if p.HasError() {
+				if !(p.Precpred(p.GetParserRuleContext(), 10)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 10)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(55)
+					p.Match(Excellent3ParserAND)
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(56)
+					p.expression(11)
+				}
+
+			case 8:
+				localctx = NewOrContext(p, NewExpressionContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, Excellent3ParserRULE_expression)
+				p.SetState(57)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 9)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 9)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(58)
+					p.Match(Excellent3ParserOR)
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(59)
+					p.expression(10)
+				}
+
+			case 9:
+				localctx = NewCoalesceContext(p, NewExpressionContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, Excellent3ParserRULE_expression)
+				p.SetState(60)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 8)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 8)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(61)
+					p.Match(Excellent3ParserCOALESCE)
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(62)
+					p.expression(9)
+				}
+
+			case 10:
+				localctx = NewTernaryContext(p, NewExpressionContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, Excellent3ParserRULE_expression)
+				p.SetState(63)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 7)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 7)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(64)
+					p.Match(Excellent3ParserQUESTION)
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(65)
+					p.expression(0)
+				}
+				{
+					p.SetState(66)
+					p.Match(Excellent3ParserCOLON)
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(67)
+					p.expression(7)
+				}
+
+			case antlr.ATNInvalidAltNumber:
+				goto errorExit
+			}
+
+		}
+		p.SetState(73)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 2, p.GetParserRuleContext())
+		if p.HasError() {
 			goto errorExit
 		}
 	}
@@ -1895,6 +2449,134 @@ func (s *DotLookupContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 	}
 }
 
+type ObjectLiteralContext struct {
+	AtomContext
+}
+
+func NewObjectLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ObjectLiteralContext {
+	var p = new(ObjectLiteralContext)
+
+	InitEmptyAtomContext(&p.AtomContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*AtomContext))
+
+	return p
+}
+
+func (s *ObjectLiteralContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ObjectLiteralContext) LBRACE() antlr.TerminalNode {
+	return s.GetToken(Excellent3ParserLBRACE, 0)
+}
+
+func (s *ObjectLiteralContext) RBRACE() antlr.TerminalNode {
+	return s.GetToken(Excellent3ParserRBRACE, 0)
+}
+
+func (s *ObjectLiteralContext) Properties() IPropertiesContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IPropertiesContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IPropertiesContext)
+}
+
+func (s *ObjectLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent3Listener); ok {
+		listenerT.EnterObjectLiteral(s)
+	}
+}
+
+func (s *ObjectLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent3Listener); ok {
+		listenerT.ExitObjectLiteral(s)
+	}
+}
+
+func (s *ObjectLiteralContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case Excellent3Visitor:
+		return t.VisitObjectLiteral(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+type ArrayLiteralContext struct {
+	AtomContext
+}
+
+func NewArrayLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ArrayLiteralContext {
+	var p = new(ArrayLiteralContext)
+
+	InitEmptyAtomContext(&p.AtomContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*AtomContext))
+
+	return p
+}
+
+func (s *ArrayLiteralContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ArrayLiteralContext) LBRACK() antlr.TerminalNode {
+	return s.GetToken(Excellent3ParserLBRACK, 0)
+}
+
+func (s *ArrayLiteralContext) RBRACK() antlr.TerminalNode {
+	return s.GetToken(Excellent3ParserRBRACK, 0)
+}
+
+func (s *ArrayLiteralContext) Parameters() IParametersContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IParametersContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IParametersContext)
+}
+
+func (s *ArrayLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent3Listener); ok {
+		listenerT.EnterArrayLiteral(s)
+	}
+}
+
+func (s *ArrayLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent3Listener); ok {
+		listenerT.ExitArrayLiteral(s)
+	}
+}
+
+func (s *ArrayLiteralContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case Excellent3Visitor:
+		return t.VisitArrayLiteral(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
 type FunctionCallContext struct {
 	AtomContext
 }
@@ -2117,7 +2799,7 @@ func (p *Excellent3Parser) atom(_p int) (localctx IAtomContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(59)
+	p.SetState(90)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -2130,7 +2812,7 @@ func (p *Excellent3Parser) atom(_p int) (localctx IAtomContext) {
 		_prevctx = localctx
 
 		{
-			p.SetState(54)
+			p.SetState(75)
 			p.Match(Excellent3ParserLPAREN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2138,11 +2820,11 @@ func (p *Excellent3Parser) atom(_p int) (localctx IAtomContext) {
 			}
 		}
 		{
-			p.SetState(55)
+			p.SetState(76)
 			p.expression(0)
 		}
 		{
-			p.SetState(56)
+			p.SetState(77)
 			p.Match(Excellent3ParserRPAREN)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2150,12 +2832,82 @@ func (p *Excellent3Parser) atom(_p int) (localctx IAtomContext) {
 			}
 		}
 
+	case Excellent3ParserLBRACK:
+		localctx = NewArrayLiteralContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(79)
+			p.Match(Excellent3ParserLBRACK)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(81)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&34124861608) != 0 {
+			{
+				p.SetState(80)
+				p.Parameters()
+			}
+
+		}
+		{
+			p.SetState(83)
+			p.Match(Excellent3ParserRBRACK)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
+	case Excellent3ParserLBRACE:
+		localctx = NewObjectLiteralContext(p, localctx)
+		p.SetParserRuleContext(localctx)
+		_prevctx = localctx
+		{
+			p.SetState(84)
+			p.Match(Excellent3ParserLBRACE)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		p.SetState(86)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+
+		if _la == Excellent3ParserTEXT {
+			{
+				p.SetState(85)
+				p.Properties()
+			}
+
+		}
+		{
+			p.SetState(88)
+			p.Match(Excellent3ParserRBRACE)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
 	case Excellent3ParserNAME:
 		localctx = NewContextReferenceContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(58)
+			p.SetState(89)
 			p.Match(Excellent3ParserNAME)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2168,12 +2920,12 @@ func (p *Excellent3Parser) atom(_p int) (localctx IAtomContext) {
 		goto errorExit
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(77)
+	p.SetState(108)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
-	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 6, p.GetParserRuleContext())
+	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 8, p.GetParserRuleContext())
 	if p.HasError() {
 		goto errorExit
 	}
@@ -2183,46 +2935,46 @@ func (p *Excellent3Parser) atom(_p int) (localctx IAtomContext) {
 				p.TriggerExitRuleEvent()
 			}
 			_prevctx = localctx
-			p.SetState(75)
+			p.SetState(106)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
 			}
 
-			switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 5, p.GetParserRuleContext()) {
+			switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 7, p.GetParserRuleContext()) {
 			case 1:
 				localctx = NewFunctionCallContext(p, NewAtomContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, Excellent3ParserRULE_atom)
-				p.SetState(61)
+				p.SetState(92)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 5)) {
-					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 5)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 7)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 7)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(62)
+					p.SetState(93)
 					p.Match(Excellent3ParserLPAREN)
 					if p.HasError() {
 						// Recognition error - abort rule
 						goto errorExit
 					}
 				}
-				p.SetState(64)
+				p.SetState(95)
 				p.GetErrorHandler().Sync(p)
 				if p.HasError() {
 					goto errorExit
 				}
 				_la = p.GetTokenStream().LA(1)
 
-				if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&133169668) != 0 {
+				if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&34124861608) != 0 {
 					{
-						p.SetState(63)
+						p.SetState(94)
 						p.Parameters()
 					}
 
 				}
 				{
-					p.SetState(66)
+					p.SetState(97)
 					p.Match(Excellent3ParserRPAREN)
 					if p.HasError() {
 						// Recognition error - abort rule
@@ -2233,14 +2985,14 @@ func (p *Excellent3Parser) atom(_p int) (localctx IAtomContext) {
 			case 2:
 				localctx = NewDotLookupContext(p, NewAtomContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, Excellent3ParserRULE_atom)
-				p.SetState(67)
+				p.SetState(98)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 4)) {
-					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 4)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 6)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 6)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(68)
+					p.SetState(99)
 					p.Match(Excellent3ParserDOT)
 					if p.HasError() {
 						// Recognition error - abort rule
@@ -2248,7 +3000,7 @@ func (p *Excellent3Parser) atom(_p int) (localctx IAtomContext) {
 					}
 				}
 				{
-					p.SetState(69)
+					p.SetState(100)
 					_la = p.GetTokenStream().LA(1)
 
 					if !(_la == Excellent3ParserINTEGER || _la == Excellent3ParserNAME) {
@@ -2262,14 +3014,14 @@ func (p *Excellent3Parser) atom(_p int) (localctx IAtomContext) {
 			case 3:
 				localctx = NewArrayLookupContext(p, NewAtomContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, Excellent3ParserRULE_atom)
-				p.SetState(70)
+				p.SetState(101)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 3)) {
-					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 3)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 5)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 5)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(71)
+					p.SetState(102)
 					p.Match(Excellent3ParserLBRACK)
 					if p.HasError() {
 						// Recognition error - abort rule
@@ -2277,11 +3029,11 @@ func (p *Excellent3Parser) atom(_p int) (localctx IAtomContext) {
 					}
 				}
 				{
-					p.SetState(72)
+					p.SetState(103)
 					p.expression(0)
 				}
 				{
-					p.SetState(73)
+					p.SetState(104)
 					p.Match(Excellent3ParserRBRACK)
 					if p.HasError() {
 						// Recognition error - abort rule
@@ -2294,12 +3046,12 @@ func (p *Excellent3Parser) atom(_p int) (localctx IAtomContext) {
 			}
 
 		}
-		p.SetState(79)
+		p.SetState(110)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
-		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 6, p.GetParserRuleContext())
+		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 8, p.GetParserRuleContext())
 		if p.HasError() {
 			goto errorExit
 		}
@@ -2469,10 +3221,10 @@ func (p *Excellent3Parser) Parameters() (localctx IParametersContext) {
 	localctx = NewFunctionParametersContext(p, localctx)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(80)
+		p.SetState(111)
 		p.expression(0)
 	}
-	p.SetState(85)
+	p.SetState(116)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -2481,7 +3233,7 @@ func (p *Excellent3Parser) Parameters() (localctx IParametersContext) {
 
 	for _la == Excellent3ParserCOMMA {
 		{
-			p.SetState(81)
+			p.SetState(112)
 			p.Match(Excellent3ParserCOMMA)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2489,11 +3241,191 @@ func (p *Excellent3Parser) Parameters() (localctx IParametersContext) {
 			}
 		}
 		{
-			p.SetState(82)
+			p.SetState(113)
 			p.expression(0)
 		}
 
-		p.SetState(87)
+		p.SetState(118)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IPropertiesContext is an interface to support dynamic dispatch.
+type IPropertiesContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	AllProperty() []IPropertyContext
+	Property(i int) IPropertyContext
+	AllCOMMA() []antlr.TerminalNode
+	COMMA(i int) antlr.TerminalNode
+
+	// IsPropertiesContext differentiates from other interfaces.
+	IsPropertiesContext()
+}
+
+type PropertiesContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyPropertiesContext() *PropertiesContext {
+	var p = new(PropertiesContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = Excellent3ParserRULE_properties
+	return p
+}
+
+func InitEmptyPropertiesContext(p *PropertiesContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = Excellent3ParserRULE_properties
+}
+
+func (*PropertiesContext) IsPropertiesContext() {}
+
+func NewPropertiesContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *PropertiesContext {
+	var p = new(PropertiesContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = Excellent3ParserRULE_properties
+
+	return p
+}
+
+func (s *PropertiesContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *PropertiesContext) AllProperty() []IPropertyContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IPropertyContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IPropertyContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IPropertyContext); ok {
+			tst[i] = t.(IPropertyContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *PropertiesContext) Property(i int) IPropertyContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IPropertyContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IPropertyContext)
+}
+
+func (s *PropertiesContext) AllCOMMA() []antlr.TerminalNode {
+	return s.GetTokens(Excellent3ParserCOMMA)
+}
+
+func (s *PropertiesContext) COMMA(i int) antlr.TerminalNode {
+	return s.GetToken(Excellent3ParserCOMMA, i)
+}
+
+func (s *PropertiesContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *PropertiesContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *PropertiesContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent3Listener); ok {
+		listenerT.EnterProperties(s)
+	}
+}
+
+func (s *PropertiesContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent3Listener); ok {
+		listenerT.ExitProperties(s)
+	}
+}
+
+func (s *PropertiesContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case Excellent3Visitor:
+		return t.VisitProperties(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *Excellent3Parser) Properties() (localctx IPropertiesContext) {
+	localctx = NewPropertiesContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 8, Excellent3ParserRULE_properties)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(119)
+		p.Property()
+	}
+	p.SetState(124)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == Excellent3ParserCOMMA {
+		{
+			p.SetState(120)
+			p.Match(Excellent3ParserCOMMA)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(121)
+			p.Property()
+		}
+
+		p.SetState(126)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -2514,6 +3446,146 @@ errorExit:
 	goto errorExit // Trick to prevent compiler error if the label is not used
 }
 
+// IPropertyContext is an interface to support dynamic dispatch.
+type IPropertyContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	TEXT() antlr.TerminalNode
+	COLON() antlr.TerminalNode
+	Expression() IExpressionContext
+
+	// IsPropertyContext differentiates from other interfaces.
+	IsPropertyContext()
+}
+
+type PropertyContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyPropertyContext() *PropertyContext {
+	var p = new(PropertyContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = Excellent3ParserRULE_property
+	return p
+}
+
+func InitEmptyPropertyContext(p *PropertyContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = Excellent3ParserRULE_property
+}
+
+func (*PropertyContext) IsPropertyContext() {}
+
+func NewPropertyContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *PropertyContext {
+	var p = new(PropertyContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = Excellent3ParserRULE_property
+
+	return p
+}
+
+func (s *PropertyContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *PropertyContext) TEXT() antlr.TerminalNode {
+	return s.GetToken(Excellent3ParserTEXT, 0)
+}
+
+func (s *PropertyContext) COLON() antlr.TerminalNode {
+	return s.GetToken(Excellent3ParserCOLON, 0)
+}
+
+func (s *PropertyContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *PropertyContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *PropertyContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *PropertyContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent3Listener); ok {
+		listenerT.EnterProperty(s)
+	}
+}
+
+func (s *PropertyContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(Excellent3Listener); ok {
+		listenerT.ExitProperty(s)
+	}
+}
+
+func (s *PropertyContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
+	switch t := visitor.(type) {
+	case Excellent3Visitor:
+		return t.VisitProperty(s)
+
+	default:
+		return t.VisitChildren(s)
+	}
+}
+
+func (p *Excellent3Parser) Property() (localctx IPropertyContext) {
+	localctx = NewPropertyContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 10, Excellent3ParserRULE_property)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(127)
+		p.Match(Excellent3ParserTEXT)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(128)
+		p.Match(Excellent3ParserCOLON)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(129)
+		p.expression(0)
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
 // INameListContext is an interface to support dynamic dispatch.
 type INameListContext interface {
 	antlr.ParserRuleContext
@@ -2611,19 +3683,19 @@ func (s *NameListContext) Accept(visitor antlr.ParseTreeVisitor) interface{} {
 
 func (p *Excellent3Parser) NameList() (localctx INameListContext) {
 	localctx = NewNameListContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 8, Excellent3ParserRULE_nameList)
+	p.EnterRule(localctx, 12, Excellent3ParserRULE_nameList)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(88)
+		p.SetState(131)
 		p.Match(Excellent3ParserNAME)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(93)
+	p.SetState(136)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -2632,7 +3704,7 @@ func (p *Excellent3Parser) NameList() (localctx INameListContext) {
 
 	for _la == Excellent3ParserCOMMA {
 		{
-			p.SetState(89)
+			p.SetState(132)
 			p.Match(Excellent3ParserCOMMA)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2640,7 +3712,7 @@ func (p *Excellent3Parser) NameList() (localctx INameListContext) {
 			}
 		}
 		{
-			p.SetState(90)
+			p.SetState(133)
 			p.Match(Excellent3ParserNAME)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -2648,7 +3720,7 @@ func (p *Excellent3Parser) NameList() (localctx INameListContext) {
 			}
 		}
 
-		p.SetState(95)
+		p.SetState(138)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -2693,21 +3765,33 @@ func (p *Excellent3Parser) Sempred(localctx antlr.RuleContext, ruleIndex, predIn
 func (p *Excellent3Parser) Expression_Sempred(localctx antlr.RuleContext, predIndex int) bool {
 	switch predIndex {
 	case 0:
-		return p.Precpred(p.GetParserRuleContext(), 12)
+		return p.Precpred(p.GetParserRuleContext(), 16)
 
 	case 1:
-		return p.Precpred(p.GetParserRuleContext(), 11)
+		return p.Precpred(p.GetParserRuleContext(), 15)
 
 	case 2:
-		return p.Precpred(p.GetParserRuleContext(), 10)
+		return p.Precpred(p.GetParserRuleContext(), 14)
 
 	case 3:
-		return p.Precpred(p.GetParserRuleContext(), 9)
+		return p.Precpred(p.GetParserRuleContext(), 13)
 
 	case 4:
-		return p.Precpred(p.GetParserRuleContext(), 8)
+		return p.Precpred(p.GetParserRuleContext(), 12)
 
 	case 5:
+		return p.Precpred(p.GetParserRuleContext(), 11)
+
+	case 6:
+		return p.Precpred(p.GetParserRuleContext(), 10)
+
+	case 7:
+		return p.Precpred(p.GetParserRuleContext(), 9)
+
+	case 8:
+		return p.Precpred(p.GetParserRuleContext(), 8)
+
+	case 9:
 		return p.Precpred(p.GetParserRuleContext(), 7)
 
 	default:
@@ -2717,14 +3801,14 @@ func (p *Excellent3Parser) Expression_Sempred(localctx antlr.RuleContext, predIn
 
 func (p *Excellent3Parser) Atom_Sempred(localctx antlr.RuleContext, predIndex int) bool {
 	switch predIndex {
-	case 6:
-		return p.Precpred(p.GetParserRuleContext(), 5)
+	case 10:
+		return p.Precpred(p.GetParserRuleContext(), 7)
 
-	case 7:
-		return p.Precpred(p.GetParserRuleContext(), 4)
+	case 11:
+		return p.Precpred(p.GetParserRuleContext(), 6)
 
-	case 8:
-		return p.Precpred(p.GetParserRuleContext(), 3)
+	case 12:
+		return p.Precpred(p.GetParserRuleContext(), 5)
 
 	default:
 		panic("No predicate with index: " + fmt.Sprint(predIndex))
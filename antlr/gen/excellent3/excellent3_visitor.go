@@ -16,21 +16,33 @@ type Excellent3Visitor interface {
 	// Visit a parse tree produced by Excellent3Parser#comparison.
 	VisitComparison(ctx *ComparisonContext) interface{}
 
+	// Visit a parse tree produced by Excellent3Parser#or.
+	VisitOr(ctx *OrContext) interface{}
+
 	// Visit a parse tree produced by Excellent3Parser#false.
 	VisitFalse(ctx *FalseContext) interface{}
 
+	// Visit a parse tree produced by Excellent3Parser#coalesce.
+	VisitCoalesce(ctx *CoalesceContext) interface{}
+
 	// Visit a parse tree produced by Excellent3Parser#additionOrSubtraction.
 	VisitAdditionOrSubtraction(ctx *AdditionOrSubtractionContext) interface{}
 
 	// Visit a parse tree produced by Excellent3Parser#textLiteral.
 	VisitTextLiteral(ctx *TextLiteralContext) interface{}
 
+	// Visit a parse tree produced by Excellent3Parser#not.
+	VisitNot(ctx *NotContext) interface{}
+
 	// Visit a parse tree produced by Excellent3Parser#concatenation.
 	VisitConcatenation(ctx *ConcatenationContext) interface{}
 
 	// Visit a parse tree produced by Excellent3Parser#null.
 	VisitNull(ctx *NullContext) interface{}
 
+	// Visit a parse tree produced by Excellent3Parser#and.
+	VisitAnd(ctx *AndContext) interface{}
+
 	// Visit a parse tree produced by Excellent3Parser#multiplicationOrDivision.
 	VisitMultiplicationOrDivision(ctx *MultiplicationOrDivisionContext) interface{}
 
@@ -46,6 +58,9 @@ type Excellent3Visitor interface {
 	// Visit a parse tree produced by Excellent3Parser#equality.
 	VisitEquality(ctx *EqualityContext) interface{}
 
+	// Visit a parse tree produced by Excellent3Parser#ternary.
+	VisitTernary(ctx *TernaryContext) interface{}
+
 	// Visit a parse tree produced by Excellent3Parser#numberLiteral.
 	VisitNumberLiteral(ctx *NumberLiteralContext) interface{}
 
@@ -58,6 +73,12 @@ type Excellent3Visitor interface {
 	// Visit a parse tree produced by Excellent3Parser#dotLookup.
 	VisitDotLookup(ctx *DotLookupContext) interface{}
 
+	// Visit a parse tree produced by Excellent3Parser#objectLiteral.
+	VisitObjectLiteral(ctx *ObjectLiteralContext) interface{}
+
+	// Visit a parse tree produced by Excellent3Parser#arrayLiteral.
+	VisitArrayLiteral(ctx *ArrayLiteralContext) interface{}
+
 	// Visit a parse tree produced by Excellent3Parser#functionCall.
 	VisitFunctionCall(ctx *FunctionCallContext) interface{}
 
@@ -70,6 +91,12 @@ type Excellent3Visitor interface {
 	// Visit a parse tree produced by Excellent3Parser#functionParameters.
 	VisitFunctionParameters(ctx *FunctionParametersContext) interface{}
 
+	// Visit a parse tree produced by Excellent3Parser#properties.
+	VisitProperties(ctx *PropertiesContext) interface{}
+
+	// Visit a parse tree produced by Excellent3Parser#property.
+	VisitProperty(ctx *PropertyContext) interface{}
+
 	// Visit a parse tree produced by Excellent3Parser#nameList.
 	VisitNameList(ctx *NameListContext) interface{}
 }
@@ -1,6 +1,9 @@
 package assets
 
-import "github.com/nyaruka/goflow/envs"
+import (
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/utils"
+)
 
 // LocationHierarchy is a searchable hierarchy of locations.
 //
@@ -11,6 +14,9 @@ import "github.com/nyaruka/goflow/envs"
 //	    {
 //	      "name": "Kigali City",
 //	      "aliases": ["Kigali", "Kigari"],
+//	      "boundary": [
+//	        [[29.95, -2.05], [30.25, -2.05], [30.25, -1.85], [29.95, -1.85]]
+//	      ],
 //	      "children": [
 //	        {
 //	          "name": "Gasabo",
@@ -42,3 +48,8 @@ type LocationHierarchy interface {
 	FindByPath(path envs.LocationPath) *envs.Location
 	FindByName(env envs.Environment, name string, level envs.LocationLevel, parent *envs.Location) []*envs.Location
 }
+
+// PointLocationHierarchy is optionally implemented by location hierarchies which can find locations by GPS point
+type PointLocationHierarchy interface {
+	FindByPoint(point utils.GeoPoint, level envs.LocationLevel) []*envs.Location
+}
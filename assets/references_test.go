@@ -114,6 +114,14 @@ func TestReferences(t *testing.T) {
 		"field 'uuid' is mutually exclusive with 'name_match', field 'name_match' is mutually exclusive with 'uuid'",
 	)
 
+	scheduleRef := assets.NewScheduleReference("61602f3e-f603-4c70-8a8f-c477505bf4bf", "Office Hours")
+	assert.Equal(t, "schedule", scheduleRef.Type())
+	assert.Equal(t, "61602f3e-f603-4c70-8a8f-c477505bf4bf", scheduleRef.Identity())
+	assert.Equal(t, uuids.UUID("61602f3e-f603-4c70-8a8f-c477505bf4bf"), scheduleRef.GenericUUID())
+	assert.Equal(t, "schedule[uuid=61602f3e-f603-4c70-8a8f-c477505bf4bf,name=Office Hours]", scheduleRef.String())
+	assert.False(t, scheduleRef.Variable())
+	assert.NoError(t, utils.Validate(scheduleRef))
+
 	templateRef := assets.NewTemplateReference("61602f3e-f603-4c70-8a8f-c477505bf4bf", "Affirmation")
 	assert.Equal(t, "template", templateRef.Type())
 	assert.Equal(t, "61602f3e-f603-4c70-8a8f-c477505bf4bf", templateRef.Identity())
@@ -0,0 +1,81 @@
+package assets
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nyaruka/gocommon/dates"
+	"github.com/nyaruka/gocommon/uuids"
+)
+
+// ScheduleUUID is the UUID of a schedule
+type ScheduleUUID uuids.UUID
+
+// Schedule is a set of weekly opening hours, e.g. business hours, with optional holidays on which it is closed for
+// the entire day. If timezone is omitted, the timezone of the environment is used. An interval which ends at 00:00
+// is open until the end of the day, and an interval which ends before it starts is open until that time on the
+// following day, e.g. 22:00 to 06:00. Holidays apply to intervals which start on that day.
+//
+//	{
+//	  "uuid": "4b4a7b9c-0a7d-4a65-9a0b-7f3b0f1b0d3c",
+//	  "name": "Office Hours",
+//	  "timezone": "Africa/Kigali",
+//	  "intervals": [
+//	    {"days": ["mon", "tue", "wed", "thu", "fri"], "start": "09:00", "end": "17:00"},
+//	    {"days": ["sat"], "start": "10:00", "end": "13:00"}
+//	  ],
+//	  "holidays": ["2024-12-25", "2025-01-01"]
+//	}
+//
+// @asset schedule
+type Schedule interface {
+	UUID() ScheduleUUID
+	Name() string
+	Timezone() string
+	Intervals() []ScheduleInterval
+	Holidays() []dates.Date
+}
+
+// ScheduleInterval is a period of time on one or more days of the week during which a schedule is open
+type ScheduleInterval interface {
+	Days() []time.Weekday
+	Start() dates.TimeOfDay
+	End() dates.TimeOfDay
+}
+
+// ScheduleReference is used to reference a schedule
+type ScheduleReference struct {
+	UUID ScheduleUUID `json:"uuid" validate:"required,uuid"`
+	Name string       `json:"name"`
+}
+
+// NewScheduleReference creates a new schedule reference with the given UUID and name
+func NewScheduleReference(uuid ScheduleUUID, name string) *ScheduleReference {
+	return &ScheduleReference{UUID: uuid, Name: name}
+}
+
+// Type returns the name of the asset type
+func (r *ScheduleReference) Type() string {
+	return "schedule"
+}
+
+// GenericUUID returns the untyped UUID
+func (r *ScheduleReference) GenericUUID() uuids.UUID {
+	return uuids.UUID(r.UUID)
+}
+
+// Identity returns the unique identity of the asset
+func (r *ScheduleReference) Identity() string {
+	return string(r.UUID)
+}
+
+// Variable returns whether this a variable (vs concrete) reference
+func (r *ScheduleReference) Variable() bool {
+	return false
+}
+
+func (r *ScheduleReference) String() string {
+	return fmt.Sprintf("%s[uuid=%s,name=%s]", r.Type(), r.Identity(), r.Name)
+}
+
+var _ UUIDReference = (*ScheduleReference)(nil)
@@ -13,6 +13,7 @@ type Source interface {
 	Locations() ([]LocationHierarchy, error)
 	OptIns() ([]OptIn, error)
 	Resthooks() ([]Resthook, error)
+	Schedules() ([]Schedule, error)
 	Templates() ([]Template, error)
 	Topics() ([]Topic, error)
 	Users() ([]User, error)
@@ -0,0 +1,118 @@
+package static
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nyaruka/gocommon/dates"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/utils"
+)
+
+func init() {
+	utils.RegisterValidatorAlias("schedule_day", "eq=mon|eq=tue|eq=wed|eq=thu|eq=fri|eq=sat|eq=sun", func(validator.FieldError) string {
+		return "is not a valid day of the week"
+	})
+	utils.RegisterValidatorAlias("schedule_time", "datetime=15:04", func(validator.FieldError) string {
+		return "is not a valid time of day"
+	})
+	utils.RegisterValidatorAlias("schedule_date", "datetime=2006-01-02", func(validator.FieldError) string {
+		return "is not a valid date"
+	})
+	utils.RegisterValidatorAlias("schedule_timezone", "timezone", func(validator.FieldError) string {
+		return "is not a valid timezone"
+	})
+}
+
+var scheduleDays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Schedule is a JSON serializable implementation of a schedule asset
+type Schedule struct {
+	UUID_      assets.ScheduleUUID `json:"uuid"               validate:"required,uuid"`
+	Name_      string              `json:"name"`
+	Timezone_  string              `json:"timezone,omitempty" validate:"omitempty,schedule_timezone"`
+	Intervals_ []*ScheduleInterval `json:"intervals"          validate:"omitempty,dive"`
+	Holidays_  []string            `json:"holidays,omitempty" validate:"omitempty,dive,schedule_date"`
+}
+
+// NewSchedule creates a new schedule
+func NewSchedule(uuid assets.ScheduleUUID, name, timezone string, intervals []*ScheduleInterval, holidays []string) assets.Schedule {
+	return &Schedule{
+		UUID_:      uuid,
+		Name_:      name,
+		Timezone_:  timezone,
+		Intervals_: intervals,
+		Holidays_:  holidays,
+	}
+}
+
+// UUID returns the UUID of this schedule
+func (s *Schedule) UUID() assets.ScheduleUUID { return s.UUID_ }
+
+// Name returns the name of this schedule
+func (s *Schedule) Name() string { return s.Name_ }
+
+// Timezone returns the name of the timezone of this schedule
+func (s *Schedule) Timezone() string { return s.Timezone_ }
+
+// Intervals returns the weekly intervals during which this schedule is open
+func (s *Schedule) Intervals() []assets.ScheduleInterval {
+	intervals := make([]assets.ScheduleInterval, len(s.Intervals_))
+	for i := range s.Intervals_ {
+		intervals[i] = s.Intervals_[i]
+	}
+	return intervals
+}
+
+// Holidays returns the dates on which this schedule is closed
+func (s *Schedule) Holidays() []dates.Date {
+	holidays := make([]dates.Date, 0, len(s.Holidays_))
+	for _, h := range s.Holidays_ {
+		if d, err := time.Parse("2006-01-02", h); err == nil {
+			holidays = append(holidays, dates.ExtractDate(d))
+		}
+	}
+	return holidays
+}
+
+// ScheduleInterval is a JSON serializable implementation of a schedule interval
+type ScheduleInterval struct {
+	Days_  []string `json:"days"  validate:"required,min=1,dive,schedule_day"`
+	Start_ string   `json:"start" validate:"required,schedule_time"`
+	End_   string   `json:"end"   validate:"required,schedule_time"`
+}
+
+// NewScheduleInterval creates a new schedule interval
+func NewScheduleInterval(days []string, start, end string) *ScheduleInterval {
+	return &ScheduleInterval{Days_: days, Start_: start, End_: end}
+}
+
+// Days returns the days of the week this interval applies to
+func (i *ScheduleInterval) Days() []time.Weekday {
+	days := make([]time.Weekday, 0, len(i.Days_))
+	for _, d := range i.Days_ {
+		if day, ok := scheduleDays[d]; ok {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// Start returns the time of day this interval opens
+func (i *ScheduleInterval) Start() dates.TimeOfDay { return parseTimeOfDay(i.Start_) }
+
+// End returns the time of day this interval closes
+func (i *ScheduleInterval) End() dates.TimeOfDay { return parseTimeOfDay(i.End_) }
+
+func parseTimeOfDay(s string) dates.TimeOfDay {
+	t, _ := time.Parse("15:04", s)
+	return dates.ExtractTimeOfDay(t)
+}
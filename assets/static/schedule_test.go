@@ -0,0 +1,44 @@
+package static_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nyaruka/gocommon/dates"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/assets/static"
+	"github.com/nyaruka/goflow/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedule(t *testing.T) {
+	schedule := static.NewSchedule(
+		assets.ScheduleUUID("4b4a7b9c-0a7d-4a65-9a0b-7f3b0f1b0d3c"),
+		"Office Hours",
+		"Africa/Kigali",
+		[]*static.ScheduleInterval{
+			static.NewScheduleInterval([]string{"mon", "tue", "wed"}, "09:00", "17:30"),
+		},
+		[]string{"2024-12-25"},
+	)
+	assert.Equal(t, assets.ScheduleUUID("4b4a7b9c-0a7d-4a65-9a0b-7f3b0f1b0d3c"), schedule.UUID())
+	assert.Equal(t, "Office Hours", schedule.Name())
+	assert.Equal(t, "Africa/Kigali", schedule.Timezone())
+	assert.Equal(t, 1, len(schedule.Intervals()))
+	assert.Equal(t, []time.Weekday{time.Monday, time.Tuesday, time.Wednesday}, schedule.Intervals()[0].Days())
+	assert.Equal(t, dates.NewTimeOfDay(9, 0, 0, 0), schedule.Intervals()[0].Start())
+	assert.Equal(t, dates.NewTimeOfDay(17, 30, 0, 0), schedule.Intervals()[0].End())
+	assert.Equal(t, []dates.Date{dates.NewDate(2024, 12, 25)}, schedule.Holidays())
+
+	invalid := static.NewSchedule(
+		assets.ScheduleUUID("4b4a7b9c-0a7d-4a65-9a0b-7f3b0f1b0d3c"),
+		"Office Hours",
+		"Mars/Olympus",
+		[]*static.ScheduleInterval{
+			static.NewScheduleInterval([]string{"mon", "xxx"}, "9am", "17:30"),
+		},
+		[]string{"25/12/2024"},
+	)
+	assert.EqualError(t, utils.Validate(invalid), "field 'timezone' is not a valid timezone, field 'intervals[0].days[1]' is not a valid day of the week, field 'intervals[0].start' is not a valid time of day, field 'holidays[0]' is not a valid date")
+}
@@ -25,6 +25,7 @@ type StaticSource struct {
 		Locations   []*envs.LocationHierarchy `json:"locations"`
 		OptIns      []*OptIn                  `json:"optins" validate:"omitempty,dive"`
 		Resthooks   []*Resthook               `json:"resthooks" validate:"omitempty,dive"`
+		Schedules   []*Schedule               `json:"schedules" validate:"omitempty,dive"`
 		Templates   []*Template               `json:"templates" validate:"omitempty,dive"`
 		Topics      []*Topic                  `json:"topics" validate:"omitempty,dive"`
 		Users       []*User                   `json:"users" validate:"omitempty,dive"`
@@ -155,6 +156,15 @@ func (s *StaticSource) Resthooks() ([]assets.Resthook, error) {
 	return set, nil
 }
 
+// Schedules returns all schedule assets
+func (s *StaticSource) Schedules() ([]assets.Schedule, error) {
+	set := make([]assets.Schedule, len(s.s.Schedules))
+	for i := range s.s.Schedules {
+		set[i] = s.s.Schedules[i]
+	}
+	return set, nil
+}
+
 // Templates returns all template assets
 func (s *StaticSource) Templates() ([]assets.Template, error) {
 	set := make([]assets.Template, len(s.s.Templates))
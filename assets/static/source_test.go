@@ -62,6 +62,15 @@ var assetsJSON = `{
 				"http://temba.io/"
 			]
 		}
+	],
+	"schedules": [
+		{
+			"uuid": "4b4a7b9c-0a7d-4a65-9a0b-7f3b0f1b0d3c",
+			"name": "Office Hours",
+			"intervals": [
+				{"days": ["mon", "tue", "wed", "thu", "fri"], "start": "09:00", "end": "17:00"}
+			]
+		}
 	]
 }`
 
@@ -121,6 +130,10 @@ func TestSource(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, resthooks, 1)
 
+	schedules, err := src.Schedules()
+	assert.NoError(t, err)
+	assert.Len(t, schedules, 1)
+
 	templates, err := src.Templates()
 	assert.NoError(t, err)
 	assert.Len(t, templates, 0)
@@ -0,0 +1,292 @@
+package main
+
+// go install github.com/nyaruka/goflow/cmd/contactql
+// contactql -contact contact.json -timezone Africa/Kigali assets.json 'age > 18 AND group = "U-Reporters"'
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nyaruka/gocommon/jsonx"
+	"github.com/nyaruka/gocommon/uuids"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/assets/static"
+	"github.com/nyaruka/goflow/contactql"
+	"github.com/nyaruka/goflow/contactql/es"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/engine"
+)
+
+const usage = `usage: contactql [flags] <assets.json> <query>`
+
+func main() {
+	var contactPath, timezone, dateFormat string
+	flags := flag.NewFlagSet("", flag.ExitOnError)
+	flags.StringVar(&contactPath, "contact", "", "path of a contact JSON file to evaluate the query against")
+	flags.StringVar(&timezone, "timezone", "UTC", "timezone of the environment, e.g. Africa/Kigali")
+	flags.StringVar(&dateFormat, "date-format", string(envs.DateFormatYearMonthDay), "date format of the environment, one of YYYY-MM-DD, MM-DD-YYYY or DD-MM-YYYY")
+	flags.Parse(os.Args[1:])
+	args := flags.Args()
+
+	if len(args) != 2 {
+		fmt.Println(usage)
+		flags.PrintDefaults()
+		os.Exit(1)
+	}
+
+	env, err := NewEnvironment(timezone, dateFormat)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	sa, err := LoadAssets(env, args[0])
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	var contact *flows.Contact
+	if contactPath != "" {
+		if contact, err = ReadContact(sa, contactPath); err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+	}
+
+	if err := Debug(env, sa, args[1], contact, os.Stdout); err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+}
+
+// NewEnvironment creates an environment with the given timezone and date format
+func NewEnvironment(timezone, dateFormat string) (envs.Environment, error) {
+	tz, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone '%s': %w", timezone, err)
+	}
+
+	df := envs.DateFormat(dateFormat)
+	if df != envs.DateFormatYearMonthDay && df != envs.DateFormatMonthDayYear && df != envs.DateFormatDayMonthYear {
+		return nil, fmt.Errorf("invalid date format '%s'", dateFormat)
+	}
+
+	return envs.NewBuilder().WithTimezone(tz).WithDateFormat(df).Build(), nil
+}
+
+// LoadAssets loads session assets from the given JSON file
+func LoadAssets(env envs.Environment, path string) (flows.SessionAssets, error) {
+	source, err := static.LoadSource(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading assets file '%s': %w", path, err)
+	}
+
+	sa, err := engine.NewSessionAssets(env, source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error loading assets from '%s': %w", path, err)
+	}
+	return sa, nil
+}
+
+// ReadContact reads a contact from the given JSON file
+func ReadContact(sa flows.SessionAssets, path string) (*flows.Contact, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading contact file '%s': %w", path, err)
+	}
+
+	contact, err := flows.ReadContact(sa, data, assets.IgnoreMissing)
+	if err != nil {
+		return nil, fmt.Errorf("error reading contact from '%s': %w", path, err)
+	}
+	return contact, nil
+}
+
+// Debug parses the given query and prints its parse tree as parsed, its simplified form, inspection and Elastic query, and if a
+// contact is provided, which of its clauses the contact matches. Conditions on groups, flows and results depend on
+// data which only exists in the database, so are shown as unknown.
+func Debug(env envs.Environment, sa flows.SessionAssets, text string, contact *flows.Contact, out io.Writer) error {
+	tree, err := contactql.ParseTree(env, text, sa)
+	if err != nil {
+		return fmt.Errorf("error parsing query: %w", err)
+	}
+
+	query, _ := contactql.ParseQuery(env, text, sa)
+
+	fmt.Fprintf(out, "================ parse tree ================\n")
+	printNode(tree, 0, out, func(contactql.QueryNode) string { return "" })
+
+	fmt.Fprintf(out, "================ simplified ================\n")
+	fmt.Fprintf(out, "%s\n", query.String())
+
+	inspection, _ := jsonx.MarshalPretty(contactql.Inspect(query))
+
+	fmt.Fprintf(out, "================ inspection ================\n")
+	fmt.Fprintf(out, "%s\n", inspection)
+
+	// assets don't have database ids so we number them in the order they're used
+	mapper := &sequentialMapper{ids: make(map[uuids.UUID]int64)}
+	elastic, _ := jsonx.MarshalPretty(es.ToElasticQuery(env, mapper, query))
+
+	fmt.Fprintf(out, "================ elastic ================\n")
+	fmt.Fprintf(out, "%s\n", elastic)
+	for _, m := range mapper.mappings {
+		fmt.Fprintf(out, "%s\n", m)
+	}
+
+	if contact != nil {
+		fmt.Fprintf(out, "================ evaluation ================\n")
+		printNode(query.Root(), 0, out, func(node contactql.QueryNode) string {
+			return evaluate(env, sa, node, contact).String() + " "
+		})
+	}
+
+	return nil
+}
+
+// prints the given node and its children indented by depth, with each line prefixed by the given function
+func printNode(node contactql.QueryNode, depth int, out io.Writer, prefix func(contactql.QueryNode) string) {
+	indent := strings.Repeat("  ", depth)
+
+	switch n := node.(type) {
+	case *contactql.BoolCombination:
+		fmt.Fprintf(out, "%s%s%s\n", indent, prefix(n), strings.ToUpper(string(n.Operator())))
+		for _, child := range n.Children() {
+			printNode(child, depth+1, out, prefix)
+		}
+	case *contactql.Not:
+		fmt.Fprintf(out, "%s%sNOT\n", indent, prefix(n))
+		printNode(n.Child(), depth+1, out, prefix)
+	case *contactql.Condition:
+		fmt.Fprintf(out, "%s%s%s\n", indent, prefix(n), n.String())
+	}
+}
+
+// the result of evaluating part of a query against a contact
+type verdict int
+
+const (
+	verdictUnknown verdict = iota
+	verdictMatch
+	verdictNoMatch
+)
+
+func (v verdict) String() string {
+	return [...]string{"❔", "✅", "❌"}[v]
+}
+
+// attributes which contacts can't be queried on outside of a database
+var unqueryableAttributes = map[string]bool{
+	contactql.AttributeID:               true,
+	contactql.AttributeFlow:             true,
+	contactql.AttributeHistory:          true,
+	contactql.AttributeHistoryCompleted: true,
+	contactql.AttributeHistoryExited:    true,
+}
+
+// evaluates the given node against the given contact, where conditions which can't be evaluated against a contact on
+// its own are unknown and make combinations unknown unless they're decided by other conditions
+func evaluate(env envs.Environment, sa flows.SessionAssets, node contactql.QueryNode, contact *flows.Contact) verdict {
+	switch n := node.(type) {
+	case *contactql.BoolCombination:
+		decisive, other := verdictNoMatch, verdictMatch
+		if n.Operator() == contactql.BoolOperatorOr {
+			decisive, other = verdictMatch, verdictNoMatch
+		}
+
+		result := other
+		for _, child := range n.Children() {
+			switch evaluate(env, sa, child, contact) {
+			case decisive:
+				return decisive
+			case verdictUnknown:
+				result = verdictUnknown
+			}
+		}
+		return result
+
+	case *contactql.Not:
+		switch evaluate(env, sa, n.Child(), contact) {
+		case verdictMatch:
+			return verdictNoMatch
+		case verdictNoMatch:
+			return verdictMatch
+		}
+		return verdictUnknown
+
+	case *contactql.Condition:
+		if n.Operator() == contactql.OpIn || n.Operator() == contactql.OpBetween {
+			return evaluate(env, sa, n.Expand(), contact)
+		}
+
+		// contacts don't include their groups or status when queried but we can check them directly
+		if n.PropertyType() == contactql.PropertyTypeAttribute {
+			switch n.PropertyKey() {
+			case contactql.AttributeGroup:
+				return evaluateGroup(sa, n, contact)
+			case contactql.AttributeStatus:
+				return toVerdict(strings.EqualFold(string(contact.Status()), n.Value()) == (n.Operator() == contactql.OpEqual))
+			}
+		}
+
+		if n.PropertyType() == contactql.PropertyTypeResult || (n.PropertyType() == contactql.PropertyTypeAttribute && unqueryableAttributes[n.PropertyKey()]) {
+			return verdictUnknown
+		}
+
+		// evaluate the condition as a query of its own
+		clause, err := contactql.Node(n).Query(env, sa)
+		if err != nil {
+			return verdictUnknown
+		}
+		return toVerdict(contactql.EvaluateQuery(env, clause, contact))
+	}
+	return verdictUnknown
+}
+
+func evaluateGroup(sa flows.SessionAssets, c *contactql.Condition, contact *flows.Contact) verdict {
+	var inGroup bool
+	if c.Value() == "" {
+		inGroup = contact.Groups().Count() > 0
+	} else if group := c.ValueAsGroup(sa); group != nil {
+		inGroup = contact.Groups().FindByUUID(group.UUID()) != nil
+	}
+
+	// = "" and != value are the negations of != "" and = value
+	return toVerdict(inGroup == (c.Operator() == contactql.OpEqual) != (c.Value() == ""))
+}
+
+func toVerdict(match bool) verdict {
+	if match {
+		return verdictMatch
+	}
+	return verdictNoMatch
+}
+
+type sequentialMapper struct {
+	ids      map[uuids.UUID]int64
+	mappings []string
+}
+
+func (m *sequentialMapper) Flow(f assets.Flow) int64 {
+	return m.id(uuids.UUID(f.UUID()), fmt.Sprintf("flow '%s'", f.Name()))
+}
+
+func (m *sequentialMapper) Group(g assets.Group) int64 {
+	return m.id(uuids.UUID(g.UUID()), fmt.Sprintf("group '%s'", g.Name()))
+}
+
+func (m *sequentialMapper) id(uuid uuids.UUID, name string) int64 {
+	id, seen := m.ids[uuid]
+	if !seen {
+		id = int64(len(m.ids) + 1)
+		m.ids[uuid] = id
+		m.mappings = append(m.mappings, fmt.Sprintf("%d = %s", id, name))
+	}
+	return id
+}
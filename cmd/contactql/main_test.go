@@ -0,0 +1,129 @@
+package main_test
+
+import (
+	"strings"
+	"testing"
+
+	main "github.com/nyaruka/goflow/cmd/contactql"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebug(t *testing.T) {
+	env := envs.NewBuilder().Build()
+
+	sa, err := main.LoadAssets(env, "testdata/assets.json")
+	require.NoError(t, err)
+
+	contact, err := main.ReadContact(sa, "testdata/contact.json")
+	require.NoError(t, err)
+
+	out := &strings.Builder{}
+	err = main.Debug(env, sa, `(age > 18 AND gender = female) OR group = "u-reporters" AND NOT group = testers AND results.registration.joined = yes`, contact, out)
+	require.NoError(t, err)
+
+	output := out.String()
+
+	assert.Contains(t, output, `================ parse tree ================
+OR
+  AND
+    fields.age > 18
+    fields.gender = "female"
+  AND
+    AND
+      group = "u-reporters"
+      NOT
+        group = "testers"
+    results.registration.joined = "yes"
+================ simplified ================
+(fields.age > 18 AND fields.gender = "female") OR (group = "u-reporters" AND NOT group = "testers" AND results.registration.joined = "yes")
+================ inspection ================
+{
+    "attributes": [
+        "group"
+    ],`)
+	assert.Contains(t, output, `"group_ids": 1`)
+	assert.Contains(t, output, `"results.flow": 3`)
+	assert.Contains(t, output, `1 = group 'U-Reporters'
+2 = group 'Testers'
+3 = flow 'Registration'
+================ evaluation ================
+❔ OR
+  ❌ AND
+    ✅ fields.age > 18
+    ❌ fields.gender = "female"
+  ❔ AND
+    ✅ group = "u-reporters"
+    ✅ NOT
+      ❌ group = "testers"
+    ❔ results.registration.joined = "yes"
+`)
+
+	out = &strings.Builder{}
+	err = main.Debug(env, sa, `age BETWEEN 18 AND 30 AND (gender = male OR flow = registration) AND group != "" AND status != blocked`, contact, out)
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), `================ evaluation ================
+✅ AND
+  ✅ fields.age BETWEEN 18 AND 30
+  ✅ OR
+    ✅ fields.gender = "male"
+    ❔ flow = "registration"
+  ✅ group != ""
+  ✅ status != "blocked"
+`)
+
+	// parse tree is shown as parsed and only the simplified form has double negatives removed
+	out = &strings.Builder{}
+	err = main.Debug(env, sa, `NOT NOT age > 5`, nil, out)
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), `================ parse tree ================
+NOT
+  NOT
+    fields.age > 5
+================ simplified ================
+fields.age > 5
+`)
+
+	// without a contact there's no evaluation
+	out = &strings.Builder{}
+	err = main.Debug(env, sa, `age > 18`, nil, out)
+	require.NoError(t, err)
+	assert.NotContains(t, out.String(), "evaluation")
+
+	err = main.Debug(env, sa, `age > `, nil, out)
+	assert.EqualError(t, err, "error parsing query: mismatched input '<EOF>' expecting {'-', NOT, IN, BETWEEN, SET, STRING, PROPERTY, TEXT}")
+
+	err = main.Debug(env, sa, `group = Nope`, nil, out)
+	assert.EqualError(t, err, "error parsing query: 'Nope' is not a valid group name")
+
+	_, err = main.LoadAssets(env, "testdata/missing.json")
+	assert.EqualError(t, err, "error reading assets file 'testdata/missing.json': error reading file 'testdata/missing.json': open testdata/missing.json: no such file or directory")
+
+	_, err = main.ReadContact(sa, "testdata/missing.json")
+	assert.EqualError(t, err, "error reading contact file 'testdata/missing.json': open testdata/missing.json: no such file or directory")
+}
+
+func TestNewEnvironment(t *testing.T) {
+	env, err := main.NewEnvironment("Africa/Kigali", "DD-MM-YYYY")
+	require.NoError(t, err)
+	assert.Equal(t, "Africa/Kigali", env.Timezone().String())
+	assert.Equal(t, envs.DateFormatDayMonthYear, env.DateFormat())
+
+	sa, err := main.LoadAssets(env, "testdata/assets.json")
+	require.NoError(t, err)
+
+	// dates in queries are parsed with the date format and timezone of the environment
+	out := &strings.Builder{}
+	err = main.Debug(env, sa, `created_on > 15-01-2024`, nil, out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), `"from": "2024-01-16T00:00:00+02:00"`)
+
+	_, err = main.NewEnvironment("Africa/Nowhere", "DD-MM-YYYY")
+	assert.EqualError(t, err, "invalid timezone 'Africa/Nowhere': unknown time zone Africa/Nowhere")
+
+	_, err = main.NewEnvironment("UTC", "DD/MM/YY")
+	assert.EqualError(t, err, "invalid date format 'DD/MM/YY'")
+}
@@ -46,4 +46,13 @@ func TestCompletion(t *testing.T) {
 		{Path: "contact.groups[0].uuid", Help: "the UUID of the group"},
 		{Path: "contact.groups[0].name", Help: "the name of the group"},
 	}, nodes)
+
+	assert.Equal(t, &completion.Schema{
+		Types: map[string]*completion.SchemaType{
+			"group":   {Properties: map[string]*completion.SchemaProperty{"uuid": {Type: "text"}, "name": {Type: "text"}}},
+			"fields":  {KeySource: "fields", Property: &completion.SchemaProperty{Type: "any"}},
+			"contact": {Properties: map[string]*completion.SchemaProperty{"name": {Type: "text"}, "fields": {Type: "fields"}, "groups": {Type: "group", Array: true}}},
+		},
+		Root: map[string]*completion.SchemaProperty{"contact": {Type: "contact"}, "legacy_extra": {Type: "any"}},
+	}, c.Schema())
 }
@@ -0,0 +1,67 @@
+package completion
+
+// SchemaProperty is a property of a context type without any help text
+type SchemaProperty struct {
+	Type  string `json:"type"`
+	Array bool   `json:"array,omitempty"`
+}
+
+// SchemaType is a context type without any help text. Static types have properties and dynamic types
+// have a key source and the type of the properties with keys from that source.
+type SchemaType struct {
+	Properties map[string]*SchemaProperty `json:"properties,omitempty"`
+	KeySource  string                     `json:"key_source,omitempty"`
+	Property   *SchemaProperty            `json:"property,omitempty"`
+}
+
+// Schema is a locale independent description of the context used for static checking of expressions
+type Schema struct {
+	Types map[string]*SchemaType     `json:"types"`
+	Root  map[string]*SchemaProperty `json:"root"`
+}
+
+// context values which aren't documented or offered for completion, but which expressions migrated from legacy flows
+// use and so mustn't be reported as invalid
+var legacyRoot = map[string]*SchemaProperty{"legacy_extra": {Type: "any"}}
+var legacyProperties = map[string]map[string]*SchemaProperty{
+	"input": {"type": {Type: "text"}},
+	"run":   {"path": {Type: "any"}},
+}
+
+// Schema creates a schema from this completion
+func (c *Completion) Schema() *Schema {
+	s := &Schema{Types: make(map[string]*SchemaType, len(c.Types)), Root: toSchemaProperties(c.Root)}
+
+	for _, t := range c.Types {
+		switch typed := t.(type) {
+		case *staticType:
+			s.Types[typed.Name_] = &SchemaType{Properties: toSchemaProperties(typed.Properties)}
+		case *dynamicType:
+			s.Types[typed.Name_] = &SchemaType{KeySource: typed.KeySource, Property: toSchemaProperty(typed.PropertyTemplate)}
+		}
+	}
+
+	for key, p := range legacyRoot {
+		s.Root[key] = p
+	}
+	for name, props := range legacyProperties {
+		if t := s.Types[name]; t != nil && t.Properties != nil {
+			for key, p := range props {
+				t.Properties[key] = p
+			}
+		}
+	}
+	return s
+}
+
+func toSchemaProperties(props []*Property) map[string]*SchemaProperty {
+	m := make(map[string]*SchemaProperty, len(props))
+	for _, p := range props {
+		m[p.Key] = toSchemaProperty(p)
+	}
+	return m
+}
+
+func toSchemaProperty(p *Property) *SchemaProperty {
+	return &SchemaProperty{Type: p.Type, Array: p.Array}
+}
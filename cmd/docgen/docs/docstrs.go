@@ -105,6 +105,9 @@ func findTaggedItems(baseDir string, searchDir string, callback func(item *Tagge
 			for _, m := range t.Funcs {
 				tryToParse(m.Doc, m.Name)
 			}
+			for _, v := range t.Vars {
+				tryToParse(v.Doc, v.Names[0])
+			}
 		}
 		for _, t := range p.Funcs {
 			tryToParse(t.Doc, t.Name)
@@ -65,6 +65,11 @@ func (g *editorSupportGenerator) Generate(baseDir, outputDir string, items map[s
 		return err
 	}
 
+	// and the context schema which is embedded for static checking of expressions
+	if err := createContextSchemaFile(baseDir, es.Context); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -153,6 +158,17 @@ func createContextPathListFile(outputDir string, c *completion.Completion) error
 	return os.WriteFile(listPath, []byte(nodeOutput.String()), 0755)
 }
 
+// creates the locale independent context schema file used by excellent/tools
+func createContextSchemaFile(baseDir string, c *completion.Completion) error {
+	marshaled, err := jsonx.MarshalPretty(c.Schema())
+	if err != nil {
+		return err
+	}
+
+	schemaPath := path.Join(baseDir, "excellent", "tools", "specdata", "context.json")
+	return os.WriteFile(schemaPath, append(marshaled, '\n'), 0644)
+}
+
 func createURNsType(gettext func(string) string) completion.Type {
 	properties := make([]*completion.Property, 0, len(urns.Schemes))
 	for _, s := range urns.Schemes {
@@ -0,0 +1,123 @@
+package main
+
+// go install github.com/nyaruka/goflow/cmd/excellent
+// excellent -trace context.json
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent"
+	"github.com/nyaruka/goflow/excellent/types"
+
+	// register router tests as Excellent functions
+	_ "github.com/nyaruka/goflow/flows/routers/cases"
+)
+
+const usage = `usage: excellent [flags] <context.json>`
+
+func main() {
+	var trace bool
+	flags := flag.NewFlagSet("", flag.ExitOnError)
+	flags.BoolVar(&trace, "trace", false, "print a trace of how each expression is evaluated")
+	flags.Parse(os.Args[1:])
+	args := flags.Args()
+
+	if len(args) != 1 {
+		fmt.Println(usage)
+		flags.PrintDefaults()
+		os.Exit(1)
+	}
+
+	ctx, err := ReadContext(args[0])
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	REPL(envs.NewBuilder().Build(), ctx, trace, os.Stdin, os.Stdout)
+}
+
+// ReadContext reads a context object from the given JSON file
+func ReadContext(path string) (*types.XObject, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading context file '%s': %w", path, err)
+	}
+
+	ctx, isObject := types.JSONToXValue(data).(*types.XObject)
+	if !isObject {
+		return nil, fmt.Errorf("context file '%s' doesn't contain a JSON object", path)
+	}
+	return ctx, nil
+}
+
+// REPL reads templates line by line, evaluating each and printing the result
+func REPL(env envs.Environment, ctx *types.XObject, trace bool, in io.Reader, out io.Writer) {
+	eval := excellent.NewEvaluator()
+	scanner := bufio.NewScanner(in)
+
+	for {
+		fmt.Fprintf(out, "> ")
+		if !scanner.Scan() {
+			break
+		}
+
+		template := scanner.Text()
+		if strings.TrimSpace(template) == "" {
+			continue
+		}
+
+		value, warnings, traces, err := eval.TraceTemplateValue(env, ctx, template)
+
+		if trace {
+			for _, t := range traces {
+				printTrace(t, 0, out)
+			}
+		}
+		for _, w := range warnings {
+			fmt.Fprintf(out, "⚠️ %s\n", w)
+		}
+
+		if err != nil {
+			fmt.Fprintf(out, "❌ %s\n", err.Error())
+		} else if types.IsXError(value) {
+			fmt.Fprintf(out, "❌ %s\n", value.(error).Error())
+		} else {
+			fmt.Fprintf(out, "%s\n", render(value))
+		}
+	}
+}
+
+// renders a value for output, using JSON for arrays and objects so that their contents are visible
+func render(v types.XValue) string {
+	switch v.(type) {
+	case *types.XArray, *types.XObject:
+		if asJSON, err := types.ToXJSON(v); err == nil {
+			return asJSON.Native()
+		}
+	}
+	return types.Render(v)
+}
+
+func printTrace(node *excellent.TraceNode, depth int, out io.Writer) {
+	indent := strings.Repeat("  ", depth)
+
+	if node.Error != "" {
+		fmt.Fprintf(out, "%s%s ❌ %s\n", indent, node.Expression, node.Error)
+	} else {
+		fmt.Fprintf(out, "%s%s → %s\n", indent, node.Expression, node.Value)
+	}
+	for _, w := range node.Warnings {
+		fmt.Fprintf(out, "%s  ⚠️ %s\n", indent, w)
+	}
+
+	for _, c := range node.Children {
+		printTrace(c, depth+1, out)
+	}
+}
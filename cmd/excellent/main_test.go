@@ -0,0 +1,51 @@
+package main_test
+
+import (
+	"strings"
+	"testing"
+
+	main "github.com/nyaruka/goflow/cmd/excellent"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestREPL(t *testing.T) {
+	ctx, err := main.ReadContext("testdata/context.json")
+	require.NoError(t, err)
+
+	in := strings.NewReader("Hi @contact.name\n\n@(contact.fields.age + 1)\n@(upper(contact.nmae))\n")
+	out := &strings.Builder{}
+
+	main.REPL(envs.NewBuilder().Build(), ctx, false, in, out)
+
+	assert.Equal(t, "> Hi Bob\n> > 24\n> ❌ error calling upper(...): object has no property 'nmae'\n> ", out.String())
+
+	// datetimes, arrays and objects are printed as values rather than their types
+	in = strings.NewReader("@(datetime(\"2024-01-15T10:30:00Z\") + duration(\"P1D\"))\n@({\"a\": 1, \"b\": [1, \"x\"]})\n@(array(contact.name, 2))\n")
+	out = &strings.Builder{}
+
+	main.REPL(envs.NewBuilder().Build(), ctx, false, in, out)
+
+	assert.Equal(t, "> 2024-01-16T10:30:00.000000Z\n> {\"a\":1,\"b\":[1,\"x\"]}\n> [\"Bob\",2]\n> ", out.String())
+
+	in = strings.NewReader("@(if(has_text(webhook.json.x), \"a\", \"b\"))\n")
+	out = &strings.Builder{}
+
+	main.REPL(envs.NewBuilder().Build(), ctx, true, in, out)
+
+	assert.Equal(t, "> "+`if(has_text(webhook.json.x), "a", "b") → "a"
+  if → if(...)
+  has_text(webhook.json.x) → {"match":"yes"}
+    has_text → has_text(...)
+    webhook.json.x → "yes"
+      webhook.json → {"x":"yes"}
+        webhook → {"json":{"x":"yes"}}
+  "a" → "a"
+  "b" → "b"
+a
+> `, out.String())
+
+	_, err = main.ReadContext("testdata/missing.json")
+	assert.EqualError(t, err, "error reading context file 'testdata/missing.json': open testdata/missing.json: no such file or directory")
+}
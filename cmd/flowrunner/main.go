@@ -183,6 +183,8 @@ func RunFlow(eng flows.Engine, assetsPath string, flowUUID assets.FlowUUID, init
 		// create our resume
 		if text == "/timeout" {
 			resume = resumes.NewWaitTimeout(nil, nil)
+		} else if text == "/timer" {
+			resume = resumes.NewTimer(nil, nil)
 		} else if strings.HasPrefix(text, "/dial") {
 			status := flows.DialStatus(strings.TrimSpace(text[5:]))
 			resume = resumes.NewDial(nil, nil, flows.NewDial(status, 10))
@@ -300,6 +302,8 @@ func PrintEvent(event flows.Event, out io.Writer) {
 		msg = fmt.Sprintf("🏁 session triggered for '%s'", typed.Flow.Name)
 	case *events.TicketOpenedEvent:
 		msg = fmt.Sprintf("🎟️ ticket opened with topic \"%s\"", typed.Ticket.Topic.Name)
+	case *events.TimerWaitEvent:
+		msg = fmt.Sprintf("⏳ waiting until %s (type /timer to simulate)...", typed.ResumeOn.Format(time.RFC3339))
 	case *events.WaitTimedOutEvent:
 		msg = "⏲️ resuming due to wait timeout"
 	case *events.WebhookCalledEvent:
@@ -84,8 +84,9 @@ func TestPrintEvent(t *testing.T) {
 		{events.NewFailure(errors.New("this really didn't work")), `🛑 this really didn't work`},
 		{events.NewFlowEntered(flow.Reference(false), "", false), `↪️ entered flow 'Registration'`},
 		{events.NewInputLabelsAdded("2a786bbc-2314-4d57-a0c9-b66e1642e5e2", []*flows.Label{sa.Labels().FindByName("Spam")}), `🏷️ labeled with 'Spam'`},
-		{events.NewMsgWait(nil, nil, nil), `⏳ waiting for message...`},
-		{events.NewMsgWait(&timeout, &expiresOn, nil), `⏳ waiting for message (3 sec timeout, type /timeout to simulate)...`},
+		{events.NewMsgWait(nil, nil, nil, nil), `⏳ waiting for message...`},
+		{events.NewMsgWait(&timeout, &expiresOn, nil, nil), `⏳ waiting for message (3 sec timeout, type /timeout to simulate)...`},
+		{events.NewTimerWait(time.Date(2022, 2, 1, 9, 0, 0, 0, time.UTC), &expiresOn), `⏳ waiting until 2022-02-01T09:00:00Z (type /timer to simulate)...`},
 	}
 
 	for _, tc := range tests {
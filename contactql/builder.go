@@ -0,0 +1,148 @@
+package contactql
+
+import (
+	"strings"
+
+	"github.com/nyaruka/goflow/envs"
+)
+
+// Builder is a fluent builder of query trees, e.g.
+//
+//	contactql.Field("age").GreaterThan("18").And(contactql.Attribute(contactql.AttributeGroup).Equal("VIP"))
+type Builder struct {
+	node QueryNode
+}
+
+// Property builds conditions on a single property
+type Property struct {
+	propType PropertyType
+	propKey  string
+}
+
+// Attribute starts a condition on the given attribute, e.g. name or group
+func Attribute(key string) *Property {
+	return &Property{propType: PropertyTypeAttribute, propKey: strings.ToLower(key)}
+}
+
+// Field starts a condition on the contact field with the given key
+func Field(key string) *Property {
+	return &Property{propType: PropertyTypeField, propKey: strings.ToLower(key)}
+}
+
+// URN starts a condition on URNs with the given scheme
+func URN(scheme string) *Property {
+	return &Property{propType: PropertyTypeURN, propKey: strings.ToLower(scheme)}
+}
+
+// Result starts a condition on the value of the given result of the given flow
+func Result(flow, key string) *Property {
+	return &Property{propType: PropertyTypeResult, propKey: resultFlowKey(flow) + "." + strings.ToLower(key)}
+}
+
+// ResultCategory starts a condition on the category of the given result of the given flow
+func ResultCategory(flow, key string) *Property {
+	return &Property{propType: PropertyTypeResult, propKey: resultFlowKey(flow) + "." + strings.ToLower(key) + ".category"}
+}
+
+// flows are referenced in result properties by name with spaces written as underscores
+func resultFlowKey(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), " ", "_")
+}
+
+// Equal builds a condition that the property is equal to the given value
+func (p *Property) Equal(value string) *Builder { return p.condition(OpEqual, value) }
+
+// NotEqual builds a condition that the property is not equal to the given value
+func (p *Property) NotEqual(value string) *Builder { return p.condition(OpNotEqual, value) }
+
+// Contains builds a condition that the property contains the given value
+func (p *Property) Contains(value string) *Builder { return p.condition(OpContains, value) }
+
+// GreaterThan builds a condition that the property is greater than the given value
+func (p *Property) GreaterThan(value string) *Builder { return p.condition(OpGreaterThan, value) }
+
+// GreaterThanOrEqual builds a condition that the property is greater than or equal to the given value
+func (p *Property) GreaterThanOrEqual(value string) *Builder {
+	return p.condition(OpGreaterThanOrEqual, value)
+}
+
+// LessThan builds a condition that the property is less than the given value
+func (p *Property) LessThan(value string) *Builder { return p.condition(OpLessThan, value) }
+
+// LessThanOrEqual builds a condition that the property is less than or equal to the given value
+func (p *Property) LessThanOrEqual(value string) *Builder {
+	return p.condition(OpLessThanOrEqual, value)
+}
+
+// In builds a condition that the property is equal to one of the given values
+func (p *Property) In(values ...string) *Builder {
+	return &Builder{node: NewListCondition(p.propType, p.propKey, OpIn, values)}
+}
+
+// Between builds a condition that the property is between the given values inclusive
+func (p *Property) Between(min, max string) *Builder {
+	return &Builder{node: NewListCondition(p.propType, p.propKey, OpBetween, []string{min, max})}
+}
+
+// IsSet builds a condition that the property has a value
+func (p *Property) IsSet() *Builder { return p.condition(OpNotEqual, "") }
+
+// IsNotSet builds a condition that the property has no value
+func (p *Property) IsNotSet() *Builder { return p.condition(OpEqual, "") }
+
+func (p *Property) condition(op Operator, value string) *Builder {
+	return &Builder{node: NewCondition(p.propType, p.propKey, op, value)}
+}
+
+// Node wraps an existing query node so that it can be combined with others
+func Node(node QueryNode) *Builder {
+	return &Builder{node: node}
+}
+
+// And combines the given builders with AND
+func And(builders ...*Builder) *Builder {
+	return combine(BoolOperatorAnd, builders)
+}
+
+// Or combines the given builders with OR
+func Or(builders ...*Builder) *Builder {
+	return combine(BoolOperatorOr, builders)
+}
+
+func combine(op BoolOperator, builders []*Builder) *Builder {
+	children := make([]QueryNode, len(builders))
+	for i, b := range builders {
+		children[i] = b.node
+	}
+	return &Builder{node: NewBoolCombination(op, children...)}
+}
+
+// And combines this builder and the given builders with AND
+func (b *Builder) And(others ...*Builder) *Builder {
+	return And(append([]*Builder{b}, others...)...)
+}
+
+// Or combines this builder and the given builders with OR
+func (b *Builder) Or(others ...*Builder) *Builder {
+	return Or(append([]*Builder{b}, others...)...)
+}
+
+// Not negates this builder
+func (b *Builder) Not() *Builder {
+	return &Builder{node: NewNot(b.node)}
+}
+
+// Node returns the built query node, simplified
+func (b *Builder) Node() QueryNode {
+	return b.node.Simplify()
+}
+
+// String returns the built query as ContactQL
+func (b *Builder) String() string {
+	return Stringify(b.Node())
+}
+
+// Query parses the built query so that it's validated exactly as if it had been written by hand
+func (b *Builder) Query(env envs.Environment, resolver Resolver) (*ContactQuery, error) {
+	return ParseQuery(env, b.String(), resolver)
+}
@@ -0,0 +1,81 @@
+package contactql_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/assets/static"
+	"github.com/nyaruka/goflow/contactql"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder(t *testing.T) {
+	env := envs.NewBuilder().Build()
+	resolver := contactql.NewMockResolver(
+		[]assets.Field{
+			static.NewField("f1b5aea6-6586-41c7-9020-1a6326cc6565", "age", "Age", assets.FieldTypeNumber),
+			static.NewField("3810a485-3fda-4011-a589-7320c0b8dbef", "dob", "DOB", assets.FieldTypeDatetime),
+			static.NewField("e52f34ad-a5a7-4855-9040-05a910a75f57", "district", "District", assets.FieldTypeDistrict),
+		},
+		[]assets.Flow{
+			static.NewFlow("f87fd7cd-e501-4394-9cff-62309af85138", "Registration", registrationDefinition),
+		},
+		[]assets.Group{
+			static.NewGroup("a9b5b0a0-1098-4bc2-8384-eea09ae43e6b", "VIP", ""),
+		},
+	)
+
+	tests := []struct {
+		builder *contactql.Builder
+		query   string
+	}{
+		{contactql.Attribute("Name").Contains("bob"), `name ~ "bob"`},
+		{contactql.Field("AGE").GreaterThan("18"), `fields.age > 18`},
+		{contactql.Field("age").GreaterThanOrEqual("18").Or(contactql.Field("age").LessThanOrEqual("10")), `fields.age >= 18 OR fields.age <= 10`},
+		{contactql.Field("dob").LessThan("-7d"), `fields.dob < "-7d"`},
+		{contactql.URN("tel").IsSet(), `urns.tel != ""`},
+		{contactql.Field("district").IsNotSet(), `fields.district = ""`},
+		{contactql.Attribute(contactql.AttributeGroup).NotEqual("VIP"), `group != "VIP"`},
+		{contactql.Field("age").In("18", "19"), `fields.age IN (18, 19)`},
+		{contactql.Field("age").Between("18", "30"), `fields.age BETWEEN 18 AND 30`},
+		{contactql.Result("Registration", "Age").GreaterThan("18"), `results.registration.age > 18`},
+		{contactql.ResultCategory("Registration", "Category").Equal("Yes"), `results.registration.category.category = "Yes"`},
+		{contactql.Field("district").Equal("Kano").Not(), `NOT fields.district = "Kano"`},
+		{
+			contactql.Field("age").GreaterThan("18").And(contactql.Field("district").Equal("Kano")).Or(contactql.Attribute("group").Equal("VIP")),
+			`(fields.age > 18 AND fields.district = "Kano") OR group = "VIP"`,
+		},
+		{
+			contactql.And(contactql.Field("age").GreaterThan("18"), contactql.And(contactql.Field("district").Equal("Kano"), contactql.URN("tel").IsSet())),
+			`fields.age > 18 AND fields.district = "Kano" AND urns.tel != ""`,
+		},
+		{
+			contactql.Or(contactql.Field("age").GreaterThan("18")).Not().Not(),
+			`fields.age > 18`,
+		},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.query, tc.builder.String())
+
+		// built queries should parse back to the same query
+		parsed, err := tc.builder.Query(env, resolver)
+		require.NoError(t, err, "unexpected error for '%s'", tc.query)
+		assert.Equal(t, tc.query, parsed.String())
+	}
+
+	// built queries are validated like parsed queries
+	_, err := contactql.Attribute(contactql.AttributeGroup).Equal("Nope").Query(env, resolver)
+	assert.EqualError(t, err, "'Nope' is not a valid group name")
+
+	_, err = contactql.Field("district").GreaterThan("3").Query(env, resolver)
+	assert.EqualError(t, err, "comparisons with > can only be used with date and number fields")
+
+	// existing nodes can be combined with built nodes
+	parsed, err := contactql.ParseQuery(env, `age > 18 OR district = Kano`, resolver)
+	require.NoError(t, err)
+
+	assert.Equal(t, `(fields.age > 18 OR fields.district = "Kano") AND group = "VIP"`, contactql.Node(parsed.Root()).And(contactql.Attribute("group").Equal("VIP")).String())
+}
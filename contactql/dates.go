@@ -0,0 +1,89 @@
+package contactql
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// relative dates can be offsets like -7d or +2w, or like 3 days ago
+var relativeOffsetRegex = regexp.MustCompile(`^([+-]\d+)\s*([dwmy])$`)
+var relativeAgoRegex = regexp.MustCompile(`^(\d+)\s*(day|week|month|year)s?\s+ago$`)
+
+// named relative dates and how to resolve them from the current time and the day that weeks start on
+var relativeDates = map[string]func(time.Time, time.Weekday) time.Time{
+	"today":     func(t time.Time, _ time.Weekday) time.Time { return t },
+	"yesterday": func(t time.Time, _ time.Weekday) time.Time { return t.AddDate(0, 0, -1) },
+	"tomorrow":  func(t time.Time, _ time.Weekday) time.Time { return t.AddDate(0, 0, 1) },
+	"start_of_week": func(t time.Time, ws time.Weekday) time.Time {
+		return t.AddDate(0, 0, -((7 + int(t.Weekday()) - int(ws)) % 7))
+	},
+	"start_of_month": func(t time.Time, _ time.Weekday) time.Time {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	},
+	"start_of_year": func(t time.Time, _ time.Weekday) time.Time {
+		return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location())
+	},
+}
+
+// IsRelativeDate returns whether the given value is a relative date like -7d, 2 weeks ago or today
+func IsRelativeDate(value string) bool {
+	_, _, _, ok := splitRelativeDate(value)
+	return ok
+}
+
+// splits a relative date value into its name, e.g. today, or its offset and unit, e.g. -7 and d
+func splitRelativeDate(value string) (string, int, string, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	if _, found := relativeDates[value]; found {
+		return value, 0, "", true
+	}
+
+	if match := relativeOffsetRegex.FindStringSubmatch(value); match != nil {
+		num, _ := strconv.Atoi(match[1])
+		return "", num, match[2], true
+	}
+	if match := relativeAgoRegex.FindStringSubmatch(value); match != nil {
+		num, _ := strconv.Atoi(match[1])
+		return "", -num, match[2][:1], true
+	}
+
+	return "", 0, "", false
+}
+
+// parses a relative date value against the given current time and day that weeks start on
+func parseRelativeDate(now time.Time, weekStart time.Weekday, value string) (time.Time, bool) {
+	name, num, unit, ok := splitRelativeDate(value)
+	if !ok {
+		return time.Time{}, false
+	}
+	if name != "" {
+		return relativeDates[name](now, weekStart), true
+	}
+	return offsetDate(now, num, unit), true
+}
+
+// offsets the given date by a number of days, weeks, months or years
+func offsetDate(t time.Time, num int, unit string) time.Time {
+	switch unit {
+	case "d":
+		return t.AddDate(0, 0, num)
+	case "w":
+		return t.AddDate(0, 0, num*7)
+	case "m":
+		return addMonths(t, num)
+	default:
+		return addMonths(t, num*12)
+	}
+}
+
+// adds a number of months to the given date, clamping the day to the end of the target month so that
+// a month before 2024-03-31 is 2024-02-29 rather than overflowing into March
+func addMonths(t time.Time, num int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(num), 1, 0, 0, 0, 0, t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+
+	return time.Date(first.Year(), first.Month(), min(t.Day(), lastDay), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
@@ -0,0 +1,289 @@
+package contactql
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/nyaruka/gocommon/i18n"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/locale"
+)
+
+// the gettext domain of the catalogs used to translate descriptions
+const describeDomain = "contactql"
+
+// Clause is a node in the structured explanation of a query. Combinations and negations have an op of and, or or
+// not and the clauses they combine or negate as children.
+type Clause struct {
+	Text     string    `json:"text"`
+	Op       string    `json:"op,omitempty"`
+	Children []*Clause `json:"children,omitempty"`
+}
+
+// Description is a human readable description of a query
+type Description struct {
+	Text   string  `json:"text"`
+	Clause *Clause `json:"clause,omitempty"`
+}
+
+// Describe describes the given query in the given locale, falling back to English if the locale has no translations.
+// If the query was parsed with a resolver then fields, groups and flows are described by their names.
+func Describe(query *ContactQuery, loc i18n.Locale) *Description {
+	d := &describer{resolver: query.Resolver(), gettext: locale.GetText(loc, describeDomain)}
+
+	// a query can simplify to nothing in which case it matches everything
+	if query.Root() == nil {
+		return &Description{Text: d.gettext("all contacts")}
+	}
+
+	clause := d.node(query.Root())
+	return &Description{Text: clause.Text, Clause: clause}
+}
+
+// attributes which have names, rather than being described by their own templates
+var attributeNames = map[string]string{
+	AttributeUUID:       "UUID",
+	AttributeID:         "ID",
+	AttributeName:       "name",
+	AttributeStatus:     "status",
+	AttributeLanguage:   "language",
+	AttributeURN:        "URN",
+	AttributeTickets:    "open tickets",
+	AttributeCreatedOn:  "created on",
+	AttributeLastSeenOn: "last seen on",
+}
+
+// templates for conditions on properties by operator
+var propertyTemplates = map[Operator]string{
+	OpEqual:              "{property} is {value}",
+	OpNotEqual:           "{property} is not {value}",
+	OpContains:           "{property} contains {value}",
+	OpGreaterThan:        "{property} is greater than {value}",
+	OpGreaterThanOrEqual: "{property} is greater than or equal to {value}",
+	OpLessThan:           "{property} is less than {value}",
+	OpLessThanOrEqual:    "{property} is less than or equal to {value}",
+	OpIn:                 "{property} is one of {values}",
+	OpBetween:            "{property} is between {min} and {max}",
+}
+
+// templates for conditions on date properties by operator, which compare whole days
+var dateTemplates = map[Operator]string{
+	OpEqual:              "{property} is on {value}",
+	OpNotEqual:           "{property} is not on {value}",
+	OpGreaterThan:        "{property} is after {value}",
+	OpGreaterThanOrEqual: "{property} is on or after {value}",
+	OpLessThan:           "{property} is before {value}",
+	OpLessThanOrEqual:    "{property} is on or before {value}",
+}
+
+// templates for conditions on groups and flows, as = value, != value, is set and is not set
+var membershipTemplates = map[string][4]string{
+	AttributeGroup:            {"is in group {value}", "is not in group {value}", "is in any group", "is not in any group"},
+	AttributeFlow:             {"is in flow {value}", "is not in flow {value}", "is in a flow", "is not in a flow"},
+	AttributeHistory:          {"has been in flow {value}", "has not been in flow {value}", "has been in a flow", "has never been in a flow"},
+	AttributeHistoryCompleted: {"has completed flow {value}", "has not completed flow {value}", "has completed a flow", "has never completed a flow"},
+	AttributeHistoryExited:    {"has exited flow {value}", "has not exited flow {value}", "has exited a flow", "has never exited a flow"},
+}
+
+// named relative dates and their descriptions
+var relativeDateNames = map[string]string{
+	"today":          "today",
+	"yesterday":      "yesterday",
+	"tomorrow":       "tomorrow",
+	"start_of_week":  "the start of the week",
+	"start_of_month": "the start of the month",
+	"start_of_year":  "the start of the year",
+}
+
+// templates for relative date offsets by unit, as one ago, many ago, one from now and many from now
+var relativeOffsetTemplates = map[string][4]string{
+	"d": {"1 day ago", "{count} days ago", "1 day from now", "{count} days from now"},
+	"w": {"1 week ago", "{count} weeks ago", "1 week from now", "{count} weeks from now"},
+	"m": {"1 month ago", "{count} months ago", "1 month from now", "{count} months from now"},
+	"y": {"1 year ago", "{count} years ago", "1 year from now", "{count} years from now"},
+}
+
+type describer struct {
+	resolver Resolver
+	gettext  func(string) string
+}
+
+func (d *describer) node(node QueryNode) *Clause {
+	switch n := node.(type) {
+	case *BoolCombination:
+		return d.boolCombination(n)
+	case *Not:
+		child := d.node(n.Child())
+		return &Clause{Text: d.format("not {clause}", "{clause}", "("+child.Text+")"), Op: "not", Children: []*Clause{child}}
+	case *Condition:
+		return d.condition(n)
+	}
+	return nil
+}
+
+func (d *describer) boolCombination(b *BoolCombination) *Clause {
+	children := make([]*Clause, len(b.Children()))
+	texts := make([]string, len(b.Children()))
+
+	for i, child := range b.Children() {
+		children[i] = d.node(child)
+		texts[i] = children[i].Text
+
+		// nested combinations are wrapped in parentheses to make precedence clear
+		if _, isCombination := child.(*BoolCombination); isCombination {
+			texts[i] = "(" + texts[i] + ")"
+		}
+	}
+
+	return &Clause{Text: strings.Join(texts, " "+d.gettext(string(b.Operator()))+" "), Op: string(b.Operator()), Children: children}
+}
+
+func (d *describer) condition(c *Condition) *Clause {
+	// groups and flows have their own templates, and lists of them are described as their expansions
+	if templates, isMembership := membershipTemplates[c.propKey]; isMembership && c.propType == PropertyTypeAttribute {
+		if c.operator == OpIn {
+			return d.node(c.Expand())
+		}
+
+		var template string
+		switch {
+		case c.operator == OpEqual && c.value == "":
+			template = templates[3]
+		case c.operator == OpNotEqual && c.value == "":
+			template = templates[2]
+		case c.operator == OpEqual:
+			template = templates[0]
+		default:
+			template = templates[1]
+		}
+		return &Clause{Text: d.format(template, "{value}", strconv.Quote(d.membershipName(c)))}
+	}
+
+	property := d.propertyName(c)
+
+	if c.value == "" && c.values == nil {
+		if c.operator == OpEqual {
+			return &Clause{Text: d.format("{property} is not set", "{property}", property)}
+		} else if c.operator == OpNotEqual {
+			return &Clause{Text: d.format("{property} is set", "{property}", property)}
+		}
+	}
+
+	valueType := d.valueType(c)
+	template := propertyTemplates[c.operator]
+	if valueType == assets.FieldTypeDatetime && dateTemplates[c.operator] != "" {
+		template = dateTemplates[c.operator]
+	}
+
+	values := make([]string, len(c.values))
+	for i, v := range c.values {
+		values[i] = d.value(valueType, v)
+	}
+
+	replacements := []string{"{property}", property, "{value}", d.value(valueType, c.value), "{values}", strings.Join(values, ", ")}
+	if c.operator == OpBetween {
+		replacements = append(replacements, "{min}", values[0], "{max}", values[1])
+	}
+
+	return &Clause{Text: d.format(template, replacements...)}
+}
+
+// gets the name of the property of the given condition
+func (d *describer) propertyName(c *Condition) string {
+	switch c.propType {
+	case PropertyTypeAttribute:
+		return d.gettext(attributeNames[c.propKey])
+	case PropertyTypeURN:
+		return d.format("{scheme} URN", "{scheme}", c.propKey)
+	case PropertyTypeField:
+		if d.resolver != nil {
+			if field := d.resolver.ResolveField(c.propKey); field != nil {
+				return field.Name()
+			}
+		}
+	case PropertyTypeResult:
+		key, isCategory := c.ResultKey()
+		flow := strings.SplitN(c.propKey, ".", 2)[0]
+		if d.resolver != nil {
+			if f := c.ResultFlow(d.resolver); f != nil {
+				flow = f.Name()
+			}
+		}
+
+		if isCategory {
+			return d.format("category of {result} in {flow}", "{result}", key, "{flow}", flow)
+		}
+		return d.format("{result} in {flow}", "{result}", key, "{flow}", flow)
+	}
+	return c.propKey
+}
+
+// gets the name of the group or flow of the given condition
+func (d *describer) membershipName(c *Condition) string {
+	if d.resolver == nil {
+		return c.value
+	}
+
+	if c.propKey == AttributeGroup {
+		if group := c.ValueAsGroup(d.resolver); group != nil {
+			return group.Name()
+		}
+	} else if flow := c.ValueAsFlow(d.resolver); flow != nil {
+		return flow.Name()
+	}
+	return c.value
+}
+
+// gets the type of the values compared by the given condition, which is unknown for fields without a resolver
+func (d *describer) valueType(c *Condition) assets.FieldType {
+	if c.propType == PropertyTypeField && d.resolver == nil {
+		return ""
+	}
+	return c.resolveValueType(d.resolver)
+}
+
+// formats a value of the given type, quoting text but not numbers or dates
+func (d *describer) value(valueType assets.FieldType, value string) string {
+	switch valueType {
+	case assets.FieldTypeNumber:
+		return value
+	case assets.FieldTypeDatetime:
+		return d.date(value)
+	case "":
+		return quoteValue(value) // type is unknown so only numbers are left unquoted
+	}
+	return strconv.Quote(value)
+}
+
+// formats a date value, describing relative dates like -7d or today with localized phrases
+func (d *describer) date(value string) string {
+	name, num, unit, isRelative := splitRelativeDate(value)
+	if !isRelative {
+		return value
+	}
+	if name != "" {
+		return d.gettext(relativeDateNames[name])
+	}
+	if num == 0 {
+		return d.gettext("today")
+	}
+
+	templates := relativeOffsetTemplates[unit]
+	count := num
+	template := 0
+	if num > 0 {
+		template = 2
+	} else {
+		count = -num
+	}
+	if count != 1 {
+		template++
+	}
+
+	return d.format(templates[template], "{count}", strconv.Itoa(count))
+}
+
+// translates the given template and replaces its placeholders
+func (d *describer) format(template string, replacements ...string) string {
+	return strings.NewReplacer(replacements...).Replace(d.gettext(template))
+}
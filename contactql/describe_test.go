@@ -0,0 +1,128 @@
+package contactql_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/gocommon/i18n"
+	"github.com/nyaruka/gocommon/jsonx"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/assets/static"
+	"github.com/nyaruka/goflow/contactql"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribe(t *testing.T) {
+	env := envs.NewBuilder().WithDateFormat(envs.DateFormatYearMonthDay).Build()
+	resolver := contactql.NewMockResolver(
+		[]assets.Field{
+			static.NewField("f1b5aea6-6586-41c7-9020-1a6326cc6565", "age", "Age", assets.FieldTypeNumber),
+			static.NewField("3810a485-3fda-4011-a589-7320c0b8dbef", "dob", "Date Of Birth", assets.FieldTypeDatetime),
+			static.NewField("e52f34ad-a5a7-4855-9040-05a910a75f57", "district", "District", assets.FieldTypeDistrict),
+		},
+		[]assets.Flow{
+			static.NewFlow("f87fd7cd-e501-4394-9cff-62309af85138", "Registration", registrationDefinition),
+		},
+		[]assets.Group{
+			static.NewGroup("a9b5b0a0-1098-4bc2-8384-eea09ae43e6b", "VIP", ""),
+			static.NewGroup("4eeca453-f474-4767-bdd0-434b180223db", "U-Reporters", ""),
+		},
+	)
+
+	tests := []struct {
+		query    string
+		resolver contactql.Resolver
+		locale   i18n.Locale
+		text     string
+	}{
+		{query: `(age > 18 AND district = "Kano") OR group = "vip"`, resolver: resolver, locale: "eng-US", text: `(Age is greater than 18 and District is "Kano") or is in group "VIP"`},
+		{query: `(age > 18 AND district = "Kano") OR group = "vip"`, resolver: resolver, locale: "spa", text: `(Age es mayor que 18 y District es "Kano") o está en el grupo "VIP"`},
+		{query: `(age > 18 AND district = "Kano") OR group = "vip"`, resolver: resolver, locale: "fra", text: `(Age est supérieur à 18 et District est "Kano") ou est dans le groupe "VIP"`},
+		{query: `(age > 18 AND district = "Kano") OR group = "vip"`, resolver: resolver, locale: "por-BR", text: `(Age é maior que 18 e District é "Kano") ou está no grupo "VIP"`},
+		{query: `(age > 18 AND district = "Kano") OR group = "vip"`, locale: "eng-US", text: `(age is greater than 18 and district is "Kano") or is in group "vip"`},
+		{query: `(age > 18 AND district = "Kano") OR group = "vip"`, resolver: resolver, locale: "kin", text: `(Age is greater than 18 and District is "Kano") or is in group "VIP"`},
+
+		{query: `bob`, resolver: resolver, locale: "spa", text: `nombre contiene "bob"`},
+		{query: `name = ""`, resolver: resolver, locale: "spa", text: `nombre no está definido`},
+		{query: `tel != ""`, resolver: resolver, locale: "spa", text: `URN tel está definido`},
+		{query: `uuid != 123`, resolver: resolver, locale: "spa", text: `UUID no es "123"`},
+		{query: `id = 123`, resolver: resolver, locale: "spa", text: `ID es "123"`},
+		{query: `status = active`, resolver: resolver, locale: "spa", text: `estado es "active"`},
+		{query: `language = spa`, resolver: resolver, locale: "spa", text: `idioma es "spa"`},
+		{query: `urn ~ 1234`, resolver: resolver, locale: "spa", text: `URN contiene "1234"`},
+		{query: `tickets >= 1`, resolver: resolver, locale: "spa", text: `tickets abiertos es mayor o igual que 1`},
+		{query: `age < 18 OR age <= 10`, resolver: resolver, locale: "spa", text: `Age es menor que 18 o Age es menor o igual que 10`},
+		{query: `age IN (18, 19)`, resolver: resolver, locale: "spa", text: `Age es uno de 18, 19`},
+		{query: `age BETWEEN 18 AND 30`, resolver: resolver, locale: "spa", text: `Age está entre 18 y 30`},
+		{query: `dob = 2000-01-01 OR dob != 2000-01-02`, resolver: resolver, locale: "spa", text: `Date Of Birth es 2000-01-01 o Date Of Birth no es 2000-01-02`},
+		{query: `created_on > 2020-01-01 AND created_on >= 2020-01-01`, resolver: resolver, locale: "spa", text: `fecha de creación es después de 2020-01-01 y fecha de creación es 2020-01-01 o después`},
+		{query: `last_seen_on < -7d AND last_seen_on <= today`, resolver: resolver, locale: "spa", text: `última vez visto es antes de hace 7 días y última vez visto es hoy o antes`},
+		{query: `last_seen_on < -7d AND last_seen_on <= today`, resolver: resolver, locale: "eng", text: `last seen on is before 7 days ago and last seen on is on or before today`},
+		{query: `dob > "1 year ago" AND dob < +1w`, resolver: resolver, locale: "eng", text: `Date Of Birth is after 1 year ago and Date Of Birth is before 1 week from now`},
+		{query: `created_on >= start_of_week`, resolver: resolver, locale: "fra", text: `date de création est le début de la semaine ou après`},
+		{query: `created_on BETWEEN "3 months ago" AND yesterday`, resolver: resolver, locale: "por-BR", text: `data de criação está entre há 3 meses e ontem`},
+		{query: `created_on = yesterday`, resolver: resolver, locale: "kin", text: `created on is on yesterday`},
+		{query: `district IN (Kano, 123)`, resolver: resolver, locale: "eng", text: `District is one of "Kano", "123"`},
+		{query: `group = 123`, locale: "eng", text: `is in group "123"`},
+		{query: `group != vip AND group != ""`, resolver: resolver, locale: "spa", text: `no está en el grupo "VIP" y está en algún grupo`},
+		{query: `group = ""`, resolver: resolver, locale: "spa", text: `no está en ningún grupo`},
+		{query: `group IN (vip, u-reporters)`, resolver: resolver, locale: "spa", text: `está en el grupo "VIP" o está en el grupo "U-Reporters"`},
+		{query: `flow = registration OR flow != registration`, resolver: resolver, locale: "spa", text: `está en el flujo "Registration" o no está en el flujo "Registration"`},
+		{query: `flow != "" OR flow = ""`, resolver: resolver, locale: "spa", text: `está en un flujo o no está en un flujo`},
+		{query: `history = registration OR history != registration`, resolver: resolver, locale: "spa", text: `ha estado en el flujo "Registration" o no ha estado en el flujo "Registration"`},
+		{query: `history != "" OR history = ""`, resolver: resolver, locale: "spa", text: `ha estado en algún flujo o nunca ha estado en un flujo`},
+		{query: `history.completed = registration OR history.completed != registration`, resolver: resolver, locale: "spa", text: `ha completado el flujo "Registration" o no ha completado el flujo "Registration"`},
+		{query: `history.completed != "" OR history.completed = ""`, resolver: resolver, locale: "spa", text: `ha completado algún flujo o nunca ha completado un flujo`},
+		{query: `history.exited = registration OR history.exited != registration`, resolver: resolver, locale: "spa", text: `ha salido del flujo "Registration" o no ha salido del flujo "Registration"`},
+		{query: `history.exited != "" OR history.exited = ""`, resolver: resolver, locale: "spa", text: `ha salido de algún flujo o nunca ha salido de un flujo`},
+		{query: `results.registration.age > 18`, resolver: resolver, locale: "spa", text: `age en Registration es mayor que 18`},
+		{query: `results.registration.category.category = Yes`, resolver: resolver, locale: "spa", text: `categoría de category en Registration es "Yes"`},
+		{query: `results.registration.age > 18`, locale: "eng", text: `age in registration is greater than 18`},
+		{query: `NOT (age > 18 OR district = Kano)`, resolver: resolver, locale: "spa", text: `no (Age es mayor que 18 o District es "Kano")`},
+		{query: `-district = Kano`, resolver: resolver, locale: "eng", text: `not (District is "Kano")`},
+		{query: `age > 18 AND (district = Kano OR (dob != "" AND bob))`, resolver: resolver, locale: "eng", text: `Age is greater than 18 and (District is "Kano" or (Date Of Birth is set and name contains "bob"))`},
+	}
+
+	for _, tc := range tests {
+		query, err := contactql.ParseQuery(env, tc.query, tc.resolver)
+		require.NoError(t, err, "unexpected error parsing '%s'", tc.query)
+
+		desc := contactql.Describe(query, tc.locale)
+		assert.Equal(t, tc.text, desc.Text, "description mismatch for '%s' in '%s'", tc.query, tc.locale)
+	}
+
+	// check the structured explanation
+	query, err := contactql.ParseQuery(env, `(age > 18 AND district = "Kano") OR NOT group = "vip"`, resolver)
+	require.NoError(t, err)
+
+	test.AssertEqualJSON(t, []byte(`{
+		"text": "(Age is greater than 18 and District is \"Kano\") or not (is in group \"VIP\")",
+		"clause": {
+			"text": "(Age is greater than 18 and District is \"Kano\") or not (is in group \"VIP\")",
+			"op": "or",
+			"children": [
+				{
+					"text": "Age is greater than 18 and District is \"Kano\"",
+					"op": "and",
+					"children": [
+						{"text": "Age is greater than 18"},
+						{"text": "District is \"Kano\""}
+					]
+				},
+				{
+					"text": "not (is in group \"VIP\")",
+					"op": "not",
+					"children": [
+						{"text": "is in group \"VIP\""}
+					]
+				}
+			]
+		}
+	}`), jsonx.MustMarshal(contactql.Describe(query, "eng")), "explanation mismatch")
+
+	// a query which simplifies to nothing
+	query = &contactql.ContactQuery{}
+	assert.Equal(t, &contactql.Description{Text: "todos los contactos"}, contactql.Describe(query, "spa"))
+}
@@ -25,6 +25,14 @@ var contactStatusCodes = map[string]string{
 	"archived": "V",
 }
 
+// the fields which store the current flow and the flows the contact has run
+var historyFieldNames = map[string]string{
+	contactql.AttributeFlow:             "flow_id",
+	contactql.AttributeHistory:          "flow_history_ids",
+	contactql.AttributeHistoryCompleted: "flow_completed_ids",
+	contactql.AttributeHistoryExited:    "flow_exited_ids",
+}
+
 // ToElasticQuery converts a contactql query to an Elastic query
 func ToElasticQuery(env envs.Environment, mapper AssetMapper, query *contactql.ContactQuery) elastic.Query {
 	if query.Resolver() == nil {
@@ -38,6 +46,8 @@ func nodeToElastic(env envs.Environment, resolver contactql.Resolver, mapper Ass
 	switch n := node.(type) {
 	case *contactql.BoolCombination:
 		return boolCombination(env, resolver, mapper, n)
+	case *contactql.Not:
+		return elastic.Not(nodeToElastic(env, resolver, mapper, n.Child()))
 	case *contactql.Condition:
 		return condition(env, resolver, mapper, n)
 	default:
@@ -59,6 +69,11 @@ func boolCombination(env envs.Environment, resolver contactql.Resolver, mapper A
 }
 
 func condition(env envs.Environment, resolver contactql.Resolver, mapper AssetMapper, c *contactql.Condition) elastic.Query {
+	// IN and BETWEEN conditions are converted as the single value conditions they're equivalent to
+	if c.Operator() == contactql.OpIn || c.Operator() == contactql.OpBetween {
+		return nodeToElastic(env, resolver, mapper, c.Expand())
+	}
+
 	switch c.PropertyType() {
 	case contactql.PropertyTypeField:
 		return fieldCondition(env, resolver, c)
@@ -66,6 +81,8 @@ func condition(env envs.Environment, resolver contactql.Resolver, mapper AssetMa
 		return attributeCondition(env, resolver, mapper, c)
 	case contactql.PropertyTypeURN:
 		return schemeCondition(c)
+	case contactql.PropertyTypeResult:
+		return resultCondition(env, resolver, mapper, c)
 	default:
 		panic(fmt.Sprintf("unsupported property type: %s", c.PropertyType()))
 	}
@@ -313,11 +330,8 @@ func attributeCondition(env envs.Environment, resolver contactql.Resolver, mappe
 		default:
 			panic(fmt.Sprintf("unsupported group attribute operator: %s", c.Operator()))
 		}
-	case contactql.AttributeFlow, contactql.AttributeHistory:
-		fieldName := "flow_id"
-		if c.PropertyKey() == contactql.AttributeHistory {
-			fieldName = "flow_history_ids"
-		}
+	case contactql.AttributeFlow, contactql.AttributeHistory, contactql.AttributeHistoryCompleted, contactql.AttributeHistoryExited:
+		fieldName := historyFieldNames[c.PropertyKey()]
 
 		// special case for set/unset
 		if (c.Operator() == contactql.OpEqual || c.Operator() == contactql.OpNotEqual) && value == "" {
@@ -345,6 +359,61 @@ func attributeCondition(env envs.Environment, resolver contactql.Resolver, mappe
 	}
 }
 
+// results are stored as nested documents with flow (ID), key, value and category (lowercase keywords), and number and
+// datetime for values which match contactql.ResultNumberRegex and contactql.ResultDateRegex
+func resultCondition(env envs.Environment, resolver contactql.Resolver, mapper AssetMapper, c *contactql.Condition) elastic.Query {
+	key, isCategory := c.ResultKey()
+	flowQuery := elastic.Term("results.flow", mapper.Flow(c.ResultFlow(resolver)))
+	keyQuery := elastic.Term("results.key", key)
+
+	name := "results.value"
+	if isCategory {
+		name = "results.category"
+	}
+
+	// special cases for set/unset
+	if (c.Operator() == contactql.OpEqual || c.Operator() == contactql.OpNotEqual) && c.Value() == "" {
+		query := elastic.Nested("results", elastic.All(flowQuery, keyQuery, elastic.Exists(name)))
+
+		// if we are looking for unset, inverse our query
+		if c.Operator() == contactql.OpEqual {
+			query = elastic.Not(query)
+		}
+		return query
+	}
+
+	var query elastic.Query
+
+	switch c.Operator() {
+	case contactql.OpEqual:
+		query = elastic.Term(name, strings.ToLower(c.Value()))
+	case contactql.OpNotEqual:
+		return elastic.Not(elastic.Nested("results", elastic.All(flowQuery, keyQuery, elastic.Term(name, strings.ToLower(c.Value())))))
+	case contactql.OpGreaterThan, contactql.OpGreaterThanOrEqual, contactql.OpLessThan, contactql.OpLessThanOrEqual:
+		if value, err := c.ValueAsNumber(); err == nil {
+			query = rangeQuery("results.number", c.Operator(), value)
+		} else {
+			value, _ := c.ValueAsDate(env)
+			start, end := dates.DayToUTCRange(value, value.Location())
+
+			switch c.Operator() {
+			case contactql.OpGreaterThan:
+				query = elastic.GreaterThanOrEqual("results.datetime", end)
+			case contactql.OpGreaterThanOrEqual:
+				query = elastic.GreaterThanOrEqual("results.datetime", start)
+			case contactql.OpLessThan:
+				query = elastic.LessThan("results.datetime", start)
+			case contactql.OpLessThanOrEqual:
+				query = elastic.LessThan("results.datetime", end)
+			}
+		}
+	default:
+		panic(fmt.Sprintf("unsupported result operator: %s", c.Operator()))
+	}
+
+	return elastic.Nested("results", elastic.All(flowQuery, keyQuery, query))
+}
+
 func schemeCondition(c *contactql.Condition) elastic.Query {
 	key := c.PropertyKey()
 	value := strings.ToLower(c.Value())
@@ -391,6 +460,13 @@ func numericalAttributeQuery(c *contactql.Condition, name string) elastic.Query
 		return elastic.Match(name, value)
 	case contactql.OpNotEqual:
 		return elastic.Not(elastic.Match(name, value))
+	default:
+		return rangeQuery(name, c.Operator(), value)
+	}
+}
+
+func rangeQuery(name string, op contactql.Operator, value any) elastic.Query {
+	switch op {
 	case contactql.OpGreaterThan:
 		return elastic.GreaterThan(name, value)
 	case contactql.OpGreaterThanOrEqual:
@@ -400,6 +476,6 @@ func numericalAttributeQuery(c *contactql.Condition, name string) elastic.Query
 	case contactql.OpLessThanOrEqual:
 		return elastic.LessThanOrEqual(name, value)
 	default:
-		panic(fmt.Sprintf("unsupported %s attribute operator: %s", name, c.Operator()))
+		panic(fmt.Sprintf("unsupported %s operator: %s", name, op))
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/gocommon/jsonx"
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/assets/static"
@@ -47,7 +48,12 @@ func newMockResolver() contactql.Resolver {
 			static.NewField("fde8f740-c337-421b-8abb-83b954897c80", "ward", "Ward", assets.FieldTypeWard),
 		},
 		[]assets.Flow{
-			static.NewFlow("c261165a-f5b0-40ba-b916-76fb49667a4f", "Registration", []byte(`{}`)),
+			static.NewFlow("c261165a-f5b0-40ba-b916-76fb49667a4f", "Registration", []byte(`{
+				"nodes": [
+					{"actions": [{"type": "set_run_result", "name": "Age"}, {"type": "set_run_result", "name": "Joined"}]},
+					{"actions": [], "router": {"result_name": "Category", "categories": [{"name": "Yes"}, {"name": "No"}]}}
+				]
+			}`)),
 		},
 		[]assets.Group{
 			static.NewGroup("8de30b78-d9ef-4db2-b2e8-4f7b6aef64cf", "U-Reporters", ""),
@@ -73,6 +79,8 @@ func TestElasticQuery(t *testing.T) {
 		Query       string          `json:"query"`
 		Elastic     json.RawMessage `json:"elastic"`
 		RedactURNs  bool            `json:"redact_urns"`
+		WeekStart   envs.WeekStart  `json:"week_start"`
+		Now         *time.Time      `json:"now,omitempty"`
 	}
 	tcs := make([]testCase, 0, 20)
 	tcJSON, err := os.ReadFile("testdata/to_query.json")
@@ -81,14 +89,24 @@ func TestElasticQuery(t *testing.T) {
 
 	ny, _ := time.LoadLocation("America/New_York")
 
+	// relative dates are resolved against now, which test cases can override
+	defaultNow := time.Date(2020, 3, 18, 15, 30, 0, 0, time.UTC)
+	defer dates.SetNowSource(dates.DefaultNowSource)
+
 	for _, tc := range tcs {
 		testName := fmt.Sprintf("test '%s' for query '%s'", tc.Description, tc.Query)
 
+		now := defaultNow
+		if tc.Now != nil {
+			now = *tc.Now
+		}
+		dates.SetNowSource(dates.NewFixedNowSource(now))
+
 		redactionPolicy := envs.RedactionPolicyNone
 		if tc.RedactURNs {
 			redactionPolicy = envs.RedactionPolicyURNs
 		}
-		env := envs.NewBuilder().WithTimezone(ny).WithRedactionPolicy(redactionPolicy).Build()
+		env := envs.NewBuilder().WithTimezone(ny).WithRedactionPolicy(redactionPolicy).WithWeekStart(tc.WeekStart.Weekday()).Build()
 
 		parsed, err := contactql.ParseQuery(env, tc.Query, resolver)
 		require.NoError(t, err)
@@ -2,6 +2,7 @@ package contactql
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
@@ -31,6 +32,8 @@ func evaluateNode(env envs.Environment, resolver Resolver, node QueryNode, query
 	switch n := node.(type) {
 	case *BoolCombination:
 		return evaluateBoolCombination(env, resolver, n, queryable)
+	case *Not:
+		return !evaluateNode(env, resolver, n.Child(), queryable)
 	case *Condition:
 		return evaluateCondition(env, resolver, n, queryable)
 	default:
@@ -57,6 +60,11 @@ func evaluateBoolCombination(env envs.Environment, resolver Resolver, b *BoolCom
 }
 
 func evaluateCondition(env envs.Environment, resolver Resolver, c *Condition, queryable Queryable) bool {
+	// IN and BETWEEN conditions are evaluated as the single value conditions they're equivalent to
+	if c.operator == OpIn || c.operator == OpBetween {
+		return evaluateNode(env, resolver, c.Expand(), queryable)
+	}
+
 	// contacts can return multiple values per key, e.g. multiple phone numbers in a "tel = x" condition
 	vals := queryable.QueryProperty(env, c.PropertyKey(), c.PropertyType())
 
@@ -92,6 +100,14 @@ func evaluateCondition(env envs.Environment, resolver Resolver, c *Condition, qu
 func evaluateConditionWithValue(env envs.Environment, resolver Resolver, c *Condition, val any) bool {
 	valueType := c.resolveValueType(resolver)
 
+	// results are text values so need converting for number and date comparisons
+	if c.propType == PropertyTypeResult {
+		var isValid bool
+		if val, isValid = convertResultValue(env, val.(string), valueType); !isValid {
+			return false
+		}
+	}
+
 	switch valueType {
 	case assets.FieldTypeNumber:
 		asNumber, _ := c.ValueAsNumber()
@@ -105,6 +121,67 @@ func evaluateConditionWithValue(env envs.Environment, resolver Resolver, c *Cond
 	}
 }
 
+// ResultNumberRegex matches result values which can be compared as numbers. It's also valid as a PostgreSQL regular
+// expression so that queries on results are the same whether they're evaluated or converted to SQL.
+var ResultNumberRegex = regexp.MustCompile(`^\s*[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d{1,3})?\s*$`)
+
+// ResultDateRegex matches result values which can be compared as dates, i.e. ISO8601 dates with an optional time and
+// offset. A value can match and still not be a valid date, e.g. 2024-02-30. It's also valid as a PostgreSQL regular
+// expression so that queries on results are the same whether they're evaluated or converted to SQL.
+var ResultDateRegex = regexp.MustCompile(`^[1-9]\d{3}-(0[1-9]|1[0-2])-(0[1-9]|[12]\d|3[01])([T ]([01]\d|2[0-3]):[0-5]\d(:[0-5]\d(\.\d+)?)?(Z|[+-](0\d|1[0-5])(:?[0-5]\d)?)?)?$`)
+
+// converts a result value to the given value type, returning false if that isn't possible
+func convertResultValue(env envs.Environment, val string, valueType assets.FieldType) (any, bool) {
+	switch valueType {
+	case assets.FieldTypeNumber:
+		return parseResultNumber(val)
+	case assets.FieldTypeDatetime:
+		return parseResultDate(env, val)
+	}
+	return val, true
+}
+
+// parses a result value as a number if it matches ResultNumberRegex
+func parseResultNumber(val string) (decimal.Decimal, bool) {
+	if !ResultNumberRegex.MatchString(val) {
+		return decimal.Zero, false
+	}
+
+	asNumber, err := decimal.NewFromString(strings.TrimSpace(val))
+	return asNumber, err == nil
+}
+
+// parses a result value as a date if it matches ResultDateRegex, using the environment timezone if it has no offset
+func parseResultDate(env envs.Environment, val string) (time.Time, bool) {
+	match := ResultDateRegex.FindStringSubmatch(val)
+	if match == nil {
+		return time.Time{}, false
+	}
+
+	layout := "2006-01-02"
+	if match[3] != "" {
+		val = val[:10] + "T" + val[11:] // time can be separated by a space or a T
+		layout += "T15:04"
+
+		if match[5] != "" {
+			layout += ":05" // parsing allows fractional seconds without them being in the layout
+		}
+
+		switch {
+		case match[7] == "":
+		case match[7] == "Z" || strings.Contains(match[7], ":"):
+			layout += "Z07:00"
+		case match[9] != "":
+			layout += "Z0700"
+		default:
+			layout += "Z07"
+		}
+	}
+
+	asDate, err := time.ParseInLocation(layout, val, env.Timezone())
+	return asDate, err == nil
+}
+
 func textComparison(objectVal string, op Operator, queryVal string, isName bool) bool {
 	objectVal = strings.TrimSpace(strings.ToLower(objectVal))
 	queryVal = strings.TrimSpace(strings.ToLower(queryVal))
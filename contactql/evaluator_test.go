@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/assets/static"
 	"github.com/nyaruka/goflow/contactql"
@@ -36,6 +37,15 @@ func TestEvaluateQuery(t *testing.T) {
 		"ward":     []any{"Ndera"},
 		"empty":    []any{""},
 		"nope":     []any{envs.NewBuilder().Build()},
+
+		"registration.age":               []any{"36", "thirty six"},
+		"registration.joined":            []any{"2020-03-18T15:30:00.000000Z"},
+		"registration.score":             []any{"+5"},
+		"registration.ratio":             []any{".5"},
+		"registration.started":           []any{"15/01/2020"},
+		"registration.ended":             []any{"2020-03-18 10:30+02"},
+		"registration.category":          []any{"Yes"},
+		"registration.category.category": []any{"Yes"},
 	}
 
 	tests := []struct {
@@ -141,6 +151,56 @@ func TestEvaluateQuery(t *testing.T) {
 		{query: `age = 35 OR gender = female`, result: false},
 		{query: `(age = 36 OR gender = female) AND age > 35`, result: true},
 		{query: `age = 36 OR gender = male AND age = 34`, result: true}, // AND has precedence
+
+		// negations
+		{query: `NOT age = 36`, result: false},
+		{query: `not age = 35`, result: true},
+		{query: `-gender = female`, result: true},
+		{query: `NOT (age = 36 OR gender = female)`, result: false},
+		{query: `-(age = 35 OR gender = female)`, result: true},
+		{query: `NOT NOT age = 36`, result: true},
+		{query: `age = 36 AND NOT xyz IS SET`, result: true},
+
+		// lists
+		{query: `district IN (Nyarugenge, "Gasabo")`, result: true},
+		{query: `district IN (Nyarugenge, Kicukiro)`, result: false},
+		{query: `age IN (35, 36, 37)`, result: true},
+		{query: `NOT district IN (Nyarugenge, Kicukiro)`, result: true},
+		{query: `age BETWEEN 30 AND 40`, result: true},
+		{query: `age BETWEEN 36 AND 36`, result: true},
+		{query: `age between 37 and 40`, result: false},
+		{query: `dob BETWEEN 1981/05/28 AND 1981/06/01`, result: true},
+		{query: `dob BETWEEN 1981/05/20 AND 1981/05/27`, result: false},
+
+		// set checks
+		{query: `age IS SET`, result: true},
+		{query: `age IS NOT SET`, result: false},
+		{query: `xyz is set`, result: false},
+		{query: `xyz is not set`, result: true},
+
+		// flow results, where values which aren't numbers or dates are ignored by comparisons
+		{query: `results.registration.age = 36`, result: true},
+		{query: `results.registration.age = "Thirty Six"`, result: true},
+		{query: `results.registration.age != 36`, result: false},
+		{query: `results.registration.age > 35`, result: true},
+		{query: `results.registration.age > 36`, result: false},
+		{query: `results.registration.age BETWEEN 30 AND 40`, result: true},
+		{query: `results.registration.joined = 2020-03-18`, result: false}, // equality is text based
+		{query: `results.registration.joined > 2020-03-17`, result: true},
+		{query: `results.registration.joined < 2020-03-18`, result: false},
+		{query: `results.registration.score > 4`, result: true},
+		{query: `results.registration.score < 5`, result: false},
+		{query: `results.registration.ratio < 1`, result: true},
+		{query: `results.registration.ratio > .5`, result: false},
+		{query: `results.registration.started > 2020-01-01`, result: false}, // only ISO8601 dates are compared
+		{query: `results.registration.started < 2020-03-01`, result: false},
+		{query: `results.registration.ended > 2020-03-17`, result: true},
+		{query: `results.registration.ended < 2020-03-18`, result: false},
+		{query: `results.registration.category = yes`, result: true},
+		{query: `results.registration.category.category = no`, result: false},
+		{query: `results.registration.category.category != no`, result: true},
+		{query: `results.registration.category IS SET`, result: true},
+		{query: `results.catch_all.category IS SET`, result: false},
 	}
 
 	resolver := contactql.NewMockResolver(
@@ -155,8 +215,17 @@ func TestEvaluateQuery(t *testing.T) {
 			static.NewField("81e25783-a1d8-42b9-85e4-68c7ab2df39d", "xyz", "XYZ", assets.FieldTypeText),
 		},
 		[]assets.Flow{
-			static.NewFlow("ea351bf8-3c49-46dd-935c-5b20e2a00b7a", "Registration", []byte(`{}`)),
-			static.NewFlow("1b73528f-6e4e-4c64-b393-78088449fb49", "Catch All", []byte(`{}`)),
+			static.NewFlow("ea351bf8-3c49-46dd-935c-5b20e2a00b7a", "Registration", []byte(`{
+				"nodes": [
+					{"actions": [{"type": "set_run_result", "name": "Age"}, {"type": "set_run_result", "name": "Joined"}, {"type": "set_run_result", "name": "Score"}, {"type": "set_run_result", "name": "Ratio"}, {"type": "set_run_result", "name": "Started"}, {"type": "set_run_result", "name": "Ended"}]},
+					{"actions": [], "router": {"result_name": "Category", "categories": [{"name": "Yes"}, {"name": "No"}]}}
+				]
+			}`)),
+			static.NewFlow("1b73528f-6e4e-4c64-b393-78088449fb49", "Catch All", []byte(`{
+				"nodes": [
+					{"actions": [], "router": {"result_name": "Category", "categories": [{"name": "All Responses"}]}}
+				]
+			}`)),
 		},
 		[]assets.Group{},
 	)
@@ -169,3 +238,135 @@ func TestEvaluateQuery(t *testing.T) {
 		assert.Equal(t, test.result, actualResult, "unexpected result for '%s'", test.query)
 	}
 }
+
+func TestResultRegexes(t *testing.T) {
+	numbers := []struct {
+		value   string
+		isMatch bool
+	}{
+		{"5", true},
+		{" -5.25 ", true},
+		{"+5", true},
+		{".5", true},
+		{"5.", true},
+		{"1e3", true},
+		{"-2.5E-10", true},
+		{"1e1000", false}, // would overflow a numeric
+		{"e3", false},
+		{"1,000", false},
+		{"five", false},
+		{"", false},
+	}
+
+	for _, tc := range numbers {
+		assert.Equal(t, tc.isMatch, contactql.ResultNumberRegex.MatchString(tc.value), "number match mismatch for '%s'", tc.value)
+	}
+
+	dates := []struct {
+		value   string
+		isMatch bool
+	}{
+		{"2024-01-15", true},
+		{"2024-01-15T10:30", true},
+		{"2024-01-15T10:30:45Z", true},
+		{"2024-01-15T10:30:45.123456-05:00", true},
+		{"2024-01-15 10:30:45+0530", true},
+		{"2024-02-30", true}, // matches but isn't a valid date
+		{"2024-13-45", false},
+		{"2024-00-10", false},
+		{"2024-01-32", false},
+		{"0000-01-15", false},
+		{"2024-01-15T24:00", false},
+		{"2024-01-15T10:60", false},
+		{"2024-01-15T10:30:45+16:00", false},
+		{"2024-01-15 and more", false},
+		{"15-01-2024", false},
+		{"15/01/2024", false},
+		{"", false},
+	}
+
+	for _, tc := range dates {
+		assert.Equal(t, tc.isMatch, contactql.ResultDateRegex.MatchString(tc.value), "date match mismatch for '%s'", tc.value)
+	}
+}
+
+func TestEvaluateRelativeDates(t *testing.T) {
+	defaultNow := time.Date(1981, 6, 4, 10, 0, 0, 0, time.UTC)
+	defer dates.SetNowSource(dates.DefaultNowSource)
+
+	env := envs.NewBuilder().Build()
+	sundayEnv := envs.NewBuilder().WithWeekStart(time.Sunday).Build()
+	testObj := TestQueryable{
+		"dob":          []any{time.Date(1981, 5, 28, 13, 30, 23, 0, time.UTC)},
+		"last_seen_on": []any{time.Date(1981, 6, 3, 18, 0, 0, 0, time.UTC)},
+		"created_on":   []any{time.Date(1981, 5, 31, 12, 0, 0, 0, time.UTC)}, // a sunday
+		"leap_day":     []any{time.Date(1984, 2, 29, 12, 0, 0, 0, time.UTC)},
+		"anniversary":  []any{time.Date(1985, 2, 28, 12, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		query       string
+		sundayStart bool
+		now         time.Time
+		result      bool
+	}{
+		{query: `dob = "-7d"`, result: true},
+		{query: `dob = "-1w"`, result: true},
+		{query: `dob = "7 days ago"`, result: true},
+		{query: `dob = "1 week ago"`, result: true},
+		{query: `dob > "-7d"`, result: false},
+		{query: `dob >= -7d`, result: true},
+		{query: `dob < "-6d"`, result: true},
+		{query: `dob > "1 month ago"`, result: true},
+		{query: `dob < "1 year ago"`, result: false},
+		{query: `dob < START_OF_MONTH`, result: true},
+		{query: `dob > start_of_year`, result: true},
+		{query: `dob < start_of_week`, result: true}, // 1981-06-01
+		{query: `created_on < start_of_week`, result: true},
+		{query: `created_on = start_of_week`, sundayStart: true, result: true}, // 1981-05-31
+		{query: `created_on < start_of_week`, sundayStart: true, result: false},
+		{query: `last_seen_on = yesterday`, result: true},
+		{query: `last_seen_on = today`, result: false},
+		{query: `last_seen_on < today`, result: true},
+		{query: `last_seen_on > "-2d" AND last_seen_on < tomorrow`, result: true},
+		{query: `last_seen_on BETWEEN "-7d" AND yesterday`, result: true},
+
+		// month and year offsets are clamped to the end of shorter months
+		{query: `leap_day = "-1m"`, now: time.Date(1984, 3, 31, 10, 0, 0, 0, time.UTC), result: true},
+		{query: `leap_day = "1 month ago"`, now: time.Date(1984, 3, 30, 10, 0, 0, 0, time.UTC), result: true},
+		{query: `leap_day < "-1m"`, now: time.Date(1984, 3, 31, 10, 0, 0, 0, time.UTC), result: false},
+		{query: `leap_day = "-13m"`, now: time.Date(1985, 3, 31, 10, 0, 0, 0, time.UTC), result: true},
+		{query: `anniversary = "+1y"`, now: time.Date(1984, 2, 29, 10, 0, 0, 0, time.UTC), result: true},
+		{query: `anniversary < "+1y"`, now: time.Date(1984, 2, 29, 10, 0, 0, 0, time.UTC), result: false},
+		{query: `dob = "+1m"`, now: time.Date(1981, 4, 28, 10, 0, 0, 0, time.UTC), result: true},
+	}
+
+	resolver := contactql.NewMockResolver(
+		[]assets.Field{
+			static.NewField("3810a485-3fda-4011-a589-7320c0b8dbef", "dob", "DOB", assets.FieldTypeDatetime),
+			static.NewField("1e3bf0d2-6c8b-4d54-8f0a-0f5a5b9a0c1e", "leap_day", "Leap Day", assets.FieldTypeDatetime),
+			static.NewField("5d2b1c8e-8f43-4a9b-9a5e-2f6c7d8e9a0b", "anniversary", "Anniversary", assets.FieldTypeDatetime),
+		},
+		[]assets.Flow{},
+		[]assets.Group{},
+	)
+
+	for _, test := range tests {
+		now := defaultNow
+		if !test.now.IsZero() {
+			now = test.now
+		}
+		dates.SetNowSource(dates.NewFixedNowSource(now))
+
+		testEnv := env
+		if test.sundayStart {
+			testEnv = sundayEnv
+		}
+
+		parsed, err := contactql.ParseQuery(testEnv, test.query, resolver)
+		assert.NoError(t, err, "unexpected error parsing '%s'", test.query)
+
+		actualResult := contactql.EvaluateQuery(testEnv, parsed, testObj)
+		assert.Equal(t, test.result, actualResult, "unexpected result for '%s'", test.query)
+	}
+}
@@ -0,0 +1,363 @@
+package contactql
+
+import (
+	"math/bits"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nyaruka/gocommon/dates"
+	"github.com/nyaruka/gocommon/uuids"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/shopspring/decimal"
+)
+
+// Index is an in-memory index of contacts which can evaluate a query against all of them at once. Indexes for each
+// property are built the first time a query uses them, and queries are evaluated as set operations on those indexes.
+// Results are the same as evaluating the query against each contact with EvaluateQuery.
+type Index struct {
+	env       envs.Environment
+	uuids     []uuids.UUID
+	positions map[uuids.UUID]int
+	contacts  []Queryable
+
+	mutex      sync.Mutex
+	properties map[indexKey]*propertyIndex
+}
+
+type indexKey struct {
+	propType PropertyType
+	propKey  string
+}
+
+// NewIndex creates a new empty index
+func NewIndex(env envs.Environment) *Index {
+	return &Index{env: env, positions: make(map[uuids.UUID]int), properties: make(map[indexKey]*propertyIndex)}
+}
+
+// Add adds the given contact to this index, replacing any existing contact with the same UUID
+func (x *Index) Add(uuid uuids.UUID, contact Queryable) {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	if pos, exists := x.positions[uuid]; exists {
+		x.contacts[pos] = contact
+	} else {
+		x.positions[uuid] = len(x.uuids)
+		x.uuids = append(x.uuids, uuid)
+		x.contacts = append(x.contacts, contact)
+	}
+
+	// existing property indexes are now stale
+	clear(x.properties)
+}
+
+// Len returns the number of contacts in this index
+func (x *Index) Len() int {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	return len(x.uuids)
+}
+
+// Search returns the UUIDs of the contacts which match the given query, in the order they were added. That query
+// must have been parsed with a resolver to ensure all fields and groups resolve. If not function panics.
+func (x *Index) Search(query *ContactQuery) []uuids.UUID {
+	matches := x.evaluate(query)
+
+	results := make([]uuids.UUID, 0, matches.count())
+	matches.each(func(pos int) { results = append(results, x.uuids[pos]) })
+	return results
+}
+
+// Count returns the number of contacts which match the given query
+func (x *Index) Count(query *ContactQuery) int {
+	return x.evaluate(query).count()
+}
+
+func (x *Index) evaluate(query *ContactQuery) bitset {
+	if query.Resolver() == nil {
+		panic("can only evaluate queries parsed with a resolver")
+	}
+
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	return x.evaluateNode(query.Resolver(), query.Root())
+}
+
+func (x *Index) evaluateNode(resolver Resolver, node QueryNode) bitset {
+	switch n := node.(type) {
+	case *BoolCombination:
+		result := x.evaluateNode(resolver, n.children[0])
+		for _, child := range n.children[1:] {
+			if n.op == BoolOperatorAnd {
+				result = result.and(x.evaluateNode(resolver, child))
+			} else {
+				result = result.or(x.evaluateNode(resolver, child))
+			}
+		}
+		return result
+	case *Not:
+		return x.evaluateNode(resolver, n.child).not(len(x.uuids))
+	case *Condition:
+		return x.evaluateCondition(resolver, n)
+	default:
+		// a query which simplified to nothing matches everything
+		return newBitset(len(x.uuids)).not(len(x.uuids))
+	}
+}
+
+func (x *Index) evaluateCondition(resolver Resolver, c *Condition) bitset {
+	if c.operator == OpIn || c.operator == OpBetween {
+		return x.evaluateNode(resolver, c.Expand())
+	}
+
+	prop := x.property(c.propType, c.propKey)
+
+	// is this an existence check?
+	if c.value == "" {
+		if c.operator == OpEqual {
+			return prop.hasValue.not(len(x.uuids))
+		} else if c.operator == OpNotEqual {
+			return prop.hasValue
+		}
+	}
+
+	// results are text values so number and date comparisons have to check the values of each contact
+	valueType := c.resolveValueType(resolver)
+	if c.propType == PropertyTypeResult && valueType != assets.FieldTypeText {
+		return x.scan(resolver, c, prop)
+	}
+
+	switch valueType {
+	case assets.FieldTypeNumber:
+		value, _ := c.ValueAsNumber()
+		return prop.numberComparison(c.operator, value, len(x.uuids))
+	case assets.FieldTypeDatetime:
+		value, _ := c.ValueAsDate(x.env)
+		return prop.dateComparison(c.operator, value, len(x.uuids))
+	}
+
+	if c.operator == OpEqual || c.operator == OpNotEqual {
+		matches := prop.texts[strings.TrimSpace(strings.ToLower(c.value))]
+		if matches == nil {
+			matches = newBitset(len(x.uuids))
+		}
+
+		// foo != x is only true if all values of foo are not x
+		if c.operator == OpNotEqual {
+			return matches.not(len(x.uuids))
+		}
+		return matches
+	}
+
+	// other text comparisons can't be looked up so check the values of each contact
+	return x.scan(resolver, c, prop)
+}
+
+// evaluates the given condition against the values of each contact
+func (x *Index) scan(resolver Resolver, c *Condition, prop *propertyIndex) bitset {
+	matches := newBitset(len(x.uuids))
+	for pos, vals := range prop.values {
+		if evaluateCondition(x.env, resolver, c, staticQueryable(vals)) {
+			matches.set(pos)
+		}
+	}
+	return matches
+}
+
+// gets the index for the given property, building it if necessary
+func (x *Index) property(propType PropertyType, propKey string) *propertyIndex {
+	key := indexKey{propType, propKey}
+	prop := x.properties[key]
+	if prop == nil {
+		prop = newPropertyIndex(x.env, x.contacts, propType, propKey)
+		x.properties[key] = prop
+	}
+	return prop
+}
+
+// a queryable which returns the same values for any property
+type staticQueryable []any
+
+func (q staticQueryable) QueryProperty(envs.Environment, string, PropertyType) []any { return q }
+
+type numberEntry struct {
+	value decimal.Decimal
+	pos   int
+}
+
+type dateEntry struct {
+	value time.Time
+	pos   int
+}
+
+// the index of a single property across all contacts
+type propertyIndex struct {
+	values   [][]any
+	hasValue bitset
+	texts    map[string]bitset
+	numbers  []numberEntry // sorted by value
+	dates    []dateEntry   // sorted by value
+}
+
+func newPropertyIndex(env envs.Environment, contacts []Queryable, propType PropertyType, propKey string) *propertyIndex {
+	p := &propertyIndex{
+		values:   make([][]any, len(contacts)),
+		hasValue: newBitset(len(contacts)),
+		texts:    make(map[string]bitset),
+	}
+
+	for pos, contact := range contacts {
+		vals := contact.QueryProperty(env, propKey, propType)
+		p.values[pos] = vals
+
+		if len(vals) > 0 {
+			p.hasValue.set(pos)
+		}
+
+		for _, val := range vals {
+			switch typed := val.(type) {
+			case string:
+				text := strings.TrimSpace(strings.ToLower(typed))
+				if p.texts[text] == nil {
+					p.texts[text] = newBitset(len(contacts))
+				}
+				p.texts[text].set(pos)
+			case decimal.Decimal:
+				p.numbers = append(p.numbers, numberEntry{typed, pos})
+			case time.Time:
+				p.dates = append(p.dates, dateEntry{typed, pos})
+			}
+		}
+	}
+
+	sort.Slice(p.numbers, func(i, j int) bool { return p.numbers[i].value.LessThan(p.numbers[j].value) })
+	sort.Slice(p.dates, func(i, j int) bool { return p.dates[i].value.Before(p.dates[j].value) })
+
+	return p
+}
+
+func (p *propertyIndex) numberComparison(op Operator, value decimal.Decimal, size int) bitset {
+	// find the range of entries which are equal to the value
+	lower := sort.Search(len(p.numbers), func(i int) bool { return p.numbers[i].value.GreaterThanOrEqual(value) })
+	upper := sort.Search(len(p.numbers), func(i int) bool { return p.numbers[i].value.GreaterThan(value) })
+
+	switch op {
+	case OpEqual:
+		return p.numberRange(lower, upper, size)
+	case OpNotEqual:
+		return p.numberRange(lower, upper, size).not(size)
+	case OpGreaterThan:
+		return p.numberRange(upper, len(p.numbers), size)
+	case OpGreaterThanOrEqual:
+		return p.numberRange(lower, len(p.numbers), size)
+	case OpLessThan:
+		return p.numberRange(0, lower, size)
+	case OpLessThanOrEqual:
+		return p.numberRange(0, upper, size)
+	default:
+		return newBitset(size)
+	}
+}
+
+func (p *propertyIndex) numberRange(from, to, size int) bitset {
+	matches := newBitset(size)
+	for _, e := range p.numbers[from:to] {
+		matches.set(e.pos)
+	}
+	return matches
+}
+
+func (p *propertyIndex) dateComparison(op Operator, value time.Time, size int) bitset {
+	utcDayStart, utcDayEnd := dates.DayToUTCRange(value, value.Location())
+
+	// find the entries on or after the start and end of the day
+	start := sort.Search(len(p.dates), func(i int) bool { return !p.dates[i].value.Before(utcDayStart) })
+	end := sort.Search(len(p.dates), func(i int) bool { return !p.dates[i].value.Before(utcDayEnd) })
+
+	switch op {
+	case OpEqual:
+		return p.dateRange(start, end, size)
+	case OpNotEqual:
+		return p.dateRange(start, end, size).not(size)
+	case OpGreaterThan:
+		return p.dateRange(end, len(p.dates), size)
+	case OpGreaterThanOrEqual:
+		return p.dateRange(start, len(p.dates), size)
+	case OpLessThan:
+		return p.dateRange(0, start, size)
+	case OpLessThanOrEqual:
+		return p.dateRange(0, end, size)
+	default:
+		return newBitset(size)
+	}
+}
+
+func (p *propertyIndex) dateRange(from, to, size int) bitset {
+	matches := newBitset(size)
+	for _, e := range p.dates[from:to] {
+		matches.set(e.pos)
+	}
+	return matches
+}
+
+// a set of contact positions in an index
+type bitset []uint64
+
+func newBitset(size int) bitset {
+	return make(bitset, (size+63)/64)
+}
+
+func (b bitset) set(pos int) {
+	b[pos/64] |= 1 << (pos % 64)
+}
+
+func (b bitset) and(other bitset) bitset {
+	result := make(bitset, len(b))
+	for i := range b {
+		result[i] = b[i] & other[i]
+	}
+	return result
+}
+
+func (b bitset) or(other bitset) bitset {
+	result := make(bitset, len(b))
+	for i := range b {
+		result[i] = b[i] | other[i]
+	}
+	return result
+}
+
+// returns the complement of this set, limited to the given size
+func (b bitset) not(size int) bitset {
+	result := make(bitset, len(b))
+	for i := range b {
+		result[i] = ^b[i]
+	}
+	if rem := size % 64; rem != 0 {
+		result[len(result)-1] &= (1 << rem) - 1
+	}
+	return result
+}
+
+func (b bitset) count() int {
+	n := 0
+	for _, w := range b {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+func (b bitset) each(fn func(int)) {
+	for i, w := range b {
+		for w != 0 {
+			bit := bits.TrailingZeros64(w)
+			fn(i*64 + bit)
+			w &^= 1 << bit
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package contactql_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/nyaruka/gocommon/uuids"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/assets/static"
+	"github.com/nyaruka/goflow/contactql"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var indexResolver = contactql.NewMockResolver(
+	[]assets.Field{
+		static.NewField("f1b5aea6-6586-41c7-9020-1a6326cc6565", "age", "Age", assets.FieldTypeNumber),
+		static.NewField("3810a485-3fda-4011-a589-7320c0b8dbef", "dob", "DOB", assets.FieldTypeDatetime),
+		static.NewField("d66a7823-eada-40e5-9a3a-57239d4690bf", "gender", "Gender", assets.FieldTypeText),
+		static.NewField("e52f34ad-a5a7-4855-9040-05a910a75f57", "district", "District", assets.FieldTypeDistrict),
+	},
+	[]assets.Flow{
+		static.NewFlow("ea351bf8-3c49-46dd-935c-5b20e2a00b7a", "Registration", registrationDefinition),
+	},
+	[]assets.Group{
+		static.NewGroup("a9b5b0a0-1098-4bc2-8384-eea09ae43e6b", "U-Reporters", ""),
+	},
+)
+
+// creates contacts with a mix of set, unset and multiple values
+func newIndexTestContacts(num int) ([]uuids.UUID, []TestQueryable) {
+	rnd := rand.New(rand.NewSource(123))
+	names := []string{"Bob Smith", "Ann Smithwick", "Jim McJim", "bob", " ANN "}
+	genders := []string{"male", "female", "Male", "other"}
+	districts := []string{"Gasabo", "Kicukiro", "Nyarugenge"}
+
+	uuidList := make([]uuids.UUID, num)
+	contacts := make([]TestQueryable, num)
+
+	for i := range contacts {
+		c := TestQueryable{
+			"name":    []any{names[rnd.Intn(len(names))]},
+			"tel":     []any{},
+			"group":   []any{},
+			"tickets": []any{decimal.NewFromInt(int64(rnd.Intn(3)))},
+		}
+		for j := rnd.Intn(3); j > 0; j-- {
+			c["tel"] = append(c["tel"], fmt.Sprintf("+25078%07d", rnd.Intn(20)))
+		}
+		if rnd.Intn(2) == 0 {
+			c["group"] = []any{"U-Reporters"}
+		}
+		if rnd.Intn(4) > 0 {
+			c["age"] = []any{decimal.NewFromInt(int64(rnd.Intn(60)))}
+		}
+		if rnd.Intn(4) > 0 {
+			c["dob"] = []any{time.Date(2000, 1, 1+rnd.Intn(60), rnd.Intn(24), 0, 0, 0, time.UTC)}
+		}
+		if rnd.Intn(4) > 0 {
+			c["gender"] = []any{genders[rnd.Intn(len(genders))]}
+		}
+		if rnd.Intn(4) > 0 {
+			c["district"] = []any{districts[rnd.Intn(len(districts))]}
+		}
+		for j := rnd.Intn(3); j > 0; j-- {
+			c["registration.age"] = append(c["registration.age"], fmt.Sprint(rnd.Intn(60)))
+		}
+		if rnd.Intn(4) == 0 {
+			c["registration.age"] = append(c["registration.age"], "unknown")
+		}
+
+		uuidList[i] = uuids.UUID(fmt.Sprintf("5a3d4a6c-7f9c-4d1e-9e1b-%012d", i))
+		contacts[i] = c
+	}
+	return uuidList, contacts
+}
+
+func TestIndex(t *testing.T) {
+	env := envs.NewBuilder().WithDateFormat(envs.DateFormatYearMonthDay).Build()
+	uuidList, contacts := newIndexTestContacts(500)
+
+	index := contactql.NewIndex(env)
+	for i := range contacts {
+		index.Add(uuidList[i], contacts[i])
+	}
+	assert.Equal(t, 500, index.Len())
+
+	queries := []string{
+		`name = "bob smith"`,
+		`name = ann`,
+		`name ~ smi`,
+		`name != bob`,
+		`name != ""`,
+		`tel = +250780000005`,
+		`tel != +250780000005`,
+		`tel ~ 0000001`,
+		`tel = ""`,
+		`group = U-Reporters`,
+		`group != U-Reporters`,
+		`age = 30`,
+		`age != 30`,
+		`age > 30`,
+		`age >= 30`,
+		`age < 30`,
+		`age <= 30`,
+		`age = ""`,
+		`age != ""`,
+		`age > 1000`,
+		`tickets > 0`,
+		`dob = 2000-01-15`,
+		`dob != 2000-01-15`,
+		`dob > 2000-01-15`,
+		`dob >= 2000-01-15`,
+		`dob < 2000-01-15`,
+		`dob <= 2000-01-15`,
+		`dob = ""`,
+		`gender = male`,
+		`gender != male`,
+		`gender = ""`,
+		`district = GASABO`,
+		`district != gasabo`,
+		`age > 20 AND gender = male`,
+		`age > 20 OR gender = male OR district = Kicukiro`,
+		`(age > 20 OR gender = female) AND NOT district = Kicukiro`,
+		`NOT (age < 20 AND dob > 2000-02-01)`,
+		`-group = U-Reporters AND tel != ""`,
+		`district IN (Gasabo, Nyarugenge) AND age BETWEEN 18 AND 40`,
+		`gender IS NOT SET OR dob BETWEEN 2000-01-10 AND 2000-01-20`,
+		`results.registration.age = 30`,
+		`results.registration.age != unknown`,
+		`results.registration.age > 30`,
+		`results.registration.age IS NOT SET`,
+	}
+
+	for _, q := range queries {
+		query, err := contactql.ParseQuery(env, q, indexResolver)
+		require.NoError(t, err, "unexpected error parsing '%s'", q)
+
+		expected := make([]uuids.UUID, 0)
+		for i, contact := range contacts {
+			if contactql.EvaluateQuery(env, query, contact) {
+				expected = append(expected, uuidList[i])
+			}
+		}
+
+		assert.Equal(t, expected, index.Search(query), "search mismatch for '%s'", q)
+		assert.Equal(t, len(expected), index.Count(query), "count mismatch for '%s'", q)
+	}
+
+	// replacing a contact updates indexes
+	query, _ := contactql.ParseQuery(env, `gender = nonbinary`, indexResolver)
+	assert.Equal(t, 0, index.Count(query))
+
+	index.Add(uuidList[3], TestQueryable{"gender": []any{"Nonbinary"}})
+	assert.Equal(t, []uuids.UUID{uuidList[3]}, index.Search(query))
+	assert.Equal(t, 500, index.Len())
+}
+
+func BenchmarkIndex(b *testing.B) {
+	env := envs.NewBuilder().WithDateFormat(envs.DateFormatYearMonthDay).Build()
+	uuidList, contacts := newIndexTestContacts(100000)
+	query, _ := contactql.ParseQuery(env, `(age > 20 OR gender = female) AND NOT district = Kicukiro AND dob < 2000-02-01`, indexResolver)
+
+	index := contactql.NewIndex(env)
+	for i := range contacts {
+		index.Add(uuidList[i], contacts[i])
+	}
+	index.Count(query) // build indexes
+
+	b.Run("index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			index.Count(query)
+		}
+	})
+	b.Run("evaluator", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, contact := range contacts {
+				contactql.EvaluateQuery(env, query, contact)
+			}
+		}
+	})
+}
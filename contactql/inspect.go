@@ -7,11 +7,12 @@ import (
 
 // Inspection holds the result of inspecting a query
 type Inspection struct {
-	Attributes   []string                 `json:"attributes"`
-	Schemes      []string                 `json:"schemes"`
-	Fields       []*assets.FieldReference `json:"fields"`
-	Groups       []*assets.GroupReference `json:"groups"`
-	AllowAsGroup bool                     `json:"allow_as_group"`
+	Attributes    []string                 `json:"attributes"`
+	Schemes       []string                 `json:"schemes"`
+	Fields        []*assets.FieldReference `json:"fields"`
+	Groups        []*assets.GroupReference `json:"groups"`
+	AllowAsGroup  bool                     `json:"allow_as_group"`
+	TimeDependent bool                     `json:"time_dependent"`
 }
 
 // Inspect extracts information about a query
@@ -20,6 +21,8 @@ func Inspect(query *ContactQuery) *Inspection {
 	schemes := make(map[string]bool)
 	refs := make([]assets.Reference, 0)
 	refsSeen := make(map[string]bool)
+	timeDependent := false
+	usesResults := false
 
 	addRef := func(ref assets.Reference) {
 		if !refsSeen[ref.String()] {
@@ -29,11 +32,20 @@ func Inspect(query *ContactQuery) *Inspection {
 	}
 
 	walk(query.Root(), func(c *Condition) {
+		// queries with relative dates match different contacts as time passes.. fields can't be resolved without a
+		// resolver so assume any relative date value is a date
+		if IsRelativeDate(c.value) {
+			if c.propType == PropertyTypeField && query.resolver == nil || c.resolveValueType(query.resolver) == assets.FieldTypeDatetime {
+				timeDependent = true
+			}
+		}
+
 		switch c.propType {
 		case PropertyTypeAttribute:
 			attributes[c.propKey] = true
 
-			if c.propKey == AttributeGroup {
+			// group set checks don't reference a particular group
+			if c.propKey == AttributeGroup && c.value != "" {
 				if query.resolver != nil {
 					group := query.resolver.ResolveGroup(c.value)
 					addRef(assets.NewGroupReference(group.UUID(), group.Name()))
@@ -43,6 +55,8 @@ func Inspect(query *ContactQuery) *Inspection {
 			}
 		case PropertyTypeURN:
 			schemes[c.propKey] = true
+		case PropertyTypeResult:
+			usesResults = true
 		case PropertyTypeField:
 			if query.resolver != nil {
 				field := query.resolver.ResolveField(c.propKey)
@@ -65,15 +79,16 @@ func Inspect(query *ContactQuery) *Inspection {
 		}
 	}
 
-	// can't turn a query into a group if it uses id, status, group, flow or history
-	allowAsGroup := !(attributes[AttributeID] || attributes[AttributeStatus] || attributes[AttributeGroup] || attributes[AttributeFlow] || attributes[AttributeHistory])
+	// can't turn a query into a group if it uses id, status, group, flow, history or results
+	allowAsGroup := !(attributes[AttributeID] || attributes[AttributeStatus] || attributes[AttributeGroup] || attributes[AttributeFlow] || attributes[AttributeHistory] || attributes[AttributeHistoryCompleted] || attributes[AttributeHistoryExited] || usesResults)
 
 	return &Inspection{
-		Attributes:   utils.SortedKeys(attributes),
-		Schemes:      utils.SortedKeys(schemes),
-		Fields:       fieldRefs,
-		Groups:       groupRefs,
-		AllowAsGroup: allowAsGroup,
+		Attributes:    utils.SortedKeys(attributes),
+		Schemes:       utils.SortedKeys(schemes),
+		Fields:        fieldRefs,
+		Groups:        groupRefs,
+		AllowAsGroup:  allowAsGroup,
+		TimeDependent: timeDependent,
 	}
 }
 
@@ -84,7 +99,14 @@ func walk(node QueryNode, conditionCallback func(*Condition)) {
 			walk(n, conditionCallback)
 		}
 
+	case *Not:
+		walk(n.Child(), conditionCallback)
+
 	case *Condition:
-		conditionCallback(n)
+		if n.operator == OpIn || n.operator == OpBetween {
+			walk(n.Expand(), conditionCallback)
+		} else {
+			conditionCallback(n)
+		}
 	}
 }
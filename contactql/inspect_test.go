@@ -19,7 +19,9 @@ func TestInspect(t *testing.T) {
 			static.NewField(assets.FieldUUID("3810a485-3fda-4011-a589-7320c0b8dbef"), "dob", "DOB", assets.FieldTypeDatetime),
 			static.NewField(assets.FieldUUID("d66a7823-eada-40e5-9a3a-57239d4690bf"), "gender", "Gender", assets.FieldTypeText),
 		},
-		[]assets.Flow{},
+		[]assets.Flow{
+			static.NewFlow("f87fd7cd-e501-4394-9cff-62309af85138", "Registration", registrationDefinition),
+		},
 		[]assets.Group{
 			static.NewGroup(assets.GroupUUID("4eeca453-f474-4767-bdd0-434b180223db"), "U-Reporters", ""),
 		},
@@ -66,6 +68,30 @@ func TestInspect(t *testing.T) {
 				AllowAsGroup: true,
 			},
 		},
+		{
+			query:    "results.registration.age > 18 AND gender = male",
+			resolver: resolver,
+			inspection: &contactql.Inspection{
+				Attributes: []string{},
+				Schemes:    []string{},
+				Fields: []*assets.FieldReference{
+					assets.NewFieldReference("gender", "Gender"),
+				},
+				Groups:       []*assets.GroupReference{},
+				AllowAsGroup: false,
+			},
+		},
+		{
+			query:    "history.completed = registration",
+			resolver: resolver,
+			inspection: &contactql.Inspection{
+				Attributes:   []string{"history.completed"},
+				Schemes:      []string{},
+				Fields:       []*assets.FieldReference{},
+				Groups:       []*assets.GroupReference{},
+				AllowAsGroup: false,
+			},
+		},
 		{
 			query:    "id = 123",
 			resolver: resolver,
@@ -102,6 +128,64 @@ func TestInspect(t *testing.T) {
 				AllowAsGroup: false,
 			},
 		},
+		{
+			query:    `group != "" AND group != U-reporters`, // group set check doesn't reference a group
+			resolver: resolver,
+			inspection: &contactql.Inspection{
+				Attributes: []string{"group"},
+				Schemes:    []string{},
+				Fields:     []*assets.FieldReference{},
+				Groups: []*assets.GroupReference{
+					assets.NewGroupReference("4eeca453-f474-4767-bdd0-434b180223db", "U-Reporters"),
+				},
+				AllowAsGroup: false,
+			},
+		},
+		{
+			query: "NOT gender IN (M, F) AND group IN (U-reporters, \"U-Reporters\") OR -(dob BETWEEN 01-01-2000 AND 31-12-2000)",
+			inspection: &contactql.Inspection{
+				Attributes: []string{"group"},
+				Schemes:    []string{},
+				Fields: []*assets.FieldReference{
+					assets.NewFieldReference("gender", ""),
+					assets.NewFieldReference("dob", ""),
+				},
+				Groups: []*assets.GroupReference{
+					assets.NewVariableGroupReference("U-reporters"),
+				},
+				AllowAsGroup: false,
+			},
+		},
+		{
+			query:    "dob > -7d AND last_seen_on < start_of_month AND gender = today",
+			resolver: resolver,
+			inspection: &contactql.Inspection{
+				Attributes: []string{"last_seen_on"},
+				Schemes:    []string{},
+				Fields: []*assets.FieldReference{
+					assets.NewFieldReference("dob", "DOB"),
+					assets.NewFieldReference("gender", "Gender"),
+				},
+				Groups:        []*assets.GroupReference{},
+				AllowAsGroup:  true,
+				TimeDependent: true,
+			},
+		},
+		{
+			query:    "gender = today OR dob = 2020-01-01",
+			resolver: resolver,
+			inspection: &contactql.Inspection{
+				Attributes: []string{},
+				Schemes:    []string{},
+				Fields: []*assets.FieldReference{
+					assets.NewFieldReference("gender", "Gender"),
+					assets.NewFieldReference("dob", "DOB"),
+				},
+				Groups:        []*assets.GroupReference{},
+				AllowAsGroup:  true,
+				TimeDependent: false,
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -1,9 +1,11 @@
 package contactql
 
 import (
+	"encoding/json"
 	"strings"
 
 	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/utils"
 )
 
 type mockResolver struct {
@@ -47,3 +49,47 @@ func (r *mockResolver) ResolveFlow(name string) assets.Flow {
 	}
 	return nil
 }
+
+// ResolveResult looks for results in the flow definition, i.e. set_run_result actions and routers with result names
+func (r *mockResolver) ResolveResult(flow assets.Flow, key string) ([]string, bool) {
+	var definition struct {
+		Nodes []struct {
+			Actions []struct {
+				Type     string `json:"type"`
+				Name     string `json:"name"`
+				Category string `json:"category"`
+			} `json:"actions"`
+			Router *struct {
+				ResultName string `json:"result_name"`
+				Categories []struct {
+					Name string `json:"name"`
+				} `json:"categories"`
+			} `json:"router"`
+		} `json:"nodes"`
+	}
+	if err := json.Unmarshal(flow.Definition(), &definition); err != nil {
+		return nil, false
+	}
+
+	categories := make([]string, 0)
+	found := false
+
+	for _, node := range definition.Nodes {
+		for _, action := range node.Actions {
+			if action.Type == "set_run_result" && utils.Snakify(action.Name) == key {
+				found = true
+				if action.Category != "" {
+					categories = append(categories, action.Category)
+				}
+			}
+		}
+		if node.Router != nil && node.Router.ResultName != "" && utils.Snakify(node.Router.ResultName) == key {
+			found = true
+			for _, category := range node.Router.Categories {
+				categories = append(categories, category.Name)
+			}
+		}
+	}
+
+	return categories, found
+}
@@ -0,0 +1,169 @@
+package contactql
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Normalize returns a canonical form of the given node so that equivalent queries have the same string form. It
+// simplifies the node, lowercases property keys and text values, flattens nested combinations with the same operator,
+// removes duplicate clauses and sorts the children of combinations and the values of IN conditions.
+func Normalize(node QueryNode) QueryNode {
+	if node == nil {
+		return nil
+	}
+	node = node.Simplify()
+	if node == nil {
+		return nil
+	}
+
+	switch n := node.(type) {
+	case *BoolCombination:
+		return normalizeCombination(n)
+	case *Not:
+		return NewNot(Normalize(n.child)).Simplify()
+	case *Condition:
+		return normalizeCondition(n)
+	}
+	return node
+}
+
+func normalizeCombination(b *BoolCombination) QueryNode {
+	children := make([]QueryNode, 0, len(b.children))
+	seen := make(map[string]bool, len(b.children))
+
+	var add func(QueryNode)
+	add = func(child QueryNode) {
+		// removing duplicates can reduce a child to a combination with the same operator which we can flatten
+		if typed, isCombination := child.(*BoolCombination); isCombination && typed.op == b.op {
+			for _, grandchild := range typed.children {
+				add(grandchild)
+			}
+			return
+		}
+
+		key := child.String()
+		if !seen[key] {
+			seen[key] = true
+			children = append(children, child)
+		}
+	}
+
+	for _, child := range b.children {
+		add(Normalize(child))
+	}
+
+	if len(children) == 1 {
+		return children[0]
+	}
+
+	slices.SortFunc(children, func(a, b QueryNode) int { return strings.Compare(a.String(), b.String()) })
+
+	return NewBoolCombination(b.op, children...)
+}
+
+func normalizeCondition(c *Condition) QueryNode {
+	propKey := strings.ToLower(c.propKey)
+
+	switch c.operator {
+	case OpIn:
+		values := make([]string, len(c.values))
+		for i, v := range c.values {
+			values[i] = normalizeValue(v)
+		}
+		slices.Sort(values)
+		values = slices.Compact(values)
+		if len(values) == 1 {
+			return NewCondition(c.propType, propKey, OpEqual, values[0])
+		}
+		return NewListCondition(c.propType, propKey, OpIn, values)
+	case OpBetween:
+		return NewListCondition(c.propType, propKey, OpBetween, slices.Clone(c.values))
+	}
+
+	return NewCondition(c.propType, propKey, c.operator, normalizeValue(c.value))
+}
+
+// text values are compared case insensitively so are lowercased, but numbers and dates are left as they are
+func normalizeValue(value string) string {
+	if _, err := decimal.NewFromString(value); err == nil || ResultDateRegex.MatchString(value) || IsRelativeDate(value) {
+		return value
+	}
+	return strings.ToLower(value)
+}
+
+// Equivalent returns whether the given nodes are the same once normalized
+func Equivalent(node1, node2 QueryNode) bool {
+	return Stringify(Normalize(node1)) == Stringify(Normalize(node2))
+}
+
+// QueryDiff is the difference between two queries as the clauses added and removed
+type QueryDiff struct {
+	Added   []QueryNode
+	Removed []QueryNode
+}
+
+// Equivalent returns whether there is no difference between the queries
+func (d *QueryDiff) Equivalent() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// Diff compares the given nodes once normalized. If they are combined by the same operator then the clauses are the
+// children of that combination, e.g. going from `age > 18 AND district = Kano` to `age > 18 AND group = VIP` removes
+// `district = "kano"` and adds `group = "vip"`. Otherwise each node is a single clause.
+func Diff(from, to QueryNode) *QueryDiff {
+	from, to = Normalize(from), Normalize(to)
+
+	op := clauseOperator(from, to)
+	fromClauses, toClauses := clauses(from, op), clauses(to, op)
+
+	diff := &QueryDiff{Added: []QueryNode{}, Removed: []QueryNode{}}
+
+	for _, c := range toClauses {
+		if !containsClause(fromClauses, c) {
+			diff.Added = append(diff.Added, c)
+		}
+	}
+	for _, c := range fromClauses {
+		if !containsClause(toClauses, c) {
+			diff.Removed = append(diff.Removed, c)
+		}
+	}
+	return diff
+}
+
+// gets the operator which combines the clauses of the given nodes, or empty if they are combined differently
+func clauseOperator(node1, node2 QueryNode) BoolOperator {
+	b1, is1 := node1.(*BoolCombination)
+	b2, is2 := node2.(*BoolCombination)
+
+	switch {
+	case is1 && is2:
+		if b1.op == b2.op {
+			return b1.op
+		}
+		return ""
+	case is1:
+		return b1.op
+	case is2:
+		return b2.op
+	}
+	return ""
+}
+
+// splits the given node into its clauses, where nil matches everything and so has no clauses
+func clauses(node QueryNode, op BoolOperator) []QueryNode {
+	if node == nil {
+		return nil
+	}
+	if b, isCombination := node.(*BoolCombination); isCombination && op != "" && b.op == op {
+		return b.children
+	}
+	return []QueryNode{node}
+}
+
+func containsClause(nodes []QueryNode, node QueryNode) bool {
+	return slices.ContainsFunc(nodes, func(n QueryNode) bool { return n.String() == node.String() })
+}
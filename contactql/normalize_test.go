@@ -0,0 +1,105 @@
+package contactql_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/goflow/contactql"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	env := envs.NewBuilder().Build()
+
+	tests := []struct {
+		query      string
+		normalized string
+	}{
+		{`age > 18`, `fields.age > 18`},
+		{`name = bob AND age > 18`, `fields.age > 18 AND name = "bob"`},
+		{`name = bob AND age > 18 AND name = bob`, `fields.age > 18 AND name = "bob"`},
+		{`(c = 1 OR b = 2) AND (b = 2 OR c = 1)`, `fields.b = 2 OR fields.c = 1`},
+		{`a = 1 AND (b = 2 OR (c = 3 OR (d = 4 AND a = 1)))`, `((fields.a = 1 AND fields.d = 4) OR fields.b = 2 OR fields.c = 3) AND fields.a = 1`},
+		{`a = 1 AND ((b = 2 AND c = 3) OR (c = 3 AND b = 2))`, `fields.a = 1 AND fields.b = 2 AND fields.c = 3`},
+		{`NOT (b = 2 OR a = 1)`, `NOT (fields.a = 1 OR fields.b = 2)`},
+		{`NOT NOT a = 1`, `fields.a = 1`},
+		{`a IN (3, 1, 2, 1)`, `fields.a IN (1, 2, 3)`},
+		{`a IN (2, 2)`, `fields.a = 2`},
+		{`a BETWEEN 3 AND 1`, `fields.a BETWEEN 3 AND 1`},
+		{`name = Bob`, `name = "bob"`},
+		{`name ~ BOB`, `name ~ "bob"`},
+		{`district IN ("Kano", "kano")`, `fields.district = "kano"`},
+		{`district IN ("Lagos", "kano", "Abuja")`, `fields.district IN ("abuja", "kano", "lagos")`},
+		{`joined > TODAY`, `fields.joined > "TODAY"`},
+		{`joined > 2024-01-15T10:30Z`, `fields.joined > "2024-01-15T10:30Z"`},
+		{`a IN (1E3, 5)`, `fields.a IN ("1E3", 5)`},
+	}
+
+	for _, tc := range tests {
+		parsed, err := contactql.ParseQuery(env, tc.query, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, tc.normalized, contactql.Stringify(contactql.Normalize(parsed.Root())), "normalized mismatch for '%s'", tc.query)
+	}
+
+	// constructed nodes may have keys which aren't lowercase
+	node := contactql.NewBoolCombination(contactql.BoolOperatorOr,
+		contactql.NewCondition(contactql.PropertyTypeField, "Age", contactql.OpEqual, "18"),
+		contactql.NewCondition(contactql.PropertyTypeField, "age", contactql.OpEqual, "18"),
+	)
+	assert.Equal(t, `fields.age = 18`, contactql.Stringify(contactql.Normalize(node)))
+
+	assert.Nil(t, contactql.Normalize(nil))
+	assert.Nil(t, contactql.Normalize(contactql.NewBoolCombination(contactql.BoolOperatorAnd)))
+}
+
+func TestDiff(t *testing.T) {
+	env := envs.NewBuilder().Build()
+
+	tests := []struct {
+		from       string
+		to         string
+		added      []string
+		removed    []string
+		equivalent bool
+	}{
+		{`age > 18`, `fields.age > 18`, []string{}, []string{}, true},
+		{`age > 18 AND name = bob`, `NAME = "bob" AND fields.age > 18`, []string{}, []string{}, true},
+		{`name = Bob`, `name = bob`, []string{}, []string{}, true},
+		{`district IN ("Kano", "kano")`, `district = KANO`, []string{}, []string{}, true},
+		{`age > 18 AND district = Kano`, `fields.age > 18 AND group = VIP`, []string{`group = "vip"`}, []string{`fields.district = "kano"`}, false},
+		{`age > 18`, `fields.age > 18 AND group = VIP`, []string{`group = "vip"`}, []string{}, false},
+		{`age > 18 OR group = VIP`, `group = VIP`, []string{}, []string{`fields.age > 18`}, false},
+		{`age > 18 AND (a = 1 OR b = 2)`, `fields.age > 18 AND (fields.b = 2 OR fields.a = 1)`, []string{}, []string{}, true},
+		{`age > 18 AND (a = 1 OR b = 2)`, `fields.age > 18 AND (fields.a = 1 OR fields.c = 3)`, []string{`(fields.a = 1 OR fields.c = 3)`}, []string{`(fields.a = 1 OR fields.b = 2)`}, false},
+		{`a = 1 AND b = 2`, `fields.a = 1 OR fields.b = 2`, []string{`(fields.a = 1 OR fields.b = 2)`}, []string{`(fields.a = 1 AND fields.b = 2)`}, false},
+	}
+
+	for _, tc := range tests {
+		from, err := contactql.ParseQuery(env, tc.from, nil)
+		require.NoError(t, err)
+		to, err := contactql.ParseQuery(env, tc.to, nil)
+		require.NoError(t, err)
+
+		diff := contactql.Diff(from.Root(), to.Root())
+
+		assert.Equal(t, tc.added, stringifyNodes(diff.Added), "added mismatch for '%s' > '%s'", tc.from, tc.to)
+		assert.Equal(t, tc.removed, stringifyNodes(diff.Removed), "removed mismatch for '%s' > '%s'", tc.from, tc.to)
+		assert.Equal(t, tc.equivalent, diff.Equivalent(), "equivalent mismatch for '%s' > '%s'", tc.from, tc.to)
+		assert.Equal(t, tc.equivalent, contactql.Equivalent(from.Root(), to.Root()), "equivalent mismatch for '%s' > '%s'", tc.from, tc.to)
+	}
+
+	// a query which matches everything has no clauses
+	diff := contactql.Diff(nil, contactql.Field("age").GreaterThan("18").Node())
+	assert.Equal(t, []string{`fields.age > 18`}, stringifyNodes(diff.Added))
+	assert.Equal(t, []string{}, stringifyNodes(diff.Removed))
+}
+
+func stringifyNodes(nodes []contactql.QueryNode) []string {
+	strs := make([]string, len(nodes))
+	for i, n := range nodes {
+		strs[i] = n.String()
+	}
+	return strs
+}
@@ -3,6 +3,7 @@ package contactql
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -28,6 +29,8 @@ const (
 	OpLessThan           Operator = "<"
 	OpGreaterThanOrEqual Operator = ">="
 	OpLessThanOrEqual    Operator = "<="
+	OpIn                 Operator = "in"
+	OpBetween            Operator = "between"
 )
 
 // BoolOperator is a boolean operator (and or or)
@@ -53,6 +56,9 @@ const (
 
 	// PropertyTypeField is a custom contact field
 	PropertyTypeField PropertyType = "field"
+
+	// PropertyTypeResult is a result of a flow, with a key like flow.result or flow.result.category
+	PropertyTypeResult PropertyType = "result"
 )
 
 // name based contains conditions are tokenized but only tokens of at least 2 characters are used
@@ -76,6 +82,7 @@ type Condition struct {
 	propKey  string
 	operator Operator
 	value    string
+	values   []string
 }
 
 func NewCondition(propType PropertyType, propKey string, operator Operator, value string) *Condition {
@@ -87,7 +94,17 @@ func NewCondition(propType PropertyType, propKey string, operator Operator, valu
 	}
 }
 
-// PropertyType returns the type (attribute, scheme, field)
+// NewListCondition creates a new condition with an operator which takes a list of values, i.e. IN or BETWEEN
+func NewListCondition(propType PropertyType, propKey string, operator Operator, values []string) *Condition {
+	return &Condition{
+		propType: propType,
+		propKey:  propKey,
+		operator: operator,
+		values:   values,
+	}
+}
+
+// PropertyType returns the type (attribute, scheme, field, result)
 func (c *Condition) PropertyType() PropertyType { return c.propType }
 
 // PropertyKey returns the key for the property being queried
@@ -99,13 +116,39 @@ func (c *Condition) Operator() Operator { return c.operator }
 // Value returns the value being compared against
 func (c *Condition) Value() string { return c.value }
 
+// Values returns the values being compared against by IN and BETWEEN conditions
+func (c *Condition) Values() []string { return c.values }
+
+// Expand returns an equivalent node made of single value conditions for IN and BETWEEN conditions,
+// or the condition itself for other operators
+func (c *Condition) Expand() QueryNode {
+	switch c.operator {
+	case OpIn:
+		children := make([]QueryNode, len(c.values))
+		for i, v := range c.values {
+			children[i] = NewCondition(c.propType, c.propKey, OpEqual, v)
+		}
+		return NewBoolCombination(BoolOperatorOr, children...)
+	case OpBetween:
+		return NewBoolCombination(BoolOperatorAnd,
+			NewCondition(c.propType, c.propKey, OpGreaterThanOrEqual, c.values[0]),
+			NewCondition(c.propType, c.propKey, OpLessThanOrEqual, c.values[1]),
+		)
+	}
+	return c
+}
+
 // ValueAsNumber returns the value as a number if possible, or an error if not
 func (c *Condition) ValueAsNumber() (decimal.Decimal, error) {
 	return decimal.NewFromString(c.value)
 }
 
-// ValueAsDate returns the value as a date if possible, or an error if not
+// ValueAsDate returns the value as a date if possible, or an error if not. Relative dates like -7d or today are
+// resolved against the current time and week start of the environment.
 func (c *Condition) ValueAsDate(env envs.Environment) (time.Time, error) {
+	if date, isRelative := parseRelativeDate(env.Now(), envs.WeekStartOf(env), c.value); isRelative {
+		return date, nil
+	}
 	return envs.DateTimeFromString(env, c.value, false)
 }
 
@@ -119,8 +162,28 @@ func (c *Condition) ValueAsFlow(resolver Resolver) assets.Flow {
 	return resolver.ResolveFlow(c.value)
 }
 
+// ResultFlow returns the flow of a condition on a flow result. Flows are referenced by name with any spaces written
+// as underscores, e.g. results.favorite_color.color
+func (c *Condition) ResultFlow(resolver Resolver) assets.Flow {
+	name := strings.SplitN(c.propKey, ".", 2)[0]
+
+	if flow := resolver.ResolveFlow(name); flow != nil {
+		return flow
+	}
+	return resolver.ResolveFlow(strings.ReplaceAll(name, "_", " "))
+}
+
+// ResultKey returns the result key of a condition on a flow result, and whether it's on the category of the result
+// rather than its value
+func (c *Condition) ResultKey() (string, bool) {
+	parts := strings.Split(c.propKey, ".")
+	return parts[1], len(parts) == 3
+}
+
 func (c *Condition) resolveValueType(resolver Resolver) assets.FieldType {
 	switch c.propType {
+	case PropertyTypeResult:
+		return c.resultValueType()
 	case PropertyTypeAttribute:
 		return attributes[c.propKey]
 	case PropertyTypeURN:
@@ -134,6 +197,26 @@ func (c *Condition) resolveValueType(resolver Resolver) assets.FieldType {
 	return ""
 }
 
+// results are stored as text, so are compared as text unless the comparison only makes sense for numbers or dates
+func (c *Condition) resultValueType() assets.FieldType {
+	if _, isCategory := c.ResultKey(); isCategory {
+		return assets.FieldTypeText
+	}
+
+	switch c.operator {
+	case OpGreaterThan, OpGreaterThanOrEqual, OpLessThan, OpLessThanOrEqual, OpBetween:
+		value := c.value
+		if c.operator == OpBetween {
+			value = c.values[0]
+		}
+		if _, err := decimal.NewFromString(value); err == nil {
+			return assets.FieldTypeNumber
+		}
+		return assets.FieldTypeDatetime
+	}
+	return assets.FieldTypeText
+}
+
 // Validate checks that this condition is valid (and thus can be evaluated)
 func (c *Condition) validate(env envs.Environment, resolver Resolver) error {
 	// if our property is a field and we don't have a resolver, we can't validate because we don't know the value type
@@ -141,6 +224,12 @@ func (c *Condition) validate(env envs.Environment, resolver Resolver) error {
 		return nil
 	}
 
+	if c.propType == PropertyTypeResult && resolver != nil {
+		if err := c.validateResult(resolver); err != nil {
+			return err
+		}
+	}
+
 	valueType := c.resolveValueType(resolver)
 	if valueType == "" {
 		return NewQueryError(ErrUnknownProperty, "can't resolve '%s' to attribute, scheme or field", c.propKey).withExtra("property", c.propKey)
@@ -161,12 +250,17 @@ func (c *Condition) validate(env envs.Environment, resolver Resolver) error {
 			return NewQueryError(ErrUnsupportedContains, "contains conditions can only be used with name or URN values").withExtra("property", c.propKey)
 		}
 
-	case OpGreaterThan, OpGreaterThanOrEqual, OpLessThan, OpLessThanOrEqual:
+	case OpGreaterThan, OpGreaterThanOrEqual, OpLessThan, OpLessThanOrEqual, OpBetween:
 		if valueType != assets.FieldTypeNumber && valueType != assets.FieldTypeDatetime {
 			return NewQueryError(ErrUnsupportedComparison, "comparisons with %s can only be used with date and number fields", c.operator).withExtra("property", c.propKey).withExtra("operator", string(c.operator))
 		}
 	}
 
+	// list conditions are valid if the single value conditions they're equivalent to are valid
+	if c.operator == OpIn || c.operator == OpBetween {
+		return c.Expand().validate(env, resolver)
+	}
+
 	// if existence check, disallow certain attributes
 	if (c.operator == OpEqual || c.operator == OpNotEqual) && c.value == "" {
 		switch c.propKey {
@@ -194,7 +288,7 @@ func (c *Condition) validate(env envs.Environment, resolver Resolver) error {
 				if group == nil {
 					return NewQueryError(ErrInvalidGroup, "'%s' is not a valid group name", c.value).withExtra("value", c.value)
 				}
-			} else if (c.propKey == AttributeFlow || c.propKey == AttributeHistory) && resolver != nil {
+			} else if (c.propKey == AttributeFlow || c.propKey == AttributeHistory || c.propKey == AttributeHistoryCompleted || c.propKey == AttributeHistoryExited) && resolver != nil {
 				flow := c.ValueAsFlow(resolver)
 				if flow == nil {
 					return NewQueryError(ErrInvalidFlow, "'%s' is not a valid flow name", c.value).withExtra("value", c.value)
@@ -218,6 +312,42 @@ func (c *Condition) validate(env envs.Environment, resolver Resolver) error {
 	return nil
 }
 
+// checks that the flow of a result condition exists, and if the resolver can resolve results, that the flow has the
+// result and category being queried
+func (c *Condition) validateResult(resolver Resolver) error {
+	flowName := strings.SplitN(c.propKey, ".", 2)[0]
+	flow := c.ResultFlow(resolver)
+	if flow == nil {
+		return NewQueryError(ErrInvalidFlow, "'%s' is not a valid flow name", flowName).withExtra("value", flowName)
+	}
+
+	resultResolver, canResolve := resolver.(ResultResolver)
+	if !canResolve {
+		return nil
+	}
+
+	key, isCategory := c.ResultKey()
+	categories, found := resultResolver.ResolveResult(flow, key)
+	if !found {
+		return NewQueryError(ErrInvalidResult, "'%s' is not a valid result of flow '%s'", key, flow.Name()).withExtra("flow", flow.Name()).withExtra("result", key)
+	}
+
+	// results without fixed categories can have any category
+	if isCategory && len(categories) > 0 {
+		values := c.values
+		if values == nil {
+			values = []string{c.value}
+		}
+		for _, value := range values {
+			if value != "" && !slices.ContainsFunc(categories, func(cat string) bool { return strings.EqualFold(cat, value) }) {
+				return NewQueryError(ErrInvalidResultCategory, "'%s' is not a valid category of result '%s'", value, key).withExtra("value", value).withExtra("result", key)
+			}
+		}
+	}
+
+	return nil
+}
+
 func (c *Condition) Simplify() QueryNode {
 	return c
 }
@@ -231,14 +361,30 @@ func (c *Condition) String() string {
 		property = fmt.Sprintf(`fields.%s`, property)
 	} else if c.propType == PropertyTypeURN {
 		property = fmt.Sprintf(`urns.%s`, property)
+	} else if c.propType == PropertyTypeResult {
+		property = fmt.Sprintf(`results.%s`, property)
 	}
 
-	if !isNumberRegex.MatchString(value) {
-		// if not a decimal then quote
-		value = strconv.Quote(value)
+	switch c.operator {
+	case OpIn:
+		values := make([]string, len(c.values))
+		for i, v := range c.values {
+			values[i] = quoteValue(v)
+		}
+		return fmt.Sprintf(`%s IN (%s)`, property, strings.Join(values, ", "))
+	case OpBetween:
+		return fmt.Sprintf(`%s BETWEEN %s AND %s`, property, quoteValue(c.values[0]), quoteValue(c.values[1]))
 	}
 
-	return fmt.Sprintf(`%s %s %s`, property, c.operator, value)
+	return fmt.Sprintf(`%s %s %s`, property, c.operator, quoteValue(value))
+}
+
+// quotes the given value unless it's a decimal
+func quoteValue(value string) string {
+	if !isNumberRegex.MatchString(value) {
+		return strconv.Quote(value)
+	}
+	return value
 }
 
 // BoolCombination is a AND or OR combination of multiple conditions
@@ -291,7 +437,7 @@ func (b *BoolCombination) Simplify() QueryNode {
 			} else {
 				newChildren = append(newChildren, typed)
 			}
-		case *Condition:
+		default:
 			newChildren = append(newChildren, typed)
 		}
 	}
@@ -314,6 +460,41 @@ func (b *BoolCombination) String() string {
 	return fmt.Sprintf("(%s)", strings.Join(children, fmt.Sprintf(" %s ", strings.ToUpper(string(b.op)))))
 }
 
+// Not is the negation of another node
+type Not struct {
+	child QueryNode
+}
+
+// NewNot creates a new negation of the given node
+func NewNot(child QueryNode) *Not {
+	return &Not{child: child}
+}
+
+// Child returns the node being negated
+func (n *Not) Child() QueryNode { return n.child }
+
+func (n *Not) validate(env envs.Environment, resolver Resolver) error {
+	return n.child.validate(env, resolver)
+}
+
+func (n *Not) Simplify() QueryNode {
+	child := n.child.Simplify()
+	if child == nil {
+		return nil
+	}
+
+	// remove double negations
+	if typed, isNot := child.(*Not); isNot {
+		return typed.child
+	}
+
+	return &Not{child: child}
+}
+
+func (n *Not) String() string {
+	return "NOT " + n.child.String()
+}
+
 // ContactQuery is a parsed contact QL query
 type ContactQuery struct {
 	root     QueryNode
@@ -350,6 +531,17 @@ func Stringify(n QueryNode) string {
 // ParseQuery parses a ContactQL query from the given input. If resolver is provided then we validate against it
 // to ensure that fields and groups exist. If not provided then still validate what we can.
 func ParseQuery(env envs.Environment, text string, resolver Resolver) (*ContactQuery, error) {
+	rootNode, err := ParseTree(env, text, resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ContactQuery{root: rootNode.Simplify(), resolver: resolver}, nil
+}
+
+// ParseTree parses a ContactQL query from the given input like ParseQuery, but returns the root node as parsed,
+// without it being simplified
+func ParseTree(env envs.Environment, text string, resolver Resolver) (QueryNode, error) {
 	// preprocess text before parsing
 	text = strings.TrimSpace(text)
 
@@ -386,9 +578,7 @@ func ParseQuery(env envs.Environment, text string, resolver Resolver) (*ContactQ
 		return nil, err
 	}
 
-	rootNode = rootNode.Simplify()
-
-	return &ContactQuery{root: rootNode, resolver: resolver}, nil
+	return rootNode, nil
 }
 
 type errorListener struct {
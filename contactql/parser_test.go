@@ -10,6 +10,19 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// a flow definition with an age result from a set_run_result action and a category result from a router
+var registrationDefinition = []byte(`{
+	"nodes": [
+		{
+			"actions": [{"type": "set_run_result", "name": "Age", "value": "@input"}]
+		},
+		{
+			"actions": [],
+			"router": {"type": "switch", "result_name": "Category", "categories": [{"name": "Yes"}, {"name": "No"}, {"name": "Other"}]}
+		}
+	]
+}`)
+
 func TestParseQuery(t *testing.T) {
 	resolver := contactql.NewMockResolver(
 		[]assets.Field{
@@ -20,7 +33,8 @@ func TestParseQuery(t *testing.T) {
 			static.NewField("85baf5e1-b57a-46dc-a726-a84e8c4229c7", "language", "Language", assets.FieldTypeText), // conflicts with language attribute
 		},
 		[]assets.Flow{
-			static.NewFlow("f87fd7cd-e501-4394-9cff-62309af85138", "Registration", []byte(`{}`)),
+			static.NewFlow("f87fd7cd-e501-4394-9cff-62309af85138", "Registration", registrationDefinition),
+			static.NewFlow("c0d57e9d-2d79-4ea0-a3d2-0cb3fe49e5a8", "Favorite Color", []byte(`{}`)),
 		},
 		[]assets.Group{
 			static.NewGroup("a9b5b0a0-1098-4bc2-8384-eea09ae43e6b", "U-Reporters", ""),
@@ -118,6 +132,11 @@ func TestParseQuery(t *testing.T) {
 		{text: `DOB >= 27-01-2020`, parsed: `fields.dob >= "27-01-2020"`, resolver: resolver},
 		{text: `DOB < 27/01/2020`, parsed: `fields.dob < "27/01/2020"`, resolver: resolver},
 		{text: `DOB <= 27.01.2020`, parsed: `fields.dob <= "27.01.2020"`, resolver: resolver},
+		{text: `DOB > -7d`, parsed: `fields.dob > "-7d"`, resolver: resolver},
+		{text: `created_on < "1 month ago"`, parsed: `created_on < "1 month ago"`, resolver: resolver},
+		{text: `last_seen_on >= Yesterday`, parsed: `last_seen_on >= "Yesterday"`, resolver: resolver},
+		{text: `dob > "7 days"`, err: "can't convert '7 days' to a date", resolver: resolver},
+		{text: `age > -7d`, err: "can't convert '-7d' to a number", resolver: resolver},
 		{text: `name > Will`, err: "comparisons with > can only be used with date and number fields", resolver: resolver},
 		{text: `tel < 23425`, err: "comparisons with < can only be used with date and number fields", resolver: resolver},
 
@@ -154,6 +173,56 @@ func TestParseQuery(t *testing.T) {
 			resolver: resolver,
 		},
 
+		// negations
+		{text: `NOT age = 18`, parsed: `NOT fields.age = 18`, resolver: resolver},
+		{text: `-group = U-Reporters`, parsed: `NOT group = "U-Reporters"`, resolver: resolver},
+		{text: `-fields.age > 18`, parsed: `NOT fields.age > 18`, resolver: resolver},
+		{text: `not (age < 18 or gender = male)`, parsed: `NOT (fields.age < 18 OR fields.gender = "male")`, resolver: resolver},
+		{text: `-(age < 18 or gender = male) and will`, parsed: `NOT (fields.age < 18 OR fields.gender = "male") AND name ~ "will"`, resolver: resolver},
+		{text: `not not age = 18`, parsed: `fields.age = 18`, resolver: resolver},
+		{text: `not (not (age = 18))`, parsed: `fields.age = 18`, resolver: resolver},
+		{text: `not`, parsed: `name ~ "not"`, resolver: resolver},             // not followed by anything so just a name
+		{text: `name = not`, parsed: `name = "not"`, resolver: resolver},      // values can't be negated
+		{text: `-12345`, parsed: `urns.tel ~ 12345`, resolver: resolver},      // looks like a phone number
+		{text: `age = -18`, parsed: `fields.age = "-18"`, resolver: resolver}, // negative number
+		{text: `not xyz = 1`, err: "can't resolve 'xyz' to attribute, scheme or field", resolver: resolver},
+
+		// only conditions and groupings can be negated so free text containing not or starting with - is still a name search
+		{text: `not will`, parsed: `name ~ "not" AND name ~ "will"`, resolver: resolver},
+		{text: `will not felix`, parsed: `name ~ "will" AND name ~ "not" AND name ~ "felix"`, resolver: resolver},
+		{text: `not not will`, parsed: `name ~ "not" AND name ~ "not" AND name ~ "will"`, resolver: resolver},
+		{text: `-bob`, parsed: `name ~ "-bob"`, resolver: resolver},
+		{text: `--bob`, parsed: `name ~ "--bob"`, resolver: resolver},
+		{text: `will -bob`, parsed: `name ~ "will" AND name ~ "-bob"`, resolver: resolver},
+		{text: `not -bob`, parsed: `name ~ "not" AND name ~ "-bob"`, resolver: resolver},
+		{text: `in`, parsed: `name ~ "in"`, resolver: resolver},
+		{text: `set`, parsed: `name ~ "set"`, resolver: resolver},
+		{text: `between`, parsed: `name ~ "between"`, resolver: resolver},
+
+		// IN lists
+		{text: `state IN (Pichincha, "Guayas")`, parsed: `fields.state IN ("Pichincha", "Guayas")`, resolver: resolver},
+		{text: `age in (18, 19,20)`, parsed: `fields.age IN (18, 19, 20)`, resolver: resolver},
+		{text: `Group IN ("U-Reporters")`, parsed: `group IN ("U-Reporters")`, resolver: resolver},
+		{text: `not state in (Pichincha, Guayas) and age = 18`, parsed: `NOT fields.state IN ("Pichincha", "Guayas") AND fields.age = 18`, resolver: resolver},
+		{text: `(state in (Pichincha) or age > 18)`, parsed: `fields.state IN ("Pichincha") OR fields.age > 18`, resolver: resolver},
+		{text: `age in (18, XZ)`, err: "can't convert 'XZ' to a number", resolver: resolver},
+		{text: `group in (U-Reporters, Gamers)`, err: "'Gamers' is not a valid group name", resolver: resolver},
+		{text: `tel in (12345, 67890)`, err: "cannot query on redacted URNs", redactURNs: true, resolver: resolver},
+		{text: `will in town`, parsed: `name ~ "will" AND name ~ "in" AND name ~ "town"`, resolver: resolver}, // not followed by a list
+
+		// BETWEEN ranges
+		{text: `age BETWEEN 18 AND 30`, parsed: `fields.age BETWEEN 18 AND 30`, resolver: resolver},
+		{text: `dob between "01-01-2000" and 31-12-2000`, parsed: `fields.dob BETWEEN "01-01-2000" AND "31-12-2000"`, resolver: resolver},
+		{text: `age between 18 and 30 or age between 50 and 60`, parsed: `fields.age BETWEEN 18 AND 30 OR fields.age BETWEEN 50 AND 60`, resolver: resolver},
+		{text: `gender between a and b`, err: "comparisons with between can only be used with date and number fields", resolver: resolver},
+		{text: `age between 18 and XZ`, err: "can't convert 'XZ' to a number", resolver: resolver},
+
+		// set checks
+		{text: `age IS SET`, parsed: `fields.age != ""`, resolver: resolver},
+		{text: `age is not set`, parsed: `fields.age = ""`, resolver: resolver},
+		{text: `name is "set"`, parsed: `name = "set"`, resolver: resolver}, // quoted so not a keyword
+		{text: `uuid is set`, err: "can't check whether 'uuid' is set or not set", resolver: resolver},
+
 		{text: `xyz != ""`, err: "can't resolve 'xyz' to attribute, scheme or field", resolver: resolver},
 		{text: `group != "Gamers"`, err: "'Gamers' is not a valid group name", resolver: resolver},
 		{text: `flow = "Catch All"`, err: "'Catch All' is not a valid flow name", resolver: resolver},
@@ -247,6 +316,32 @@ func TestParseQuery(t *testing.T) {
 		{text: `dob > 20-02-2020`, parsed: `fields.dob > "20-02-2020"`, resolver: resolver},
 		{text: `state > Pichincha`, err: "comparisons with > can only be used with date and number fields", resolver: resolver},
 
+		// flow results
+		{text: `results.registration.age > 18`, parsed: `results.registration.age > 18`, resolver: resolver},
+		{text: `Results.Registration.Age >= 2020-01-01`, parsed: `results.registration.age >= "2020-01-01"`, resolver: resolver},
+		{text: `results.registration.age = "thirty"`, parsed: `results.registration.age = "thirty"`, resolver: resolver},
+		{text: `results.registration.category = "Yes"`, parsed: `results.registration.category = "Yes"`, resolver: resolver},
+		{text: `results.registration.category.category = no`, parsed: `results.registration.category.category = "no"`, resolver: resolver},
+		{text: `results.registration.age IS SET`, parsed: `results.registration.age != ""`, resolver: resolver},
+		{text: `results.registration.age BETWEEN 18 AND 30`, parsed: `results.registration.age BETWEEN 18 AND 30`, resolver: resolver},
+		{text: `results.registration.category IN (yes, no)`, parsed: `results.registration.category IN ("yes", "no")`, resolver: resolver},
+		{text: `-results.registration.category = "Yes"`, parsed: `NOT results.registration.category = "Yes"`, resolver: resolver},
+		{text: `name = results.registration.age`, parsed: `name = "results.registration.age"`, resolver: resolver},
+		{text: `results.favorite_color.color = red`, err: "'color' is not a valid result of flow 'Favorite Color'", resolver: resolver},
+		{text: `results.registration.color = red`, err: "'color' is not a valid result of flow 'Registration'", resolver: resolver},
+		{text: `results.catch_all.age = 18`, err: "'catch_all' is not a valid flow name", resolver: resolver},
+		{text: `results.registration.category.category = maybe`, err: "'maybe' is not a valid category of result 'category'", resolver: resolver},
+		{text: `results.registration.age.value = 18`, err: "can't resolve 'results.registration.age.value' to a flow result", resolver: resolver},
+		{text: `results.registration.age ~ 18`, err: "contains conditions can only be used with name or URN values", resolver: resolver},
+		{text: `results.registration.age > abc`, err: "can't convert 'abc' to a date", resolver: resolver},
+		{text: `results.registration.age > 18`, parsed: `results.registration.age > 18`},
+
+		// qualified history
+		{text: `history.completed = registration`, parsed: `history.completed = "registration"`, resolver: resolver},
+		{text: `History.Exited != "Registration"`, parsed: `history.exited != "Registration"`, resolver: resolver},
+		{text: `history.exited = "Catch All"`, err: "'Catch All' is not a valid flow name", resolver: resolver},
+		{text: `history.failed = registration`, err: "unknown property type 'history'", resolver: resolver},
+
 		// however if we don't provide a resolver, we don't know the field type, so allowed for all
 		{text: `age > 18`, parsed: `fields.age > 18`},
 		{text: `gender > male`, parsed: `fields.gender > "male"`},
@@ -282,19 +377,19 @@ func TestParsingErrors(t *testing.T) {
 	}{
 		{
 			query:    `$`,
-			errMsg:   "mismatched input '$' expecting {'(', STRING, PROPERTY, TEXT}",
+			errMsg:   "mismatched input '$' expecting {'(', '-', NOT, IN, BETWEEN, SET, STRING, PROPERTY, TEXT}",
 			errCode:  "syntax",
 			errExtra: nil,
 		},
 		{
 			query:    `name = `,
-			errMsg:   "mismatched input '<EOF>' expecting {STRING, PROPERTY, TEXT}",
+			errMsg:   "mismatched input '<EOF>' expecting {'-', NOT, IN, BETWEEN, SET, STRING, PROPERTY, TEXT}",
 			errCode:  "syntax",
 			errExtra: nil,
 		},
 		{
 			query:    `name = "x`,
-			errMsg:   "extraneous input '\"' expecting {STRING, PROPERTY, TEXT}",
+			errMsg:   "extraneous input '\"' expecting {'-', NOT, IN, BETWEEN, SET, STRING, PROPERTY, TEXT}",
 			errCode:  "syntax",
 			errExtra: nil,
 		},
@@ -316,6 +411,24 @@ func TestParsingErrors(t *testing.T) {
 			errCode:  "syntax",
 			errExtra: nil,
 		},
+		{
+			query:    `age in (18, 19`,
+			errMsg:   "extraneous input '<EOF>' expecting {')', ','}",
+			errCode:  "syntax",
+			errExtra: nil,
+		},
+		{
+			query:    `age in (18, )`,
+			errMsg:   "mismatched input ')' expecting {'-', NOT, IN, BETWEEN, SET, STRING, PROPERTY, TEXT}",
+			errCode:  "syntax",
+			errExtra: nil,
+		},
+		{
+			query:    `not (age = )`,
+			errMsg:   "mismatched input ')' expecting {'-', NOT, IN, BETWEEN, SET, STRING, PROPERTY, TEXT}",
+			errCode:  "syntax",
+			errExtra: nil,
+		},
 		{
 			query:    `age = XZ`,
 			errMsg:   "can't convert 'XZ' to a number",
@@ -394,6 +507,24 @@ func TestParsingErrors(t *testing.T) {
 			errCode:  "unknown_property_type",
 			errExtra: map[string]any{"type": "xxx"},
 		},
+		{
+			query:    `results.registration.color = red`,
+			errMsg:   "'color' is not a valid result of flow 'Registration'",
+			errCode:  "invalid_result",
+			errExtra: map[string]any{"flow": "Registration", "result": "color"},
+		},
+		{
+			query:    `results.registration.category.category = maybe`,
+			errMsg:   "'maybe' is not a valid category of result 'category'",
+			errCode:  "invalid_result_category",
+			errExtra: map[string]any{"value": "maybe", "result": "category"},
+		},
+		{
+			query:    `results.registration = 12`,
+			errMsg:   "can't resolve 'results.registration' to a flow result",
+			errCode:  "unknown_property",
+			errExtra: map[string]any{"property": "results.registration"},
+		},
 	}
 
 	env := envs.NewBuilder().WithDefaultCountry("US").Build()
@@ -403,7 +534,9 @@ func TestParsingErrors(t *testing.T) {
 			static.NewField("3810a485-3fda-4011-a589-7320c0b8dbef", "dob", "DOB", assets.FieldTypeDatetime),
 			static.NewField("d66a7823-eada-40e5-9a3a-57239d4690bf", "gender", "Gender", assets.FieldTypeText),
 		},
-		[]assets.Flow{},
+		[]assets.Flow{
+			static.NewFlow("f87fd7cd-e501-4394-9cff-62309af85138", "Registration", registrationDefinition),
+		},
 		[]assets.Group{},
 	)
 
@@ -463,6 +596,14 @@ func TestSimplify(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, tc.parsed, parsed.String(), "parsed mismatch for input '%s'", tc.text)
 	}
+
+	// the tree as parsed isn't simplified
+	tree, err := contactql.ParseTree(env, `NOT NOT age > 10 and age < 20 and age < 40`, resolver)
+	assert.NoError(t, err)
+	assert.Equal(t, `(NOT NOT fields.age > 10 AND fields.age < 20) AND fields.age < 40`, contactql.Stringify(tree))
+
+	_, err = contactql.ParseTree(env, `xyz > 10`, resolver)
+	assert.EqualError(t, err, "can't resolve 'xyz' to attribute, scheme or field")
 }
 
 func TestQueryBuilding(t *testing.T) {
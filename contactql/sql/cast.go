@@ -0,0 +1,21 @@
+package sql
+
+import (
+	"fmt"
+
+	"github.com/nyaruka/goflow/contactql"
+)
+
+// casts a result value to a number, or NULL if it isn't a valid number, since a failed cast would error and fail the
+// whole query
+func castResultNumber(value string) string {
+	return fmt.Sprintf(`(CASE WHEN %s ~ '%s' THEN %s::numeric END)`, value, contactql.ResultNumberRegex, value)
+}
+
+// casts a result value to a date, or NULL if it isn't a valid date. The nested CASE checks the day exists in the month
+// only once the value is known to match the pattern, e.g. so 2024-02-30 is NULL rather than an error.
+func castResultDate(value string) string {
+	lastDay := fmt.Sprintf(`EXTRACT(DAY FROM make_date(substr(%s, 1, 4)::int, substr(%s, 6, 2)::int, 1) + INTERVAL '1 month - 1 day')`, value, value)
+
+	return fmt.Sprintf(`(CASE WHEN %s ~ '%s' THEN CASE WHEN substr(%s, 9, 2)::int <= %s THEN %s::timestamptz END END)`, value, contactql.ResultDateRegex, value, lastDay, value)
+}
@@ -0,0 +1,375 @@
+package sql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nyaruka/gocommon/dates"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/contactql"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/utils"
+)
+
+// AssetMapper is used to map engine assets to however the database identifies them
+type AssetMapper interface {
+	Flow(assets.Flow) int64
+	Group(assets.Group) int64
+}
+
+// we store contact status in the database as single char codes
+var contactStatusCodes = map[string]string{
+	"active":   "A",
+	"blocked":  "B",
+	"stopped":  "S",
+	"archived": "V",
+}
+
+// qualified history attributes only consider runs with certain statuses
+var runStatusConditions = map[string]string{
+	contactql.AttributeHistoryCompleted: " AND r.status = 'C'",
+	contactql.AttributeHistoryExited:    " AND r.status IN ('I', 'X', 'F')",
+}
+
+// name based contains conditions are matched against prefixes of this length, like the evaluator
+const nameTokenPrefixLength = 8
+
+// ToSQL converts a contactql query to a parameterized SQL condition with PostgreSQL style $n placeholders, returning
+// the SQL and its arguments. The condition is on a contacts table aliased as c with the following columns:
+//
+//	id, uuid, name, status, language, ticket_count, created_on, last_seen_on, current_flow_id
+//	fields (JSONB keyed by field UUID, values are objects with text, number, datetime, state, district and ward keys)
+//
+// and related contacts_contacturn (contact_id, scheme, path), contacts_contactgroup_contacts (contact_id,
+// contactgroup_id) and flows_flowrun (contact_id, flow_id, status, results) tables, where run results are JSONB keyed
+// by result key, with values that are objects with value and category keys.
+func ToSQL(env envs.Environment, mapper AssetMapper, query *contactql.ContactQuery) (string, []any) {
+	if query.Resolver() == nil {
+		panic("can only convert queries parsed with a resolver")
+	}
+
+	c := &converter{env: env, resolver: query.Resolver(), mapper: mapper}
+
+	// a query can simplify to nothing in which case it matches everything
+	if query.Root() == nil {
+		return "TRUE", nil
+	}
+
+	return c.node(query.Root()), c.args
+}
+
+// converts nodes to SQL, collecting the arguments as it goes
+type converter struct {
+	env      envs.Environment
+	resolver contactql.Resolver
+	mapper   AssetMapper
+	args     []any
+}
+
+// adds an argument, returning its placeholder
+func (c *converter) arg(v any) string {
+	c.args = append(c.args, v)
+	return fmt.Sprintf("$%d", len(c.args))
+}
+
+func (c *converter) node(node contactql.QueryNode) string {
+	switch n := node.(type) {
+	case *contactql.BoolCombination:
+		return c.boolCombination(n)
+	case *contactql.Not:
+		child := n.Child()
+		if cond, isCondition := child.(*contactql.Condition); isCondition {
+			child = cond.Expand()
+		}
+
+		// combinations are already wrapped in parentheses
+		if _, isCombination := child.(*contactql.BoolCombination); isCombination {
+			return "NOT " + c.node(child)
+		}
+		return fmt.Sprintf("NOT (%s)", c.node(child))
+	case *contactql.Condition:
+		return c.condition(n)
+	default:
+		panic(fmt.Sprintf("unsupported node type: %T", n))
+	}
+}
+
+func (c *converter) boolCombination(combination *contactql.BoolCombination) string {
+	clauses := make([]string, len(combination.Children()))
+	for i, child := range combination.Children() {
+		clauses[i] = c.node(child)
+	}
+
+	op := " AND "
+	if combination.Operator() == contactql.BoolOperatorOr {
+		op = " OR "
+	}
+
+	return "(" + strings.Join(clauses, op) + ")"
+}
+
+func (c *converter) condition(cond *contactql.Condition) string {
+	// IN and BETWEEN conditions are converted as the single value conditions they're equivalent to
+	if cond.Operator() == contactql.OpIn || cond.Operator() == contactql.OpBetween {
+		return c.node(cond.Expand())
+	}
+
+	switch cond.PropertyType() {
+	case contactql.PropertyTypeField:
+		return c.fieldCondition(cond)
+	case contactql.PropertyTypeAttribute:
+		return c.attributeCondition(cond)
+	case contactql.PropertyTypeURN:
+		return c.schemeCondition(cond)
+	case contactql.PropertyTypeResult:
+		return c.resultCondition(cond)
+	default:
+		panic(fmt.Sprintf("unsupported property type: %s", cond.PropertyType()))
+	}
+}
+
+func (c *converter) fieldCondition(cond *contactql.Condition) string {
+	field := c.resolver.ResolveField(cond.PropertyKey())
+	fieldType := field.Type()
+	value := fmt.Sprintf("(c.fields -> %s ->> '%s')", c.arg(string(field.UUID())), fieldType)
+
+	// special cases for set/unset
+	if isSetCheck(cond) {
+		return setCheck(cond, value+" IS NOT NULL")
+	}
+
+	switch fieldType {
+	case assets.FieldTypeText:
+		return c.textComparison(cond, value)
+	case assets.FieldTypeNumber:
+		return c.numberComparison(cond, value+"::numeric")
+	case assets.FieldTypeDatetime:
+		return c.dateComparison(cond, value+"::timestamptz")
+	case assets.FieldTypeState, assets.FieldTypeDistrict, assets.FieldTypeWard:
+		// locations may be stored as paths like "Rwanda > Kigali City" so only compare the last part
+		return c.textComparison(cond, fmt.Sprintf(`regexp_replace(%s, '^.* > ', '')`, value))
+	}
+
+	panic(fmt.Sprintf("unsupported field type: %s", fieldType))
+}
+
+func (c *converter) attributeCondition(cond *contactql.Condition) string {
+	key := cond.PropertyKey()
+
+	switch key {
+	case contactql.AttributeUUID:
+		return c.textComparison(cond, "c.uuid::text")
+	case contactql.AttributeID:
+		return c.textComparison(cond, "c.id::text")
+	case contactql.AttributeName:
+		if isSetCheck(cond) {
+			return setCheck(cond, "COALESCE(TRIM(c.name), '') != ''")
+		}
+		if cond.Operator() == contactql.OpContains {
+			return c.nameContains(cond)
+		}
+		return c.textComparison(cond, "c.name")
+	case contactql.AttributeStatus:
+		code := contactStatusCodes[strings.ToLower(cond.Value())]
+		return c.comparison("c.status", cond.Operator(), c.arg(code))
+	case contactql.AttributeLanguage:
+		if isSetCheck(cond) {
+			return setCheck(cond, "COALESCE(c.language, '') != ''")
+		}
+		return c.textComparison(cond, "c.language")
+	case contactql.AttributeCreatedOn:
+		return c.dateComparison(cond, "c.created_on")
+	case contactql.AttributeLastSeenOn:
+		if isSetCheck(cond) {
+			return setCheck(cond, "c.last_seen_on IS NOT NULL")
+		}
+		return c.dateComparison(cond, "c.last_seen_on")
+	case contactql.AttributeURN:
+		return c.urnComparison(cond, "")
+	case contactql.AttributeGroup:
+		groups := "SELECT 1 FROM contacts_contactgroup_contacts g WHERE g.contact_id = c.id"
+		if isSetCheck(cond) {
+			return setCheck(cond, fmt.Sprintf("EXISTS (%s)", groups))
+		}
+
+		group := cond.ValueAsGroup(c.resolver)
+		return c.existsComparison(cond, fmt.Sprintf("%s AND g.contactgroup_id = %s", groups, c.arg(c.mapper.Group(group))))
+	case contactql.AttributeFlow:
+		if isSetCheck(cond) {
+			return setCheck(cond, "c.current_flow_id IS NOT NULL")
+		}
+
+		flow := cond.ValueAsFlow(c.resolver)
+		return c.comparison("c.current_flow_id", cond.Operator(), c.arg(c.mapper.Flow(flow)))
+	case contactql.AttributeHistory, contactql.AttributeHistoryCompleted, contactql.AttributeHistoryExited:
+		runs := "SELECT 1 FROM flows_flowrun r WHERE r.contact_id = c.id" + runStatusConditions[key]
+		if isSetCheck(cond) {
+			return setCheck(cond, fmt.Sprintf("EXISTS (%s)", runs))
+		}
+
+		flow := cond.ValueAsFlow(c.resolver)
+		return c.existsComparison(cond, fmt.Sprintf("%s AND r.flow_id = %s", runs, c.arg(c.mapper.Flow(flow))))
+	case contactql.AttributeTickets:
+		return c.numberComparison(cond, "c.ticket_count")
+	default:
+		panic(fmt.Sprintf("unsupported contact attribute: %s", key))
+	}
+}
+
+func (c *converter) schemeCondition(cond *contactql.Condition) string {
+	return c.urnComparison(cond, cond.PropertyKey())
+}
+
+// contacts can have multiple runs of a flow so conditions are true if any run's result matches, or for != if no run's
+// result matches
+func (c *converter) resultCondition(cond *contactql.Condition) string {
+	key, isCategory := cond.ResultKey()
+	flow := cond.ResultFlow(c.resolver)
+	runs := fmt.Sprintf("SELECT 1 FROM flows_flowrun r WHERE r.contact_id = c.id AND r.flow_id = %s", c.arg(c.mapper.Flow(flow)))
+
+	part := "value"
+	if isCategory {
+		part = "category"
+	}
+	value := fmt.Sprintf("(r.results -> %s ->> '%s')", c.arg(key), part)
+
+	if isSetCheck(cond) {
+		return setCheck(cond, fmt.Sprintf("EXISTS (%s AND COALESCE(%s, '') != '')", runs, value))
+	}
+
+	switch cond.Operator() {
+	case contactql.OpEqual, contactql.OpNotEqual:
+		return c.existsComparison(cond, fmt.Sprintf("%s AND LOWER(TRIM(%s)) = %s", runs, value, c.arg(strings.ToLower(strings.TrimSpace(cond.Value())))))
+	}
+
+	// values which aren't numbers or dates are ignored rather than cast
+	if _, err := cond.ValueAsNumber(); err == nil {
+		return fmt.Sprintf("EXISTS (%s AND %s)", runs, c.numberComparison(cond, castResultNumber(value)))
+	}
+	return fmt.Sprintf("EXISTS (%s AND %s)", runs, c.dateComparison(cond, castResultDate(value)))
+}
+
+// contacts can have multiple URNs so conditions are true if any URN matches, or for != if no URN matches
+func (c *converter) urnComparison(cond *contactql.Condition, scheme string) string {
+	urns := "SELECT 1 FROM contacts_contacturn u WHERE u.contact_id = c.id"
+	if scheme != "" {
+		urns += " AND u.scheme = " + c.arg(scheme)
+	}
+
+	if isSetCheck(cond) {
+		return setCheck(cond, fmt.Sprintf("EXISTS (%s)", urns))
+	}
+
+	value := strings.ToLower(strings.TrimSpace(cond.Value()))
+
+	switch cond.Operator() {
+	case contactql.OpEqual, contactql.OpNotEqual:
+		return c.existsComparison(cond, fmt.Sprintf("%s AND LOWER(u.path) = %s", urns, c.arg(value)))
+	case contactql.OpContains:
+		return fmt.Sprintf(`EXISTS (%s AND LOWER(u.path) LIKE %s)`, urns, c.arg("%"+escapeLike(value)+"%"))
+	default:
+		panic(fmt.Sprintf("unsupported URN operator: %s", cond.Operator()))
+	}
+}
+
+// name contains conditions are true if any token of the name starts with any token of the value
+func (c *converter) nameContains(cond *contactql.Condition) string {
+	tokens := utils.TokenizeStringByUnicodeSeg(strings.ToLower(cond.Value()))
+	matches := make([]string, 0, len(tokens))
+
+	for _, token := range tokens {
+		if len(token) >= 2 {
+			prefix := []rune(token)
+			if len(prefix) > nameTokenPrefixLength {
+				prefix = prefix[:nameTokenPrefixLength]
+			}
+			matches = append(matches, fmt.Sprintf("LEFT(t, %d) LIKE %s", nameTokenPrefixLength, c.arg(escapeLike(string(prefix))+"%")))
+		}
+	}
+
+	return fmt.Sprintf(`EXISTS (SELECT 1 FROM regexp_split_to_table(LOWER(c.name), '[^[:alnum:]_]+') t WHERE LENGTH(t) >= 2 AND (%s))`, strings.Join(matches, " OR "))
+}
+
+// compares single text values case insensitively, where != is true for contacts without a value
+func (c *converter) textComparison(cond *contactql.Condition, expr string) string {
+	value := strings.ToLower(strings.TrimSpace(cond.Value()))
+
+	if isSetCheck(cond) {
+		return setCheck(cond, fmt.Sprintf("COALESCE(%s, '') != ''", expr))
+	}
+
+	return c.comparison(fmt.Sprintf("LOWER(TRIM(%s))", expr), cond.Operator(), c.arg(value))
+}
+
+func (c *converter) numberComparison(cond *contactql.Condition, expr string) string {
+	value, _ := cond.ValueAsNumber()
+
+	return c.comparison(expr, cond.Operator(), c.arg(value))
+}
+
+// compares dates against the day of the value in the environment's timezone
+func (c *converter) dateComparison(cond *contactql.Condition, expr string) string {
+	value, _ := cond.ValueAsDate(c.env)
+	start, end := dates.DayToUTCRange(value, value.Location())
+
+	switch cond.Operator() {
+	case contactql.OpEqual:
+		return fmt.Sprintf("COALESCE(%s >= %s AND %s < %s, FALSE)", expr, c.arg(start), expr, c.arg(end))
+	case contactql.OpNotEqual:
+		return fmt.Sprintf("NOT COALESCE(%s >= %s AND %s < %s, FALSE)", expr, c.arg(start), expr, c.arg(end))
+	case contactql.OpGreaterThan:
+		return fmt.Sprintf("COALESCE(%s >= %s, FALSE)", expr, c.arg(end))
+	case contactql.OpGreaterThanOrEqual:
+		return fmt.Sprintf("COALESCE(%s >= %s, FALSE)", expr, c.arg(start))
+	case contactql.OpLessThan:
+		return fmt.Sprintf("COALESCE(%s < %s, FALSE)", expr, c.arg(start))
+	case contactql.OpLessThanOrEqual:
+		return fmt.Sprintf("COALESCE(%s < %s, FALSE)", expr, c.arg(end))
+	default:
+		panic(fmt.Sprintf("unsupported date operator: %s", cond.Operator()))
+	}
+}
+
+// compares a single value which may be NULL, so that the result is never NULL and so can be safely negated
+func (c *converter) comparison(expr string, op contactql.Operator, placeholder string) string {
+	switch op {
+	case contactql.OpEqual:
+		return fmt.Sprintf("COALESCE(%s = %s, FALSE)", expr, placeholder)
+	case contactql.OpNotEqual:
+		return fmt.Sprintf("NOT COALESCE(%s = %s, FALSE)", expr, placeholder)
+	case contactql.OpGreaterThan, contactql.OpGreaterThanOrEqual, contactql.OpLessThan, contactql.OpLessThanOrEqual:
+		return fmt.Sprintf("COALESCE(%s %s %s, FALSE)", expr, op, placeholder)
+	default:
+		panic(fmt.Sprintf("unsupported operator: %s", op))
+	}
+}
+
+// compares with a related table, where = is true if a matching row exists and != if none do
+func (c *converter) existsComparison(cond *contactql.Condition, subquery string) string {
+	switch cond.Operator() {
+	case contactql.OpEqual:
+		return fmt.Sprintf("EXISTS (%s)", subquery)
+	case contactql.OpNotEqual:
+		return fmt.Sprintf("NOT EXISTS (%s)", subquery)
+	default:
+		panic(fmt.Sprintf("unsupported %s operator: %s", cond.PropertyKey(), cond.Operator()))
+	}
+}
+
+// checks whether the given condition is an existence check, i.e. x = "" or x != ""
+func isSetCheck(cond *contactql.Condition) bool {
+	return (cond.Operator() == contactql.OpEqual || cond.Operator() == contactql.OpNotEqual) && cond.Value() == ""
+}
+
+// converts an existence check to SQL given the SQL for the property being set
+func setCheck(cond *contactql.Condition, isSet string) string {
+	if cond.Operator() == contactql.OpEqual {
+		return fmt.Sprintf("NOT (%s)", isSet)
+	}
+	return isSet
+}
+
+// escapes a value for use in a LIKE pattern
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
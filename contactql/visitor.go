@@ -37,6 +37,10 @@ const (
 	AttributeTickets    = "tickets"
 	AttributeCreatedOn  = "created_on"
 	AttributeLastSeenOn = "last_seen_on"
+
+	// qualified history attributes for flows which the contact completed or exited (interrupted, expired or failed)
+	AttributeHistoryCompleted = "history.completed"
+	AttributeHistoryExited    = "history.exited"
 )
 
 var attributes = map[string]assets.FieldType{
@@ -52,6 +56,9 @@ var attributes = map[string]assets.FieldType{
 	AttributeTickets:    assets.FieldTypeNumber,
 	AttributeCreatedOn:  assets.FieldTypeDatetime,
 	AttributeLastSeenOn: assets.FieldTypeDatetime,
+
+	AttributeHistoryCompleted: assets.FieldTypeText,
+	AttributeHistoryExited:    assets.FieldTypeText,
 }
 
 // Resolver provides functions for resolving assets referenced in queries
@@ -61,6 +68,12 @@ type Resolver interface {
 	ResolveFlow(name string) assets.Flow
 }
 
+// ResultResolver is an optional interface for resolvers which can also resolve the results of flows, returning the
+// possible categories of the given result and whether the flow has that result
+type ResultResolver interface {
+	ResolveResult(flow assets.Flow, key string) ([]string, bool)
+}
+
 type visitor struct {
 	gen.BaseContactQLVisitor
 
@@ -114,10 +127,46 @@ func (v *visitor) VisitImplicitCondition(ctx *gen.ImplicitConditionContext) any
 	return NewCondition(PropertyTypeAttribute, AttributeName, operator, value)
 }
 
-// expression : PROPERTY COMPARATOR literal
-func (v *visitor) VisitCondition(ctx *gen.ConditionContext) any {
-	propText := strings.ToLower(ctx.PROPERTY().GetText())
-	operatorText := strings.ToLower(ctx.COMPARATOR().GetText())
+// expression : negation
+func (v *visitor) VisitNegatedExpression(ctx *gen.NegatedExpressionContext) any {
+	return v.Visit(ctx.Negation())
+}
+
+// expression : condition
+func (v *visitor) VisitExplicitCondition(ctx *gen.ExplicitConditionContext) any {
+	return v.Visit(ctx.Condition())
+}
+
+// negation : (NOT | MINUS) (LPAREN expression RPAREN | negation | condition)
+func (v *visitor) VisitNegation(ctx *gen.NegationContext) any {
+	var child QueryNode
+	if ctx.Expression() != nil {
+		child = v.Visit(ctx.Expression()).(QueryNode)
+	} else if ctx.Negation() != nil {
+		child = v.Visit(ctx.Negation()).(QueryNode)
+	} else {
+		child = v.Visit(ctx.Condition()).(QueryNode)
+	}
+	return NewNot(child)
+}
+
+// condition : PROPERTY IS NOT? SET
+func (v *visitor) VisitSetCondition(ctx *gen.SetConditionContext) any {
+	// equivalent to property != "" and property = ""
+	if ctx.NOT() != nil {
+		return v.newCondition(ctx.PROPERTY().GetText(), OpEqual, "")
+	}
+	return v.newCondition(ctx.PROPERTY().GetText(), OpNotEqual, "")
+}
+
+// condition : PROPERTY (COMPARATOR | IS) literal
+func (v *visitor) VisitComparisonCondition(ctx *gen.ComparisonConditionContext) any {
+	propText := ctx.PROPERTY().GetText()
+	comparator := ctx.COMPARATOR()
+	if comparator == nil {
+		comparator = ctx.IS()
+	}
+	operatorText := strings.ToLower(comparator.GetText())
 	value := v.Visit(ctx.Literal()).(string)
 
 	operator, isAlias := operatorAliases[operatorText]
@@ -125,14 +174,56 @@ func (v *visitor) VisitCondition(ctx *gen.ConditionContext) any {
 		operator = Operator(operatorText)
 	}
 
+	return v.newCondition(propText, operator, value)
+}
+
+// condition : PROPERTY IN LPAREN literal (COMMA literal)* RPAREN
+func (v *visitor) VisitListCondition(ctx *gen.ListConditionContext) any {
+	literals := ctx.AllLiteral()
+	values := make([]string, len(literals))
+	for i, literal := range literals {
+		values[i] = v.Visit(literal).(string)
+	}
+
+	return v.newCondition(ctx.PROPERTY().GetText(), OpIn, values...)
+}
+
+// condition : PROPERTY BETWEEN literal AND literal
+func (v *visitor) VisitRangeCondition(ctx *gen.RangeConditionContext) any {
+	from := v.Visit(ctx.Literal(0)).(string)
+	to := v.Visit(ctx.Literal(1)).(string)
+
+	return v.newCondition(ctx.PROPERTY().GetText(), OpBetween, from, to)
+}
+
+// creates a new condition on the given property, which for IN and BETWEEN conditions will have multiple values
+func (v *visitor) newCondition(propText string, operator Operator, values ...string) *Condition {
+	propText = strings.ToLower(propText)
+
+	// only URN existence checks are allowed when URNs are redacted
+	hasValue := false
+	for _, value := range values {
+		if value != "" {
+			hasValue = true
+		}
+	}
+
 	var propType PropertyType
 	var propKey string
 
 	// check if property type is specified as prefix
-	if strings.Contains(propText, ".") {
+	if _, isAttribute := attributes[propText]; !isAttribute && strings.Contains(propText, ".") {
 		parts := strings.SplitN(propText, ".", 2)
 
-		if parts[0] == "fields" {
+		if parts[0] == "results" {
+			// results.flow.key or results.flow.key.category
+			keyParts := strings.Split(parts[1], ".")
+			if len(keyParts) < 2 || len(keyParts) > 3 || (len(keyParts) == 3 && keyParts[2] != "category") {
+				v.addError(NewQueryError(ErrUnknownProperty, "can't resolve '%s' to a flow result", propText).withExtra("property", propText))
+			}
+			propType = PropertyTypeResult
+			propKey = parts[1]
+		} else if parts[0] == "fields" {
 			propType = PropertyTypeField
 			propKey = parts[1]
 		} else if parts[0] == "urns" {
@@ -149,7 +240,7 @@ func (v *visitor) VisitCondition(ctx *gen.ConditionContext) any {
 		if isAttribute {
 			propType = PropertyTypeAttribute
 
-			if propKey == AttributeURN && v.env.RedactionPolicy() == envs.RedactionPolicyURNs && value != "" {
+			if propKey == AttributeURN && v.env.RedactionPolicy() == envs.RedactionPolicyURNs && hasValue {
 				v.addError(NewQueryError(ErrRedactedURNs, "cannot query on redacted URNs"))
 			}
 
@@ -157,7 +248,7 @@ func (v *visitor) VisitCondition(ctx *gen.ConditionContext) any {
 			// second try to match a URN scheme
 			propType = PropertyTypeURN
 
-			if v.env.RedactionPolicy() == envs.RedactionPolicyURNs && value != "" {
+			if v.env.RedactionPolicy() == envs.RedactionPolicyURNs && hasValue {
 				v.addError(NewQueryError(ErrRedactedURNs, "cannot query on redacted URNs"))
 			}
 		} else {
@@ -165,7 +256,10 @@ func (v *visitor) VisitCondition(ctx *gen.ConditionContext) any {
 		}
 	}
 
-	return NewCondition(propType, propKey, operator, value)
+	if operator == OpIn || operator == OpBetween {
+		return NewListCondition(propType, propKey, operator, values)
+	}
+	return NewCondition(propType, propKey, operator, values[0])
 }
 
 // expression : expression AND expression
@@ -194,15 +288,17 @@ func (v *visitor) VisitExpressionGrouping(ctx *gen.ExpressionGroupingContext) an
 	return v.Visit(ctx.Expression())
 }
 
-// literal : TEXT | NAME
+// literal : MINUS* (PROPERTY | TEXT | NOT | IN | BETWEEN | SET | MINUS)
 func (v *visitor) VisitTextLiteral(ctx *gen.TextLiteralContext) any {
 	return ctx.GetText()
 }
 
 // literal : STRING
 func (v *visitor) VisitStringLiteral(ctx *gen.StringLiteralContext) any {
-	value := ctx.GetText()
+	return unquoteString(ctx.GetText())
+}
 
+func unquoteString(value string) string {
 	// unquote, this takes care of escape sequences as well
 	unquoted, err := strconv.Unquote(value)
 
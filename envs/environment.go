@@ -2,6 +2,7 @@ package envs
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -36,6 +37,37 @@ type NumberFormat struct {
 // DefaultNumberFormat is the default number formatting, e.g. 1,234.567
 var DefaultNumberFormat = &NumberFormat{DecimalSymbol: `.`, DigitGroupingSymbol: `,`}
 
+// WeekStart is the day of the week that weeks start on
+type WeekStart string
+
+const (
+	WeekStartMonday    WeekStart = "monday"
+	WeekStartTuesday   WeekStart = "tuesday"
+	WeekStartWednesday WeekStart = "wednesday"
+	WeekStartThursday  WeekStart = "thursday"
+	WeekStartFriday    WeekStart = "friday"
+	WeekStartSaturday  WeekStart = "saturday"
+	WeekStartSunday    WeekStart = "sunday"
+)
+
+var weekStartDays = map[WeekStart]time.Weekday{
+	WeekStartMonday:    time.Monday,
+	WeekStartTuesday:   time.Tuesday,
+	WeekStartWednesday: time.Wednesday,
+	WeekStartThursday:  time.Thursday,
+	WeekStartFriday:    time.Friday,
+	WeekStartSaturday:  time.Saturday,
+	WeekStartSunday:    time.Sunday,
+}
+
+// Weekday returns the day of the week, defaulting to Monday if week start isn't set
+func (w WeekStart) Weekday() time.Weekday {
+	if d, found := weekStartDays[w]; found {
+		return d
+	}
+	return time.Monday
+}
+
 // Environment defines the environment that the Excellent function is running in, this includes
 // the timezone the user is in as well as the preferred date and time formats.
 type Environment interface {
@@ -59,6 +91,19 @@ type Environment interface {
 	Equal(Environment) bool
 }
 
+// WeekStartEnvironment is optionally implemented by environments which have a configured day that weeks start on
+type WeekStartEnvironment interface {
+	WeekStart() time.Weekday
+}
+
+// WeekStartOf returns the day that weeks start on in the given environment, defaulting to Monday
+func WeekStartOf(env Environment) time.Weekday {
+	if wse, ok := env.(WeekStartEnvironment); ok {
+		return wse.WeekStart()
+	}
+	return time.Monday
+}
+
 type environment struct {
 	dateFormat       DateFormat
 	timeFormat       TimeFormat
@@ -68,6 +113,7 @@ type environment struct {
 	numberFormat     *NumberFormat
 	redactionPolicy  RedactionPolicy
 	inputCollation   Collation
+	weekStart        time.Weekday
 }
 
 func (e *environment) DateFormat() DateFormat            { return e.dateFormat }
@@ -78,6 +124,7 @@ func (e *environment) DefaultCountry() i18n.Country      { return e.defaultCount
 func (e *environment) NumberFormat() *NumberFormat       { return e.numberFormat }
 func (e *environment) InputCollation() Collation         { return e.inputCollation }
 func (e *environment) RedactionPolicy() RedactionPolicy  { return e.redactionPolicy }
+func (e *environment) WeekStart() time.Weekday           { return e.weekStart }
 
 // DefaultLanguage is the first allowed language
 func (e *environment) DefaultLanguage() i18n.Language {
@@ -117,6 +164,7 @@ type envEnvelope struct {
 	DefaultCountry   i18n.Country    `json:"default_country,omitempty" validate:"omitempty,country"`
 	InputCollation   Collation       `json:"input_collation"`
 	RedactionPolicy  RedactionPolicy `json:"redaction_policy" validate:"omitempty,eq=none|eq=urns"`
+	WeekStart        WeekStart       `json:"week_start,omitempty" validate:"omitempty,oneof=monday tuesday wednesday thursday friday saturday sunday"`
 }
 
 // ReadEnvironment reads an environment from the given JSON
@@ -136,6 +184,7 @@ func ReadEnvironment(data json.RawMessage) (Environment, error) {
 	env.numberFormat = envelope.NumberFormat
 	env.inputCollation = envelope.InputCollation
 	env.redactionPolicy = envelope.RedactionPolicy
+	env.weekStart = envelope.WeekStart.Weekday()
 
 	tz, err := time.LoadLocation(envelope.Timezone)
 	if err != nil {
@@ -147,6 +196,12 @@ func ReadEnvironment(data json.RawMessage) (Environment, error) {
 }
 
 func (e *environment) toEnvelope() *envEnvelope {
+	// week start is only included if it's not the default of Monday
+	var weekStart WeekStart
+	if e.weekStart != time.Monday {
+		weekStart = WeekStart(strings.ToLower(e.weekStart.String()))
+	}
+
 	return &envEnvelope{
 		DateFormat:       e.dateFormat,
 		TimeFormat:       e.timeFormat,
@@ -156,6 +211,7 @@ func (e *environment) toEnvelope() *envEnvelope {
 		NumberFormat:     e.numberFormat,
 		InputCollation:   e.inputCollation,
 		RedactionPolicy:  e.redactionPolicy,
+		WeekStart:        weekStart,
 	}
 }
 
@@ -185,6 +241,7 @@ func NewBuilder() *EnvironmentBuilder {
 			numberFormat:     DefaultNumberFormat,
 			inputCollation:   CollationDefault,
 			redactionPolicy:  RedactionPolicyNone,
+			weekStart:        time.Monday,
 		},
 	}
 }
@@ -231,5 +288,10 @@ func (b *EnvironmentBuilder) WithRedactionPolicy(redactionPolicy RedactionPolicy
 	return b
 }
 
+func (b *EnvironmentBuilder) WithWeekStart(weekStart time.Weekday) *EnvironmentBuilder {
+	b.env.weekStart = weekStart
+	return b
+}
+
 // Build returns the final environment
 func (b *EnvironmentBuilder) Build() Environment { return b.env }
@@ -37,6 +37,10 @@ func TestEnvironmentMarshaling(t *testing.T) {
 	_, err = envs.ReadEnvironment(json.RawMessage(`{"date_format": "DD-MM-YYYY", "time_format": "tttttt", "timezone": "Cuenca"}`))
 	assert.Error(t, err)
 
+	// can't create with invalid week start
+	_, err = envs.ReadEnvironment(json.RawMessage(`{"date_format": "DD-MM-YYYY", "time_format": "tt:mm:ss", "week_start": "someday"}`))
+	assert.Error(t, err)
+
 	// empty environment uses all defaults
 	env, err := envs.ReadEnvironment(json.RawMessage(`{}`))
 	assert.NoError(t, err)
@@ -46,6 +50,7 @@ func TestEnvironmentMarshaling(t *testing.T) {
 	assert.Equal(t, i18n.NilLanguage, env.DefaultLanguage())
 	assert.Nil(t, env.AllowedLanguages())
 	assert.Equal(t, i18n.NilCountry, env.DefaultCountry())
+	assert.Equal(t, time.Monday, envs.WeekStartOf(env))
 	assert.Nil(t, env.LocationResolver())
 
 	// can create with valid values
@@ -54,7 +59,8 @@ func TestEnvironmentMarshaling(t *testing.T) {
 		"time_format": "tt:mm:ss", 
 		"allowed_languages": ["eng", "fra"], 
 		"default_country": "RW", 
-		"timezone": "Africa/Kigali"
+		"timezone": "Africa/Kigali",
+		"week_start": "sunday"
 	}`))
 	assert.NoError(t, err)
 	assert.Equal(t, envs.DateFormatDayMonthYear, env.DateFormat())
@@ -66,11 +72,12 @@ func TestEnvironmentMarshaling(t *testing.T) {
 	assert.Equal(t, i18n.Locale("eng-RW"), env.DefaultLocale())
 	assert.Equal(t, envs.CollationDefault, env.InputCollation())
 	assert.Equal(t, envs.RedactionPolicyNone, env.RedactionPolicy())
+	assert.Equal(t, time.Sunday, envs.WeekStartOf(env))
 	assert.Nil(t, env.LocationResolver())
 
 	data, err := jsonx.Marshal(env)
 	require.NoError(t, err)
-	assert.Equal(t, string(data), `{"date_format":"DD-MM-YYYY","time_format":"tt:mm:ss","timezone":"Africa/Kigali","allowed_languages":["eng","fra"],"number_format":{"decimal_symbol":".","digit_grouping_symbol":","},"default_country":"RW","input_collation":"default","redaction_policy":"none"}`)
+	assert.Equal(t, string(data), `{"date_format":"DD-MM-YYYY","time_format":"tt:mm:ss","timezone":"Africa/Kigali","allowed_languages":["eng","fra"],"number_format":{"decimal_symbol":".","digit_grouping_symbol":","},"default_country":"RW","input_collation":"default","redaction_policy":"none","week_start":"sunday"}`)
 }
 
 func TestEnvironmentEqual(t *testing.T) {
@@ -108,6 +115,7 @@ func TestEnvironmentBuilder(t *testing.T) {
 		WithDefaultCountry(i18n.Country("RW")).
 		WithNumberFormat(&envs.NumberFormat{DecimalSymbol: "'"}).
 		WithRedactionPolicy(envs.RedactionPolicyURNs).
+		WithWeekStart(time.Saturday).
 		Build()
 
 	assert.Equal(t, envs.DateFormatDayMonthYear, env.DateFormat())
@@ -117,5 +125,18 @@ func TestEnvironmentBuilder(t *testing.T) {
 	assert.Equal(t, i18n.Country("RW"), env.DefaultCountry())
 	assert.Equal(t, &envs.NumberFormat{DecimalSymbol: "'"}, env.NumberFormat())
 	assert.Equal(t, envs.RedactionPolicyURNs, env.RedactionPolicy())
+	assert.Equal(t, time.Saturday, envs.WeekStartOf(env))
 	assert.Nil(t, env.LocationResolver())
 }
+
+// an environment which doesn't have a configured week start
+type noWeekStartEnv struct {
+	envs.Environment
+}
+
+func TestWeekStartOf(t *testing.T) {
+	env := envs.NewBuilder().WithWeekStart(time.Sunday).Build()
+
+	assert.Equal(t, time.Sunday, envs.WeekStartOf(env))
+	assert.Equal(t, time.Monday, envs.WeekStartOf(&noWeekStartEnv{env}))
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/utils"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -30,9 +31,16 @@ var locationHierarchyJSON = `
 		{
 			"name": "Kigali City",
 			"aliases": ["Kigali", "Kigari"],
+			"boundary": [
+				[[29.95, -2.05], [30.25, -2.05], [30.25, -1.85], [29.95, -1.85]]
+			],
 			"children": [
 				{
 					"name": "Gasabo",
+					"boundary": [
+						[[30.05, -2.0], [30.25, -2.0], [30.25, -1.85], [30.05, -1.85]],
+						[[30.3, -1.8], [30.4, -1.8], [30.4, -1.7], [30.3, -1.7]]
+					],
 					"children": [
 						{
 							"id": "575743222",
@@ -113,4 +121,19 @@ func TestLocationHierarchy(t *testing.T) {
 	assert.Equal(t, kigali, hierarchy.FindByPath("RWANDA > KIGALI CITY"))
 	assert.Equal(t, gasabo, hierarchy.FindByPath("rwanda > kigali city > gasabo"))
 	assert.Equal(t, ndera, hierarchy.FindByPath("rwanda > kigali city > gasabo > ndera"))
+
+	assert.Nil(t, rwanda.Boundary())
+	assert.Equal(t, 1, len(kigali.Boundary()))
+	assert.Equal(t, utils.GeoPoint{Lat: -2.05, Lng: 29.95}, kigali.Boundary()[0][0])
+	assert.Equal(t, 2, len(gasabo.Boundary()))
+
+	assert.True(t, kigali.Contains(utils.GeoPoint{Lat: -1.95, Lng: 30.1}))
+	assert.False(t, kigali.Contains(utils.GeoPoint{Lat: -1.75, Lng: 30.35}))
+	assert.True(t, gasabo.Contains(utils.GeoPoint{Lat: -1.75, Lng: 30.35})) // in second polygon
+	assert.False(t, rwanda.Contains(utils.GeoPoint{Lat: -1.95, Lng: 30.1})) // no boundary
+
+	assert.Equal(t, []*envs.Location{kigali}, hierarchy.FindByPoint(utils.GeoPoint{Lat: -1.95, Lng: 30.1}, envs.LocationLevel(1)))
+	assert.Equal(t, []*envs.Location{gasabo}, hierarchy.FindByPoint(utils.GeoPoint{Lat: -1.95, Lng: 30.1}, envs.LocationLevel(2)))
+	assert.Equal(t, []*envs.Location{}, hierarchy.FindByPoint(utils.GeoPoint{Lat: -1.95, Lng: 30.0}, envs.LocationLevel(2)))
+	assert.Equal(t, []*envs.Location{}, hierarchy.FindByPoint(utils.GeoPoint{Lat: 40.7, Lng: -74.0}, envs.LocationLevel(1)))
 }
@@ -21,6 +21,11 @@ type LocationResolver interface {
 	LookupLocation(LocationPath) *Location
 }
 
+// PointLocationResolver is optionally implemented by location resolvers which can resolve locations from GPS points
+type PointLocationResolver interface {
+	FindLocationsByPoint(utils.GeoPoint, LocationLevel) []*Location
+}
+
 const (
 	LocationPathSeparator = ">"
 )
@@ -68,6 +73,7 @@ type Location struct {
 	name     string
 	path     LocationPath
 	aliases  []string
+	boundary []utils.GeoPolygon
 	parent   *Location
 	children []*Location
 }
@@ -89,6 +95,19 @@ func (l *Location) Path() LocationPath { return l.path }
 // Aliases gets the aliases of this location
 func (l *Location) Aliases() []string { return l.aliases }
 
+// Boundary gets the polygons which make up the boundary of this location
+func (l *Location) Boundary() []utils.GeoPolygon { return l.boundary }
+
+// Contains returns whether the given point is inside the boundary of this location
+func (l *Location) Contains(point utils.GeoPoint) bool {
+	for _, polygon := range l.boundary {
+		if polygon.Contains(point) {
+			return true
+		}
+	}
+	return false
+}
+
 // Parent gets the parent of this location
 func (l *Location) Parent() *Location { return l.parent }
 
@@ -212,6 +231,19 @@ func (h *LocationHierarchy) FindByName(env Environment, name string, level Locat
 	return []*Location{}
 }
 
+// FindByPoint looks for all locations in the hierarchy with the given level whose boundaries contain the given point
+func (h *LocationHierarchy) FindByPoint(point utils.GeoPoint, level LocationLevel) []*Location {
+	matches := make([]*Location, 0, 1)
+
+	h.root.visit(func(location *Location) {
+		if location.level == level && location.Contains(point) {
+			matches = append(matches, location)
+		}
+	})
+
+	return matches
+}
+
 // FindByPath looks for a location in the hierarchy with the given path
 func (h *LocationHierarchy) FindByPath(path LocationPath) *Location {
 	return h.pathLookup.lookup(path)
@@ -238,6 +270,7 @@ func (h *LocationHierarchy) UnmarshalJSON(data []byte) error {
 type locationEnvelope struct {
 	Name     string              `json:"name" validate:"required"`
 	Aliases  []string            `json:"aliases,omitempty"`
+	Boundary [][][2]float64      `json:"boundary,omitempty"`
 	Children []*locationEnvelope `json:"children,omitempty"`
 }
 
@@ -249,6 +282,17 @@ func locationFromEnvelope(envelope *locationEnvelope, currentLevel LocationLevel
 		parent:  parent,
 	}
 
+	// boundary polygons are lists of points in GeoJSON order, i.e. [longitude, latitude]
+	if len(envelope.Boundary) > 0 {
+		location.boundary = make([]utils.GeoPolygon, len(envelope.Boundary))
+		for i, coords := range envelope.Boundary {
+			location.boundary[i] = make(utils.GeoPolygon, len(coords))
+			for j, c := range coords {
+				location.boundary[i][j] = utils.GeoPoint{Lat: c[1], Lng: c[0]}
+			}
+		}
+	}
+
 	location.children = make([]*Location, len(envelope.Children))
 	for i := range envelope.Children {
 		location.children[i] = locationFromEnvelope(envelope.Children[i], currentLevel+1, location)
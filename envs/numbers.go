@@ -0,0 +1,53 @@
+package envs
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/nyaruka/gocommon/i18n"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+var localeNumberFormats sync.Map
+
+// NumberFormatForLocale returns the number format conventionally used by the given locale, or nil if that
+// can't be determined
+func NumberFormatForLocale(locale i18n.Locale) *NumberFormat {
+	if locale == i18n.NilLocale {
+		return nil
+	}
+
+	if cached, found := localeNumberFormats.Load(locale); found {
+		return cached.(*NumberFormat)
+	}
+
+	format := numberFormatForLocale(locale)
+	localeNumberFormats.Store(locale, format)
+	return format
+}
+
+func numberFormatForLocale(locale i18n.Locale) *NumberFormat {
+	tag, err := language.Parse(string(locale))
+	if err != nil {
+		return nil
+	}
+
+	// format a number which will include a grouping and a decimal symbol, and extract them
+	formatted := message.NewPrinter(tag).Sprint(number.Decimal(1234567.5, number.MinFractionDigits(1)))
+	symbols := strings.FieldsFunc(formatted, unicode.IsDigit)
+	if len(symbols) < 2 {
+		return nil
+	}
+
+	grouping, decimal := symbols[0], symbols[len(symbols)-1]
+
+	// locales which group with non-breaking spaces are more likely to see regular spaces in input
+	if strings.TrimSpace(grouping) == "" || grouping == " " {
+		grouping = " "
+	}
+
+	return &NumberFormat{DecimalSymbol: decimal, DigitGroupingSymbol: grouping}
+}
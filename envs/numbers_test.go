@@ -0,0 +1,33 @@
+package envs_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/gocommon/i18n"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumberFormatForLocale(t *testing.T) {
+	tcs := []struct {
+		locale   i18n.Locale
+		expected *envs.NumberFormat
+	}{
+		{"eng-US", &envs.NumberFormat{DecimalSymbol: ".", DigitGroupingSymbol: ","}},
+		{"eng", &envs.NumberFormat{DecimalSymbol: ".", DigitGroupingSymbol: ","}},
+		{"spa-EC", &envs.NumberFormat{DecimalSymbol: ",", DigitGroupingSymbol: "."}},
+		{"por-BR", &envs.NumberFormat{DecimalSymbol: ",", DigitGroupingSymbol: "."}},
+		{"fra-FR", &envs.NumberFormat{DecimalSymbol: ",", DigitGroupingSymbol: " "}},
+		{"deu-CH", &envs.NumberFormat{DecimalSymbol: ".", DigitGroupingSymbol: "’"}},
+		{"hin-IN", &envs.NumberFormat{DecimalSymbol: ".", DigitGroupingSymbol: ","}},
+		{"xyz", nil},
+		{i18n.NilLocale, nil},
+	}
+
+	for _, tc := range tcs {
+		assert.Equal(t, tc.expected, envs.NumberFormatForLocale(tc.locale), "number format mismatch for locale %s", tc.locale)
+
+		// check again to test caching
+		assert.Equal(t, tc.expected, envs.NumberFormatForLocale(tc.locale), "number format mismatch for locale %s", tc.locale)
+	}
+}
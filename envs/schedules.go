@@ -0,0 +1,19 @@
+package envs
+
+import "time"
+
+// Schedule is a set of opening hours which can be tested against points in time
+type Schedule interface {
+	IsOpen(Environment, time.Time) bool
+	NextOpen(Environment, time.Time) *time.Time
+}
+
+// ScheduleResolver is used to resolve schedules from names or UUIDs
+type ScheduleResolver interface {
+	FindSchedule(string) Schedule
+}
+
+// ScheduleEnvironment is optionally implemented by environments which can resolve schedules
+type ScheduleEnvironment interface {
+	ScheduleResolver() ScheduleResolver
+}
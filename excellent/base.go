@@ -9,96 +9,208 @@ import (
 	"github.com/nyaruka/goflow/excellent/types"
 )
 
-// Evaluator evaluates templates and expressions.
-type Evaluator struct{}
+// Evaluator evaluates templates and expressions, caching them in their parsed form so that templates
+// which are evaluated repeatedly only have to be parsed once. Evaluators don't limit the resources used evaluating
+// expressions unless limits are set with WithLimits. The zero value is an evaluator without caching or limits.
+type Evaluator struct {
+	templates   *lruCache[*compiledTemplate]
+	expressions *lruCache[*compiledExpression]
+	limits      *Limits
+}
+
+// DefaultCacheSize is the number of templates and expressions cached by an evaluator created with NewEvaluator
+const DefaultCacheSize = 1000
 
 // NewEvaluator creates a new evaluator
 func NewEvaluator() *Evaluator {
-	return &Evaluator{}
+	return NewEvaluatorWithCacheSize(DefaultCacheSize)
+}
+
+// NewEvaluatorWithCacheSize creates a new evaluator which caches up to the given number of templates and
+// expressions, where a size of zero disables caching
+func NewEvaluatorWithCacheSize(size int) *Evaluator {
+	return &Evaluator{
+		templates:   newLRUCache[*compiledTemplate](size),
+		expressions: newLRUCache[*compiledExpression](size),
+	}
+}
+
+// WithLimits returns a copy of this evaluator, sharing its caches, which limits the resources used evaluating each
+// expression to the given limits, where nil means no limits
+func (e *Evaluator) WithLimits(limits *Limits) *Evaluator {
+	return &Evaluator{templates: e.templates, expressions: e.expressions, limits: limits}
 }
 
 // Escaping is a function applied to expressions in a template after they've been evaluated
 type Escaping func(string) string
 
-// Template evaluates the passed in template
-func (e *Evaluator) Template(env envs.Environment, ctx *types.XObject, template string, escaping Escaping) (string, []string, error) {
+// Template evaluates the passed in template, escaping the values of expressions according to the given escaping mode
+// if that isn't nil
+func (e *Evaluator) Template(env envs.Environment, ctx *types.XObject, template string, escaping *EscapingMode) (string, []string, error) {
+	return e.template(env, ctx, template, escaping, nil)
+}
+
+// TraceTemplate is equivalent to Template but also returns a trace of the evaluation of each expression in the template
+func (e *Evaluator) TraceTemplate(env envs.Environment, ctx *types.XObject, template string, escaping *EscapingMode) (string, []string, []*TraceNode, error) {
+	t := newTracer()
+	value, warnings, err := e.template(env, ctx, template, escaping, t)
+	return value, warnings, t.traces(), err
+}
+
+func (e *Evaluator) template(env envs.Environment, ctx *types.XObject, template string, escaping *EscapingMode, t *tracer) (string, []string, error) {
+	// nothing todo for an empty template
+	if template == "" {
+		return "", nil, nil
+	}
+
+	topLevels := ctx.Properties()
+	compiled := e.templates.getOrCreate(templateKey(template, topLevels), func() *compiledTemplate {
+		return compileTemplate(template, topLevels)
+	})
+
 	var buf strings.Builder
 	var allWarnings []string
+	errors := NewTemplateErrors()
 
-	err := VisitTemplate(template, ctx.Properties(), true, func(tokenType XTokenType, token string) error {
-		switch tokenType {
+	// if values are only escaped inside strings in JSON, track where we are in the JSON as it's written
+	var json *jsonState
+	if escaping != nil && escaping.JSONStrings {
+		json = &jsonState{}
+	}
+
+	for _, part := range compiled.parts {
+		switch part.tokenType {
 		case BODY:
-			buf.WriteString(token)
+			buf.WriteString(part.token)
+			json.write(part.token)
 		case IDENTIFIER, EXPRESSION:
-			value, warnings := e.Expression(env, ctx, token)
+			value, warnings := e.evaluate(env, ctx, part.expression, t)
 
 			allWarnings = append(allWarnings, warnings...)
 
-			// if we got an error, return that
+			// if we got an error, record that
 			if types.IsXError(value) {
-				return value.(error)
+				errors.Add(tokenRepr(part.tokenType, part.token), value.(error).Error())
+				continue
 			}
 
 			// if not, stringify value and append to the output
 			asText, _ := types.ToXText(env, value)
 			asString := asText.Native()
 
-			if escaping != nil {
-				asString = escaping(asString)
+			if escaping != nil && (json == nil || json.inString()) {
+				asString = escaping.Escaping(asString)
 			}
 
 			buf.WriteString(asString)
+			json.write(asString)
 		}
-		return nil
-	})
+	}
 
-	return buf.String(), allWarnings, err
+	if errors.HasErrors() {
+		return buf.String(), allWarnings, errors
+	}
+	return buf.String(), allWarnings, nil
 }
 
 // TemplateValue is equivalent to Template except in the case where the template contains
 // a single identifier or expression, ie: "@contact" or "@(first(contact.urns))". In these cases we return
 // the typed value from EvaluateExpression instead of stringifying the result.
 func (e *Evaluator) TemplateValue(env envs.Environment, ctx *types.XObject, template string) (types.XValue, []string, error) {
-	template = strings.TrimSpace(template)
-	scanner := NewXScanner(strings.NewReader(template), ctx.Properties())
+	return e.templateValue(env, ctx, template, nil)
+}
+
+// TraceTemplateValue is equivalent to TemplateValue but also returns a trace of the evaluation of each expression in the template
+func (e *Evaluator) TraceTemplateValue(env envs.Environment, ctx *types.XObject, template string) (types.XValue, []string, []*TraceNode, error) {
+	t := newTracer()
+	value, warnings, err := e.templateValue(env, ctx, template, t)
+	return value, warnings, t.traces(), err
+}
 
-	// parse our first token
-	tokenType, token := scanner.Scan()
+func (e *Evaluator) templateValue(env envs.Environment, ctx *types.XObject, template string, t *tracer) (types.XValue, []string, error) {
+	template = strings.TrimSpace(template)
 
-	// try to scan to our next token
-	nextTT, _ := scanner.Scan()
+	if template != "" {
+		topLevels := ctx.Properties()
+		compiled := e.templates.getOrCreate(templateKey(template, topLevels), func() *compiledTemplate {
+			return compileTemplate(template, topLevels)
+		})
 
-	// if we only have an identifier or an expression, evaluate it on its own
-	if nextTT == EOF {
-		switch tokenType {
-		case IDENTIFIER, EXPRESSION:
-			val, warnings := e.Expression(env, ctx, token)
+		// if we only have an identifier or an expression, evaluate it on its own
+		if len(compiled.parts) == 1 && compiled.parts[0].tokenType != BODY {
+			val, warnings := e.evaluate(env, ctx, compiled.parts[0].expression, t)
 			return val, warnings, nil
 		}
 	}
 
 	// otherwise fallback to full template evaluation
-	asStr, warnings, err := e.Template(env, ctx, template, nil)
+	asStr, warnings, err := e.template(env, ctx, template, nil, t)
 	return types.NewXText(asStr), warnings, err
 }
 
 // Expression evalutes the passed in Excellent expression, returning the typed value it evaluates to,
 // which might be an error, e.g. "2 / 3" or "contact.fields.age"
 func (e *Evaluator) Expression(env envs.Environment, ctx *types.XObject, expression string) (types.XValue, []string) {
-	parsed, err := Parse(expression, nil)
-	if err != nil {
-		return types.NewXError(err), nil
+	compiled := e.expressions.getOrCreate(expression, func() *compiledExpression {
+		return compileExpression(expression)
+	})
+
+	return e.evaluate(env, ctx, compiled, nil)
+}
+
+// evaluates a compiled expression, recording a trace of the evaluation to the given tracer if that isn't nil
+func (e *Evaluator) evaluate(env envs.Environment, ctx *types.XObject, compiled *compiledExpression, t *tracer) (types.XValue, []string) {
+	if compiled.err != nil {
+		if t != nil {
+			t.add(&TraceNode{Expression: compiled.source, Error: compiled.err.Error()})
+		}
+		return types.NewXError(compiled.err), nil
+	}
+
+	parsed := compiled.parsed
+	if t != nil {
+		parsed = traceTree(parsed, t)
 	}
 
 	scope := NewScope(ctx, nil)
+	scope.budget = newBudget(e.limits)
+
+	// functions which can create large values check their size against our budget before creating them
+	if scope.budget != nil {
+		env = &budgetedEnvironment{Environment: env, budget: scope.budget}
+	}
 
 	warnings := &Warnings{}
 
-	return parsed.Evaluate(env, scope, warnings), warnings.all
+	value := parsed.Evaluate(env, scope, warnings)
+
+	// if we went over budget, that's our result even if something like a coalesce swallowed the error
+	if scope.budget != nil && scope.budget.exceeded != nil {
+		value = scope.budget.exceeded
+	}
+
+	return value, warnings.all
 }
 
+// Positions maps the nodes of a parsed expression to their offsets in runes within that expression
+type Positions map[Expression]int
+
 // Parse parses an expression
 func Parse(expression string, contextCallback func([]string)) (Expression, error) {
+	return parse(expression, contextCallback, nil)
+}
+
+// ParseWithPositions parses an expression and also returns the position of each node in the tree
+func ParseWithPositions(expression string) (Expression, Positions, error) {
+	positions := make(Positions)
+	parsed, err := parse(expression, nil, positions)
+	if err != nil {
+		return nil, nil, err
+	}
+	return parsed, positions, nil
+}
+
+func parse(expression string, contextCallback func([]string), positions Positions) (Expression, error) {
 	errListener := NewErrorListener(expression)
 
 	input := antlr.NewInputStream(expression)
@@ -114,7 +226,7 @@ func Parse(expression string, contextCallback func([]string)) (Expression, error
 		return nil, errListener.Errors()[0]
 	}
 
-	visitor := &visitor{contextCallback: contextCallback}
+	visitor := &visitor{contextCallback: contextCallback, positions: positions}
 	output := visitor.Visit(tree)
 	return toExpression(output), nil
 }
@@ -133,14 +245,7 @@ func VisitTemplate(template string, allowedTopLevels []string, unescapeBody bool
 	for tokenType, token := scanner.Scan(); tokenType != EOF; tokenType, token = scanner.Scan() {
 		err := callback(tokenType, token)
 		if err != nil {
-			var repr string
-			if tokenType == IDENTIFIER {
-				repr = "@" + token
-			} else {
-				repr = "@(" + token + ")"
-			}
-
-			errors.Add(repr, err.Error())
+			errors.Add(tokenRepr(tokenType, token), err.Error())
 		}
 	}
 
@@ -150,6 +255,14 @@ func VisitTemplate(template string, allowedTopLevels []string, unescapeBody bool
 	return nil
 }
 
+// gets the representation of an identifier or expression token as it appears in a template
+func tokenRepr(tokenType XTokenType, token string) string {
+	if tokenType == IDENTIFIER {
+		return "@" + token
+	}
+	return "@(" + token + ")"
+}
+
 // HasExpressions returns whether the given template contains any expressions or identifiers
 func HasExpressions(template string, allowedTopLevels []string) bool {
 	found := false
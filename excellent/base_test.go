@@ -35,6 +35,31 @@ func TestParse(t *testing.T) {
 	assert.EqualError(t, err, "syntax error at )")
 }
 
+func TestParseWithPositions(t *testing.T) {
+	exp, positions, err := excellent.ParseWithPositions(`upper(contact.name) & " é " & (foo[0])`)
+	assert.NoError(t, err)
+
+	found := make(map[string]int)
+	exp.Visit(func(x excellent.Expression) { found[x.String()] = positions[x] })
+
+	assert.Equal(t, map[string]int{
+		`upper`:                       0,
+		`contact`:                     6,
+		`contact.name`:                14, // position of a dot lookup is its property name
+		`upper(contact.name)`:         0,
+		`" é "`:                       22,
+		`upper(contact.name) & " é "`: 0,
+		`foo`:                         31,
+		`0`:                           35,
+		`foo[0]`:                      31,
+		`(foo[0])`:                    30,
+		`upper(contact.name) & " é " & (foo[0])`: 0,
+	}, found)
+
+	_, _, err = excellent.ParseWithPositions(`(foo +)`)
+	assert.EqualError(t, err, "syntax error at )")
+}
+
 func TestEvaluateTemplateValue(t *testing.T) {
 	array1d := types.NewXArray(types.NewXText("a"), types.NewXText("b"), types.NewXText("c"))
 	array2d := types.NewXArray(array1d, types.NewXArray(types.NewXText("one"), types.NewXText("two"), types.NewXText("three")))
@@ -168,6 +193,42 @@ func TestEvaluateTemplateValue(t *testing.T) {
 		{"@(null = NULL)", types.XBooleanTrue},
 		{"@(null != NULL)", types.XBooleanFalse},
 
+		// logical operators
+		{"@(!true)", types.XBooleanFalse},
+		{`@(!"")`, types.XBooleanTrue},
+		{"@(!missing)", ERROR},
+		{"@(true && 1)", types.XBooleanTrue},
+		{"@(true && 0)", types.XBooleanFalse},
+		{"@(false && missing)", types.XBooleanFalse}, // second operand not considered
+		{"@(missing && true)", ERROR},
+		{"@(false || string1)", types.XBooleanTrue},
+		{`@(false || "")`, types.XBooleanFalse},
+		{"@(true || missing)", types.XBooleanTrue},
+		{"@(true || false && false)", types.XBooleanTrue}, // && binds tighter than ||
+		{"@(!false && int1 > int2)", types.XBooleanFalse},
+
+		// coalescing and conditionals
+		{"@(missing ?? string1)", xs("foo")},
+		{"@(null ?? string1)", xs("foo")},
+		{"@(string2 ?? string1)", xs("bar")},
+		{`@("" ?? string1)`, xs("")},
+		{"@(missing ?? null ?? int1)", xi(1)},
+		{"@(int1 < int2 ? string1 : string2)", xs("foo")},
+		{"@(int1 > int2 ? string1 : string2)", xs("bar")},
+		{`@(int1 = 2 ? "a" : int1 = 1 ? "b" : "c")`, xs("b")}, // right associative
+		{`@(missing ? "a" : "b")`, ERROR},
+
+		// array and object literals
+		{"@([])", types.NewXArray()},
+		{`@([int1, "x", true])`, types.NewXArray(xi(1), xs("x"), types.XBooleanTrue)},
+		{`@([int1, "x", true][-1])`, types.XBooleanTrue},
+		{"@([1, missing])", ERROR},
+		{"@({})", types.NewXObject(map[string]types.XValue{})},
+		{`@({"a": int1, "b c": [string1]})`, types.NewXObject(map[string]types.XValue{"a": xi(1), "b c": types.NewXArray(xs("foo"))})},
+		{`@({"a": int1}.a)`, xi(1)},
+		{`@({"a": int1}["b"])`, nil},
+		{`@({"a": missing})`, ERROR},
+
 		{"@(\"foo\" & \"bar\")", xs("foobar")},
 		{"@(missing & \"bar\")", ERROR},
 		{"@(\"foo\" & missing)", ERROR},
@@ -355,9 +416,9 @@ func TestEvaluateTemplateWithEscaping(t *testing.T) {
 		"string1": types.NewXText(`""; DROP`),
 	})
 
-	escaping := func(s string) string {
+	escaping := excellent.NewEscapingMode(func(s string) string {
 		return strings.Replace(s, `"`, `\"`, -1)
-	}
+	})
 
 	eval := excellent.NewEvaluator()
 	env := envs.NewBuilder().Build()
@@ -469,3 +530,171 @@ func TestHasExpressions(t *testing.T) {
 	assert.True(t, excellent.HasExpressions("hi @foo.x", topLevels))
 	assert.True(t, excellent.HasExpressions("hi @(foo)", topLevels))
 }
+
+func TestTraceTemplate(t *testing.T) {
+	env := envs.NewBuilder().Build()
+	old := xs("old")
+	old.SetDeprecated("old")
+	ctx := types.NewXObject(map[string]types.XValue{
+		"foo":  xs("bar"),
+		"nums": types.NewXArray(xi(1), xi(2)),
+		"old":  old,
+	})
+
+	eval := excellent.NewEvaluator()
+
+	output, warnings, traces, err := eval.TraceTemplate(env, ctx, `Hi @foo @(upper(old) & "!") @(foreach(nums, (n) => n * 2)) @(1 / 0) @(1 +)`, nil)
+	assert.Equal(t, `Hi bar OLD! [2, 4]  `, output)
+	assert.Equal(t, []string{"deprecated context value accessed: old"}, warnings)
+	assert.EqualError(t, err, "error evaluating @(1 / 0): division by zero, error evaluating @(1 +): syntax error at ")
+	assert.Equal(t, []*excellent.TraceNode{
+		{Expression: "foo", Value: `"bar"`},
+		{Expression: `upper(old) & "!"`, Value: `"OLD!"`, Warnings: []string{"deprecated context value accessed: old"}, Children: []*excellent.TraceNode{
+			{Expression: "upper(old)", Value: `"OLD"`, Warnings: []string{"deprecated context value accessed: old"}, Children: []*excellent.TraceNode{
+				{Expression: "upper", Value: "upper(...)"},
+				{Expression: "old", Value: `"old"`, Warnings: []string{"deprecated context value accessed: old"}},
+			}},
+			{Expression: `"!"`, Value: `"!"`},
+		}},
+		{Expression: "foreach(nums, (n) => n * 2)", Value: "[2,4]", Children: []*excellent.TraceNode{
+			{Expression: "foreach", Value: "foreach(...)"},
+			{Expression: "nums", Value: "[1,2]"},
+			{Expression: "(n) => n * 2", Value: "<anon>(...)"},
+			{Expression: "n * 2", Value: "2", Children: []*excellent.TraceNode{{Expression: "n", Value: "1"}, {Expression: "2", Value: "2"}}},
+			{Expression: "n * 2", Value: "4", Children: []*excellent.TraceNode{{Expression: "n", Value: "2"}, {Expression: "2", Value: "2"}}},
+		}},
+		{Expression: "1 / 0", Error: "division by zero", Children: []*excellent.TraceNode{{Expression: "1", Value: "1"}, {Expression: "0", Value: "0"}}},
+		{Expression: "1 +", Error: "syntax error at "},
+	}, traces)
+
+	// tracing a single expression template gives us the typed value
+	value, _, traces, err := eval.TraceTemplateValue(env, ctx, `@(nums[1])`)
+	assert.NoError(t, err)
+	assert.Equal(t, xi(2), value)
+	assert.Equal(t, []*excellent.TraceNode{
+		{Expression: "nums[1]", Value: "2", Children: []*excellent.TraceNode{{Expression: "nums", Value: "[1,2]"}, {Expression: "1", Value: "1"}}},
+	}, traces)
+
+	// operands and branches which aren't needed aren't evaluated
+	for _, tc := range []struct {
+		template string
+		value    types.XValue
+		children []string
+	}{
+		{`@(false && upper(foo))`, types.XBooleanFalse, []string{"false"}},
+		{`@(true || upper(foo))`, types.XBooleanTrue, []string{"true"}},
+		{`@(foo ?? upper(foo))`, xs("bar"), []string{"foo"}},
+		{`@(true ? foo : upper(foo))`, xs("bar"), []string{"true", "foo"}},
+		{`@(false ? upper(foo) : foo)`, xs("bar"), []string{"false", "foo"}},
+		{`@(true && upper(foo))`, types.XBooleanTrue, []string{"true", "upper(foo)"}},
+		{`@(1 / 0 ?? foo)`, xs("bar"), []string{"1 / 0", "foo"}},
+	} {
+		value, _, traces, err := eval.TraceTemplateValue(env, ctx, tc.template)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.value, value, "value mismatch for %s", tc.template)
+
+		children := make([]string, len(traces[0].Children))
+		for i, c := range traces[0].Children {
+			children[i] = c.Expression
+		}
+		assert.Equal(t, tc.children, children, "evaluated operands mismatch for %s", tc.template)
+	}
+
+	// tracing shouldn't affect later untraced evaluation of the same cached template
+	output, _, err = eval.Template(env, ctx, `@(foreach(nums, (n) => n * 2))`, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "[2, 4]", output)
+
+	// long values are truncated
+	_, _, traces, err = eval.TraceTemplateValue(env, ctx, `@(repeat("x", 300))`)
+	assert.NoError(t, err)
+	assert.Equal(t, `"`+strings.Repeat("x", 196)+"...", traces[0].Value)
+
+	// and traces stop recording once they have too many nodes, without affecting evaluation
+	value, _, traces, err = eval.TraceTemplateValue(env, ctx, `@(count(foreach(split(repeat("a ", 400), " "), (w) => upper(w))))`)
+	assert.NoError(t, err)
+	assert.Equal(t, xi(400), value)
+
+	var countNodes func([]*excellent.TraceNode) int
+	countNodes = func(nodes []*excellent.TraceNode) int {
+		n := len(nodes)
+		for _, node := range nodes {
+			n += countNodes(node.Children)
+		}
+		return n
+	}
+	assert.Equal(t, 500, countNodes(traces))
+}
+
+func TestEvaluationLimits(t *testing.T) {
+	env := envs.NewBuilder().Build()
+	ctx := types.NewXObject(map[string]types.XValue{
+		"nums": types.NewXArray(xi(1), xi(2), xi(3)),
+	})
+
+	eval := excellent.NewEvaluator().WithLimits(&excellent.Limits{MaxOperations: 30, MaxValueLength: 20, MaxDepth: 2})
+
+	tcs := []struct {
+		template string
+		output   string
+		err      string
+	}{
+		{`@(foreach(nums, (n) => n * 2))`, `[2, 4, 6]`, ""},
+		{`@(join(foreach(nums, (n) => upper("x")), ""))`, `XXX`, ""},
+		{`@(foreach(foreach(nums, (n) => n), (n) => upper(n & n & n)))`, ``, "error evaluating @(foreach(foreach(nums, (n) => n), (n) => upper(n & n & n))): evaluation budget exceeded: more than 30 operations"},
+		{`@(1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1)`, `15`, ""},
+		{`@(1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1)`, ``, "error evaluating @(1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1 + 1): evaluation budget exceeded: more than 30 operations"},
+		{`@(repeat("x", 20))`, `xxxxxxxxxxxxxxxxxxxx`, ""},
+		{`@(repeat("x", 21))`, ``, "error evaluating @(repeat(\"x\", 21)): evaluation budget exceeded: text longer than 20 characters"},
+		{`@(repeat("x", 1000000000))`, ``, "error evaluating @(repeat(\"x\", 1000000000)): evaluation budget exceeded: text longer than 20 characters"},
+		{`@(pad_left("7", 20, "0"))`, `00000000000000000007`, ""},
+		{`@(pad_left("7", 2000000000, "0"))`, ``, "error evaluating @(pad_left(\"7\", 2000000000, \"0\")): evaluation budget exceeded: text longer than 20 characters"},
+		{`@(pad_right("7", 21))`, ``, "error evaluating @(pad_right(\"7\", 21)): evaluation budget exceeded: text longer than 20 characters"},
+		{`@(join(array("a", "b", "c"), repeat("-", 8)))`, `a--------b--------c`, ""},
+		{`@(join(array("a", "b", "c"), repeat("-", 10)))`, ``, "error evaluating @(join(array(\"a\", \"b\", \"c\"), repeat(\"-\", 10))): evaluation budget exceeded: text longer than 20 characters"},
+		{`@(repeat("x", 11) & repeat("x", 10))`, ``, "error evaluating @(repeat(\"x\", 11) & repeat(\"x\", 10)): evaluation budget exceeded: text longer than 20 characters"},
+		{`@({"a": repeat("x", 21)})`, ``, "error evaluating @({\"a\": repeat(\"x\", 21)}): evaluation budget exceeded: text longer than 20 characters"},
+		{`@({"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6, "g": 7, "h": 8, "i": 9, "j": 10, "k": 11, "l": 12, "m": 13, "n": 14, "o": 15, "p": 16, "q": 17, "r": 18, "s": 19, "t": 20, "u": 21})`, ``, "error evaluating @({\"a\": 1, \"b\": 2, \"c\": 3, \"d\": 4, \"e\": 5, \"f\": 6, \"g\": 7, \"h\": 8, \"i\": 9, \"j\": 10, \"k\": 11, \"l\": 12, \"m\": 13, \"n\": 14, \"o\": 15, \"p\": 16, \"q\": 17, \"r\": 18, \"s\": 19, \"t\": 20, \"u\": 21}): evaluation budget exceeded: object with more than 20 properties"},
+		{`@(array(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21))`, ``, "error evaluating @(array(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21)): evaluation budget exceeded: array with more than 20 items"},
+		{`@(foreach(nums, (a) => foreach(nums, (b) => b)))`, `[[1, 2, 3], [1, 2, 3], [1, 2, 3]]`, ""},
+		{`@(foreach([1], (a) => foreach([1], (b) => foreach([1], (c) => c))))`, ``, "error evaluating @(foreach([1], (a) => foreach([1], (b) => foreach([1], (c) => c)))): evaluation budget exceeded: anonymous functions nested more than 2 deep"},
+
+		// errors from exceeding the budget can't be swallowed
+		{`@(default(repeat("x", 30), "y"))`, ``, "error evaluating @(default(repeat(\"x\", 30), \"y\")): evaluation budget exceeded: text longer than 20 characters"},
+		{`@(repeat("x", 30) ?? "y")`, ``, "error evaluating @(repeat(\"x\", 30) ?? \"y\"): evaluation budget exceeded: text longer than 20 characters"},
+	}
+
+	for _, tc := range tcs {
+		output, _, err := eval.Template(env, ctx, tc.template, nil)
+
+		assert.Equal(t, tc.output, output, "output mismatch for template: %s", tc.template)
+		if tc.err != "" {
+			assert.EqualError(t, err, tc.err, "error mismatch for template: %s", tc.template)
+		} else {
+			assert.NoError(t, err, "unexpected error for template: %s", tc.template)
+		}
+	}
+
+	// budget errors wrap a typed error
+	value, _ := eval.Expression(env, ctx, `repeat("x", 21)`)
+	assert.ErrorIs(t, value.(error), excellent.ErrBudgetExceeded)
+
+	// evaluators have no limits unless they're set
+	output, _, err := excellent.NewEvaluator().Template(env, ctx, `@(text_length(repeat("x", 1000001)))`, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "1000001", output)
+
+	// limits can be removed entirely
+	unlimited := eval.WithLimits(nil)
+	output, _, err = unlimited.Template(env, ctx, `@(text_length(repeat("x", 50000) & repeat("x", 50000)))`, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "100000", output)
+
+	// without changing the evaluator it was copied from
+	_, _, err = eval.Template(env, ctx, `@(repeat("x", 21))`, nil)
+	assert.EqualError(t, err, "error evaluating @(repeat(\"x\", 21)): evaluation budget exceeded: text longer than 20 characters")
+
+	// default limits can't be changed by modifying them
+	excellent.DefaultLimits().MaxValueLength = 5
+	assert.Equal(t, 1000000, excellent.DefaultLimits().MaxValueLength)
+}
@@ -0,0 +1,153 @@
+package excellent
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent/types"
+)
+
+// ErrBudgetExceeded is the error wrapped by errors returned when evaluation of an expression exceeds its limits
+var ErrBudgetExceeded = errors.New("evaluation budget exceeded")
+
+// Limits are the resources an expression can use during evaluation, where zero means no limit
+type Limits struct {
+	MaxOperations  int // the maximum number of expressions evaluated, e.g. operators, lookups and function calls
+	MaxValueLength int // the maximum length of intermediate text values and items in arrays and objects
+	MaxDepth       int // the maximum nesting of anonymous function calls
+}
+
+// DefaultLimits returns the limits used by engines unless others are set
+func DefaultLimits() *Limits {
+	return &Limits{
+		MaxOperations:  100000,
+		MaxValueLength: 1000000,
+		MaxDepth:       20,
+	}
+}
+
+// tracks the resources used evaluating a single expression
+type budget struct {
+	limits     *Limits
+	operations int
+	depth      int
+	exceeded   *types.XError
+}
+
+func newBudget(limits *Limits) *budget {
+	if limits == nil {
+		return nil
+	}
+	return &budget{limits: limits}
+}
+
+func (b *budget) exceed(format string, args ...any) *types.XError {
+	if b.exceeded == nil {
+		b.exceeded = types.NewXError(fmt.Errorf("%w: %s", ErrBudgetExceeded, fmt.Sprintf(format, args...)))
+	}
+	return b.exceeded
+}
+
+// records an operation, returning an error if that takes us over our limit
+func (b *budget) operation() *types.XError {
+	if b == nil {
+		return nil
+	}
+	if b.exceeded != nil {
+		return b.exceeded
+	}
+
+	b.operations++
+	if b.limits.MaxOperations > 0 && b.operations > b.limits.MaxOperations {
+		return b.exceed("more than %d operations", b.limits.MaxOperations)
+	}
+	return nil
+}
+
+// records entering an anonymous function call, returning an error if that takes us over our limit
+func (b *budget) enter() *types.XError {
+	if b == nil {
+		return nil
+	}
+	if b.exceeded != nil {
+		return b.exceeded
+	}
+
+	b.depth++
+	if b.limits.MaxDepth > 0 && b.depth > b.limits.MaxDepth {
+		return b.exceed("anonymous functions nested more than %d deep", b.limits.MaxDepth)
+	}
+	return nil
+}
+
+// records leaving an anonymous function call
+func (b *budget) exit() {
+	if b != nil {
+		b.depth--
+	}
+}
+
+// checks the size of an intermediate value, returning it or an error if it's over our limit
+func (b *budget) check(v types.XValue) types.XValue {
+	if b == nil || b.limits.MaxValueLength <= 0 {
+		return v
+	}
+
+	switch typed := v.(type) {
+	case *types.XText:
+		if len(typed.Native()) > b.limits.MaxValueLength {
+			if xerr := b.checkLength(utf8.RuneCountInString(typed.Native())); xerr != nil {
+				return xerr
+			}
+		}
+	case *types.XArray:
+		if typed.Count() > b.limits.MaxValueLength {
+			return b.exceed("array with more than %d items", b.limits.MaxValueLength)
+		}
+	case *types.XObject:
+		if typed.Count() > b.limits.MaxValueLength {
+			return b.exceed("object with more than %d properties", b.limits.MaxValueLength)
+		}
+	}
+	return v
+}
+
+// checks the length of a text value before it's created, returning an error if it would be over our limit
+func (b *budget) checkLength(length int) *types.XError {
+	if b == nil || b.limits.MaxValueLength <= 0 {
+		return nil
+	}
+	if b.exceeded != nil {
+		return b.exceeded
+	}
+	if length > b.limits.MaxValueLength {
+		return b.exceed("text longer than %d characters", b.limits.MaxValueLength)
+	}
+	return nil
+}
+
+// an environment which lets functions check the lengths of values against the budget before creating them
+type budgetedEnvironment struct {
+	envs.Environment
+
+	budget *budget
+}
+
+// WeekStart returns the day that weeks start on in the wrapped environment
+func (e *budgetedEnvironment) WeekStart() time.Weekday { return envs.WeekStartOf(e.Environment) }
+
+// ScheduleResolver returns the schedule resolver of the wrapped environment if it has one
+func (e *budgetedEnvironment) ScheduleResolver() envs.ScheduleResolver {
+	if se, ok := e.Environment.(envs.ScheduleEnvironment); ok {
+		return se.ScheduleResolver()
+	}
+	return nil
+}
+
+// CheckLength returns an error if a text value of the given length would be over our limit
+func (e *budgetedEnvironment) CheckLength(length int) *types.XError {
+	return e.budget.checkLength(length)
+}
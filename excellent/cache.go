@@ -0,0 +1,116 @@
+package excellent
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+)
+
+// a bounded and thread safe cache which evicts the least recently used item when full
+type lruCache[V any] struct {
+	mutex    sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+type lruEntry[V any] struct {
+	key   string
+	value V
+}
+
+func newLRUCache[V any](capacity int) *lruCache[V] {
+	return &lruCache[V]{capacity: capacity, items: make(map[string]*list.Element, capacity), order: list.New()}
+}
+
+// gets the item with the given key, creating it with the given function if it isn't cached. A nil cache caches nothing.
+func (c *lruCache[V]) getOrCreate(key string, create func() V) V {
+	if c == nil || c.capacity <= 0 {
+		return create()
+	}
+
+	c.mutex.Lock()
+	if e, found := c.items[key]; found {
+		c.order.MoveToFront(e)
+		c.mutex.Unlock()
+		return e.Value.(*lruEntry[V]).value
+	}
+	c.mutex.Unlock()
+
+	// create outside of the lock as parsing can be slow.. worst case two goroutines do the same work
+	value := create()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if e, found := c.items[key]; found {
+		c.order.MoveToFront(e)
+		return e.Value.(*lruEntry[V]).value
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry[V]{key: key, value: value})
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry[V]).key)
+	}
+
+	return value
+}
+
+// returns the number of cached items
+func (c *lruCache[V]) len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.order.Len()
+}
+
+// an expression which has been parsed, or the error from trying to parse it
+type compiledExpression struct {
+	source string
+	parsed Expression
+	err    error
+}
+
+func compileExpression(expression string) *compiledExpression {
+	parsed, err := Parse(expression, nil)
+	return &compiledExpression{source: expression, parsed: parsed, err: err}
+}
+
+// a piece of a template which is either body text or an identifier or expression
+type templatePart struct {
+	tokenType  XTokenType
+	token      string
+	expression *compiledExpression
+}
+
+// a template which has been scanned into parts and had its expressions parsed
+type compiledTemplate struct {
+	parts []templatePart
+}
+
+func compileTemplate(template string, allowedTopLevels []string) *compiledTemplate {
+	parts := make([]templatePart, 0, 3)
+
+	VisitTemplate(template, allowedTopLevels, true, func(tokenType XTokenType, token string) error {
+		part := templatePart{tokenType: tokenType, token: token}
+		if tokenType == IDENTIFIER || tokenType == EXPRESSION {
+			part.expression = compileExpression(token)
+		}
+		parts = append(parts, part)
+		return nil
+	})
+
+	return &compiledTemplate{parts: parts}
+}
+
+// returns the cache key for a template which also depends on the top levels which can be identifiers
+func templateKey(template string, allowedTopLevels []string) string {
+	return strings.Join(allowedTopLevels, ",") + "\x00" + template
+}
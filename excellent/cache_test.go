@@ -0,0 +1,107 @@
+package excellent
+
+import (
+	"testing"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUCache(t *testing.T) {
+	created := 0
+	create := func(v string) func() string {
+		return func() string {
+			created++
+			return v
+		}
+	}
+
+	cache := newLRUCache[string](2)
+	assert.Equal(t, "A", cache.getOrCreate("a", create("A")))
+	assert.Equal(t, "B", cache.getOrCreate("b", create("B")))
+	assert.Equal(t, "A", cache.getOrCreate("a", create("X")))
+	assert.Equal(t, 2, created)
+	assert.Equal(t, 2, cache.len())
+
+	// adding c should evict b as a was used more recently
+	assert.Equal(t, "C", cache.getOrCreate("c", create("C")))
+	assert.Equal(t, 2, cache.len())
+	assert.Equal(t, "A", cache.getOrCreate("a", create("X")))
+	assert.Equal(t, "B2", cache.getOrCreate("b", create("B2")))
+	assert.Equal(t, 4, created)
+
+	// zero capacity means nothing is cached
+	cache = newLRUCache[string](0)
+	assert.Equal(t, "A", cache.getOrCreate("a", create("A")))
+	assert.Equal(t, "A2", cache.getOrCreate("a", create("A2")))
+	assert.Equal(t, 0, cache.len())
+
+	// as does a nil cache
+	cache = nil
+	assert.Equal(t, "A", cache.getOrCreate("a", create("A")))
+	assert.Equal(t, "A3", cache.getOrCreate("a", create("A3")))
+	assert.Equal(t, 0, cache.len())
+}
+
+func TestEvaluatorCaching(t *testing.T) {
+	env := envs.NewBuilder().Build()
+	ctx := types.NewXObject(map[string]types.XValue{
+		"foo": types.NewXText("bar"),
+		"num": types.NewXNumberFromInt(3),
+	})
+
+	cached := NewEvaluator()
+	uncached := NewEvaluatorWithCacheSize(0)
+
+	templates := []string{
+		``,
+		`Hello`,
+		`@foo`,
+		` @num `,
+		`@(num + 1) @foo @@foo @(foo`,
+		`@(1 / 0) and @xxx and @(num * 2)`,
+		`@(upper(foo)`,
+	}
+
+	// results should be identical whether or not templates were cached, and on repeated evaluation
+	for i := 0; i < 2; i++ {
+		for _, template := range templates {
+			expected, expectedWarnings, expectedErr := uncached.Template(env, ctx, template, nil)
+			actual, actualWarnings, actualErr := cached.Template(env, ctx, template, nil)
+
+			assert.Equal(t, expected, actual, "output mismatch for template: %s", template)
+			assert.Equal(t, expectedWarnings, actualWarnings, "warnings mismatch for template: %s", template)
+			assert.Equal(t, expectedErr, actualErr, "error mismatch for template: %s", template)
+
+			expectedVal, _, expectedErr := uncached.TemplateValue(env, ctx, template)
+			actualVal, _, actualErr := cached.TemplateValue(env, ctx, template)
+
+			assert.Equal(t, expectedVal, actualVal, "value mismatch for template: %s", template)
+			assert.Equal(t, expectedErr, actualErr, "error mismatch for template: %s", template)
+		}
+	}
+
+	// a zero value evaluator doesn't cache
+	zero := &Evaluator{}
+	for _, template := range templates {
+		expected, _, expectedErr := uncached.Template(env, ctx, template, nil)
+		actual, _, actualErr := zero.Template(env, ctx, template, nil)
+
+		assert.Equal(t, expected, actual, "output mismatch for template: %s", template)
+		assert.Equal(t, expectedErr, actualErr, "error mismatch for template: %s", template)
+	}
+	val, _ := zero.Expression(env, ctx, "num + 2")
+	assert.Equal(t, types.NewXNumberFromInt(5), val)
+	assert.Equal(t, 0, zero.templates.len())
+
+	// " @num " is trimmed to "@num" by TemplateValue so cached separately
+	assert.Equal(t, 7, cached.templates.len())
+	assert.Equal(t, 0, uncached.templates.len())
+
+	val, _ = cached.Expression(env, ctx, "num + 2")
+	assert.Equal(t, types.NewXNumberFromInt(5), val)
+	val, _ = cached.Expression(env, ctx, "num + ")
+	assert.Equal(t, types.NewXErrorf("syntax error at "), val)
+	assert.Equal(t, 2, cached.expressions.len())
+}
@@ -0,0 +1,95 @@
+package excellent
+
+import (
+	"html"
+	"unicode"
+
+	"github.com/nyaruka/gocommon/jsonx"
+)
+
+// EscapingMode is a way of escaping the values of expressions in a template
+type EscapingMode struct {
+	// the escaping applied to values
+	Escaping Escaping
+
+	// whether values are only escaped when they're inserted inside strings in JSON
+	JSONStrings bool
+}
+
+// NewEscapingMode creates a new escaping mode which applies the given escaping to all values
+func NewEscapingMode(escaping Escaping) *EscapingMode {
+	return &EscapingMode{Escaping: escaping}
+}
+
+// named escaping modes which can be selected for evaluated fields with engine tags
+var escapingModes = map[string]*EscapingMode{}
+
+func init() {
+	RegisterEscapingMode("json", JSONEscapingMode)
+	RegisterEscapingMode("ssml", NewEscapingMode(XMLEscaping))
+}
+
+// RegisterEscapingMode registers a new named escaping mode
+func RegisterEscapingMode(name string, mode *EscapingMode) {
+	escapingModes[name] = mode
+}
+
+// LookupEscapingMode returns the escaping mode with the given name or nil
+func LookupEscapingMode(name string) *EscapingMode {
+	return escapingModes[name]
+}
+
+// JSONEscapingMode escapes values inserted inside strings in a JSON object or array, and leaves other values, e.g. from
+// @(json(...)), unchanged so that they can still be used to insert JSON values
+var JSONEscapingMode = &EscapingMode{Escaping: JSONEscaping, JSONStrings: true}
+
+// JSONEscaping escapes values for use inside strings in JSON
+func JSONEscaping(s string) string {
+	quoted := string(jsonx.MustMarshal(s))
+	return quoted[1 : len(quoted)-1]
+}
+
+// tracks whether the output of a template is inside a string in JSON as it's written, so that the output doesn't have
+// to be rescanned for each value
+type jsonState struct {
+	started bool // whether we've seen any non-whitespace
+	isJSON  bool // whether the output starts like a JSON object or array
+	inside  bool // whether we're inside a string
+	escaped bool // whether the previous character was an escaping backslash
+}
+
+func (s *jsonState) write(text string) {
+	if s == nil || (s.started && !s.isJSON) {
+		return
+	}
+
+	for _, r := range text {
+		if !s.started {
+			if unicode.IsSpace(r) {
+				continue
+			}
+			s.started = true
+			s.isJSON = r == '{' || r == '['
+			if !s.isJSON {
+				return
+			}
+		}
+
+		if s.escaped {
+			s.escaped = false
+		} else if s.inside && r == '\\' {
+			s.escaped = true
+		} else if r == '"' {
+			s.inside = !s.inside
+		}
+	}
+}
+
+func (s *jsonState) inString() bool {
+	return s.isJSON && s.inside
+}
+
+// XMLEscaping escapes values for use in XML text and attributes, including SSML for IVR
+func XMLEscaping(s string) string {
+	return html.EscapeString(s)
+}
@@ -0,0 +1,62 @@
+package excellent_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent"
+	"github.com/nyaruka/goflow/excellent/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapings(t *testing.T) {
+	tcs := []struct {
+		escaping string
+		value    string
+		escaped  string
+	}{
+		{"json", `say "hi" \ <b>`, `say \"hi\" \\ <b>`},
+		{"json", "a\nb", `a\nb`},
+		{"ssml", `Tom & "Jerry" <3`, `Tom &amp; &#34;Jerry&#34; &lt;3`},
+		{"ssml", `<break/>`, `&lt;break/&gt;`},
+	}
+
+	for _, tc := range tcs {
+		mode := excellent.LookupEscapingMode(tc.escaping)
+		assert.Equal(t, tc.escaped, mode.Escaping(tc.value), "escaping mismatch for %s escaping of '%s'", tc.escaping, tc.value)
+	}
+
+	assert.Nil(t, excellent.LookupEscapingMode("xxx"))
+}
+
+func TestJSONEscapingMode(t *testing.T) {
+	ctx := types.NewXObject(map[string]types.XValue{
+		"name":  xs(`Bob "The Builder"`),
+		"slash": xs(`\`),
+		"tags":  types.NewXArray(xs("a"), xs("b")),
+	})
+	eval := excellent.NewEvaluator()
+	env := envs.NewBuilder().Build()
+
+	tcs := []struct {
+		template string
+		output   string
+	}{
+		{`Hi @name`, `Hi Bob "The Builder"`},                                             // not JSON so not escaped
+		{`"@name"`, `"Bob "The Builder""`},                                               // not an object or array so not escaped
+		{` {"name": "@name"}`, ` {"name": "Bob \"The Builder\""}`},                       // leading whitespace is ignored
+		{`[1, "x\"@name"]`, `[1, "x\"Bob \"The Builder\""]`},                             // escaped quotes don't end strings
+		{`{"a": "x\\", "b": @(json(name))}`, `{"a": "x\\", "b": "Bob \"The Builder\""}`}, // escaped backslashes don't escape quotes
+		{`{"a": "@slash", "b": @(json(tags))}`, `{"a": "\\", "b": ["a","b"]}`},           // escaped values don't affect later state
+		{
+			`{"name": "@name", "tags": @(json(tags)), "greeting": "Hi @name"}`,
+			`{"name": "Bob \"The Builder\"", "tags": ["a","b"], "greeting": "Hi Bob \"The Builder\""}`,
+		},
+	}
+
+	for _, tc := range tcs {
+		val, _, err := eval.Template(env, ctx, tc.template, excellent.JSONEscapingMode)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.output, val, "output mismatch for template: %s", tc.template)
+	}
+}
@@ -1,20 +1,79 @@
 package functions
 
 import (
+	"math"
 	"strings"
 
+	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/excellent/types"
 )
 
 // XFUNCTIONS is our map of functions available in Excellent which aren't tests
 var XFUNCTIONS = map[string]*types.XFunction{}
 
+// XSIGNATURES is our map of the signatures of functions, used for static checking of expressions
+var XSIGNATURES = map[string]*Signature{}
+
 // RegisterXFunction registers a new function in Excellent
 func RegisterXFunction(name string, f types.XFunc) {
 	XFUNCTIONS[name] = types.NewXFunction(name, f)
 }
 
+// RegisterXFunctionWithSignature registers a new function in Excellent along with the signature used to statically
+// check calls to it
+func RegisterXFunctionWithSignature(name string, f types.XFunc, s *Signature) {
+	RegisterXFunction(name, f)
+	XSIGNATURES[name] = s
+}
+
 // Lookup returns the function with the given name (case-insensitive) or nil
 func Lookup(name string) *types.XFunction {
 	return XFUNCTIONS[strings.ToLower(name)]
 }
+
+// LookupSignature returns the signature of the function with the given name (case-insensitive) or nil
+func LookupSignature(name string) *Signature {
+	return XSIGNATURES[strings.ToLower(name)]
+}
+
+// Signature describes the arguments accepted by a function
+type Signature struct {
+	MinArgs  int      // the minimum number of arguments
+	MaxArgs  int      // the maximum number of arguments or -1 if there is no maximum
+	ArgTypes []string // the types leading arguments are converted to, e.g. text or number, where any means no conversion
+}
+
+// NewSignature creates a new signature
+func NewSignature(minArgs, maxArgs int, argTypes ...string) *Signature {
+	return &Signature{MinArgs: minArgs, MaxArgs: maxArgs, ArgTypes: argTypes}
+}
+
+// ArgType returns the type the argument at the given index is converted to
+func (s *Signature) ArgType(i int) string {
+	if i < len(s.ArgTypes) {
+		return s.ArgTypes[i]
+	}
+	return "any"
+}
+
+// implemented by environments which limit the length of values, so that functions which can create large values can
+// check their length before creating them
+type lengthLimiter interface {
+	CheckLength(length int) *types.XError
+}
+
+// checks that a text value or array of the given length can be created in the given environment
+func checkLength(env envs.Environment, length int) *types.XError {
+	if limiter, ok := env.(lengthLimiter); ok {
+		return limiter.CheckLength(length)
+	}
+	return nil
+}
+
+// multiplies two lengths, saturating rather than overflowing
+func multiplyLength(a, b int) int {
+	if a > 0 && b > math.MaxInt/a {
+		return math.MaxInt
+	}
+	return a * b
+}
@@ -2,6 +2,12 @@ package functions
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"html"
 	"math"
@@ -16,134 +22,195 @@ import (
 	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/gocommon/random"
 	"github.com/nyaruka/gocommon/urns"
+	"github.com/nyaruka/gocommon/uuids"
 	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/excellent/types"
 	"github.com/nyaruka/goflow/utils"
 	"github.com/shopspring/decimal"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
 )
 
 var nanosPerSecond = decimal.RequireFromString("1000000000")
 var nonPrintableRegex = regexp.MustCompile(`[\p{Cc}\p{C}]`)
+var nonSlugRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// the most business days that can be added to a date, which is about 4000 years
+const maxBusinessDays = 1000000
+
+// a function along with the signature used to statically check calls to it
+type funcWithSignature struct {
+	fn        types.XFunc
+	signature *Signature
+}
 
 func init() {
-	builtin := map[string]types.XFunc{
+	builtin := map[string]funcWithSignature{
 		// type conversion
-		"text":     OneArgFunction(Text),
-		"boolean":  OneArgFunction(Boolean),
-		"number":   OneArgFunction(Number),
-		"date":     OneArgFunction(Date),
-		"datetime": OneArgFunction(DateTime),
-		"time":     OneArgFunction(Time),
-		"array":    Array,
-		"object":   Object,
+		"text":     {OneArgFunction(Text), NewSignature(1, 1)},
+		"boolean":  {OneArgFunction(Boolean), NewSignature(1, 1)},
+		"number":   {OneArgFunction(Number), NewSignature(1, 1)},
+		"date":     {OneArgFunction(Date), NewSignature(1, 1)},
+		"datetime": {OneArgFunction(DateTime), NewSignature(1, 1)},
+		"time":     {OneArgFunction(Time), NewSignature(1, 1)},
+		"duration": {OneArgFunction(Duration), NewSignature(1, 1)},
+		"array":    {Array, NewSignature(0, -1)},
+		"object":   {Object, NewSignature(0, -1)},
 
 		// text functions
-		"char":              OneNumberFunction(Char),
-		"code":              OneTextFunction(Code),
-		"split":             TextAndOptionalTextFunction(Split, types.XTextEmpty),
-		"trim":              TextAndOptionalTextFunction(Trim, types.XTextEmpty),
-		"trim_left":         TextAndOptionalTextFunction(TrimLeft, types.XTextEmpty),
-		"trim_right":        TextAndOptionalTextFunction(TrimRight, types.XTextEmpty),
-		"title":             OneTextFunction(Title),
-		"word":              InitialTextFunction(1, 2, Word),
-		"remove_first_word": OneTextFunction(RemoveFirstWord),
-		"word_count":        TextAndOptionalTextFunction(WordCount, types.XTextEmpty),
-		"word_slice":        InitialTextFunction(1, 3, WordSlice),
-		"field":             InitialTextFunction(2, 2, Field),
-		"clean":             OneTextFunction(Clean),
-		"text_slice":        InitialTextFunction(1, 3, TextSlice),
-		"lower":             OneTextFunction(Lower),
-		"regex_match":       InitialTextFunction(1, 2, RegexMatch),
-		"text_length":       OneTextFunction(TextLength),
-		"text_compare":      TwoTextFunction(TextCompare),
-		"repeat":            TextAndIntegerFunction(Repeat),
-		"replace":           MinAndMaxArgsCheck(3, 4, Replace),
-		"upper":             OneTextFunction(Upper),
-		"percent":           OneNumberFunction(Percent),
-		"url_encode":        OneTextFunction(URLEncode),
-		"html_decode":       OneTextFunction(HTMLDecode),
+		"char":                 {OneNumberFunction(Char), NewSignature(1, 1, "number")},
+		"code":                 {OneTextFunction(Code), NewSignature(1, 1, "text")},
+		"split":                {TextAndOptionalTextFunction(Split, types.XTextEmpty), NewSignature(1, 2, "text", "text")},
+		"trim":                 {TextAndOptionalTextFunction(Trim, types.XTextEmpty), NewSignature(1, 2, "text", "text")},
+		"trim_left":            {TextAndOptionalTextFunction(TrimLeft, types.XTextEmpty), NewSignature(1, 2, "text", "text")},
+		"trim_right":           {TextAndOptionalTextFunction(TrimRight, types.XTextEmpty), NewSignature(1, 2, "text", "text")},
+		"title":                {OneTextFunction(Title), NewSignature(1, 1, "text")},
+		"word":                 {InitialTextFunction(1, 2, Word), NewSignature(2, 3, "text")},
+		"remove_first_word":    {OneTextFunction(RemoveFirstWord), NewSignature(1, 1, "text")},
+		"word_count":           {TextAndOptionalTextFunction(WordCount, types.XTextEmpty), NewSignature(1, 2, "text", "text")},
+		"word_slice":           {InitialTextFunction(1, 3, WordSlice), NewSignature(2, 4, "text")},
+		"field":                {InitialTextFunction(2, 2, Field), NewSignature(3, 3, "text")},
+		"clean":                {OneTextFunction(Clean), NewSignature(1, 1, "text")},
+		"text_slice":           {InitialTextFunction(1, 3, TextSlice), NewSignature(2, 4, "text")},
+		"lower":                {OneTextFunction(Lower), NewSignature(1, 1, "text")},
+		"regex_match":          {InitialTextFunction(1, 2, RegexMatch), NewSignature(2, 3, "text")},
+		"regex_replace":        {InitialTextFunction(2, 3, RegexReplace), NewSignature(3, 4, "text")},
+		"regex_find_all":       {InitialTextFunction(1, 2, RegexFindAll), NewSignature(2, 3, "text")},
+		"regex_split":          {InitialTextFunction(1, 1, RegexSplit), NewSignature(2, 2, "text")},
+		"text_length":          {OneTextFunction(TextLength), NewSignature(1, 1, "text")},
+		"text_compare":         {TwoTextFunction(TextCompare), NewSignature(2, 2, "text", "text")},
+		"repeat":               {TextAndIntegerFunction(Repeat), NewSignature(2, 2, "text", "number")},
+		"replace":              {MinAndMaxArgsCheck(3, 4, Replace), NewSignature(3, 4)},
+		"upper":                {OneTextFunction(Upper), NewSignature(1, 1, "text")},
+		"percent":              {OneNumberFunction(Percent), NewSignature(1, 1, "number")},
+		"url_encode":           {OneTextFunction(URLEncode), NewSignature(1, 1, "text")},
+		"url_decode":           {OneTextFunction(URLDecode), NewSignature(1, 1, "text")},
+		"html_encode":          {OneTextFunction(HTMLEncode), NewSignature(1, 1, "text")},
+		"html_decode":          {OneTextFunction(HTMLDecode), NewSignature(1, 1, "text")},
+		"pad_left":             {InitialTextFunction(1, 2, PadLeft), NewSignature(2, 3, "text")},
+		"pad_right":            {InitialTextFunction(1, 2, PadRight), NewSignature(2, 3, "text")},
+		"truncate":             {InitialTextFunction(1, 2, Truncate), NewSignature(2, 3, "text")},
+		"slugify":              {OneTextFunction(Slugify), NewSignature(1, 1, "text")},
+		"transliterate":        {OneTextFunction(Transliterate), NewSignature(1, 1, "text")},
+		"normalize_whitespace": {OneTextFunction(NormalizeWhitespace), NewSignature(1, 1, "text")},
+		"similarity":           {TwoTextFunction(Similarity), NewSignature(2, 2, "text", "text")},
 
 		// bool functions
-		"and": MinArgsCheck(1, And),
-		"if":  ThreeArgFunction(If),
-		"or":  MinArgsCheck(1, Or),
+		"and": {MinArgsCheck(1, And), NewSignature(1, -1)},
+		"if":  {ThreeArgFunction(If), NewSignature(3, 3)},
+		"or":  {MinArgsCheck(1, Or), NewSignature(1, -1)},
 
 		// number functions
-		"round":        OneNumberAndOptionalIntegerFunction(Round, 0),
-		"round_up":     OneNumberAndOptionalIntegerFunction(RoundUp, 0),
-		"round_down":   OneNumberAndOptionalIntegerFunction(RoundDown, 0),
-		"max":          MinArgsCheck(1, Max),
-		"min":          MinArgsCheck(1, Min),
-		"mean":         MinArgsCheck(1, Mean),
-		"mod":          TwoNumberFunction(Mod),
-		"rand":         NoArgFunction(Rand),
-		"rand_between": TwoNumberFunction(RandBetween),
-		"abs":          OneNumberFunction(Abs),
+		"round":        {OneNumberAndOptionalIntegerFunction(Round, 0), NewSignature(1, 2, "number", "number")},
+		"round_up":     {OneNumberAndOptionalIntegerFunction(RoundUp, 0), NewSignature(1, 2, "number", "number")},
+		"round_down":   {OneNumberAndOptionalIntegerFunction(RoundDown, 0), NewSignature(1, 2, "number", "number")},
+		"max":          {MinArgsCheck(1, Max), NewSignature(1, -1)},
+		"min":          {MinArgsCheck(1, Min), NewSignature(1, -1)},
+		"mean":         {MinArgsCheck(1, Mean), NewSignature(1, -1)},
+		"mod":          {TwoNumberFunction(Mod), NewSignature(2, 2, "number", "number")},
+		"rand":         {NoArgFunction(Rand), NewSignature(0, 0)},
+		"rand_between": {TwoNumberFunction(RandBetween), NewSignature(2, 2, "number", "number")},
+		"abs":          {OneNumberFunction(Abs), NewSignature(1, 1, "number")},
+		"parse_number": {OneTextFunction(ParseNumber), NewSignature(1, 1, "text")},
+		"spell_number": {OneNumberFunction(SpellNumber), NewSignature(1, 1, "number")},
 
 		// datetime functions
-		"parse_datetime":      MinAndMaxArgsCheck(2, 3, ParseDateTime),
-		"datetime_from_epoch": OneNumberFunction(DateTimeFromEpoch),
-		"datetime_diff":       ThreeArgFunction(DateTimeDiff),
-		"datetime_add":        DateTimeAdd,
-		"replace_time":        TwoArgFunction(ReplaceTime),
-		"tz":                  OneDateTimeFunction(TZ),
-		"tz_offset":           OneDateTimeFunction(TZOffset),
-		"now":                 NoArgFunction(Now),
-		"epoch":               OneDateTimeFunction(Epoch),
+		"parse_datetime":      {MinAndMaxArgsCheck(2, 3, ParseDateTime), NewSignature(2, 3)},
+		"datetime_from_epoch": {OneNumberFunction(DateTimeFromEpoch), NewSignature(1, 1, "number")},
+		"datetime_diff":       {ThreeArgFunction(DateTimeDiff), NewSignature(3, 3)},
+		"datetime_add":        {DateTimeAdd, NewSignature(3, 3, "datetime", "number", "text")},
+		"business_days_add":   {TwoArgFunction(BusinessDaysAdd), NewSignature(2, 2)},
+		"replace_time":        {TwoArgFunction(ReplaceTime), NewSignature(2, 2)},
+		"tz":                  {OneDateTimeFunction(TZ), NewSignature(1, 1, "datetime")},
+		"tz_offset":           {OneDateTimeFunction(TZOffset), NewSignature(1, 1, "datetime")},
+		"now":                 {NoArgFunction(Now), NewSignature(0, 0)},
+		"epoch":               {OneDateTimeFunction(Epoch), NewSignature(1, 1, "datetime")},
+		"next_opening":        {OneTextFunction(NextOpening), NewSignature(1, 1, "text")},
 
 		// date functions
-		"date_from_parts": ThreeIntegerFunction(DateFromParts),
-		"weekday":         OneDateFunction(Weekday),
-		"week_number":     OneDateFunction(WeekNumber),
-		"today":           NoArgFunction(Today),
+		"date_from_parts": {ThreeIntegerFunction(DateFromParts), NewSignature(3, 3, "number", "number", "number")},
+		"weekday":         {OneDateFunction(Weekday), NewSignature(1, 1, "date")},
+		"week_number":     {OneDateFunction(WeekNumber), NewSignature(1, 1, "date")},
+		"today":           {NoArgFunction(Today), NewSignature(0, 0)},
+		"end_of_month":    {OneDateFunction(EndOfMonth), NewSignature(1, 1, "date")},
+		"start_of_week":   {OneDateFunction(StartOfWeek), NewSignature(1, 1, "date")},
+		"age":             {OneDateFunction(Age), NewSignature(1, 1, "date")},
 
 		// time functions
-		"parse_time":      TwoArgFunction(ParseTime),
-		"time_from_parts": ThreeIntegerFunction(TimeFromParts),
+		"parse_time":      {TwoArgFunction(ParseTime), NewSignature(2, 2)},
+		"time_from_parts": {ThreeIntegerFunction(TimeFromParts), NewSignature(3, 3, "number", "number", "number")},
 
 		// array functions
-		"contains": TwoArgFunction(Contains),
-		"join":     TwoArgFunction(Join),
-		"reverse":  OneArrayFunction(Reverse),
-		"sort":     OneArrayFunction(Sort),
-		"sum":      OneArrayFunction(Sum),
-		"unique":   OneArrayFunction(Unique),
-		"concat":   TwoArrayFunction(Concat),
-		"filter":   TwoArgFunction(Filter),
+		"contains": {TwoArgFunction(Contains), NewSignature(2, 2)},
+		"join":     {TwoArgFunction(Join), NewSignature(2, 2)},
+		"reverse":  {OneArrayFunction(Reverse), NewSignature(1, 1, "array")},
+		"sort":     {OneArrayFunction(Sort), NewSignature(1, 1, "array")},
+		"sum":      {OneArrayFunction(Sum), NewSignature(1, 1, "array")},
+		"unique":   {OneArrayFunction(Unique), NewSignature(1, 1, "array")},
+		"concat":   {TwoArrayFunction(Concat), NewSignature(2, 2, "array", "array")},
+		"filter":   {TwoArgFunction(Filter), NewSignature(2, 2)},
+		"map":      {TwoArgFunction(Map), NewSignature(2, 2)},
+		"reduce":   {ThreeArgFunction(Reduce), NewSignature(3, 3)},
+		"sort_by":  {MinAndMaxArgsCheck(2, 3, SortBy), NewSignature(2, 3)},
+		"group_by": {TwoArgFunction(GroupBy), NewSignature(2, 2)},
+		"find":     {TwoArgFunction(Find), NewSignature(2, 2)},
+		"any":      {TwoArgFunction(Any), NewSignature(2, 2)},
+		"all":      {TwoArgFunction(All), NewSignature(2, 2)},
+		"flatten":  {OneArrayFunction(Flatten), NewSignature(1, 1, "array")},
+		"slice":    {MinAndMaxArgsCheck(2, 3, Slice), NewSignature(2, 3)},
+		"zip":      {MinArgsCheck(1, Zip), NewSignature(1, -1)},
 
 		// encoded text functions
-		"urn_parts":        OneTextFunction(URNParts),
-		"attachment_parts": OneTextFunction(AttachmentParts),
+		"urn_parts":        {OneTextFunction(URNParts), NewSignature(1, 1, "text")},
+		"attachment_parts": {OneTextFunction(AttachmentParts), NewSignature(1, 1, "text")},
+		"url_parts":        {OneTextFunction(URLParts), NewSignature(1, 1, "text")},
+		"base64_encode":    {OneTextFunction(Base64Encode), NewSignature(1, 1, "text")},
+		"base64_decode":    {OneTextFunction(Base64Decode), NewSignature(1, 1, "text")},
+		"hex":              {OneTextFunction(Hex), NewSignature(1, 1, "text")},
+		"md5":              {OneTextFunction(MD5), NewSignature(1, 1, "text")},
+		"sha1":             {OneTextFunction(SHA1), NewSignature(1, 1, "text")},
+		"sha256":           {OneTextFunction(SHA256), NewSignature(1, 1, "text")},
+		"hmac_sha256":      {TwoTextFunction(HMACSHA256), NewSignature(2, 2, "text", "text")},
+
+		// geo functions
+		"geo_parse":    {OneTextFunction(GeoParse), NewSignature(1, 1, "text")},
+		"geo_distance": {MinAndMaxArgsCheck(2, 3, GeoDistance), NewSignature(2, 3)},
+		"geohash":      {MinAndMaxArgsCheck(1, 2, Geohash), NewSignature(1, 2)},
+		"geo_within":   {TwoArgFunction(GeoWithin), NewSignature(2, 2)},
 
 		// json functions
-		"json":       OneArgFunction(JSON),
-		"parse_json": OneTextFunction(ParseJSON),
+		"json":       {OneArgFunction(JSON), NewSignature(1, 1)},
+		"parse_json": {OneTextFunction(ParseJSON), NewSignature(1, 1, "text")},
 
 		// formatting functions
-		"format":          OneArgFunction(Format),
-		"format_date":     MinAndMaxArgsCheck(1, 2, FormatDate),
-		"format_datetime": MinAndMaxArgsCheck(1, 3, FormatDateTime),
-		"format_time":     MinAndMaxArgsCheck(1, 2, FormatTime),
-		"format_location": OneTextFunction(FormatLocation),
-		"format_number":   MinAndMaxArgsCheck(1, 3, FormatNumber),
-		"format_urn":      OneTextFunction(FormatURN),
+		"format":          {OneArgFunction(Format), NewSignature(1, 1)},
+		"format_date":     {MinAndMaxArgsCheck(1, 2, FormatDate), NewSignature(1, 2)},
+		"format_datetime": {MinAndMaxArgsCheck(1, 3, FormatDateTime), NewSignature(1, 3)},
+		"format_time":     {MinAndMaxArgsCheck(1, 2, FormatTime), NewSignature(1, 2)},
+		"format_location": {OneTextFunction(FormatLocation), NewSignature(1, 1, "text")},
+		"format_number":   {MinAndMaxArgsCheck(1, 3, FormatNumber), NewSignature(1, 3)},
+		"format_currency": {TwoArgFunction(FormatCurrency), NewSignature(2, 2)},
+		"format_ordinal":  {OneNumberFunction(FormatOrdinal), NewSignature(1, 1, "number")},
+		"format_urn":      {OneTextFunction(FormatURN), NewSignature(1, 1, "text")},
 
 		// utility functions
-		"is_error":       OneArgFunction(IsError),
-		"count":          OneArgFunction(Count),
-		"default":        TwoArgFunction(Default),
-		"legacy_add":     TwoArgFunction(LegacyAdd),
-		"read_chars":     OneTextFunction(ReadChars),
-		"extract":        TwoArgFunction(Extract),
-		"extract_object": MinArgsCheck(2, ExtractObject),
-		"foreach":        MinArgsCheck(2, ForEach),
-		"foreach_value":  MinArgsCheck(2, ForEachValue),
+		"is_error":       {OneArgFunction(IsError), NewSignature(1, 1)},
+		"count":          {OneArgFunction(Count), NewSignature(1, 1)},
+		"default":        {TwoArgFunction(Default), NewSignature(2, 2)},
+		"legacy_add":     {TwoArgFunction(LegacyAdd), NewSignature(2, 2)},
+		"read_chars":     {OneTextFunction(ReadChars), NewSignature(1, 1, "text")},
+		"extract":        {TwoArgFunction(Extract), NewSignature(2, 2)},
+		"extract_object": {MinArgsCheck(2, ExtractObject), NewSignature(2, -1)},
+		"foreach":        {MinArgsCheck(2, ForEach), NewSignature(2, -1)},
+		"foreach_value":  {MinArgsCheck(2, ForEachValue), NewSignature(2, -1)},
+		"uuid":           {NoArgFunction(UUID), NewSignature(0, 0)},
 
-		"keys": OneObjectFunction(Keys),
+		"keys": {OneObjectFunction(Keys), NewSignature(1, 1, "object")},
 	}
 
-	for name, fn := range builtin {
-		RegisterXFunction(name, fn)
+	for name, f := range builtin {
+		RegisterXFunctionWithSignature(name, f.fn, f.signature)
 	}
 }
 
@@ -259,6 +326,25 @@ func Time(env envs.Environment, value types.XValue) types.XValue {
 	return t
 }
 
+// Duration tries to convert `value` to a duration.
+//
+// If it is text then it will be parsed as an ISO 8601 duration like `P3DT2H` or as shorthand
+// like `2h30m`. An error is returned if the value can't be converted.
+//
+//	@(duration("2h30m")) -> PT2H30M
+//	@(duration("P3D")) -> P3D
+//	@(duration("1w 2d")) -> P9D
+//	@(duration("soon")) -> ERROR
+//
+// @function duration(value)
+func Duration(env envs.Environment, value types.XValue) types.XValue {
+	d, xerr := types.ToXDuration(env, value)
+	if xerr != nil {
+		return xerr
+	}
+	return d
+}
+
 // Array takes multiple `values` and returns them as an array.
 //
 //	@(array("a", "b", 356)[1]) -> b
@@ -770,7 +856,7 @@ func Lower(env envs.Environment, text *types.XText) types.XValue {
 //
 // @function regex_match(text, pattern [,group])
 func RegexMatch(env envs.Environment, text *types.XText, args ...types.XValue) types.XValue {
-	pattern, xerr := types.ToXText(env, args[0])
+	exp, xerr := toRegex(env, args[0])
 	if xerr != nil {
 		return xerr
 	}
@@ -783,11 +869,6 @@ func RegexMatch(env envs.Environment, text *types.XText, args ...types.XValue) t
 		}
 	}
 
-	exp, err := regexp.Compile(`(?mi)` + pattern.Native())
-	if err != nil {
-		return types.NewXErrorf("invalid regular expression")
-	}
-
 	groups := exp.FindStringSubmatch(text.Native())
 
 	if groupNum < 0 || groupNum >= len(groups) {
@@ -825,12 +906,16 @@ func TextCompare(env envs.Environment, text1 *types.XText, text2 *types.XText) t
 //
 //	@(repeat("*", 8)) -> ********
 //	@(repeat("*", "foo")) -> ERROR
+//	@(repeat("*", 2000000)) -> ERROR
 //
 // @function repeat(text, count)
 func Repeat(env envs.Environment, text *types.XText, count int) types.XValue {
 	if count < 0 {
 		return types.NewXErrorf("must be called with a positive integer, got %d", count)
 	}
+	if xerr := checkLength(env, multiplyLength(text.Length(), count)); xerr != nil {
+		return xerr
+	}
 
 	var output bytes.Buffer
 	for j := 0; j < count; j++ {
@@ -911,6 +996,31 @@ func URLEncode(env envs.Environment, text *types.XText) types.XValue {
 	return types.NewXText(encoded)
 }
 
+// URLDecode decodes `text` which has been encoded for use as a URL parameter.
+//
+//	@(url_decode("two%20%26%20words")) -> two & words
+//	@(url_decode("a+b")) -> a b
+//	@(url_decode("100%")) -> ERROR
+//
+// @function url_decode(text)
+func URLDecode(env envs.Environment, text *types.XText) types.XValue {
+	decoded, err := url.QueryUnescape(text.Native())
+	if err != nil {
+		return types.NewXErrorf("%s isn't valid URL encoded text", text.Describe())
+	}
+	return types.NewXText(decoded)
+}
+
+// HTMLEncode HTML encodes `text`
+//
+//	@(html_encode("Red & Blue")) -> Red &amp; Blue
+//	@(html_encode("<b>hi</b>")) -> &lt;b&gt;hi&lt;/b&gt;
+//
+// @function html_encode(text)
+func HTMLEncode(env envs.Environment, text *types.XText) types.XValue {
+	return types.NewXText(html.EscapeString(text.Native()))
+}
+
 // HTMLDecode HTML decodes `text`
 //
 //	@(html_decode("Red &amp; Blue")) -> Red & Blue
@@ -925,6 +1035,304 @@ func HTMLDecode(env envs.Environment, text *types.XText) types.XValue {
 	return types.NewXText(decoded)
 }
 
+// RegexReplace replaces up to `count` matches of the regular expression `pattern` in `text` with `replacement`.
+//
+// The `replacement` can refer to matching groups with `$1`, `$2` etc. If `count` is omitted or is less
+// than 0 then all matches are replaced.
+//
+//	@(regex_replace("one 22 three 4444", "\d+", "#")) -> one # three #
+//	@(regex_replace("one 22 three 4444", "\d+", "#", 1)) -> one # three 4444
+//	@(regex_replace("Bob Smith", "(\w+) (\w+)", "$2, $1")) -> Smith, Bob
+//	@(regex_replace("abc", "[\.", "")) -> ERROR
+//
+// @function regex_replace(text, pattern, replacement [, count])
+func RegexReplace(env envs.Environment, text *types.XText, args ...types.XValue) types.XValue {
+	exp, xerr := toRegex(env, args[0])
+	if xerr != nil {
+		return xerr
+	}
+	replacement, xerr := types.ToXText(env, args[1])
+	if xerr != nil {
+		return xerr
+	}
+
+	count := -1
+	if len(args) == 3 {
+		if count, xerr = types.ToInteger(env, args[2]); xerr != nil {
+			return xerr
+		}
+	}
+
+	// matches are found and expanded against the original text so that anchors and boundaries behave as they would in
+	// a single pass over it
+	source := text.Native()
+	var result []byte
+	last := 0
+	for _, submatches := range exp.FindAllStringSubmatchIndex(source, count) {
+		result = append(result, source[last:submatches[0]]...)
+		result = exp.ExpandString(result, replacement.Native(), source, submatches)
+		last = submatches[1]
+	}
+	result = append(result, source[last:]...)
+
+	return types.NewXText(string(result))
+}
+
+// RegexFindAll returns all the matches of the regular expression `pattern` in `text`.
+//
+// An optional third parameter `group` determines which matching group will be returned from each match.
+//
+//	@(regex_find_all("one 22 three 4444", "\d+")) -> [22, 4444]
+//	@(regex_find_all("a=1, b=2", "(\w)=(\d)", 1)) -> [a, b]
+//	@(regex_find_all("abc", "\d")) -> []
+//	@(regex_find_all("a=1", "(\w)=(\d)", 3)) -> ERROR
+//
+// @function regex_find_all(text, pattern [,group])
+func RegexFindAll(env envs.Environment, text *types.XText, args ...types.XValue) types.XValue {
+	exp, xerr := toRegex(env, args[0])
+	if xerr != nil {
+		return xerr
+	}
+
+	groupNum := 0
+	if len(args) == 2 {
+		if groupNum, xerr = types.ToInteger(env, args[1]); xerr != nil {
+			return xerr
+		}
+	}
+
+	if groupNum < 0 || groupNum > exp.NumSubexp() {
+		return types.NewXErrorf("invalid regular expression group")
+	}
+
+	matches := exp.FindAllStringSubmatch(text.Native(), -1)
+	items := make([]types.XValue, len(matches))
+	for i, groups := range matches {
+		items[i] = types.NewXText(groups[groupNum])
+	}
+
+	return types.NewXArray(items...)
+}
+
+// RegexSplit splits `text` into an array of separated by matches of the regular expression `pattern`.
+//
+// Empty values are removed from the returned list.
+//
+//	@(regex_split("a1b22c", "\d+")) -> [a, b, c]
+//	@(regex_split("one, two;three", "[,;]\s*")) -> [one, two, three]
+//	@(regex_split("abc", "[\.")) -> ERROR
+//
+// @function regex_split(text, pattern)
+func RegexSplit(env envs.Environment, text *types.XText, args ...types.XValue) types.XValue {
+	exp, xerr := toRegex(env, args[0])
+	if xerr != nil {
+		return xerr
+	}
+
+	parts := exp.Split(text.Native(), -1)
+	items := make([]types.XValue, 0, len(parts))
+	for _, part := range parts {
+		if part != "" {
+			items = append(items, types.NewXText(part))
+		}
+	}
+
+	return types.NewXArray(items...)
+}
+
+// PadLeft pads `text` on the left to make it `length` characters long.
+//
+// The optional `char` parameter is the character to pad with, and defaults to a space.
+//
+//	@(pad_left("7", 3, "0")) -> 007
+//	@("[" & pad_left("abc", 5) & "]") -> [  abc]
+//	@(pad_left("abcdef", 3)) -> abcdef
+//
+// @function pad_left(text, length [, char])
+func PadLeft(env envs.Environment, text *types.XText, args ...types.XValue) types.XValue {
+	padding, xerr := toPadding(env, text, args)
+	if xerr != nil {
+		return xerr
+	}
+	return types.NewXText(padding + text.Native())
+}
+
+// PadRight pads `text` on the right to make it `length` characters long.
+//
+// The optional `char` parameter is the character to pad with, and defaults to a space.
+//
+//	@(pad_right("7", 3, "0")) -> 700
+//	@("[" & pad_right("abc", 5) & "]") -> [abc  ]
+//	@(pad_right("abcdef", 3)) -> abcdef
+//
+// @function pad_right(text, length [, char])
+func PadRight(env envs.Environment, text *types.XText, args ...types.XValue) types.XValue {
+	padding, xerr := toPadding(env, text, args)
+	if xerr != nil {
+		return xerr
+	}
+	return types.NewXText(text.Native() + padding)
+}
+
+// Truncate shortens `text` to at most `length` characters, ending it with `ellipsis` if it was shortened.
+//
+// The optional `ellipsis` defaults to `...` and is included in the `length` of the result.
+//
+//	@(truncate("Hello World", 8)) -> Hello...
+//	@(truncate("Hello World", 8, "…")) -> Hello W…
+//	@(truncate("Hello", 8)) -> Hello
+//
+// @function truncate(text, length [, ellipsis])
+func Truncate(env envs.Environment, text *types.XText, args ...types.XValue) types.XValue {
+	length, xerr := types.ToInteger(env, args[0])
+	if xerr != nil {
+		return xerr
+	}
+
+	ellipsis := types.NewXText("...")
+	if len(args) == 2 {
+		if ellipsis, xerr = types.ToXText(env, args[1]); xerr != nil {
+			return xerr
+		}
+	}
+
+	if length < ellipsis.Length() {
+		return types.NewXErrorf("length must be at least the length of the ellipsis")
+	}
+	if text.Length() <= length {
+		return text
+	}
+
+	runes := []rune(text.Native())
+	return types.NewXText(string(runes[:length-ellipsis.Length()]) + ellipsis.Native())
+}
+
+// Slugify converts `text` to a lowercase slug suitable for use in a URL or identifier.
+//
+//	@(slugify("Hello World!")) -> hello-world
+//	@(slugify("  Crème Brûlée  ")) -> creme-brulee
+//
+// @function slugify(text)
+func Slugify(env envs.Environment, text *types.XText) types.XValue {
+	slug := strings.ToLower(utils.Transliterate(text.Native()))
+	slug = nonSlugRegex.ReplaceAllString(slug, "-")
+	return types.NewXText(strings.Trim(slug, "-"))
+}
+
+// Transliterate converts `text` to ASCII by removing accents and replacing other Latin letters with
+// their closest ASCII equivalents.
+//
+// Characters which have no ASCII equivalent are left unchanged.
+//
+//	@(transliterate("Crème Brûlée")) -> Creme Brulee
+//	@(transliterate("Straße Ærø")) -> Strasse AEro
+//
+// @function transliterate(text)
+func Transliterate(env envs.Environment, text *types.XText) types.XValue {
+	return types.NewXText(utils.Transliterate(text.Native()))
+}
+
+// NormalizeWhitespace replaces all runs of whitespace in `text` with single spaces and trims the ends.
+//
+//	@(normalize_whitespace("  hello \n\t world ")) -> hello world
+//
+// @function normalize_whitespace(text)
+func NormalizeWhitespace(env envs.Environment, text *types.XText) types.XValue {
+	return types.NewXText(strings.Join(strings.Fields(text.Native()), " "))
+}
+
+// Similarity returns how similar `text1` and `text2` are as a number between 0 and 1.
+//
+// This is calculated as the Levenshtein edit distance between the two relative to the length
+// of the longer one, and is rounded to 2 decimal places.
+//
+//	@(similarity("kitten", "sitting")) -> 0.57
+//	@(similarity("hello", "hello")) -> 1
+//	@(similarity("abc", "xyz")) -> 0
+//
+// @function similarity(text1, text2)
+func Similarity(env envs.Environment, text1 *types.XText, text2 *types.XText) types.XValue {
+	r1, r2 := []rune(text1.Native()), []rune(text2.Native())
+	longest := max(len(r1), len(r2))
+	if longest == 0 {
+		return types.NewXNumberFromInt(1)
+	}
+
+	distance := decimal.NewFromInt(int64(levenshtein(r1, r2)))
+	ratio := decimal.NewFromInt(1).Sub(distance.Div(decimal.NewFromInt(int64(longest))))
+
+	return types.NewXNumber(ratio.Round(2))
+}
+
+// compiles the given pattern as a case insensitive, multiline regular expression
+func toRegex(env envs.Environment, pattern types.XValue) (*regexp.Regexp, *types.XError) {
+	asText, xerr := types.ToXText(env, pattern)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	exp, err := regexp.Compile(`(?mi)` + asText.Native())
+	if err != nil {
+		return nil, types.NewXErrorf("invalid regular expression")
+	}
+	return exp, nil
+}
+
+// gets the padding needed to make the given text the length in args[0] using the optional char in args[1]
+func toPadding(env envs.Environment, text *types.XText, args []types.XValue) (string, *types.XError) {
+	length, xerr := types.ToInteger(env, args[0])
+	if xerr != nil {
+		return "", xerr
+	}
+
+	char := " "
+	if len(args) == 2 {
+		asText, xerr := types.ToXText(env, args[1])
+		if xerr != nil {
+			return "", xerr
+		}
+		if asText.Length() != 1 {
+			return "", types.NewXErrorf("padding must be a single character")
+		}
+		char = asText.Native()
+	}
+
+	if text.Length() >= length {
+		return "", nil
+	}
+	if xerr := checkLength(env, length); xerr != nil {
+		return "", xerr
+	}
+	return strings.Repeat(char, length-text.Length()), nil
+}
+
+func isWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
+
+// calculates the Levenshtein edit distance between two sequences of runes
+func levenshtein(r1, r2 []rune) int {
+	prev := make([]int, len(r2)+1)
+	curr := make([]int, len(r2)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(r1); i++ {
+		curr[0] = i
+		for j := 1; j <= len(r2); j++ {
+			cost := 1
+			if r1[i-1] == r2[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(r2)]
+}
+
 //------------------------------------------------------------------------------------------
 // Number Functions
 //------------------------------------------------------------------------------------------
@@ -1114,6 +1522,78 @@ func RandBetween(env envs.Environment, min *types.XNumber, max *types.XNumber) t
 	return types.NewXNumber(val)
 }
 
+// ParseNumber parses `text` into a number using the number conventions of the contact's locale.
+//
+// If the locale isn't known, or `text` isn't a valid number in that locale, then the environment's
+// number format is used. An error is returned if `text` can't be parsed.
+//
+//	@(parse_number("1,234.5")) -> 1234.5
+//	@(parse_number("-42")) -> -42
+//	@(parse_number("12 apples")) -> ERROR
+//
+// @function parse_number(text)
+func ParseNumber(env envs.Environment, text *types.XText) types.XValue {
+	formats := []*envs.NumberFormat{env.NumberFormat()}
+	if localeFormat := envs.NumberFormatForLocale(env.DefaultLocale()); localeFormat != nil {
+		formats = append([]*envs.NumberFormat{localeFormat}, formats...)
+	}
+
+	for _, format := range formats {
+		if num := parseFormattedNumber(text.Native(), format); num != nil {
+			return num
+		}
+	}
+
+	return types.NewXErrorf("unable to parse '%s' as a number", text.Native())
+}
+
+// SpellNumber spells out `number` in words in the contact's language.
+//
+// Supported languages are English, Spanish, French and Portuguese. An error is returned if
+// `number` isn't a whole number.
+//
+//	@(spell_number(42)) -> forty-two
+//	@(spell_number(2021)) -> two thousand twenty-one
+//	@(spell_number(-3)) -> minus three
+//	@(spell_number(1.5)) -> ERROR
+//
+// @function spell_number(number)
+func SpellNumber(env envs.Environment, num *types.XNumber) types.XValue {
+	if !num.Native().IsInteger() {
+		return types.NewXErrorf("can only spell whole numbers")
+	}
+
+	spelled, err := utils.SpellNumber(num.Native().IntPart(), env.DefaultLanguage())
+	if err != nil {
+		return types.NewXError(err)
+	}
+	return types.NewXText(spelled)
+}
+
+// parses the given text as a number formatted according to the given number format, returning nil if that isn't possible
+func parseFormattedNumber(s string, format *envs.NumberFormat) *types.XNumber {
+	s = strings.TrimSpace(s)
+
+	pattern := fmt.Sprintf(`^[-+]?\d+(%s\d+)*(%s\d+)?$`, regexp.QuoteMeta(format.DigitGroupingSymbol), regexp.QuoteMeta(format.DecimalSymbol))
+	if format.DigitGroupingSymbol == "" {
+		pattern = fmt.Sprintf(`^[-+]?\d+(%s\d+)?$`, regexp.QuoteMeta(format.DecimalSymbol))
+	}
+	if !regexp.MustCompile(pattern).MatchString(s) {
+		return nil
+	}
+
+	if format.DigitGroupingSymbol != "" {
+		s = strings.ReplaceAll(s, format.DigitGroupingSymbol, "")
+	}
+	s = strings.Replace(s, format.DecimalSymbol, ".", 1)
+
+	num, xerr := types.ToXNumber(nil, types.NewXText(s))
+	if xerr != nil {
+		return nil
+	}
+	return num
+}
+
 //------------------------------------------------------------------------------------------
 // Date & Time Functions
 //------------------------------------------------------------------------------------------
@@ -1308,6 +1788,59 @@ func DateTimeAdd(env envs.Environment, args ...types.XValue) types.XValue {
 	return types.NewXErrorf("unknown unit: %s, must be one of s, m, h, D, W, M, Y", unit)
 }
 
+// BusinessDaysAdd adds `days` business days to `datetime`, skipping Saturdays and Sundays.
+//
+// Days are counted in the environment timezone and a negative number of days counts backwards.
+//
+//	@(business_days_add("2018-04-13T10:00:00Z", 1)) -> 2018-04-16T05:00:00.000000-05:00
+//	@(business_days_add(now(), 3)) -> 2018-04-16T13:24:30.123456-05:00
+//	@(business_days_add("2018-04-16", -1)) -> 2018-04-13T00:00:00.000000-05:00
+//	@(business_days_add("xx", 2)) -> ERROR
+//
+// @function business_days_add(datetime, days)
+func BusinessDaysAdd(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
+	date, xerr := types.ToXDateTime(env, arg1)
+	if xerr != nil {
+		return xerr
+	}
+
+	days, xerr := types.ToInteger(env, arg2)
+	if xerr != nil {
+		return xerr
+	}
+
+	if days > maxBusinessDays || days < -maxBusinessDays {
+		return types.NewXErrorf("days must be between -%d and %d", maxBusinessDays, maxBusinessDays)
+	}
+
+	dt := date.Native().In(env.Timezone())
+	if days == 0 {
+		return types.NewXDateTime(dt)
+	}
+
+	step := 1
+	if days < 0 {
+		step, days = -1, -days
+	}
+
+	// a weekend counts from the business day before it in the direction we're counting
+	for isWeekend(dt) {
+		dt = dt.AddDate(0, 0, -step)
+	}
+
+	// every 5 business days is a whole week, and then we only need to step through what remains
+	dt = dt.AddDate(0, 0, step*(days/5)*7)
+
+	for days %= 5; days > 0; {
+		dt = dt.AddDate(0, 0, step)
+		if !isWeekend(dt) {
+			days--
+		}
+	}
+
+	return types.NewXDateTime(dt)
+}
+
 // ReplaceTime returns a new datetime with the time part replaced by the `time`.
 //
 //	@(replace_time(now(), "10:30")) -> 2018-04-11T10:30:00.000000-05:00
@@ -1382,6 +1915,38 @@ func Now(env envs.Environment) types.XValue {
 	return types.NewXDateTime(env.Now())
 }
 
+// NextOpening returns the next date and time when the schedule with the given name or UUID is open.
+//
+// If the schedule is currently open, the current date and time is returned. If the schedule doesn't have its
+// own timezone, the current timezone is used.
+//
+//	@(next_opening("Office Hours")) -> 2018-04-11T13:24:30.123456-05:00
+//	@(next_opening("Weekend Hours")) -> 2018-04-14T10:00:00.000000-05:00
+//	@(next_opening("Holiday Hours")) -> ERROR
+//
+// @function next_opening(schedule)
+func NextOpening(env envs.Environment, schedule *types.XText) types.XValue {
+	var resolver envs.ScheduleResolver
+	if se, ok := env.(envs.ScheduleEnvironment); ok {
+		resolver = se.ScheduleResolver()
+	}
+	if resolver == nil {
+		return types.NewXErrorf("can't find schedules in environment which is not schedule enabled")
+	}
+
+	s := resolver.FindSchedule(schedule.Native())
+	if s == nil {
+		return types.NewXErrorf("no such schedule '%s'", schedule.Native())
+	}
+
+	next := s.NextOpen(env, env.Now())
+	if next == nil {
+		return types.NewXErrorf("schedule '%s' has no upcoming opening", schedule.Native())
+	}
+
+	return types.NewXDateTime(*next)
+}
+
 //------------------------------------------------------------------------------------------
 // Date Functions
 //------------------------------------------------------------------------------------------
@@ -1435,6 +2000,59 @@ func Today(env envs.Environment) types.XValue {
 	return types.NewXDate(dates.ExtractDate(env.Now()))
 }
 
+// EndOfMonth returns the last day of the month of `date`.
+//
+//	@(end_of_month("2019-02-10")) -> 2019-02-28
+//	@(end_of_month("2020-02-10")) -> 2020-02-29
+//	@(end_of_month("2018-12-31T23:00:00Z")) -> 2018-12-31
+//	@(end_of_month("xx")) -> ERROR
+//
+// @function end_of_month(date)
+func EndOfMonth(env envs.Environment, date *types.XDate) types.XValue {
+	d := date.Native()
+	return types.NewXDate(dates.ExtractDate(time.Date(d.Year, d.Month+1, 0, 0, 0, 0, 0, time.UTC)))
+}
+
+// StartOfWeek returns the first day of the week containing `date`.
+//
+// The day that weeks start on is taken from the environment and defaults to Monday.
+//
+//	@(start_of_week("2018-04-11")) -> 2018-04-09
+//	@(start_of_week("2018-04-09")) -> 2018-04-09
+//	@(start_of_week("2018-04-08")) -> 2018-04-02
+//	@(start_of_week("xx")) -> ERROR
+//
+// @function start_of_week(date)
+func StartOfWeek(env envs.Environment, date *types.XDate) types.XValue {
+	d := date.Native()
+	offset := (7 + int(d.Weekday()) - int(envs.WeekStartOf(env))) % 7
+	return types.NewXDate(dates.ExtractDate(time.Date(d.Year, d.Month, d.Day-offset, 0, 0, 0, 0, time.UTC)))
+}
+
+// Age returns the number of full years between `date` and today in the environment timezone.
+//
+//	@(age("1979-07-18")) -> 38
+//	@(age("2000-04-11")) -> 18
+//	@(age("2000-04-12")) -> 17
+//	@(age("2030-01-01")) -> ERROR
+//
+// @function age(date)
+func Age(env envs.Environment, date *types.XDate) types.XValue {
+	d := date.Native()
+	today := dates.ExtractDate(env.Now())
+
+	if d.Compare(today) > 0 {
+		return types.NewXErrorf("date is in the future")
+	}
+
+	years := today.Year - d.Year
+	if today.Month < d.Month || (today.Month == d.Month && today.Day < d.Day) {
+		years--
+	}
+
+	return types.NewXNumberFromInt(years)
+}
+
 //------------------------------------------------------------------------------------------
 // Time Functions
 //------------------------------------------------------------------------------------------
@@ -1555,20 +2173,27 @@ func Join(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XVal
 		return xerr
 	}
 
-	var output bytes.Buffer
-	for i := 0; i < array.Count(); i++ {
-		if i > 0 {
-			output.WriteString(separator.Native())
-		}
+	items := make([]string, array.Count())
+	length := 0
+	if len(items) > 1 {
+		length = multiplyLength(separator.Length(), len(items)-1)
+	}
+
+	for i := range items {
 		itemAsStr, xerr := types.ToXText(env, array.Get(i))
 		if xerr != nil {
 			return xerr
 		}
 
-		output.WriteString(itemAsStr.Native())
+		items[i] = itemAsStr.Native()
+		length += itemAsStr.Length()
 	}
 
-	return types.NewXText(output.String())
+	if xerr := checkLength(env, length); xerr != nil {
+		return xerr
+	}
+
+	return types.NewXText(strings.Join(items, separator.Native()))
 }
 
 // Reverse returns a new array with the values of `array` reversed.
@@ -1723,6 +2348,480 @@ func Filter(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XV
 	return types.NewXArray(result...)
 }
 
+// Map creates a new array or object by applying `func` to each value in `values`.
+//
+// If `values` is an object then the result is an object with the same keys.
+//
+//	@(map(array(1, 2, 3), (x) => x * 2)) -> [2, 4, 6]
+//	@(map(array("a", "b"), upper)) -> [A, B]
+//	@(map(object("a", 1, "b", 2), (x) => x + 1)) -> {a: 2, b: 3}
+//	@(map(array(1, "x"), (x) => x * 2)) -> ERROR
+//
+// @function map(values, func)
+func Map(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
+	items, keys, xerr := toItems(env, arg1)
+	if xerr != nil {
+		return xerr
+	}
+	function, xerr := types.ToXFunction(arg2)
+	if xerr != nil {
+		return xerr
+	}
+
+	result := make([]types.XValue, len(items))
+
+	for i, item := range items {
+		newItem := function.Call(env, []types.XValue{item})
+		if types.IsXError(newItem) {
+			return newItem
+		}
+		result[i] = newItem
+	}
+
+	if keys != nil {
+		props := make(map[string]types.XValue, len(keys))
+		for i, key := range keys {
+			props[key] = result[i]
+		}
+		return types.NewXObject(props)
+	}
+
+	return types.NewXArray(result...)
+}
+
+// Reduce combines the items in `values` into a single value by calling `func` with
+// the combined value so far and each item, starting with `initial`.
+//
+//	@(reduce(array(1, 2, 3), (total, x) => total + x, 0)) -> 6
+//	@(reduce(array("a", "b", "c"), (s, x) => x & s, "")) -> cba
+//	@(reduce(object("a", 1, "b", 2), (total, x) => total + x, 10)) -> 13
+//
+// @function reduce(values, func, initial)
+func Reduce(env envs.Environment, arg1 types.XValue, arg2 types.XValue, initial types.XValue) types.XValue {
+	items, _, xerr := toItems(env, arg1)
+	if xerr != nil {
+		return xerr
+	}
+	function, xerr := types.ToXFunction(arg2)
+	if xerr != nil {
+		return xerr
+	}
+	if types.IsXError(initial) {
+		return initial
+	}
+
+	result := initial
+
+	for _, item := range items {
+		result = function.Call(env, []types.XValue{result, item})
+		if types.IsXError(result) {
+			return result
+		}
+	}
+
+	return result
+}
+
+// SortBy returns a new array with the items of `array` sorted by the keys returned by `func`.
+//
+// The sort is stable so items with equal keys keep their original order. If `func` returns an
+// array then items are sorted by each of those keys in turn. The optional `direction` is either
+// `asc` or `desc`, or an array of those to use a different direction for each key. Null keys
+// sort before all other values.
+//
+//	@(sort_by(array("bb", "a", "ccc"), text_length)) -> [a, bb, ccc]
+//	@(sort_by(array(3, 1, 2), (x) => x, "desc")) -> [3, 2, 1]
+//	@(sort_by(array(object("n", "b", "a", 2), object("n", "a", "a", 2), object("n", "c", "a", 1)), (x) => array(x.a, x.n), array("desc", "asc"))) -> [{a: 2, n: a}, {a: 2, n: b}, {a: 1, n: c}]
+//	@(sort_by(array(1, "x"), (x) => x)) -> ERROR
+//
+// @function sort_by(array, func [, direction])
+func SortBy(env envs.Environment, args ...types.XValue) types.XValue {
+	array, xerr := types.ToXArray(env, args[0])
+	if xerr != nil {
+		return xerr
+	}
+	function, xerr := types.ToXFunction(args[1])
+	if xerr != nil {
+		return xerr
+	}
+
+	var directions []string
+	if len(args) == 3 {
+		if directions, xerr = toSortDirections(env, args[2]); xerr != nil {
+			return xerr
+		}
+	}
+
+	// evaluate the sort keys for every item up front
+	sorted := make([]types.XValue, array.Count())
+	keys := make(map[int][]types.XValue, array.Count())
+	order := make([]int, array.Count())
+
+	for i := 0; i < array.Count(); i++ {
+		sorted[i] = array.Get(i)
+		order[i] = i
+
+		key := function.Call(env, []types.XValue{sorted[i]})
+		if types.IsXError(key) {
+			return key
+		}
+
+		if asArray, isArray := key.(*types.XArray); isArray {
+			keys[i] = make([]types.XValue, asArray.Count())
+			for k := 0; k < asArray.Count(); k++ {
+				keys[i][k] = asArray.Get(k)
+			}
+		} else {
+			keys[i] = []types.XValue{key}
+		}
+	}
+
+	// a single direction applies to all keys
+	descending := func(k int) bool {
+		if len(directions) == 1 {
+			return directions[0] == "desc"
+		}
+		return k < len(directions) && directions[k] == "desc"
+	}
+
+	var sortErr *types.XError
+
+	sort.SliceStable(order, func(i, j int) bool {
+		keys1, keys2 := keys[order[i]], keys[order[j]]
+
+		for k := 0; k < len(keys1) && k < len(keys2); k++ {
+			c, xerr := compareSortKeys(keys1[k], keys2[k])
+			if xerr != nil {
+				sortErr = xerr
+				return false
+			}
+			if c != 0 {
+				if descending(k) {
+					return c > 0
+				}
+				return c < 0
+			}
+		}
+
+		// if all shared keys are equal, fewer keys sorts first
+		return len(keys1) < len(keys2)
+	})
+
+	if sortErr != nil {
+		return sortErr
+	}
+
+	result := make([]types.XValue, len(order))
+	for i, o := range order {
+		result[i] = sorted[o]
+	}
+
+	return types.NewXArray(result...)
+}
+
+// GroupBy returns an object which groups the items of `array` by the text of the key returned by `func`.
+//
+//	@(group_by(array("apple", "avocado", "banana"), (x) => text_slice(x, 0, 1))) -> {a: [apple, avocado], b: [banana]}
+//	@(group_by(array(1, 2, 3, 4), (x) => mod(x, 2) = 0)) -> {false: [1, 3], true: [2, 4]}
+//
+// @function group_by(array, func)
+func GroupBy(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
+	array, xerr := types.ToXArray(env, arg1)
+	if xerr != nil {
+		return xerr
+	}
+	function, xerr := types.ToXFunction(arg2)
+	if xerr != nil {
+		return xerr
+	}
+
+	groups := make(map[string][]types.XValue)
+
+	for i := 0; i < array.Count(); i++ {
+		item := array.Get(i)
+		key, xerr := types.ToXText(env, function.Call(env, []types.XValue{item}))
+		if xerr != nil {
+			return xerr
+		}
+
+		groups[key.Native()] = append(groups[key.Native()], item)
+	}
+
+	result := make(map[string]types.XValue, len(groups))
+	for key, items := range groups {
+		result[key] = types.NewXArray(items...)
+	}
+
+	return types.NewXObject(result)
+}
+
+// Find returns the first item in `values` that when passed to `func` returns true, or null if there isn't one.
+//
+//	@(find(array(1, 5, 10), (x) => x > 3)) -> 5
+//	@(find(array("a", "b"), (x) => x = "c")) ->
+//
+// @function find(values, func)
+func Find(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
+	items, _, xerr := toItems(env, arg1)
+	if xerr != nil {
+		return xerr
+	}
+	function, xerr := types.ToXFunction(arg2)
+	if xerr != nil {
+		return xerr
+	}
+
+	for _, item := range items {
+		asBool, xerr := types.ToXBoolean(function.Call(env, []types.XValue{item}))
+		if xerr != nil {
+			return xerr
+		}
+		if asBool.Native() {
+			return item
+		}
+	}
+
+	return nil
+}
+
+// Any returns whether any item in `values` when passed to `func` returns true.
+//
+//	@(any(array(1, 5, 10), (x) => x > 8)) -> true
+//	@(any(array(), (x) => x > 8)) -> false
+//
+// @function any(values, func)
+func Any(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
+	items, _, xerr := toItems(env, arg1)
+	if xerr != nil {
+		return xerr
+	}
+	function, xerr := types.ToXFunction(arg2)
+	if xerr != nil {
+		return xerr
+	}
+
+	for _, item := range items {
+		asBool, xerr := types.ToXBoolean(function.Call(env, []types.XValue{item}))
+		if xerr != nil {
+			return xerr
+		}
+		if asBool.Native() {
+			return types.XBooleanTrue
+		}
+	}
+
+	return types.XBooleanFalse
+}
+
+// All returns whether every item in `values` when passed to `func` returns true.
+//
+//	@(all(array(1, 5, 10), (x) => x > 0)) -> true
+//	@(all(array(1, 5, 10), (x) => x > 1)) -> false
+//	@(all(array(), (x) => x > 8)) -> true
+//
+// @function all(values, func)
+func All(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
+	items, _, xerr := toItems(env, arg1)
+	if xerr != nil {
+		return xerr
+	}
+	function, xerr := types.ToXFunction(arg2)
+	if xerr != nil {
+		return xerr
+	}
+
+	for _, item := range items {
+		asBool, xerr := types.ToXBoolean(function.Call(env, []types.XValue{item}))
+		if xerr != nil {
+			return xerr
+		}
+		if !asBool.Native() {
+			return types.XBooleanFalse
+		}
+	}
+
+	return types.XBooleanTrue
+}
+
+// Flatten returns a new array with the items of any arrays in `array` pulled up one level.
+//
+//	@(flatten(array(1, array(2, 3), array(array(4))))) -> [1, 2, 3, [4]]
+//	@(flatten(array())) -> []
+//
+// @function flatten(array)
+func Flatten(env envs.Environment, array *types.XArray) types.XValue {
+	flattened := make([]types.XValue, 0, array.Count())
+
+	for i := 0; i < array.Count(); i++ {
+		item := array.Get(i)
+
+		if asArray, isArray := item.(*types.XArray); isArray {
+			for j := 0; j < asArray.Count(); j++ {
+				flattened = append(flattened, asArray.Get(j))
+			}
+		} else {
+			flattened = append(flattened, item)
+		}
+	}
+
+	return types.NewXArray(flattened...)
+}
+
+// Slice returns the items of `array` between `start` (inclusive) and `end` (exclusive).
+//
+// If `end` is not specified then the entire rest of `array` will be included. Negative values
+// for `start` or `end` start at the end of `array`.
+//
+//	@(slice(array("a", "b", "c", "d"), 1)) -> [b, c, d]
+//	@(slice(array("a", "b", "c", "d"), 1, 3)) -> [b, c]
+//	@(slice(array("a", "b", "c", "d"), -2)) -> [c, d]
+//	@(slice(array("a", "b", "c", "d"), 5)) -> []
+//
+// @function slice(array, start [, end])
+func Slice(env envs.Environment, args ...types.XValue) types.XValue {
+	array, xerr := types.ToXArray(env, args[0])
+	if xerr != nil {
+		return xerr
+	}
+
+	length := array.Count()
+
+	start, xerr := types.ToInteger(env, args[1])
+	if xerr != nil {
+		return xerr
+	}
+	if start < 0 {
+		start = max(length+start, 0)
+	}
+
+	end := length
+	if len(args) == 3 {
+		if end, xerr = types.ToInteger(env, args[2]); xerr != nil {
+			return xerr
+		}
+	}
+	if end < 0 {
+		end = length + end
+	}
+	end = min(end, length)
+
+	items := make([]types.XValue, 0, max(end-start, 0))
+	for i := start; i < end; i++ {
+		items = append(items, array.Get(i))
+	}
+
+	return types.NewXArray(items...)
+}
+
+// Zip returns an array of arrays where the first contains the first item of each of `arrays`,
+// the second contains the second item of each, and so on.
+//
+// The result is as long as the shortest of `arrays`.
+//
+//	@(zip(array("a", "b", "c"), array(1, 2, 3))) -> [[a, 1], [b, 2], [c, 3]]
+//	@(zip(array("a", "b", "c"), array(1, 2))) -> [[a, 1], [b, 2]]
+//
+// @function zip(arrays...)
+func Zip(env envs.Environment, args ...types.XValue) types.XValue {
+	arrays := make([]*types.XArray, len(args))
+	length := -1
+
+	for i, arg := range args {
+		array, xerr := types.ToXArray(env, arg)
+		if xerr != nil {
+			return xerr
+		}
+		arrays[i] = array
+
+		if length < 0 || array.Count() < length {
+			length = array.Count()
+		}
+	}
+
+	zipped := make([]types.XValue, length)
+	for i := 0; i < length; i++ {
+		items := make([]types.XValue, len(arrays))
+		for j, array := range arrays {
+			items[j] = array.Get(i)
+		}
+		zipped[i] = types.NewXArray(items...)
+	}
+
+	return types.NewXArray(zipped...)
+}
+
+// gets the items of an array, or the property values of an object along with its keys
+func toItems(env envs.Environment, v types.XValue) ([]types.XValue, []string, *types.XError) {
+	if object, isObject := v.(*types.XObject); isObject && object != nil {
+		keys := object.Properties()
+		items := make([]types.XValue, len(keys))
+		for i, key := range keys {
+			items[i], _ = object.Get(key)
+		}
+		return items, keys, nil
+	}
+
+	array, xerr := types.ToXArray(env, v)
+	if xerr != nil {
+		return nil, nil, xerr
+	}
+
+	items := make([]types.XValue, array.Count())
+	for i := range items {
+		items[i] = array.Get(i)
+	}
+	return items, nil, nil
+}
+
+// converts a sort direction or array of sort directions to a slice of asc/desc values
+func toSortDirections(env envs.Environment, v types.XValue) ([]string, *types.XError) {
+	values := []types.XValue{v}
+	if asArray, isArray := v.(*types.XArray); isArray {
+		values = make([]types.XValue, asArray.Count())
+		for i := range values {
+			values[i] = asArray.Get(i)
+		}
+	}
+
+	directions := make([]string, len(values))
+	for i, value := range values {
+		asText, xerr := types.ToXText(env, value)
+		if xerr != nil {
+			return nil, xerr
+		}
+
+		direction := strings.ToLower(asText.Native())
+		if direction != "asc" && direction != "desc" {
+			return nil, types.NewXErrorf("%s isn't a valid sort direction", asText.Describe())
+		}
+		directions[i] = direction
+	}
+
+	return directions, nil
+}
+
+// compares two sort keys, where null sorts before any other value
+func compareSortKeys(k1, k2 types.XValue) (int, *types.XError) {
+	if types.IsNil(k1) || types.IsNil(k2) {
+		if types.IsNil(k1) && types.IsNil(k2) {
+			return 0, nil
+		} else if types.IsNil(k1) {
+			return -1, nil
+		}
+		return 1, nil
+	}
+
+	c1, isComparable := k1.(types.XComparable)
+	if !isComparable {
+		return 0, types.NewXErrorf("%s isn't a comparable type", types.Describe(k1))
+	}
+	if !types.SameType(k1, k2) {
+		return 0, types.NewXErrorf("can't sort by keys of different types")
+	}
+
+	return c1.Compare(k2), nil
+}
+
 //------------------------------------------------------------------------------------------
 // Encoded Text Functions
 //------------------------------------------------------------------------------------------
@@ -1764,6 +2863,298 @@ func AttachmentParts(env envs.Environment, attachment *types.XText) types.XValue
 	})
 }
 
+// URLParts parses a URL into its different parts.
+//
+// The `query` is returned as an object of parameter values, where a parameter which is repeated takes its first value.
+//
+//	@(url_parts("https://example.com:8080/path/to?x=1&y=2#top")) -> {fragment: top, host: example.com, path: /path/to, port: 8080, query: {x: 1, y: 2}, scheme: https}
+//	@(url_parts("https://example.com").host) -> example.com
+//	@(url_parts("not a url")) -> ERROR
+//
+// @function url_parts(url)
+func URLParts(env envs.Environment, text *types.XText) types.XValue {
+	u, err := url.Parse(text.Native())
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return types.NewXErrorf("%s is not a valid URL", text.Describe())
+	}
+
+	values := u.Query()
+	query := make(map[string]types.XValue, len(values))
+	for k := range values {
+		query[k] = types.NewXText(values.Get(k))
+	}
+
+	return types.NewXObject(map[string]types.XValue{
+		"scheme":   types.NewXText(u.Scheme),
+		"host":     types.NewXText(u.Hostname()),
+		"port":     types.NewXText(u.Port()),
+		"path":     types.NewXText(u.Path),
+		"query":    types.NewXObject(query),
+		"fragment": types.NewXText(u.Fragment),
+	})
+}
+
+// Base64Encode encodes `text` as base64.
+//
+//	@(base64_encode("hello")) -> aGVsbG8=
+//	@(base64_encode("bob:secret")) -> Ym9iOnNlY3JldA==
+//
+// @function base64_encode(text)
+func Base64Encode(env envs.Environment, text *types.XText) types.XValue {
+	return types.NewXText(base64.StdEncoding.EncodeToString([]byte(text.Native())))
+}
+
+// Base64Decode decodes `text` from base64.
+//
+// If `text` isn't valid base64, or doesn't decode to valid text, then an error is returned.
+//
+//	@(base64_decode("aGVsbG8=")) -> hello
+//	@(base64_decode("???")) -> ERROR
+//
+// @function base64_decode(text)
+func Base64Decode(env envs.Environment, text *types.XText) types.XValue {
+	decoded, err := base64.StdEncoding.DecodeString(text.Native())
+	if err != nil {
+		return types.NewXErrorf("%s isn't valid base64", text.Describe())
+	}
+	if !utf8.Valid(decoded) {
+		return types.NewXErrorf("%s doesn't decode to valid text", text.Describe())
+	}
+	return types.NewXText(string(decoded))
+}
+
+// Hex encodes `text` as hexadecimal.
+//
+//	@(hex("hello")) -> 68656c6c6f
+//	@(hex("😀")) -> f09f9880
+//
+// @function hex(text)
+func Hex(env envs.Environment, text *types.XText) types.XValue {
+	return types.NewXText(hex.EncodeToString([]byte(text.Native())))
+}
+
+// MD5 returns the MD5 hash of `text` as hexadecimal.
+//
+//	@(md5("hello")) -> 5d41402abc4b2a76b9719d911017c592
+//
+// @function md5(text)
+func MD5(env envs.Environment, text *types.XText) types.XValue {
+	hash := md5.Sum([]byte(text.Native()))
+	return types.NewXText(hex.EncodeToString(hash[:]))
+}
+
+// SHA1 returns the SHA-1 hash of `text` as hexadecimal.
+//
+//	@(sha1("hello")) -> aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d
+//
+// @function sha1(text)
+func SHA1(env envs.Environment, text *types.XText) types.XValue {
+	hash := sha1.Sum([]byte(text.Native()))
+	return types.NewXText(hex.EncodeToString(hash[:]))
+}
+
+// SHA256 returns the SHA-256 hash of `text` as hexadecimal.
+//
+//	@(sha256("hello")) -> 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824
+//
+// @function sha256(text)
+func SHA256(env envs.Environment, text *types.XText) types.XValue {
+	hash := sha256.Sum256([]byte(text.Native()))
+	return types.NewXText(hex.EncodeToString(hash[:]))
+}
+
+// HMACSHA256 returns the HMAC-SHA256 signature of `text` using `key` as hexadecimal.
+//
+//	@(hmac_sha256("secret", "hello")) -> 88aab3ede8d3adf94d26ab90d3bafd4a2083070c3bcce9c014ee04a443847c0b
+//
+// @function hmac_sha256(key, text)
+func HMACSHA256(env envs.Environment, key *types.XText, text *types.XText) types.XValue {
+	mac := hmac.New(sha256.New, []byte(key.Native()))
+	mac.Write([]byte(text.Native()))
+	return types.NewXText(hex.EncodeToString(mac.Sum(nil)))
+}
+
+//------------------------------------------------------------------------------------------
+// Geo Functions
+//------------------------------------------------------------------------------------------
+
+// GeoParse parses a GPS point from `text` which can be a `geo` attachment or a latitude and longitude
+// separated by a comma.
+//
+//	@(geo_parse("geo:-1.9441,30.0619")) -> {lat: -1.9441, lng: 30.0619}
+//	@(geo_parse("-1.9441, 30.0619").lng) -> 30.0619
+//	@(geo_parse("image/jpeg:https://example.com/test.jpg")) -> ERROR
+//
+// @function geo_parse(text)
+func GeoParse(env envs.Environment, text *types.XText) types.XValue {
+	point, err := utils.ParseGeoPoint(text.Native())
+	if err != nil {
+		return types.NewXError(err)
+	}
+
+	return types.NewXObject(map[string]types.XValue{
+		"lat": types.NewXNumber(decimal.NewFromFloat(point.Lat)),
+		"lng": types.NewXNumber(decimal.NewFromFloat(point.Lng)),
+	})
+}
+
+// GeoDistance returns the distance between the GPS points `point1` and `point2`.
+//
+// Points can be `geo` attachments, text like `-1.9441,30.0619` or objects returned by [function:geo_parse].
+// The optional `unit` can be `km` (the default), `m` or `mi`, and the distance is rounded to 3 decimal places.
+//
+//	@(geo_distance("geo:-1.9441,30.0619", "geo:-1.9706,30.1044")) -> 5.567
+//	@(geo_distance("-1.9441,30.0619", "-1.9706,30.1044", "m")) -> 5566.858
+//	@(geo_distance("-1.9441,30.0619", "-1.9706,30.1044", "mi")) -> 3.459
+//	@(geo_distance("-1.9441,30.0619", "-1.9706,30.1044", "ft")) -> ERROR
+//
+// @function geo_distance(point1, point2 [, unit])
+func GeoDistance(env envs.Environment, args ...types.XValue) types.XValue {
+	point1, xerr := toGeoPoint(env, args[0])
+	if xerr != nil {
+		return xerr
+	}
+	point2, xerr := toGeoPoint(env, args[1])
+	if xerr != nil {
+		return xerr
+	}
+
+	unit := "km"
+	if len(args) == 3 {
+		unitArg, xerr := types.ToXText(env, args[2])
+		if xerr != nil {
+			return xerr
+		}
+		unit = strings.ToLower(unitArg.Native())
+	}
+
+	metersPerUnit, ok := geoDistanceUnits[unit]
+	if !ok {
+		return types.NewXErrorf("%s is not a valid distance unit", unit)
+	}
+
+	distance := point1.DistanceTo(point2) / metersPerUnit
+
+	return types.NewXNumber(decimal.NewFromFloat(distance).Round(3))
+}
+
+// Geohash encodes the GPS `point` as a geohash.
+//
+// The optional `precision` is the number of characters in the geohash, from 1 to 12, and defaults to 9.
+//
+//	@(geohash("geo:-1.9441,30.0619")) -> kxtkun46v
+//	@(geohash("-1.9441,30.0619", 5)) -> kxtku
+//	@(geohash("-1.9441,30.0619", 13)) -> ERROR
+//
+// @function geohash(point [, precision])
+func Geohash(env envs.Environment, args ...types.XValue) types.XValue {
+	point, xerr := toGeoPoint(env, args[0])
+	if xerr != nil {
+		return xerr
+	}
+
+	precision := 9
+	if len(args) == 2 {
+		if precision, xerr = types.ToInteger(env, args[1]); xerr != nil {
+			return xerr
+		}
+		if precision < 1 || precision > 12 {
+			return types.NewXErrorf("precision must be between 1 and 12")
+		}
+	}
+
+	return types.NewXText(point.Geohash(precision))
+}
+
+// GeoWithin returns whether the GPS `point` is inside `area`.
+//
+// The `area` can be an array of points which make up a polygon, or the path of a location whose boundary
+// is included in the location hierarchy.
+//
+//	@(geo_within("geo:-1.9441,30.0619", "Rwanda > Kigali City")) -> true
+//	@(geo_within("geo:-1.9441,30.0619", "Rwanda > Kigali City > Nyarugenge")) -> false
+//	@(geo_within("-1.5,30.5", array("-2,30", "-2,31", "-1,31", "-1,30"))) -> true
+//	@(geo_within("-1.5,30.5", "Rwanda > Boston")) -> ERROR
+//
+// @function geo_within(point, area)
+func GeoWithin(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
+	point, xerr := toGeoPoint(env, arg1)
+	if xerr != nil {
+		return xerr
+	}
+
+	if array, isArray := arg2.(*types.XArray); isArray {
+		polygon := make(utils.GeoPolygon, array.Count())
+		for i := 0; i < array.Count(); i++ {
+			if polygon[i], xerr = toGeoPoint(env, array.Get(i)); xerr != nil {
+				return xerr
+			}
+		}
+		return types.NewXBoolean(polygon.Contains(point))
+	}
+
+	path, xerr := types.ToXText(env, arg2)
+	if xerr != nil {
+		return xerr
+	}
+
+	locations := env.LocationResolver()
+	if locations == nil {
+		return types.NewXErrorf("can't find locations in environment which is not location enabled")
+	}
+
+	location := locations.LookupLocation(envs.LocationPath(path.Native()))
+	if location == nil {
+		return types.NewXErrorf("no such location %s", path.Native())
+	}
+	if len(location.Boundary()) == 0 {
+		return types.NewXErrorf("location %s has no boundary", location.Path())
+	}
+
+	return types.NewXBoolean(location.Contains(point))
+}
+
+// distance units and their size in meters
+var geoDistanceUnits = map[string]float64{
+	"m":  1,
+	"km": 1000,
+	"mi": 1609.344,
+}
+
+// converts a value to a GPS point, accepting text or objects with lat and lng properties
+func toGeoPoint(env envs.Environment, value types.XValue) (utils.GeoPoint, *types.XError) {
+	if object, isObject := value.(*types.XObject); isObject {
+		lat, hasLat := object.Get("lat")
+		lng, hasLng := object.Get("lng")
+		if hasLat && hasLng {
+			latNum, xerr := types.ToXNumber(env, lat)
+			if xerr != nil {
+				return utils.GeoPoint{}, xerr
+			}
+			lngNum, xerr := types.ToXNumber(env, lng)
+			if xerr != nil {
+				return utils.GeoPoint{}, xerr
+			}
+			point := utils.GeoPoint{Lat: latNum.Native().InexactFloat64(), Lng: lngNum.Native().InexactFloat64()}
+			if !point.IsValid() {
+				return utils.GeoPoint{}, types.NewXErrorf("%s is not a valid geo point", point)
+			}
+			return point, nil
+		}
+	}
+
+	text, xerr := types.ToXText(env, value)
+	if xerr != nil {
+		return utils.GeoPoint{}, xerr
+	}
+
+	point, err := utils.ParseGeoPoint(text.Native())
+	if err != nil {
+		return utils.GeoPoint{}, types.NewXError(err)
+	}
+	return point, nil
+}
+
 //------------------------------------------------------------------------------------------
 // JSON Functions
 //------------------------------------------------------------------------------------------
@@ -2033,6 +3424,61 @@ func FormatNumber(env envs.Environment, args ...types.XValue) types.XValue {
 	return types.NewXText(num.FormatCustom(env.NumberFormat(), places, human.Native()))
 }
 
+// FormatCurrency formats `amount` as money in the currency with the given ISO 4217 `code`.
+//
+// The number conventions and currency symbol of the contact's locale are used, and the amount is
+// rounded to the number of decimal places used by the currency.
+//
+//	@(format_currency(1234.5, "KES")) -> KES 1,234.50
+//	@(format_currency(1234.5, "USD")) -> $ 1,234.50
+//	@(format_currency(1234.567, "JPY")) -> ¥ 1,235
+//	@(format_currency(1234.5, "XYZ")) -> ERROR
+//
+// @function format_currency(amount, code)
+func FormatCurrency(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
+	amount, xerr := types.ToXNumber(env, arg1)
+	if xerr != nil {
+		return xerr
+	}
+	code, xerr := types.ToXText(env, arg2)
+	if xerr != nil {
+		return xerr
+	}
+
+	unit, err := currency.ParseISO(code.Native())
+	if err != nil {
+		return types.NewXErrorf("%s is not a valid currency code", code.Describe())
+	}
+
+	tag, _ := language.Parse(string(env.DefaultLocale()))
+	value, _ := amount.Native().Float64()
+
+	return types.NewXText(message.NewPrinter(tag).Sprint(currency.Symbol(unit.Amount(value))))
+}
+
+// FormatOrdinal formats `number` as an ordinal in the contact's language, e.g. 1st, 2nd.
+//
+// Supported languages are English, Spanish, French and Portuguese. An error is returned if
+// `number` isn't a whole number.
+//
+//	@(format_ordinal(1)) -> 1st
+//	@(format_ordinal(22)) -> 22nd
+//	@(format_ordinal(113)) -> 113th
+//	@(format_ordinal(1.5)) -> ERROR
+//
+// @function format_ordinal(number)
+func FormatOrdinal(env envs.Environment, num *types.XNumber) types.XValue {
+	if !num.Native().IsInteger() {
+		return types.NewXErrorf("can only format whole numbers as ordinals")
+	}
+
+	formatted, err := utils.FormatOrdinal(num.Native().IntPart(), env.DefaultLanguage())
+	if err != nil {
+		return types.NewXError(err)
+	}
+	return types.NewXText(formatted)
+}
+
 // FormatLocation formats the given `location` as its name.
 //
 //	@(format_location("Rwanda")) -> Rwanda
@@ -2266,6 +3712,15 @@ func ForEachValue(env envs.Environment, args ...types.XValue) types.XValue {
 	return types.NewXObject(result)
 }
 
+// UUID returns a new random UUID which can be used as an identifier, e.g. an idempotency key.
+//
+//	@(uuid()) -> 4f15f627-b1e2-4851-8dbf-00ecf5d03034
+//
+// @function uuid()
+func UUID(env envs.Environment) types.XValue {
+	return types.NewXText(string(uuids.New()))
+}
+
 // LegacyAdd simulates our old + operator, which operated differently based on whether
 // one of the parameters was a date or not. If one is a date, then the other side is
 // expected to be an integer with a number of days to add to the date, otherwise a normal
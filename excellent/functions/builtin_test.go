@@ -3,11 +3,13 @@ package functions_test
 import (
 	"fmt"
 	"math"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/gocommon/random"
+	"github.com/nyaruka/gocommon/uuids"
 	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/excellent/functions"
 	"github.com/nyaruka/goflow/excellent/types"
@@ -37,6 +39,11 @@ func TestFunctions(t *testing.T) {
 		WithTimeFormat(envs.TimeFormatHourMinuteAmPm).
 		WithTimezone(la).
 		Build()
+	sun := envs.NewBuilder().WithWeekStart(time.Sunday).Build()
+	sat := envs.NewBuilder().WithWeekStart(time.Saturday).Build()
+	spa := envs.NewBuilder().WithAllowedLanguages("spa").WithDefaultCountry("EC").Build()
+	fra := envs.NewBuilder().WithAllowedLanguages("fra").WithDefaultCountry("FR").Build()
+	kin := envs.NewBuilder().WithAllowedLanguages("kin").WithDefaultCountry("RW").Build()
 
 	var funcTests = []struct {
 		name     string
@@ -52,12 +59,38 @@ func TestFunctions(t *testing.T) {
 		{"abs", dmy, []types.XValue{ERROR}, ERROR},
 		{"abs", dmy, []types.XValue{}, ERROR},
 
+		{"age", dmy, []types.XValue{xs("1979-07-18")}, xi(38)},
+		{"age", dmy, []types.XValue{xs("2000-04-11")}, xi(18)},
+		{"age", dmy, []types.XValue{xs("2000-04-12")}, xi(17)},
+		{"age", dmy, []types.XValue{xs("2018-04-11")}, xi(0)},
+		{"age", dmy, []types.XValue{xs("2018-04-12")}, ERROR},
+		{"age", dmy, []types.XValue{xs("xx")}, ERROR},
+		{"age", dmy, []types.XValue{}, ERROR},
+
+		{"all", dmy, []types.XValue{xa(xi(1), xs("x")), xf("boolean")}, types.XBooleanTrue},
+		{"all", dmy, []types.XValue{xa(xi(1), xi(0)), xf("boolean")}, types.XBooleanFalse},
+		{"all", dmy, []types.XValue{xa(), xf("boolean")}, types.XBooleanTrue},
+		{"all", dmy, []types.XValue{xo(map[string]types.XValue{"a": xi(1), "b": xi(2)}), xf("boolean")}, types.XBooleanTrue},
+		{"all", dmy, []types.XValue{xa(xi(1), xs("x")), xf("abs")}, ERROR},
+		{"all", dmy, []types.XValue{ERROR, xf("boolean")}, ERROR},
+		{"all", dmy, []types.XValue{xa(xi(1)), ERROR}, ERROR},
+		{"all", dmy, []types.XValue{}, ERROR},
+
 		{"and", dmy, []types.XValue{types.XBooleanTrue}, types.XBooleanTrue},
 		{"and", dmy, []types.XValue{types.XBooleanFalse}, types.XBooleanFalse},
 		{"and", dmy, []types.XValue{types.XBooleanTrue, types.XBooleanFalse}, types.XBooleanFalse},
 		{"and", dmy, []types.XValue{ERROR}, ERROR},
 		{"and", dmy, []types.XValue{}, ERROR},
 
+		{"any", dmy, []types.XValue{xa(xi(0), xs("x")), xf("boolean")}, types.XBooleanTrue},
+		{"any", dmy, []types.XValue{xa(xi(0), xs("")), xf("boolean")}, types.XBooleanFalse},
+		{"any", dmy, []types.XValue{xa(), xf("boolean")}, types.XBooleanFalse},
+		{"any", dmy, []types.XValue{xo(map[string]types.XValue{"a": xi(0), "b": xi(2)}), xf("boolean")}, types.XBooleanTrue},
+		{"any", dmy, []types.XValue{xa(xs("x"), xi(1)), xf("abs")}, ERROR},
+		{"any", dmy, []types.XValue{ERROR, xf("boolean")}, ERROR},
+		{"any", dmy, []types.XValue{xa(xi(1)), ERROR}, ERROR},
+		{"any", dmy, []types.XValue{}, ERROR},
+
 		{"array", dmy, []types.XValue{}, xa()},
 		{"array", dmy, []types.XValue{xi(123), xs("abc")}, xa(xi(123), xs("abc"))},
 		{"array", dmy, []types.XValue{xi(123), ERROR, xs("abc")}, ERROR},
@@ -73,6 +106,19 @@ func TestFunctions(t *testing.T) {
 		{"attachment_parts", dmy, []types.XValue{ERROR}, ERROR},
 		{"attachment_parts", dmy, []types.XValue{}, ERROR},
 
+		{"base64_decode", dmy, []types.XValue{xs("Ym9iOnNlY3JldA==")}, xs("bob:secret")},
+		{"base64_decode", dmy, []types.XValue{xs("")}, xs("")},
+		{"base64_decode", dmy, []types.XValue{xs("Ym9i!")}, ERROR},
+		{"base64_decode", dmy, []types.XValue{xs("/w==")}, ERROR}, // not valid UTF-8
+		{"base64_decode", dmy, []types.XValue{ERROR}, ERROR},
+		{"base64_decode", dmy, []types.XValue{}, ERROR},
+
+		{"base64_encode", dmy, []types.XValue{xs("bob:secret")}, xs("Ym9iOnNlY3JldA==")},
+		{"base64_encode", dmy, []types.XValue{xs("😀")}, xs("8J+YgA==")},
+		{"base64_encode", dmy, []types.XValue{xs("")}, xs("")},
+		{"base64_encode", dmy, []types.XValue{ERROR}, ERROR},
+		{"base64_encode", dmy, []types.XValue{}, ERROR},
+
 		{"boolean", dmy, []types.XValue{xs("abc")}, types.XBooleanTrue},
 		{"boolean", dmy, []types.XValue{xs("false")}, types.XBooleanFalse},
 		{"boolean", dmy, []types.XValue{xs("FALSE")}, types.XBooleanFalse},
@@ -81,6 +127,23 @@ func TestFunctions(t *testing.T) {
 		{"boolean", dmy, []types.XValue{ERROR}, ERROR},
 		{"boolean", dmy, []types.XValue{}, ERROR},
 
+		{"business_days_add", dmy, []types.XValue{xs("2018-04-11T10:00:00Z"), xi(2)}, xdt(time.Date(2018, 4, 13, 10, 0, 0, 0, time.UTC))},
+		{"business_days_add", dmy, []types.XValue{xs("2018-04-11T10:00:00Z"), xi(3)}, xdt(time.Date(2018, 4, 16, 10, 0, 0, 0, time.UTC))},
+		{"business_days_add", dmy, []types.XValue{xs("2018-04-14T10:00:00Z"), xi(1)}, xdt(time.Date(2018, 4, 16, 10, 0, 0, 0, time.UTC))},
+		{"business_days_add", dmy, []types.XValue{xs("2018-04-14T10:00:00Z"), xi(0)}, xdt(time.Date(2018, 4, 14, 10, 0, 0, 0, time.UTC))},
+		{"business_days_add", dmy, []types.XValue{xs("2018-04-16T10:00:00Z"), xi(-1)}, xdt(time.Date(2018, 4, 13, 10, 0, 0, 0, time.UTC))},
+		{"business_days_add", mdy, []types.XValue{xs("2018-04-14T02:00:00Z"), xi(1)}, xdt(time.Date(2018, 4, 16, 19, 0, 0, 0, la))},        // Friday in LA
+		{"business_days_add", dmy, []types.XValue{xs("2018-04-14T10:00:00Z"), xi(5)}, xdt(time.Date(2018, 4, 20, 10, 0, 0, 0, time.UTC))},  // Saturday
+		{"business_days_add", dmy, []types.XValue{xs("2018-04-15T10:00:00Z"), xi(-1)}, xdt(time.Date(2018, 4, 13, 10, 0, 0, 0, time.UTC))}, // Sunday
+		{"business_days_add", dmy, []types.XValue{xs("2018-04-15T10:00:00Z"), xi(-5)}, xdt(time.Date(2018, 4, 9, 10, 0, 0, 0, time.UTC))},
+		{"business_days_add", dmy, []types.XValue{xs("2018-04-11T10:00:00Z"), xi(12)}, xdt(time.Date(2018, 4, 27, 10, 0, 0, 0, time.UTC))},
+		{"business_days_add", dmy, []types.XValue{xs("2018-04-11T10:00:00Z"), xi(-13)}, xdt(time.Date(2018, 3, 23, 10, 0, 0, 0, time.UTC))},
+		{"business_days_add", dmy, []types.XValue{xs("2018-04-11T10:00:00Z"), xi(260)}, xdt(time.Date(2019, 4, 10, 10, 0, 0, 0, time.UTC))},
+		{"business_days_add", dmy, []types.XValue{xs("2018-04-11T10:00:00Z"), xi(1000000000)}, ERROR},
+		{"business_days_add", dmy, []types.XValue{xs("xx"), xi(1)}, ERROR},
+		{"business_days_add", dmy, []types.XValue{xs("2018-04-11T10:00:00Z"), xs("xx")}, ERROR},
+		{"business_days_add", dmy, []types.XValue{xs("2018-04-11T10:00:00Z")}, ERROR},
+
 		{"char", dmy, []types.XValue{xn("33")}, xs("!")},
 		{"char", dmy, []types.XValue{xn("128513")}, xs("😁")},
 		{"char", dmy, []types.XValue{xs("not a number")}, ERROR},
@@ -207,6 +270,13 @@ func TestFunctions(t *testing.T) {
 		{"datetime_from_epoch", dmy, []types.XValue{ERROR}, ERROR},
 		{"datetime_from_epoch", dmy, []types.XValue{}, ERROR},
 
+		{"duration", dmy, []types.XValue{xs("2h30m")}, types.NewXDuration(0, 0, 150*time.Minute)},
+		{"duration", dmy, []types.XValue{xs("P1Y2M3DT4H")}, types.NewXDuration(14, 3, 4*time.Hour)},
+		{"duration", dmy, []types.XValue{types.NewXDuration(0, 1, 0)}, types.NewXDuration(0, 1, 0)},
+		{"duration", dmy, []types.XValue{xi(3)}, ERROR},
+		{"duration", dmy, []types.XValue{xs("xx")}, ERROR},
+		{"duration", dmy, []types.XValue{}, ERROR},
+
 		{"default", dmy, []types.XValue{xs("10"), xs("20")}, xs("10")},
 		{"default", dmy, []types.XValue{nil, xs("20")}, xs("20")},
 		{"default", dmy, []types.XValue{types.NewXObject(map[string]types.XValue{"__default__": xs("hello")}), xs("def")}, types.NewXObject(map[string]types.XValue{"__default__": xs("hello")})},
@@ -214,6 +284,13 @@ func TestFunctions(t *testing.T) {
 		{"default", dmy, []types.XValue{types.NewXErrorf("This is error"), xs("20")}, xs("20")},
 		{"default", dmy, []types.XValue{}, ERROR},
 
+		{"end_of_month", dmy, []types.XValue{xs("2019-02-10")}, xd(dates.NewDate(2019, 2, 28))},
+		{"end_of_month", dmy, []types.XValue{xs("2020-02-29")}, xd(dates.NewDate(2020, 2, 29))},
+		{"end_of_month", dmy, []types.XValue{xs("2019-12-01")}, xd(dates.NewDate(2019, 12, 31))},
+		{"end_of_month", mdy, []types.XValue{xdt(time.Date(2019, 12, 1, 3, 0, 0, 0, time.UTC))}, xd(dates.NewDate(2019, 11, 30))},
+		{"end_of_month", dmy, []types.XValue{xs("xx")}, ERROR},
+		{"end_of_month", dmy, []types.XValue{}, ERROR},
+
 		{"extract", dmy, []types.XValue{types.NewXObject(map[string]types.XValue{"foo": xs("hello")}), xs("foo")}, xs("hello")},
 		{"extract", dmy, []types.XValue{types.NewXObject(map[string]types.XValue{"foo": xs("hello")}), xs("bar")}, nil},
 		{"extract", dmy, []types.XValue{types.NewXObject(map[string]types.XValue{"foo": xs("hello")}), xs("foo"), xs("bar")}, ERROR},
@@ -274,6 +351,19 @@ func TestFunctions(t *testing.T) {
 		{"filter", dmy, []types.XValue{ERROR, xf("boolean")}, ERROR},
 		{"filter", dmy, []types.XValue{xa(xi(1), xi(0), xi(2)), ERROR}, ERROR},
 
+		{"find", dmy, []types.XValue{xa(xi(0), xs(""), xs("x"), xs("y")), xf("boolean")}, xs("x")},
+		{"find", dmy, []types.XValue{xa(xi(0), xs("")), xf("boolean")}, nil},
+		{"find", dmy, []types.XValue{xo(map[string]types.XValue{"a": xi(0), "b": xi(2)}), xf("boolean")}, xi(2)},
+		{"find", dmy, []types.XValue{xa(xs("x")), xf("abs")}, ERROR},
+		{"find", dmy, []types.XValue{ERROR, xf("boolean")}, ERROR},
+		{"find", dmy, []types.XValue{xa(xi(1)), ERROR}, ERROR},
+		{"find", dmy, []types.XValue{}, ERROR},
+
+		{"flatten", dmy, []types.XValue{xa(xi(1), xa(xi(2), xi(3)), xa(xa(xi(4))))}, xa(xi(1), xi(2), xi(3), xa(xi(4)))},
+		{"flatten", dmy, []types.XValue{xa()}, xa()},
+		{"flatten", dmy, []types.XValue{ERROR}, ERROR},
+		{"flatten", dmy, []types.XValue{}, ERROR},
+
 		{"foreach", dmy, []types.XValue{xa(xs("a"), xs("b"), xs("c")), xf("upper")}, xa(xs("A"), xs("B"), xs("C"))},
 		{"foreach", dmy, []types.XValue{xa(xs("the man"), xs("fox"), xs("jumped up")), xf("word"), xi(0)}, xa(xs("the"), xs("fox"), xs("jumped"))},
 		{"foreach", dmy, []types.XValue{ERROR, xf("upper")}, ERROR},
@@ -362,6 +452,27 @@ func TestFunctions(t *testing.T) {
 		{"format_number", dmy, []types.XValue{ERROR}, ERROR},
 		{"format_number", dmy, []types.XValue{}, ERROR},
 
+		{"format_currency", dmy, []types.XValue{xn("1234.5"), xs("KES")}, xs("KES 1,234.50")},
+		{"format_currency", dmy, []types.XValue{xn("1234.567"), xs("JPY")}, xs("JP¥ 1,235")},
+		{"format_currency", spa, []types.XValue{xn("1234.5"), xs("USD")}, xs("$ 1.234,50")},
+		{"format_currency", spa, []types.XValue{xn("1234.567"), xs("EUR")}, xs("EUR 1.234,57")},
+		{"format_currency", fra, []types.XValue{xs("1234.5"), xs("EUR")}, xs("€ 1\u00a0234,50")},
+		{"format_currency", fra, []types.XValue{xs("1234.5"), xs("USD")}, xs("$US 1\u00a0234,50")},
+		{"format_currency", kin, []types.XValue{xn("-1234.5"), xs("USD")}, xs("US$ -1.234,50")},
+		{"format_currency", dmy, []types.XValue{xn("1234.5"), xs("XYZ")}, ERROR},
+		{"format_currency", dmy, []types.XValue{xs("abc"), xs("USD")}, ERROR},
+		{"format_currency", dmy, []types.XValue{xn("1234.5"), ERROR}, ERROR},
+		{"format_currency", dmy, []types.XValue{xn("1234.5")}, ERROR},
+
+		{"format_ordinal", dmy, []types.XValue{xi(1)}, xs("1st")},
+		{"format_ordinal", dmy, []types.XValue{xi(12)}, xs("12th")},
+		{"format_ordinal", dmy, []types.XValue{xi(23)}, xs("23rd")},
+		{"format_ordinal", spa, []types.XValue{xi(3)}, xs("3.º")},
+		{"format_ordinal", fra, []types.XValue{xi(1)}, xs("1er")},
+		{"format_ordinal", kin, []types.XValue{xi(1)}, ERROR},
+		{"format_ordinal", dmy, []types.XValue{xn("1.5")}, ERROR},
+		{"format_ordinal", dmy, []types.XValue{}, ERROR},
+
 		{"format_urn", dmy, []types.XValue{xs("tel:+14132378053")}, xs("(413) 237-8053")},
 		{"format_urn", dmy, []types.XValue{xs("tel:+250781234567")}, xs("0781 234 567")},
 		{"format_urn", dmy, []types.XValue{xs("twitter:134252511151#billy_bob")}, xs("billy_bob")},
@@ -370,10 +481,64 @@ func TestFunctions(t *testing.T) {
 		{"format_urn", dmy, []types.XValue{ERROR}, ERROR},
 		{"format_urn", dmy, []types.XValue{}, ERROR},
 
+		{"geo_distance", dmy, []types.XValue{xs("geo:51.5074,-0.1278"), xs("geo:48.8566,2.3522")}, xn("343.557")},
+		{"geo_distance", dmy, []types.XValue{xs("51.5074,-0.1278"), xs("51.5074,-0.1278"), xs("m")}, xi(0)},
+		{"geo_distance", dmy, []types.XValue{xo(map[string]types.XValue{"lat": xn("51.5074"), "lng": xn("-0.1278")}), xs("48.8566,2.3522"), xs("MI")}, xn("213.476")},
+		{"geo_distance", dmy, []types.XValue{xs("51.5074,-0.1278"), xs("48.8566,2.3522"), xs("ft")}, ERROR},
+		{"geo_distance", dmy, []types.XValue{xo(map[string]types.XValue{"lat": xn("95"), "lng": xn("0")}), xs("48.8566,2.3522")}, ERROR},
+		{"geo_distance", dmy, []types.XValue{xs("London"), xs("48.8566,2.3522")}, ERROR},
+		{"geo_distance", dmy, []types.XValue{xs("51.5074,-0.1278"), ERROR}, ERROR},
+		{"geo_distance", dmy, []types.XValue{xs("51.5074,-0.1278")}, ERROR},
+
+		{"geo_parse", dmy, []types.XValue{xs("geo:-1.9441,30.0619")}, xo(map[string]types.XValue{"lat": xn("-1.9441"), "lng": xn("30.0619")})},
+		{"geo_parse", dmy, []types.XValue{xs("45, -90")}, xo(map[string]types.XValue{"lat": xi(45), "lng": xi(-90)})},
+		{"geo_parse", dmy, []types.XValue{xs("image:http://example.com/test.jpg")}, ERROR},
+		{"geo_parse", dmy, []types.XValue{xs("geo:91,0")}, ERROR},
+		{"geo_parse", dmy, []types.XValue{ERROR}, ERROR},
+		{"geo_parse", dmy, []types.XValue{}, ERROR},
+
+		{"geo_within", dmy, []types.XValue{xs("-1.5,30.5"), xa(xs("-2,30"), xs("-2,31"), xs("-1,31"), xs("-1,30"))}, types.XBooleanTrue},
+		{"geo_within", dmy, []types.XValue{xs("-2.5,30.5"), xa(xs("-2,30"), xs("-2,31"), xs("-1,31"), xs("-1,30"))}, types.XBooleanFalse},
+		{"geo_within", dmy, []types.XValue{xs("-1.5,30.5"), xa(xs("-2,30"), xs("nope"))}, ERROR},
+		{"geo_within", dmy, []types.XValue{xs("-1.5,30.5"), xs("Rwanda > Kigali City")}, ERROR}, // env isn't location enabled
+		{"geo_within", dmy, []types.XValue{xs("nope"), xa()}, ERROR},
+		{"geo_within", dmy, []types.XValue{xs("-1.5,30.5")}, ERROR},
+
+		{"geohash", dmy, []types.XValue{xs("geo:57.64911,10.40744")}, xs("u4pruydqq")},
+		{"geohash", dmy, []types.XValue{xs("57.64911,10.40744"), xi(11)}, xs("u4pruydqqvj")},
+		{"geohash", dmy, []types.XValue{xs("57.64911,10.40744"), xi(0)}, ERROR},
+		{"geohash", dmy, []types.XValue{xs("57.64911,10.40744"), xs("x")}, ERROR},
+		{"geohash", dmy, []types.XValue{xs("nope")}, ERROR},
+		{"geohash", dmy, []types.XValue{}, ERROR},
+
+		{"group_by", dmy, []types.XValue{xa(xs("a"), xs("bb"), xs("c")), xf("text_length")}, xo(map[string]types.XValue{"1": xa(xs("a"), xs("c")), "2": xa(xs("bb"))})},
+		{"group_by", dmy, []types.XValue{xa(), xf("text_length")}, xo(map[string]types.XValue{})},
+		{"group_by", dmy, []types.XValue{xa(xs("a")), xf("abs")}, ERROR},
+		{"group_by", dmy, []types.XValue{ERROR, xf("text_length")}, ERROR},
+		{"group_by", dmy, []types.XValue{xa(xs("a")), ERROR}, ERROR},
+		{"group_by", dmy, []types.XValue{}, ERROR},
+
+		{"hex", dmy, []types.XValue{xs("hello")}, xs("68656c6c6f")},
+		{"hex", dmy, []types.XValue{xi(12)}, xs("3132")},
+		{"hex", dmy, []types.XValue{xs("")}, xs("")},
+		{"hex", dmy, []types.XValue{ERROR}, ERROR},
+		{"hex", dmy, []types.XValue{}, ERROR},
+
+		{"hmac_sha256", dmy, []types.XValue{xs("secret"), xs("hello")}, xs("88aab3ede8d3adf94d26ab90d3bafd4a2083070c3bcce9c014ee04a443847c0b")},
+		{"hmac_sha256", dmy, []types.XValue{xs(""), xs("")}, xs("b613679a0814d9ec772f95d778c35fc5ff1697c493715653c6c712144292c5ad")},
+		{"hmac_sha256", dmy, []types.XValue{ERROR, xs("hello")}, ERROR},
+		{"hmac_sha256", dmy, []types.XValue{xs("secret"), ERROR}, ERROR},
+		{"hmac_sha256", dmy, []types.XValue{xs("secret")}, ERROR},
+
 		{"html_decode", dmy, []types.XValue{xs(`Red&nbsp;&amp;&nbsp;Blue`)}, xs(`Red & Blue`)},
 		{"html_decode", dmy, []types.XValue{ERROR}, ERROR},
 		{"html_decode", dmy, []types.XValue{}, ERROR},
 
+		{"html_encode", dmy, []types.XValue{xs(`<a href="x">Tom & Jerry's</a>`)}, xs("&lt;a href=&#34;x&#34;&gt;Tom &amp; Jerry&#39;s&lt;/a&gt;")},
+		{"html_encode", dmy, []types.XValue{xs("hello")}, xs("hello")},
+		{"html_encode", dmy, []types.XValue{ERROR}, ERROR},
+		{"html_encode", dmy, []types.XValue{}, ERROR},
+
 		{"if", dmy, []types.XValue{types.XBooleanTrue, xs("10"), xs("20")}, xs("10")},
 		{"if", dmy, []types.XValue{types.XBooleanFalse, xs("10"), xs("20")}, xs("20")},
 		{"if", dmy, []types.XValue{types.XBooleanTrue, errorArg, xs("20")}, types.NewXErrorf("error calling if(...): I am error")},
@@ -418,6 +583,19 @@ func TestFunctions(t *testing.T) {
 		{"lower", dmy, []types.XValue{xs("😁")}, xs("😁")},
 		{"lower", dmy, []types.XValue{}, ERROR},
 
+		{"map", dmy, []types.XValue{xa(xs("a"), xs("b")), xf("upper")}, xa(xs("A"), xs("B"))},
+		{"map", dmy, []types.XValue{xa(), xf("upper")}, xa()},
+		{"map", dmy, []types.XValue{xo(map[string]types.XValue{"a": xs("x"), "b": xs("y")}), xf("upper")}, xo(map[string]types.XValue{"a": xs("X"), "b": xs("Y")})},
+		{"map", dmy, []types.XValue{xa(xs("a")), xf("abs")}, ERROR},
+		{"map", dmy, []types.XValue{ERROR, xf("upper")}, ERROR},
+		{"map", dmy, []types.XValue{xa(xs("a")), ERROR}, ERROR},
+		{"map", dmy, []types.XValue{}, ERROR},
+
+		{"md5", dmy, []types.XValue{xs("hello")}, xs("5d41402abc4b2a76b9719d911017c592")},
+		{"md5", dmy, []types.XValue{xs("")}, xs("d41d8cd98f00b204e9800998ecf8427e")},
+		{"md5", dmy, []types.XValue{ERROR}, ERROR},
+		{"md5", dmy, []types.XValue{}, ERROR},
+
 		{"max", dmy, []types.XValue{xs("10.5"), xs("11")}, xi(11)},
 		{"max", dmy, []types.XValue{xs("10.2"), xs("9")}, xn("10.2")},
 		{"max", dmy, []types.XValue{xs("not_num"), xs("9")}, ERROR},
@@ -442,9 +620,17 @@ func TestFunctions(t *testing.T) {
 		{"mod", dmy, []types.XValue{xs("9"), xs("not_num")}, ERROR},
 		{"mod", dmy, []types.XValue{}, ERROR},
 
+		{"normalize_whitespace", dmy, []types.XValue{xs("  hello \n\t world ")}, xs("hello world")},
+		{"normalize_whitespace", dmy, []types.XValue{xs("")}, xs("")},
+		{"normalize_whitespace", dmy, []types.XValue{ERROR}, ERROR},
+		{"normalize_whitespace", dmy, []types.XValue{}, ERROR},
+
 		{"now", dmy, []types.XValue{}, xdt(time.Date(2018, 4, 11, 13, 24, 30, 123456000, time.UTC))},
 		{"now", dmy, []types.XValue{ERROR}, ERROR},
 
+		{"next_opening", dmy, []types.XValue{xs("Office Hours")}, ERROR}, // not schedule enabled
+		{"next_opening", dmy, []types.XValue{}, ERROR},
+
 		{"number", dmy, []types.XValue{xn("10")}, xn("10")},
 		{"number", dmy, []types.XValue{xs("123.45000")}, xn("123.45")},
 		{"number", dmy, []types.XValue{xs("what?")}, ERROR},
@@ -462,6 +648,21 @@ func TestFunctions(t *testing.T) {
 		{"or", dmy, []types.XValue{ERROR}, ERROR},
 		{"or", dmy, []types.XValue{}, ERROR},
 
+		{"pad_left", dmy, []types.XValue{xs("7"), xi(3), xs("0")}, xs("007")},
+		{"pad_left", dmy, []types.XValue{xs("ab"), xi(4)}, xs("  ab")},
+		{"pad_left", dmy, []types.XValue{xs("😁"), xi(3), xs("😂")}, xs("😂😂😁")},
+		{"pad_left", dmy, []types.XValue{xs("abcdef"), xi(3)}, xs("abcdef")},
+		{"pad_left", dmy, []types.XValue{xs("ab"), xi(4), xs("xy")}, ERROR},
+		{"pad_left", dmy, []types.XValue{xs("ab"), xs("x")}, ERROR},
+		{"pad_left", dmy, []types.XValue{ERROR, xi(4)}, ERROR},
+		{"pad_left", dmy, []types.XValue{xs("ab")}, ERROR},
+
+		{"pad_right", dmy, []types.XValue{xs("7"), xi(3), xs("0")}, xs("700")},
+		{"pad_right", dmy, []types.XValue{xs("ab"), xi(4)}, xs("ab  ")},
+		{"pad_right", dmy, []types.XValue{xs("abcdef"), xi(3)}, xs("abcdef")},
+		{"pad_right", dmy, []types.XValue{xs("ab"), xi(4), ERROR}, ERROR},
+		{"pad_right", dmy, []types.XValue{xs("ab")}, ERROR},
+
 		{"parse_time", dmy, []types.XValue{xs("15:28"), xs("tt:mm")}, xt(dates.NewTimeOfDay(15, 28, 0, 0))},
 		{"parse_time", dmy, []types.XValue{xs("2:40 pm"), xs("h:mm aa")}, xt(dates.NewTimeOfDay(14, 40, 0, 0))},
 		{"parse_time", dmy, []types.XValue{xs("xxxx"), xs("tt:mm")}, ERROR}, // unparseable input
@@ -486,6 +687,16 @@ func TestFunctions(t *testing.T) {
 		{"parse_json", dmy, []types.XValue{xs(`{a: b}`)}, ERROR},
 		{"parse_json", dmy, []types.XValue{ERROR}, ERROR},
 
+		{"parse_number", dmy, []types.XValue{xs("1,234.5")}, xn("1234.5")},
+		{"parse_number", dmy, []types.XValue{xs(" -42 ")}, xi(-42)},
+		{"parse_number", spa, []types.XValue{xs("1.234,50")}, xn("1234.50")},
+		{"parse_number", spa, []types.XValue{xs("1,234.50")}, xn("1234.50")},
+		{"parse_number", fra, []types.XValue{xs("1 234,5")}, xn("1234.5")},
+		{"parse_number", dmy, []types.XValue{xs("1.234,50")}, ERROR},
+		{"parse_number", dmy, []types.XValue{xs("12 apples")}, ERROR},
+		{"parse_number", dmy, []types.XValue{ERROR}, ERROR},
+		{"parse_number", dmy, []types.XValue{}, ERROR},
+
 		{"percent", dmy, []types.XValue{xs(".54")}, xs("54%")},
 		{"percent", dmy, []types.XValue{xs("1.246")}, xs("125%")},
 		{"percent", dmy, []types.XValue{xs("")}, ERROR},
@@ -503,6 +714,15 @@ func TestFunctions(t *testing.T) {
 		{"read_chars", dmy, []types.XValue{xs("12")}, xs("1 , 2")},
 		{"read_chars", dmy, []types.XValue{}, ERROR},
 
+		{"reduce", dmy, []types.XValue{xa(xi(1), xi(5), xi(3)), xf("max"), xi(2)}, xi(5)},
+		{"reduce", dmy, []types.XValue{xa(), xf("max"), xi(2)}, xi(2)},
+		{"reduce", dmy, []types.XValue{xo(map[string]types.XValue{"a": xs("x"), "b": xs("y")}), xf("concat"), xa()}, ERROR},
+		{"reduce", dmy, []types.XValue{xa(xs("x")), xf("max"), xi(2)}, ERROR},
+		{"reduce", dmy, []types.XValue{xa(xi(1)), xf("max"), ERROR}, ERROR},
+		{"reduce", dmy, []types.XValue{ERROR, xf("max"), xi(2)}, ERROR},
+		{"reduce", dmy, []types.XValue{xa(xi(1)), ERROR, xi(2)}, ERROR},
+		{"reduce", dmy, []types.XValue{}, ERROR},
+
 		{"regex_match", dmy, []types.XValue{xs("zAbc"), xs(`a\w`)}, xs(`Ab`)},
 		{"regex_match", dmy, []types.XValue{xs("<html>"), xs(`<(\w+)>`), xn("1")}, xs(`html`)},
 		{"regex_match", dmy, []types.XValue{xs("<html>"), xs(`<(\w+)>`), xn("2")}, ERROR}, // invalid group
@@ -511,6 +731,33 @@ func TestFunctions(t *testing.T) {
 		{"regex_match", dmy, []types.XValue{xs("zAbc"), ERROR}, ERROR},                    // regex is error
 		{"regex_match", dmy, []types.XValue{xs("zAbc"), xs(`a\w`), ERROR}, ERROR},         // group is error
 
+		{"regex_replace", dmy, []types.XValue{xs("a1 b22 C333"), xs(`\d+`), xs("#")}, xs("a# b# C#")},
+		{"regex_replace", dmy, []types.XValue{xs("a1 b22 C333"), xs(`\d+`), xs("#"), xi(2)}, xs("a# b# C333")},
+		{"regex_replace", dmy, []types.XValue{xs("a1 b22 C333"), xs(`\d+`), xs("#"), xi(0)}, xs("a1 b22 C333")},
+		{"regex_replace", dmy, []types.XValue{xs("a1 b22 C333"), xs(`c(\d+)`), xs("[$1]")}, xs("a1 b22 [333]")},
+		{"regex_replace", dmy, []types.XValue{xs("ab"), xs(`\B(b)`), xs("<$1>")}, xs("a<b>")},
+		{"regex_replace", dmy, []types.XValue{xs("aa"), xs(`^(a)|(a)`), xs("[$1|$2]")}, xs("[a|][|a]")},
+		{"regex_replace", dmy, []types.XValue{xs("one two"), xs(`\b(\w)`), xs("$1.")}, xs("o.ne t.wo")},
+		{"regex_replace", dmy, []types.XValue{xs("abc"), xs(`^`), xs(">")}, xs(">abc")},
+		{"regex_replace", dmy, []types.XValue{xs("abc"), xs(`(??`), xs("x")}, ERROR},
+		{"regex_replace", dmy, []types.XValue{ERROR, xs(`\d`), xs("x")}, ERROR},
+		{"regex_replace", dmy, []types.XValue{xs("abc"), xs(`\d`), ERROR}, ERROR},
+		{"regex_replace", dmy, []types.XValue{xs("abc"), xs(`\d`), xs("x"), xs("x")}, ERROR},
+		{"regex_replace", dmy, []types.XValue{xs("abc"), xs(`\d`)}, ERROR},
+
+		{"regex_find_all", dmy, []types.XValue{xs("a1 b22 C333"), xs(`\d+`)}, xa(xs("1"), xs("22"), xs("333"))},
+		{"regex_find_all", dmy, []types.XValue{xs("a1 b22 C333"), xs(`([a-z])(\d+)`), xi(1)}, xa(xs("a"), xs("b"), xs("C"))},
+		{"regex_find_all", dmy, []types.XValue{xs("abc"), xs(`\d+`)}, xa()},
+		{"regex_find_all", dmy, []types.XValue{xs("a1"), xs(`(\d)`), xi(2)}, ERROR},
+		{"regex_find_all", dmy, []types.XValue{xs("a1"), xs(`(??`)}, ERROR},
+		{"regex_find_all", dmy, []types.XValue{ERROR, xs(`\d`)}, ERROR},
+
+		{"regex_split", dmy, []types.XValue{xs("a1b22c"), xs(`\d+`)}, xa(xs("a"), xs("b"), xs("c"))},
+		{"regex_split", dmy, []types.XValue{xs("1a1"), xs(`\d`)}, xa(xs("a"))},
+		{"regex_split", dmy, []types.XValue{xs(""), xs(`\d`)}, xa()},
+		{"regex_split", dmy, []types.XValue{xs("abc"), xs(`(??`)}, ERROR},
+		{"regex_split", dmy, []types.XValue{ERROR, xs(`\d`)}, ERROR},
+
 		{"remove_first_word", dmy, []types.XValue{xs("hello World")}, xs("World")},
 		{"remove_first_word", dmy, []types.XValue{xs("hello")}, xs("")},
 		{"remove_first_word", dmy, []types.XValue{xs(`"hello"`)}, xs("")},    // " ignored when extracting words
@@ -531,6 +778,7 @@ func TestFunctions(t *testing.T) {
 		{"repeat", dmy, []types.XValue{xs("😁"), xs("2")}, xs("😁😁")},
 		{"repeat", dmy, []types.XValue{xs("hi"), xs("0")}, xs("")},
 		{"repeat", dmy, []types.XValue{xs("hi"), xs("-1")}, ERROR},
+		{"repeat", dmy, []types.XValue{xs("hi"), xi(500000)}, xs(strings.Repeat("hi", 500000))},
 		{"repeat", dmy, []types.XValue{xs("hello"), nil}, ERROR},
 		{"repeat", dmy, []types.XValue{}, ERROR},
 
@@ -581,6 +829,39 @@ func TestFunctions(t *testing.T) {
 		{"round_up", dmy, []types.XValue{xs("not_num")}, ERROR},
 		{"round_up", dmy, []types.XValue{}, ERROR},
 
+		{"sha1", dmy, []types.XValue{xs("hello")}, xs("aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d")},
+		{"sha1", dmy, []types.XValue{xs("")}, xs("da39a3ee5e6b4b0d3255bfef95601890afd80709")},
+		{"sha1", dmy, []types.XValue{ERROR}, ERROR},
+		{"sha1", dmy, []types.XValue{}, ERROR},
+
+		{"sha256", dmy, []types.XValue{xs("hello")}, xs("2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824")},
+		{"sha256", dmy, []types.XValue{xs("")}, xs("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")},
+		{"sha256", dmy, []types.XValue{ERROR}, ERROR},
+		{"sha256", dmy, []types.XValue{}, ERROR},
+
+		{"similarity", dmy, []types.XValue{xs("kitten"), xs("sitting")}, xn("0.57")},
+		{"similarity", dmy, []types.XValue{xs("hello"), xs("hello")}, xi(1)},
+		{"similarity", dmy, []types.XValue{xs(""), xs("")}, xi(1)},
+		{"similarity", dmy, []types.XValue{xs("abc"), xs("")}, xi(0)},
+		{"similarity", dmy, []types.XValue{xs("café"), xs("cafe")}, xn("0.75")},
+		{"similarity", dmy, []types.XValue{ERROR, xs("abc")}, ERROR},
+
+		{"slice", dmy, []types.XValue{xa(xs("a"), xs("b"), xs("c"), xs("d")), xi(1)}, xa(xs("b"), xs("c"), xs("d"))},
+		{"slice", dmy, []types.XValue{xa(xs("a"), xs("b"), xs("c"), xs("d")), xi(1), xi(3)}, xa(xs("b"), xs("c"))},
+		{"slice", dmy, []types.XValue{xa(xs("a"), xs("b"), xs("c"), xs("d")), xi(-3), xi(-1)}, xa(xs("b"), xs("c"))},
+		{"slice", dmy, []types.XValue{xa(xs("a"), xs("b"), xs("c"), xs("d")), xi(-10), xi(10)}, xa(xs("a"), xs("b"), xs("c"), xs("d"))},
+		{"slice", dmy, []types.XValue{xa(xs("a"), xs("b"), xs("c"), xs("d")), xi(3), xi(1)}, xa()},
+		{"slice", dmy, []types.XValue{xa(xs("a"), xs("b")), xi(5)}, xa()},
+		{"slice", dmy, []types.XValue{xa(xs("a"), xs("b")), xs("x")}, ERROR},
+		{"slice", dmy, []types.XValue{xa(xs("a"), xs("b")), xi(0), ERROR}, ERROR},
+		{"slice", dmy, []types.XValue{ERROR, xi(1)}, ERROR},
+		{"slice", dmy, []types.XValue{xa()}, ERROR},
+
+		{"slugify", dmy, []types.XValue{xs("Hello World!")}, xs("hello-world")},
+		{"slugify", dmy, []types.XValue{xs(" --Ærø Straße-- ")}, xs("aero-strasse")},
+		{"slugify", dmy, []types.XValue{xs("!!!")}, xs("")},
+		{"slugify", dmy, []types.XValue{ERROR}, ERROR},
+
 		{"sort", dmy, []types.XValue{xa()}, xa()},
 		{"sort", dmy, []types.XValue{xa(xn("3"))}, xa(xn("3"))},
 		{"sort", dmy, []types.XValue{xa(xn("3"), xn("1"), xn("2"))}, xa(xn("1"), xn("2"), xn("3"))},
@@ -590,6 +871,21 @@ func TestFunctions(t *testing.T) {
 		{"sort", dmy, []types.XValue{ERROR}, ERROR},
 		{"sort", dmy, []types.XValue{}, ERROR},
 
+		{"sort_by", dmy, []types.XValue{xa(xs("ccc"), xs("a"), xs("bb")), xf("text_length")}, xa(xs("a"), xs("bb"), xs("ccc"))},
+		{"sort_by", dmy, []types.XValue{xa(xs("ccc"), xs("a"), xs("bb")), xf("text_length"), xs("DESC")}, xa(xs("ccc"), xs("bb"), xs("a"))},
+		{"sort_by", dmy, []types.XValue{xa(xs("b"), xs("aa"), xs("a")), xf("text_length")}, xa(xs("b"), xs("a"), xs("aa"))}, // stable
+		{"sort_by", dmy, []types.XValue{xa(xs("b"), xs("aa"), xs("a")), xf("text_length"), xa(xs("desc"))}, xa(xs("aa"), xs("b"), xs("a"))},
+		{"sort_by", dmy, []types.XValue{xa(xs("x"), xs("x")), xf("upper")}, xa(xs("x"), xs("x"))},
+		{"sort_by", dmy, []types.XValue{xa(xs("x"), xs("1 2"), xs("")), xf("split")}, xa(xs(""), xs("1 2"), xs("x"))}, // multi-key
+		{"sort_by", dmy, []types.XValue{xa(xs("b a"), xs("a b"), xs("a a")), xf("split"), xa(xs("asc"), xs("desc"))}, xa(xs("a b"), xs("a a"), xs("b a"))},
+		{"sort_by", dmy, []types.XValue{xa(xs("b"), xs("a")), xf("upper"), xs("up")}, ERROR},
+		{"sort_by", dmy, []types.XValue{xa(xs("b"), xs("a")), xf("upper"), ERROR}, ERROR},
+		{"sort_by", dmy, []types.XValue{xa(xs("b"), xs("a")), xf("abs")}, ERROR},
+		{"sort_by", dmy, []types.XValue{xa(xs("b"), xs("a")), xf("object")}, ERROR},
+		{"sort_by", dmy, []types.XValue{ERROR, xf("upper")}, ERROR},
+		{"sort_by", dmy, []types.XValue{xa(xs("b")), ERROR}, ERROR},
+		{"sort_by", dmy, []types.XValue{xa()}, ERROR},
+
 		{"split", dmy, []types.XValue{xs("1 2   3")}, xa(xs("1"), xs("2"), xs("3"))},
 		{"split", dmy, []types.XValue{xs("1 2,3"), nil}, xa(xs("1"), xs("2"), xs("3"))},
 		{"split", dmy, []types.XValue{xs("1,2,3"), xs(",")}, xa(xs("1"), xs("2"), xs("3"))},
@@ -598,6 +894,23 @@ func TestFunctions(t *testing.T) {
 		{"split", dmy, []types.XValue{ERROR, xs(",")}, ERROR},
 		{"split", dmy, []types.XValue{}, ERROR},
 
+		{"spell_number", dmy, []types.XValue{xi(42)}, xs("forty-two")},
+		{"spell_number", spa, []types.XValue{xi(21000)}, xs("veintiún mil")},
+		{"spell_number", fra, []types.XValue{xi(80)}, xs("quatre-vingts")},
+		{"spell_number", dmy, []types.XValue{xn("1.5")}, ERROR},
+		{"spell_number", kin, []types.XValue{xi(1)}, ERROR},
+		{"spell_number", dmy, []types.XValue{ERROR}, ERROR},
+		{"spell_number", dmy, []types.XValue{}, ERROR},
+
+		{"start_of_week", dmy, []types.XValue{xs("2018-04-11")}, xd(dates.NewDate(2018, 4, 9))},
+		{"start_of_week", dmy, []types.XValue{xs("2018-04-15")}, xd(dates.NewDate(2018, 4, 9))},
+		{"start_of_week", dmy, []types.XValue{xs("2018-04-16")}, xd(dates.NewDate(2018, 4, 16))},
+		{"start_of_week", sun, []types.XValue{xs("2018-04-15")}, xd(dates.NewDate(2018, 4, 15))},
+		{"start_of_week", sun, []types.XValue{xs("2018-04-14")}, xd(dates.NewDate(2018, 4, 8))},
+		{"start_of_week", sat, []types.XValue{xs("2018-04-13")}, xd(dates.NewDate(2018, 4, 7))},
+		{"start_of_week", dmy, []types.XValue{xs("xx")}, ERROR},
+		{"start_of_week", dmy, []types.XValue{}, ERROR},
+
 		{"sum", dmy, []types.XValue{xa(xn("1"), xn("2"), xs("3"))}, xn("6")},
 		{"sum", dmy, []types.XValue{xa()}, xn("0")},
 		{"sum", dmy, []types.XValue{xs("xx")}, ERROR},
@@ -680,6 +993,19 @@ func TestFunctions(t *testing.T) {
 		{"trim_right", dmy, []types.XValue{ERROR}, ERROR},
 		{"trim_right", dmy, []types.XValue{}, ERROR},
 
+		{"transliterate", dmy, []types.XValue{xs("Crème Brûlée")}, xs("Creme Brulee")},
+		{"transliterate", dmy, []types.XValue{xs("Łódź Straße")}, xs("Lodz Strasse")},
+		{"transliterate", dmy, []types.XValue{xs("Привет")}, xs("Привет")},
+		{"transliterate", dmy, []types.XValue{ERROR}, ERROR},
+
+		{"truncate", dmy, []types.XValue{xs("Hello World"), xi(8)}, xs("Hello...")},
+		{"truncate", dmy, []types.XValue{xs("Hello World"), xi(8), xs("")}, xs("Hello Wo")},
+		{"truncate", dmy, []types.XValue{xs("😁😁😁😁"), xi(3), xs("…")}, xs("😁😁…")},
+		{"truncate", dmy, []types.XValue{xs("Hello"), xi(5)}, xs("Hello")},
+		{"truncate", dmy, []types.XValue{xs("Hello"), xi(2)}, ERROR},
+		{"truncate", dmy, []types.XValue{xs("Hello"), ERROR}, ERROR},
+		{"truncate", dmy, []types.XValue{xs("Hello")}, ERROR},
+
 		{"tz", dmy, []types.XValue{xs("01-12-2017")}, xs("UTC")},
 		{"tz", mdy, []types.XValue{xs("01-12-2017")}, xs("America/Los_Angeles")},
 		{"tz", dmy, []types.XValue{xs("01-12-2017 10:15:33pm")}, xs("UTC")},
@@ -776,12 +1102,51 @@ func TestFunctions(t *testing.T) {
 		{"url_encode", dmy, []types.XValue{xs(`hi-% ?/`)}, xs(`hi-%25%20%3F%2F`)},
 		{"url_encode", dmy, []types.XValue{ERROR}, ERROR},
 		{"url_encode", dmy, []types.XValue{}, ERROR},
+
+		{"url_decode", dmy, []types.XValue{xs(`hi-%25%20%3F%2F`)}, xs(`hi-% ?/`)},
+		{"url_decode", dmy, []types.XValue{xs(`a+b`)}, xs(`a b`)},
+		{"url_decode", dmy, []types.XValue{xs(`100%`)}, ERROR},
+		{"url_decode", dmy, []types.XValue{ERROR}, ERROR},
+		{"url_decode", dmy, []types.XValue{}, ERROR},
+
+		{"url_parts", dmy, []types.XValue{xs("https://example.com:8080/path/to?x=1&y=2&x=3#top")}, xo(map[string]types.XValue{
+			"scheme":   xs("https"),
+			"host":     xs("example.com"),
+			"port":     xs("8080"),
+			"path":     xs("/path/to"),
+			"query":    xo(map[string]types.XValue{"x": xs("1"), "y": xs("2")}),
+			"fragment": xs("top"),
+		})},
+		{"url_parts", dmy, []types.XValue{xs("http://example.com")}, xo(map[string]types.XValue{
+			"scheme":   xs("http"),
+			"host":     xs("example.com"),
+			"port":     xs(""),
+			"path":     xs(""),
+			"query":    xo(map[string]types.XValue{}),
+			"fragment": xs(""),
+		})},
+		{"url_parts", dmy, []types.XValue{xs("/path/only")}, ERROR},
+		{"url_parts", dmy, []types.XValue{xs("http://[::1")}, ERROR},
+		{"url_parts", dmy, []types.XValue{ERROR}, ERROR},
+		{"url_parts", dmy, []types.XValue{}, ERROR},
+
+		{"uuid", dmy, []types.XValue{}, xs("d2f852ec-7b4e-457f-ae7f-f8b243c49ff5")},
+		{"uuid", dmy, []types.XValue{}, xs("692926ea-09d6-4942-bd38-d266ec8d3716")},
+		{"uuid", dmy, []types.XValue{xi(1)}, ERROR},
+
+		{"zip", dmy, []types.XValue{xa(xs("a"), xs("b")), xa(xi(1), xi(2))}, xa(xa(xs("a"), xi(1)), xa(xs("b"), xi(2)))},
+		{"zip", dmy, []types.XValue{xa(xs("a"), xs("b")), xa(xi(1)), xa(xs("x"), xs("y"))}, xa(xa(xs("a"), xi(1), xs("x")))},
+		{"zip", dmy, []types.XValue{xa(xs("a"))}, xa(xa(xs("a")))},
+		{"zip", dmy, []types.XValue{xa(xs("a")), ERROR}, ERROR},
+		{"zip", dmy, []types.XValue{}, ERROR},
 	}
 
 	defer random.SetGenerator(random.DefaultGenerator)
+	defer uuids.SetGenerator(uuids.DefaultGenerator)
 	defer dates.SetNowSource(dates.DefaultNowSource)
 
 	random.SetGenerator(random.NewSeededGenerator(123456))
+	uuids.SetGenerator(uuids.NewSeededGenerator(123456))
 	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2018, 4, 11, 13, 24, 30, 123456000, time.UTC)))
 
 	for _, tc := range funcTests {
@@ -800,3 +1165,41 @@ func TestFunctions(t *testing.T) {
 		}
 	}
 }
+
+func TestSignatures(t *testing.T) {
+	env := envs.NewBuilder().Build()
+	args := func(n int) []types.XValue {
+		a := make([]types.XValue, n)
+		for i := range a {
+			a[i] = types.NewXText("x")
+		}
+		return a
+	}
+
+	for name, fn := range functions.XFUNCTIONS {
+		sig := functions.XSIGNATURES[name]
+		if assert.NotNil(t, sig, "missing signature for function %s", name) {
+			// check the signature doesn't allow fewer or more arguments than the function accepts
+			if sig.MinArgs > 0 {
+				assert.True(t, types.IsXError(fn.Call(env, args(sig.MinArgs-1))), "expected error for too few arguments to %s", name)
+			}
+			if sig.MaxArgs >= 0 {
+				assert.True(t, types.IsXError(fn.Call(env, args(sig.MaxArgs+1))), "expected error for too many arguments to %s", name)
+			}
+			for _, n := range []int{sig.MinArgs, max(sig.MinArgs, sig.MaxArgs)} {
+				if xerr, isErr := fn.Call(env, args(n)).(*types.XError); isErr {
+					assert.NotContains(t, xerr.Error(), "argument(s)", "unexpected error for %d arguments to %s", n, name)
+				}
+			}
+		}
+	}
+
+	assert.Equal(t, &functions.Signature{MinArgs: 1, MaxArgs: 1, ArgTypes: []string{"text"}}, functions.LookupSignature("UPPER"))
+	assert.Equal(t, &functions.Signature{MinArgs: 0, MaxArgs: -1}, functions.LookupSignature("array"))
+	assert.Nil(t, functions.LookupSignature("xxx"))
+
+	sig := functions.LookupSignature("datetime_add")
+	assert.Equal(t, "datetime", sig.ArgType(0))
+	assert.Equal(t, "text", sig.ArgType(2))
+	assert.Equal(t, "any", sig.ArgType(3))
+}
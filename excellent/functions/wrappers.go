@@ -181,6 +181,22 @@ func TextAndDateFunction(f func(envs.Environment, *types.XText, *types.XDateTime
 	})
 }
 
+// DateTimeAndTextFunction creates an XFunc from a function that takes a datetime and a text arg
+func DateTimeAndTextFunction(f func(envs.Environment, *types.XDateTime, *types.XText) types.XValue) types.XFunc {
+	return NumArgsCheck(2, func(env envs.Environment, args ...types.XValue) types.XValue {
+		date, xerr := types.ToXDateTime(env, args[0])
+		if xerr != nil {
+			return xerr
+		}
+		str, xerr := types.ToXText(env, args[1])
+		if xerr != nil {
+			return xerr
+		}
+
+		return f(env, date, str)
+	})
+}
+
 // InitialTextFunction creates an XFunc from a function that takes an initial text arg followed by other args
 func InitialTextFunction(minOtherArgs int, maxOtherArgs int, f func(envs.Environment, *types.XText, ...types.XValue) types.XValue) types.XFunc {
 	return MinAndMaxArgsCheck(minOtherArgs+1, maxOtherArgs+1, func(env envs.Environment, args ...types.XValue) types.XValue {
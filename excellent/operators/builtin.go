@@ -2,7 +2,9 @@ package operators
 
 import (
 	"strings"
+	"time"
 
+	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/excellent/types"
 )
@@ -42,42 +44,121 @@ var NotEqual = textualBinary(func(env envs.Environment, text1 *types.XText, text
 	return types.NewXBoolean(!text1.Equals(text2))
 })
 
-// Negate negates a number
+// Negate negates a number or a duration.
 //
 //	@(-fields.age) -> -23
+//	@(-duration("P1D")) -> -P1D
 //
 // @operator negate "- (unary)"
-var Negate = numericalUnary(func(env envs.Environment, num *types.XNumber) types.XValue {
+var Negate = temporalOrNumericalUnary(func(env envs.Environment, d *types.XDuration) types.XValue {
+	return d.Negate()
+}, func(env envs.Environment, num *types.XNumber) types.XValue {
 	return types.NewXNumber(num.Native().Neg())
 })
 
-// Add adds two numbers.
+// Add adds two numbers, two durations, or a duration to a date or datetime.
+//
+// Adding a duration to a date gives a date unless the duration has a time part, in which case it gives
+// a datetime. Months and days are added in the timezone of the datetime so that calendar months and
+// daylight savings changes are respected.
 //
 //	@(2 + 3) -> 5
 //	@(fields.age + 10) -> 33
+//	@(duration("1h") + duration("30m")) -> PT1H30M
+//	@(date("2019-01-31") + duration("P1M")) -> 2019-02-28
+//	@(datetime("2019-01-15T10:00:00Z") + duration("2h30m")) -> 2019-01-15T12:30:00.000000Z
 //
 // @operator add "+"
-var Add = numericalBinary(func(env envs.Environment, num1 *types.XNumber, num2 *types.XNumber) types.XValue {
+var Add = temporalOrNumericalBinary(func(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
+	d1, isDuration1 := arg1.(*types.XDuration)
+	d2, isDuration2 := arg2.(*types.XDuration)
+
+	if isDuration1 && isDuration2 {
+		return d1.Add(d2)
+	} else if isDuration1 {
+		return addDuration(env, arg2, d1)
+	} else if isDuration2 {
+		return addDuration(env, arg1, d2)
+	}
+	return nil
+}, func(env envs.Environment, num1 *types.XNumber, num2 *types.XNumber) types.XValue {
 	return types.NewXNumber(num1.Native().Add(num2.Native()))
 })
 
-// Subtract subtracts two numbers.
+// Subtract subtracts two numbers, two durations, a duration from a date or datetime, or two dates or datetimes.
+//
+// Subtracting two dates gives a duration in days and subtracting two datetimes gives a duration in hours,
+// minutes and seconds.
 //
 //	@(3 - 2) -> 1
 //	@(2 - 3) -> -1
+//	@(date("2019-03-01") - duration("P1D")) -> 2019-02-28
+//	@(date("2019-03-01") - date("2019-02-01")) -> P28D
+//	@(datetime("2019-01-15T12:30:00Z") - datetime("2019-01-15T10:00:00Z")) -> PT2H30M
 //
 // @operator subtract "- (binary)"
-var Subtract = numericalBinary(func(env envs.Environment, num1 *types.XNumber, num2 *types.XNumber) types.XValue {
+var Subtract = temporalOrNumericalBinary(func(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
+	d1, isDuration1 := arg1.(*types.XDuration)
+	d2, isDuration2 := arg2.(*types.XDuration)
+
+	if isDuration1 && isDuration2 {
+		return d1.Add(d2.Negate())
+	} else if isDuration2 {
+		return addDuration(env, arg1, d2.Negate())
+	} else if isDuration1 {
+		return types.NewXErrorf("can't subtract %s from a duration", types.Describe(arg2))
+	}
+
+	date1, isDate1 := arg1.(*types.XDate)
+	date2, isDate2 := arg2.(*types.XDate)
+	if isDate1 && isDate2 {
+		t1 := date1.Native().Combine(dates.ZeroTimeOfDay, time.UTC)
+		t2 := date2.Native().Combine(dates.ZeroTimeOfDay, time.UTC)
+		return types.NewXDuration(0, int(t1.Sub(t2)/(24*time.Hour)), 0)
+	}
+
+	if isTemporal(arg1) && isTemporal(arg2) {
+		dt1, _ := types.ToXDateTime(env, arg1)
+		dt2, _ := types.ToXDateTime(env, arg2)
+		return types.NewXDuration(0, 0, dt1.Native().Sub(dt2.Native()))
+	}
+	return nil
+}, func(env envs.Environment, num1 *types.XNumber, num2 *types.XNumber) types.XValue {
 	return types.NewXNumber(num1.Native().Sub(num2.Native()))
 })
 
-// Multiply multiplies two numbers.
+// Multiply multiplies two numbers, or a duration by a whole number.
 //
 //	@(3 * 2) -> 6
 //	@(fields.age * 3) -> 69
+//	@(duration("P1DT2H") * 3) -> P3DT6H
 //
 // @operator multiply "*"
-var Multiply = numericalBinary(func(env envs.Environment, num1 *types.XNumber, num2 *types.XNumber) types.XValue {
+var Multiply = temporalOrNumericalBinary(func(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
+	d, isDuration1 := arg1.(*types.XDuration)
+	factor := arg2
+	if !isDuration1 {
+		var isDuration2 bool
+		if d, isDuration2 = arg2.(*types.XDuration); !isDuration2 {
+			return nil
+		}
+		factor = arg1
+	}
+
+	num, xerr := types.ToXNumber(env, factor)
+	if xerr != nil {
+		return xerr
+	}
+	if !num.Native().IsInteger() {
+		return types.NewXErrorf("can only multiply a duration by a whole number")
+	}
+
+	n, xerr := types.ToInteger(env, num)
+	if xerr != nil {
+		return xerr
+	}
+	return d.Multiply(n)
+}, func(env envs.Environment, num1 *types.XNumber, num2 *types.XNumber) types.XValue {
 	return types.NewXNumber(num1.Native().Mul(num2.Native()))
 })
 
@@ -149,3 +230,109 @@ var GreaterThan = numericalBinary(func(env envs.Environment, num1 *types.XNumber
 var GreaterThanOrEqual = numericalBinary(func(env envs.Environment, num1 *types.XNumber, num2 *types.XNumber) types.XValue {
 	return types.NewXBoolean(num1.Compare(num2) >= 0)
 })
+
+// Not returns true if the value is not truthy.
+//
+//	@(!true) -> false
+//	@(!"") -> true
+//	@(!(1 = 2)) -> true
+//
+// @operator not "!"
+var Not = logicalUnary(func(env envs.Environment, b *types.XBoolean) types.XValue {
+	return types.NewXBoolean(!b.Native())
+})
+
+// And returns true if both values are truthy. If the first value is falsy, the second isn't considered.
+//
+//	@(true && true) -> true
+//	@(true && "") -> false
+//	@(false && 1 / 0) -> false
+//
+// @operator and "&&"
+var And BinaryOperator = func(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
+	for _, arg := range []types.XValue{arg1, arg2} {
+		b, xerr := types.ToXBoolean(arg)
+		if xerr != nil {
+			return xerr
+		}
+		if !b.Native() {
+			return types.XBooleanFalse
+		}
+	}
+	return types.XBooleanTrue
+}
+
+// Or returns true if either value is truthy. If the first value is truthy, the second isn't considered.
+//
+//	@(false || true) -> true
+//	@("" || 0) -> false
+//	@(true || 1 / 0) -> true
+//
+// @operator or "||"
+var Or BinaryOperator = func(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
+	for _, arg := range []types.XValue{arg1, arg2} {
+		b, xerr := types.ToXBoolean(arg)
+		if xerr != nil {
+			return xerr
+		}
+		if b.Native() {
+			return types.XBooleanTrue
+		}
+	}
+	return types.XBooleanFalse
+}
+
+// Coalesce returns the first value unless it is null or an error, in which case it returns the second.
+//
+//	@(fields.age ?? 0) -> 23
+//	@(fields.not_set ?? "unknown") -> unknown
+//	@(1 / 0 ?? "oops") -> oops
+//
+// @operator coalesce "??"
+var Coalesce BinaryOperator = func(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
+	if types.IsNil(arg1) || types.IsXError(arg1) {
+		return arg2
+	}
+	return arg1
+}
+
+// Ternary returns the second value if the first is truthy, or the third value if not.
+//
+//	@(1 = 1 ? "yes" : "no") -> yes
+//	@(contact.name = "Bob" ? "Hi Bob" : "Who are you?") -> Who are you?
+//
+// @operator ternary "? :"
+var Ternary TernaryOperator = func(env envs.Environment, test types.XValue, arg1 types.XValue, arg2 types.XValue) types.XValue {
+	b, xerr := types.ToXBoolean(test)
+	if xerr != nil {
+		return xerr
+	}
+
+	if b.Native() {
+		return arg1
+	}
+	return arg2
+}
+
+// adds the given duration to a date or datetime value
+func addDuration(env envs.Environment, arg types.XValue, d *types.XDuration) types.XValue {
+	if date, isDate := arg.(*types.XDate); isDate && d.Clock() == 0 {
+		t := d.AddTo(date.Native().Combine(dates.ZeroTimeOfDay, time.UTC))
+		return types.NewXDate(dates.ExtractDate(t))
+	}
+
+	dt, xerr := types.ToXDateTime(env, arg)
+	if xerr != nil {
+		return xerr
+	}
+	return types.NewXDateTime(d.AddTo(dt.Native()))
+}
+
+// returns whether the given value is a date or datetime
+func isTemporal(arg types.XValue) bool {
+	switch arg.(type) {
+	case *types.XDate, *types.XDateTime:
+		return true
+	}
+	return false
+}
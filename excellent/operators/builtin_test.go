@@ -3,7 +3,9 @@ package operators_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/excellent/operators"
 	"github.com/nyaruka/goflow/excellent/types"
@@ -14,6 +16,9 @@ import (
 var xs = types.NewXText
 var xn = types.RequireXNumberFromString
 var xi = types.NewXNumberFromInt
+var xd = types.NewXDate
+var xdt = types.NewXDateTime
+var xdur = types.NewXDuration
 var ERROR = types.NewXErrorf("any error")
 
 func TestBinaryOperators(t *testing.T) {
@@ -49,18 +54,40 @@ func TestBinaryOperators(t *testing.T) {
 		{operators.Add, xs("1"), xs("3"), xi(4)},
 		{operators.Add, ERROR, xi(1), ERROR},
 		{operators.Add, xi(1), ERROR, ERROR},
+		{operators.Add, xdur(1, 2, time.Hour), xdur(0, 1, time.Minute), xdur(1, 3, 61*time.Minute)},
+		{operators.Add, xd(dates.NewDate(2019, 1, 31)), xdur(1, 1, 0), xd(dates.NewDate(2019, 3, 1))},
+		{operators.Add, xdur(0, 0, time.Hour), xd(dates.NewDate(2019, 1, 31)), xdt(time.Date(2019, 1, 31, 1, 0, 0, 0, time.UTC))},
+		{operators.Add, xdt(time.Date(2019, 1, 31, 10, 0, 0, 0, time.UTC)), xdur(1, 0, time.Hour), xdt(time.Date(2019, 2, 28, 11, 0, 0, 0, time.UTC))},
+		{operators.Add, xs("2019-01-31T10:00:00Z"), xdur(0, 1, 0), xdt(time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC))},
+		{operators.Add, xdur(0, 1, 0), xi(1), ERROR},
+		{operators.Add, ERROR, xdur(0, 1, 0), ERROR},
+		{operators.Add, xd(dates.NewDate(2019, 1, 31)), xi(1), ERROR},
 
 		{operators.Subtract, xi(1), xi(3), xi(-2)},
 		{operators.Subtract, xi(3), xi(1), xi(2)},
 		{operators.Subtract, xs("3"), xs("1"), xi(2)},
 		{operators.Subtract, ERROR, xi(1), ERROR},
 		{operators.Subtract, xi(1), ERROR, ERROR},
+		{operators.Subtract, xdur(1, 2, time.Hour), xdur(0, 1, time.Minute), xdur(1, 1, 59*time.Minute)},
+		{operators.Subtract, xd(dates.NewDate(2019, 3, 31)), xdur(1, 0, 0), xd(dates.NewDate(2019, 2, 28))},
+		{operators.Subtract, xdt(time.Date(2019, 1, 31, 10, 0, 0, 0, time.UTC)), xdur(0, 0, 12*time.Hour), xdt(time.Date(2019, 1, 30, 22, 0, 0, 0, time.UTC))},
+		{operators.Subtract, xd(dates.NewDate(2019, 3, 1)), xd(dates.NewDate(2018, 3, 1)), xdur(0, 365, 0)},
+		{operators.Subtract, xd(dates.NewDate(2018, 3, 1)), xd(dates.NewDate(2018, 3, 3)), xdur(0, -2, 0)},
+		{operators.Subtract, xdt(time.Date(2019, 1, 31, 10, 0, 0, 0, time.UTC)), xdt(time.Date(2019, 1, 30, 8, 30, 0, 0, time.UTC)), xdur(0, 0, 25*time.Hour+30*time.Minute)},
+		{operators.Subtract, xdt(time.Date(2019, 1, 31, 10, 0, 0, 0, time.UTC)), xd(dates.NewDate(2019, 1, 31)), xdur(0, 0, 10*time.Hour)},
+		{operators.Subtract, xdur(0, 1, 0), xd(dates.NewDate(2019, 1, 31)), ERROR},
+		{operators.Subtract, ERROR, xdur(0, 1, 0), ERROR},
 
 		{operators.Multiply, xi(2), xi(3), xi(6)},
 		{operators.Multiply, xn("1.5"), xn("2.3"), xn("3.45")},
 		{operators.Multiply, xs("2"), xs("3"), xi(6)},
 		{operators.Multiply, ERROR, xi(1), ERROR},
 		{operators.Multiply, xi(1), ERROR, ERROR},
+		{operators.Multiply, xdur(1, 2, time.Hour), xi(3), xdur(3, 6, 3*time.Hour)},
+		{operators.Multiply, xs("-2"), xdur(1, 2, time.Hour), xdur(-2, -4, -2*time.Hour)},
+		{operators.Multiply, xdur(1, 2, time.Hour), xn("1.5"), ERROR},
+		{operators.Multiply, xdur(1, 2, time.Hour), xdur(1, 2, time.Hour), ERROR},
+		{operators.Multiply, xdur(1, 2, time.Hour), ERROR, ERROR},
 
 		{operators.Divide, xi(3), xi(2), xn("1.5")},
 		{operators.Divide, xs("3"), xs("2"), xn("1.5")},
@@ -99,6 +126,24 @@ func TestBinaryOperators(t *testing.T) {
 		{operators.GreaterThanOrEqual, xi(4), xi(3), types.XBooleanTrue},
 		{operators.GreaterThanOrEqual, ERROR, xi(1), ERROR},
 		{operators.GreaterThanOrEqual, xi(1), ERROR, ERROR},
+
+		{operators.And, types.XBooleanTrue, xs("yes"), types.XBooleanTrue},
+		{operators.And, types.XBooleanTrue, xi(0), types.XBooleanFalse},
+		{operators.And, types.XBooleanFalse, ERROR, types.XBooleanFalse},
+		{operators.And, ERROR, types.XBooleanTrue, ERROR},
+		{operators.And, types.XBooleanTrue, ERROR, ERROR},
+
+		{operators.Or, types.XBooleanFalse, xs("yes"), types.XBooleanTrue},
+		{operators.Or, xs(""), xi(0), types.XBooleanFalse},
+		{operators.Or, types.XBooleanTrue, ERROR, types.XBooleanTrue},
+		{operators.Or, ERROR, types.XBooleanTrue, ERROR},
+		{operators.Or, types.XBooleanFalse, ERROR, ERROR},
+
+		{operators.Coalesce, xs("hello"), xs("world"), xs("hello")},
+		{operators.Coalesce, xs(""), xs("world"), xs("")},
+		{operators.Coalesce, nil, xs("world"), xs("world")},
+		{operators.Coalesce, ERROR, xs("world"), xs("world")},
+		{operators.Coalesce, nil, ERROR, ERROR},
 	}
 
 	for _, tc := range testCases {
@@ -127,6 +172,12 @@ func TestUnaryOperators(t *testing.T) {
 		{operators.Negate, xs("123"), xi(-123)},
 		{operators.Negate, xn("123.45"), xn("-123.45")},
 		{operators.Negate, ERROR, ERROR},
+		{operators.Negate, xdur(1, 2, time.Hour), xdur(-1, -2, -time.Hour)},
+
+		{operators.Not, types.XBooleanTrue, types.XBooleanFalse},
+		{operators.Not, xs(""), types.XBooleanTrue},
+		{operators.Not, xi(1), types.XBooleanFalse},
+		{operators.Not, ERROR, ERROR},
 	}
 
 	for _, tc := range testCases {
@@ -142,3 +193,33 @@ func TestUnaryOperators(t *testing.T) {
 		}
 	}
 }
+
+func TestTernaryOperators(t *testing.T) {
+	env := envs.NewBuilder().Build()
+
+	testCases := []struct {
+		operator operators.TernaryOperator
+		arg1     types.XValue
+		arg2     types.XValue
+		arg3     types.XValue
+		expected types.XValue
+	}{
+		{operators.Ternary, types.XBooleanTrue, xs("yes"), xs("no"), xs("yes")},
+		{operators.Ternary, xi(0), xs("yes"), xs("no"), xs("no")},
+		{operators.Ternary, types.XBooleanTrue, xs("yes"), ERROR, xs("yes")},
+		{operators.Ternary, ERROR, xs("yes"), xs("no"), ERROR},
+	}
+
+	for _, tc := range testCases {
+		testID := fmt.Sprintf("%v(%s, %s, %s)", tc.operator, tc.arg1, tc.arg2, tc.arg3)
+
+		result := tc.operator(env, tc.arg1, tc.arg2, tc.arg3)
+
+		// don't check error equality - just check that we got an error if we expected one
+		if tc.expected == ERROR {
+			assert.True(t, types.IsXError(result), "expecting error, got %T{%s} for ", result, result, testID)
+		} else {
+			test.AssertXEqual(t, tc.expected, result, "result mismatch for %s", testID)
+		}
+	}
+}
@@ -11,6 +11,9 @@ type UnaryOperator func(envs.Environment, types.XValue) types.XValue
 // BinaryOperator is an operator which takes two arguments
 type BinaryOperator func(envs.Environment, types.XValue, types.XValue) types.XValue
 
+// TernaryOperator is an operator which takes three arguments
+type TernaryOperator func(envs.Environment, types.XValue, types.XValue, types.XValue) types.XValue
+
 func textualBinary(f func(envs.Environment, *types.XText, *types.XText) types.XValue) BinaryOperator {
 	return func(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
 		text1, xerr := types.ToXText(env, arg1)
@@ -51,3 +54,41 @@ func numericalBinary(f func(envs.Environment, *types.XNumber, *types.XNumber) ty
 		return f(env, num1, num2)
 	}
 }
+
+// returns an operator which applies f if the argument is a duration, and otherwise falls back to numerical
+func temporalOrNumericalUnary(f func(envs.Environment, *types.XDuration) types.XValue, n func(envs.Environment, *types.XNumber) types.XValue) UnaryOperator {
+	numerical := numericalUnary(n)
+
+	return func(env envs.Environment, arg types.XValue) types.XValue {
+		if d, isDuration := arg.(*types.XDuration); isDuration {
+			return f(env, d)
+		}
+
+		return numerical(env, arg)
+	}
+}
+
+// returns an operator which tries f first, which returns nil if the arguments aren't temporal, and otherwise
+// falls back to numerical
+func temporalOrNumericalBinary(f func(envs.Environment, types.XValue, types.XValue) types.XValue, n func(envs.Environment, *types.XNumber, *types.XNumber) types.XValue) BinaryOperator {
+	numerical := numericalBinary(n)
+
+	return func(env envs.Environment, arg1 types.XValue, arg2 types.XValue) types.XValue {
+		if result := f(env, arg1, arg2); result != nil {
+			return result
+		}
+
+		return numerical(env, arg1, arg2)
+	}
+}
+
+func logicalUnary(f func(envs.Environment, *types.XBoolean) types.XValue) UnaryOperator {
+	return func(env envs.Environment, arg types.XValue) types.XValue {
+		b, xerr := types.ToXBoolean(arg)
+		if xerr != nil {
+			return xerr
+		}
+
+		return f(env, b)
+	}
+}
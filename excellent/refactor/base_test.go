@@ -29,6 +29,11 @@ func TestRefactorTemplate(t *testing.T) {
 		{`@(AND("x"="y", "x"!="y"))`, `@(and("x" = "y", "x" != "y"))`, false},
 		{`@(AND(1>2, 3<4, 5>=6, 7<=8))`, `@(and(1 > 2, 3 < 4, 5 >= 6, 7 <= 8))`, false},
 		{`@(FOO_Func(x, y))`, `@(foo_func(x, y))`, false},
+		{`@([ 1,"x" ,foo.bar])`, `@([1, "x", foo.bar])`, false},
+		{`@({ "a":1,"b c" :[ ] })`, `@({"a": 1, "b c": []})`, false},
+		{`@(!TRUE&&foo.bar||FALSE)`, `@(!true && foo.bar || false)`, false},
+		{`@(foo.x??"y")`, `@(foo.x ?? "y")`, false},
+		{`@(foo.bar>1?"a":foo.bar<0?"b":"c")`, `@(foo.bar > 1 ? "a" : foo.bar < 0 ? "b" : "c")`, false},
 		{`@(1 / ) @(1+2)`, `@(1 / ) @(1 + 2)`, true},
 		{`test@example.com`, `test@example.com`, false},   // not a valid top level
 		{`test@@example.com`, `test@@example.com`, false}, // escaped @ unchanged
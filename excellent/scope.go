@@ -9,6 +9,7 @@ import (
 type Scope struct {
 	get    func(string) (types.XValue, bool)
 	parent *Scope
+	budget *budget
 }
 
 // NewScope creates a new evaluation scope with an optional parent
@@ -16,7 +17,7 @@ func NewScope(ctx *types.XObject, parent *Scope) *Scope {
 	if parent == nil {
 		parent = rootScope
 	}
-	return &Scope{get: ctx.Get, parent: parent}
+	return &Scope{get: ctx.Get, parent: parent, budget: parent.budget}
 }
 
 // Get looks up a named value in the context
@@ -0,0 +1,414 @@
+package tools
+
+import (
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nyaruka/gocommon/jsonx"
+	"github.com/nyaruka/goflow/excellent"
+	"github.com/nyaruka/goflow/excellent/functions"
+	"github.com/nyaruka/goflow/excellent/types"
+)
+
+// schema of the context generated by docgen from the @context docstrings
+//
+//go:embed specdata/context.json
+var contextSchemaJSON []byte
+
+type schemaProperty struct {
+	Type  string `json:"type"`
+	Array bool   `json:"array,omitempty"`
+}
+
+type schemaType struct {
+	Properties map[string]*schemaProperty `json:"properties,omitempty"`
+	KeySource  string                     `json:"key_source,omitempty"`
+	Property   *schemaProperty            `json:"property,omitempty"`
+}
+
+type contextSchema struct {
+	Types map[string]*schemaType     `json:"types"`
+	Root  map[string]*schemaProperty `json:"root"`
+}
+
+var schema *contextSchema
+
+func init() {
+	jsonx.MustUnmarshal(contextSchemaJSON, &schema)
+}
+
+// Issue is a problem found by statically checking a template
+type Issue struct {
+	Expression string // the expression or identifier containing the problem, e.g. @contact.feilds
+	Position   int    // the offset in runes of the problem within the template
+	Message    string // a description of the problem
+}
+
+// Checker checks templates against the schema of the context
+type Checker struct {
+	keys map[string][]string
+}
+
+// NewChecker creates a new checker. Keys of dynamic types like results are only checked if their
+// key source is included in the given keys map.
+func NewChecker(keys map[string][]string) *Checker {
+	lowered := make(map[string][]string, len(keys))
+	for source, ks := range keys {
+		lowered[source] = make([]string, len(ks))
+		for i, k := range ks {
+			lowered[source][i] = strings.ToLower(k)
+		}
+	}
+	return &Checker{keys: lowered}
+}
+
+// CheckTemplate checks all the expressions in the given template, returning any issues found
+func (c *Checker) CheckTemplate(template string, allowedTopLevels []string) []*Issue {
+	var issues []*Issue
+	offset := 0
+
+	excellent.VisitTemplate(template, allowedTopLevels, false, func(tokenType excellent.XTokenType, token string) error {
+		switch tokenType {
+		case excellent.BODY:
+			offset += len([]rune(token))
+		case excellent.IDENTIFIER:
+			for _, i := range c.CheckExpression(token) {
+				issues = append(issues, &Issue{Expression: "@" + token, Position: offset + 1 + i.Position, Message: i.Message})
+			}
+			offset += 1 + len([]rune(token))
+		case excellent.EXPRESSION:
+			for _, i := range c.CheckExpression(token) {
+				issues = append(issues, &Issue{Expression: "@(" + token + ")", Position: offset + 2 + i.Position, Message: i.Message})
+			}
+			offset += 3 + len([]rune(token))
+		}
+		return nil
+	})
+
+	return issues
+}
+
+// CheckExpression checks a single expression, returning any issues found with positions relative to the expression
+func (c *Checker) CheckExpression(expression string) []*Issue {
+	parsed, positions, err := excellent.ParseWithPositions(expression)
+	if err != nil {
+		return []*Issue{{Expression: expression, Position: 0, Message: err.Error()}}
+	}
+
+	chk := &checking{checker: c, expression: expression, positions: positions}
+	chk.infer(parsed, nil)
+	return chk.issues
+}
+
+// the inferred type of a node, where name is one of the schema types, any, text, number, datetime,
+// boolean, function or object
+type xtype struct {
+	name      string
+	array     bool
+	signature *functions.Signature
+	literal   excellent.Expression
+}
+
+var anyType = xtype{name: "any"}
+
+func (t xtype) isAny() bool { return t.name == "any" && !t.array }
+
+func (t xtype) isObject() bool {
+	return !t.array && (t.name == "object" || schema.Types[t.name] != nil)
+}
+
+func (t xtype) isPrimitive() bool {
+	switch t.name {
+	case "text", "number", "datetime", "boolean", "function":
+		return !t.array
+	}
+	return false
+}
+
+// describes this type for issue messages
+func (t xtype) String() string {
+	if t.array {
+		return "array"
+	}
+	return t.name
+}
+
+func fromSchema(p *schemaProperty) xtype {
+	return xtype{name: p.Type, array: p.Array}
+}
+
+// holds state for checking a single expression
+type checking struct {
+	checker    *Checker
+	expression string
+	positions  excellent.Positions
+	issues     []*Issue
+}
+
+func (c *checking) report(x excellent.Expression, format string, args ...any) {
+	c.issues = append(c.issues, &Issue{Expression: c.expression, Position: c.positions[x], Message: fmt.Sprintf(format, args...)})
+}
+
+// infers the type of the given node, reporting any issues found along the way
+func (c *checking) infer(x excellent.Expression, anonArgs []string) xtype {
+	switch typed := x.(type) {
+	case *excellent.ContextReference:
+		name := strings.ToLower(typed.Name)
+		for _, a := range anonArgs {
+			if strings.ToLower(a) == name {
+				return anyType
+			}
+		}
+		if p := schema.Root[name]; p != nil {
+			return fromSchema(p)
+		}
+		if f := functions.Lookup(name); f != nil {
+			return xtype{name: "function", signature: functions.LookupSignature(name)}
+		}
+		c.report(x, "context has no property '%s'", typed.Name)
+		return anyType
+
+	case *excellent.DotLookup:
+		container := c.infer(typed.Container, anonArgs)
+		return c.lookup(x, container, typed.Lookup, true)
+
+	case *excellent.ArrayLookup:
+		container := c.infer(typed.Container, anonArgs)
+		lookup := c.infer(typed.Lookup, anonArgs)
+
+		switch lit := typed.Lookup.(type) {
+		case *excellent.TextLiteral:
+			return c.lookup(x, container, lit.Value.Native(), false)
+		case *excellent.NumberLiteral:
+			return c.lookup(x, container, lit.Value.Native().String(), false)
+		}
+		if container.array && lookup.name == "number" {
+			return xtype{name: container.name}
+		}
+		return anyType
+
+	case *excellent.FunctionCall:
+		return c.call(typed, anonArgs)
+
+	case *excellent.AnonFunction:
+		c.infer(typed.Body, append(anonArgs, typed.Args...))
+		return xtype{name: "function", signature: &functions.Signature{MinArgs: len(typed.Args), MaxArgs: len(typed.Args)}}
+
+	case *excellent.Parentheses:
+		return c.infer(typed.Exp, anonArgs)
+
+	case *excellent.TextLiteral:
+		return xtype{name: "text", literal: x}
+	case *excellent.NumberLiteral:
+		return xtype{name: "number", literal: x}
+	case *excellent.BooleanLiteral:
+		return xtype{name: "boolean", literal: x}
+	case *excellent.NullLiteral:
+		return anyType
+
+	case *excellent.ArrayLiteral:
+		for _, i := range typed.Items {
+			c.infer(i, anonArgs)
+		}
+		return xtype{name: "any", array: true}
+
+	case *excellent.ObjectLiteral:
+		for _, v := range typed.Values {
+			c.infer(v, anonArgs)
+		}
+		return xtype{name: "object"}
+	}
+
+	// for operators we just need to check the operands
+	for _, o := range operands(x) {
+		c.infer(o, anonArgs)
+	}
+
+	switch x.(type) {
+	case *excellent.Concatenation:
+		return xtype{name: "text"}
+	case *excellent.Multiplication, *excellent.Division, *excellent.Exponent, *excellent.Negation:
+		return xtype{name: "number"}
+	case *excellent.Equality, *excellent.InEquality, *excellent.LessThan, *excellent.LessThanOrEqual,
+		*excellent.GreaterThan, *excellent.GreaterThanOrEqual, *excellent.And, *excellent.Or, *excellent.Not:
+		return xtype{name: "boolean"}
+	}
+	return anyType
+}
+
+// gets the operands of an operator node
+func operands(x excellent.Expression) []excellent.Expression {
+	switch typed := x.(type) {
+	case *excellent.Concatenation:
+		return []excellent.Expression{typed.Exp1, typed.Exp2}
+	case *excellent.Addition:
+		return []excellent.Expression{typed.Exp1, typed.Exp2}
+	case *excellent.Subtraction:
+		return []excellent.Expression{typed.Exp1, typed.Exp2}
+	case *excellent.Multiplication:
+		return []excellent.Expression{typed.Exp1, typed.Exp2}
+	case *excellent.Division:
+		return []excellent.Expression{typed.Exp1, typed.Exp2}
+	case *excellent.Exponent:
+		return []excellent.Expression{typed.Expression, typed.Exponent}
+	case *excellent.Negation:
+		return []excellent.Expression{typed.Exp}
+	case *excellent.Not:
+		return []excellent.Expression{typed.Exp}
+	case *excellent.Equality:
+		return []excellent.Expression{typed.Exp1, typed.Exp2}
+	case *excellent.InEquality:
+		return []excellent.Expression{typed.Exp1, typed.Exp2}
+	case *excellent.LessThan:
+		return []excellent.Expression{typed.Exp1, typed.Exp2}
+	case *excellent.LessThanOrEqual:
+		return []excellent.Expression{typed.Exp1, typed.Exp2}
+	case *excellent.GreaterThan:
+		return []excellent.Expression{typed.Exp1, typed.Exp2}
+	case *excellent.GreaterThanOrEqual:
+		return []excellent.Expression{typed.Exp1, typed.Exp2}
+	case *excellent.And:
+		return []excellent.Expression{typed.Exp1, typed.Exp2}
+	case *excellent.Or:
+		return []excellent.Expression{typed.Exp1, typed.Exp2}
+	case *excellent.Coalesce:
+		return []excellent.Expression{typed.Exp1, typed.Exp2}
+	case *excellent.Ternary:
+		return []excellent.Expression{typed.Test, typed.Exp1, typed.Exp2}
+	}
+	return nil
+}
+
+// infers the type of a property lookup on a value of the given type
+func (c *checking) lookup(x excellent.Expression, container xtype, key string, dotNotation bool) xtype {
+	if container.isAny() {
+		return anyType
+	}
+
+	if container.array {
+		if _, err := strconv.Atoi(key); err != nil {
+			c.report(x, "array doesn't support lookups by '%s'", key)
+			return anyType
+		}
+		return xtype{name: container.name}
+	}
+
+	if container.isPrimitive() {
+		c.report(x, "%s doesn't support lookups", container)
+		return anyType
+	}
+
+	t := schema.Types[container.name]
+	if t == nil {
+		return anyType // e.g. object literal
+	}
+
+	lowerKey := strings.ToLower(key)
+
+	// static type with fixed properties
+	if t.KeySource == "" {
+		for k, p := range t.Properties {
+			if k == lowerKey && k != "__default__" {
+				return fromSchema(p)
+			}
+		}
+		if dotNotation {
+			c.report(x, "%s has no property '%s'", container, key)
+		}
+		return anyType
+	}
+
+	// dynamic type whose keys we can only check if we've been told them
+	known, checkKeys := c.checker.keys[t.KeySource]
+	if checkKeys && dotNotation {
+		found := false
+		for _, k := range known {
+			if k == lowerKey {
+				found = true
+				break
+			}
+		}
+		if !found {
+			c.report(x, "%s has no property '%s'", container, key)
+			return anyType
+		}
+	}
+
+	return fromSchema(t.Property)
+}
+
+// infers the type of a function call, checking the arguments against the function's signature
+func (c *checking) call(x *excellent.FunctionCall, anonArgs []string) xtype {
+	fn := c.infer(x.Func, anonArgs)
+
+	args := make([]xtype, len(x.Params))
+	for i, p := range x.Params {
+		args[i] = c.infer(p, anonArgs)
+	}
+
+	if fn.isAny() {
+		return anyType
+	}
+	if fn.name != "function" || fn.array {
+		c.report(x, "%s is not a function", x.Func.String())
+		return anyType
+	}
+
+	sig := fn.signature
+	if sig == nil {
+		return anyType
+	}
+
+	name := x.Func.String()
+	if len(args) < sig.MinArgs || (sig.MaxArgs >= 0 && len(args) > sig.MaxArgs) {
+		if sig.MinArgs == sig.MaxArgs {
+			c.report(x, "%s needs %d argument(s), got %d", name, sig.MinArgs, len(args))
+		} else if sig.MaxArgs < 0 {
+			c.report(x, "%s needs at least %d argument(s), got %d", name, sig.MinArgs, len(args))
+		} else {
+			c.report(x, "%s needs %d to %d argument(s), got %d", name, sig.MinArgs, sig.MaxArgs, len(args))
+		}
+		return anyType
+	}
+
+	for i, arg := range args {
+		if expected := sig.ArgType(i); !isConvertible(arg, expected) {
+			c.report(x.Params[i], "argument %d of %s must be %s, got %s", i+1, name, typeArticles[expected], arg)
+		}
+	}
+
+	return anyType
+}
+
+var typeArticles = map[string]string{
+	"number":   "a number",
+	"date":     "a date",
+	"datetime": "a datetime",
+	"array":    "an array",
+	"object":   "an object",
+}
+
+// returns whether a value of the given type can be converted to the expected argument type
+func isConvertible(t xtype, expected string) bool {
+	if t.isAny() {
+		return true
+	}
+
+	switch expected {
+	case "number":
+		if lit, isText := t.literal.(*excellent.TextLiteral); isText {
+			_, err := types.ToXNumber(nil, lit.Value)
+			return err == nil
+		}
+		return !t.array && t.name != "boolean" && t.name != "function"
+	case "date", "datetime":
+		return !t.array && t.name != "boolean" && t.name != "function"
+	case "array":
+		return t.array
+	case "object":
+		return t.isObject()
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/goflow/excellent/tools"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckTemplate(t *testing.T) {
+	checker := tools.NewChecker(map[string][]string{"results": {"age", "Favorite_Color"}})
+
+	tcs := []struct {
+		template string
+		issues   []*tools.Issue
+	}{
+		{``, nil},
+		{`Hi @contact.name, you are @results.age.value`, nil},
+		{`@(upper(contact.first_name & " " & run.results.favorite_color.category))`, nil},
+		{`@(format_date(contact.created_on, "YYYY")) @(contact.groups[0].name) @contact.urns.0`, nil},
+		{`@(foreach(contact.groups, (g) => g.name)) @(fields.anything) @(webhook.json.foo.bar)`, nil},
+		{`@(round("1.5")) @(abs(input.text)) @(if(contact.fields.age > 18, "adult", "child"))`, nil},
+		{`Email me at bob@nyaruka.com`, nil},
+		{
+			`Hi @contact.feilds.age`,
+			[]*tools.Issue{{Expression: "@contact.feilds.age", Position: 12, Message: "contact has no property 'feilds'"}},
+		},
+		{
+			`Hi @results.ag.value!`,
+			[]*tools.Issue{{Expression: "@results.ag.value", Position: 12, Message: "results has no property 'ag'"}},
+		},
+		{
+			`é @(run.results.ag)`,
+			[]*tools.Issue{{Expression: "@(run.results.ag)", Position: 16, Message: "results has no property 'ag'"}},
+		},
+		{
+			`@(foo) @(contact.name.first)`,
+			[]*tools.Issue{
+				{Expression: "@(foo)", Position: 2, Message: "context has no property 'foo'"},
+				{Expression: "@(contact.name.first)", Position: 22, Message: "text doesn't support lookups"},
+			},
+		},
+		{
+			`@(upper()) @(upper("a", "b")) @(format_number(1, 2, 3, 4)) @(array()) @(or())`,
+			[]*tools.Issue{
+				{Expression: "@(upper())", Position: 2, Message: "upper needs 1 argument(s), got 0"},
+				{Expression: `@(upper("a", "b"))`, Position: 13, Message: "upper needs 1 argument(s), got 2"},
+				{Expression: "@(format_number(1, 2, 3, 4))", Position: 32, Message: "format_number needs 1 to 3 argument(s), got 4"},
+				{Expression: "@(or())", Position: 72, Message: "or needs at least 1 argument(s), got 0"},
+			},
+		},
+		{
+			`@(round("abc")) @(reverse("abc")) @(keys(contact.groups)) @(abs(contact.urns))`,
+			[]*tools.Issue{
+				{Expression: `@(round("abc"))`, Position: 8, Message: "argument 1 of round must be a number, got text"},
+				{Expression: `@(reverse("abc"))`, Position: 26, Message: "argument 1 of reverse must be an array, got text"},
+				{Expression: "@(keys(contact.groups))", Position: 49, Message: "argument 1 of keys must be an object, got array"},
+				{Expression: "@(abs(contact.urns))", Position: 72, Message: "argument 1 of abs must be a number, got array"},
+			},
+		},
+		{
+			`@(contact.name(1)) @(((x) => x)(1, 2)) @(contact.groups.name)`,
+			[]*tools.Issue{
+				{Expression: "@(contact.name(1))", Position: 2, Message: "contact.name is not a function"},
+				{Expression: "@(((x) => x)(1, 2))", Position: 21, Message: "((x) => x) needs 1 argument(s), got 2"},
+				{Expression: "@(contact.groups.name)", Position: 56, Message: "array doesn't support lookups by 'name'"},
+			},
+		},
+		{
+			`@(upper(1 2))`,
+			[]*tools.Issue{{Expression: "@(upper(1 2))", Position: 2, Message: "syntax error at 2)"}},
+		},
+	}
+
+	for _, tc := range tcs {
+		actual := checker.CheckTemplate(tc.template, flows.RunContextTopLevels)
+
+		assert.Equal(t, tc.issues, actual, "issues mismatch for template: %s", tc.template)
+	}
+
+	// result keys aren't checked if they're not provided
+	assert.Nil(t, tools.NewChecker(nil).CheckTemplate(`@results.foo`, flows.RunContextTopLevels))
+}
@@ -23,6 +23,10 @@ func TestFindContextRefsInTemplate(t *testing.T) {
 		{`@(foo["bar"])`, [][]string{{`foo`}, {`foo`, `bar`}}, false},
 		{`@(3 * (foo.bar + 1) / 2)`, [][]string{{`foo`}, {`foo`, `bar`}}, false},
 		{`@("foo.bar")`, [][]string{}, false},
+		{`@([foo.bar, 1][0])`, [][]string{{`foo`}, {`foo`, `bar`}}, false},
+		{`@({"a": foo.bar}.a)`, [][]string{{`foo`}, {`foo`, `bar`}}, false},
+		{`@({"a": foo}["a"].b)`, [][]string{{`foo`}}, false},
+		{`@(!foo.x && foo.y ? foo.z : foo.w ?? "")`, [][]string{{`foo`}, {`foo`, `x`}, {`foo`}, {`foo`, `y`}, {`foo`}, {`foo`, `z`}, {`foo`}, {`foo`, `w`}}, false},
 		{`@(webhook.0.kd_prov)`, [][]string{{"webhook"}, {"webhook", "0"}, {"webhook", "0", "kd_prov"}}, false},
 	}
 
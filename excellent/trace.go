@@ -0,0 +1,142 @@
+package excellent
+
+import (
+	"reflect"
+	"unicode/utf8"
+
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent/types"
+)
+
+// TraceNode records the evaluation of a node of an expression, i.e. the value it evaluated to or the error it
+// produced, along with the evaluations of its sub-expressions. Function calls include evaluations of any
+// anonymous functions passed to them. Long values are truncated, and traces stop recording after a fixed
+// number of nodes.
+type TraceNode struct {
+	Expression string       `json:"expression"`
+	Value      string       `json:"value,omitempty"`
+	Error      string       `json:"error,omitempty"`
+	Warnings   []string     `json:"warnings,omitempty"`
+	Children   []*TraceNode `json:"children,omitempty"`
+}
+
+const (
+	// the most nodes recorded in a trace, after which further evaluations aren't recorded
+	maxTraceNodes = 500
+
+	// the most characters of a value recorded in a trace, after which it's truncated
+	maxTraceValueLength = 200
+)
+
+// records traces of the expressions evaluated in a template, tracking which node we're currently evaluating
+type tracer struct {
+	root    *TraceNode
+	current *TraceNode
+	nodes   int
+}
+
+func newTracer() *tracer {
+	root := &TraceNode{}
+	return &tracer{root: root, current: root}
+}
+
+// adds the given node as a child of the current node, returning false if the trace is already full
+func (t *tracer) add(node *TraceNode) bool {
+	if t.nodes >= maxTraceNodes {
+		return false
+	}
+	t.nodes++
+	t.current.Children = append(t.current.Children, node)
+	return true
+}
+
+// returns the traces of the top level expressions
+func (t *tracer) traces() []*TraceNode {
+	if t.root.Children == nil {
+		return []*TraceNode{}
+	}
+	return t.root.Children
+}
+
+// an expression which records its evaluation to a tracer
+type tracedExpression struct {
+	Expression
+
+	tracer *tracer
+}
+
+func (x *tracedExpression) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	node := &TraceNode{Expression: x.Expression.String()}
+	if !x.tracer.add(node) {
+		return x.Expression.Evaluate(env, scope, warnings)
+	}
+
+	parent := x.tracer.current
+	x.tracer.current = node
+
+	numWarnings := len(warnings.all)
+
+	value := x.Expression.Evaluate(env, scope, warnings)
+
+	x.tracer.current = parent
+
+	if len(warnings.all) > numWarnings {
+		node.Warnings = append([]string(nil), warnings.all[numWarnings:]...)
+	}
+	if types.IsXError(value) {
+		node.Error = value.(error).Error()
+	} else {
+		node.Value = describeTraced(value)
+	}
+
+	return value
+}
+
+// describes a value in a trace, using JSON where possible so that the contents of arrays and objects are visible
+func describeTraced(v types.XValue) string {
+	var described string
+	if _, isFunction := v.(*types.XFunction); !isFunction {
+		if asJSON, err := types.ToXJSON(v); err == nil {
+			described = asJSON.Native()
+		}
+	}
+	if described == "" {
+		described = types.Describe(v)
+	}
+
+	if utf8.RuneCountInString(described) > maxTraceValueLength {
+		described = string([]rune(described)[:maxTraceValueLength-3]) + "..."
+	}
+	return described
+}
+
+var expressionType = reflect.TypeOf((*Expression)(nil)).Elem()
+
+// creates a copy of the given expression tree where every node records its evaluation to the given tracer. We copy
+// rather than modify the tree because parsed trees are cached and shared.
+func traceTree(x Expression, t *tracer) Expression {
+	v := reflect.ValueOf(x)
+
+	if v.Kind() == reflect.Pointer && v.Elem().Kind() == reflect.Struct {
+		copied := reflect.New(v.Elem().Type())
+		copied.Elem().Set(v.Elem())
+
+		for i := 0; i < copied.Elem().NumField(); i++ {
+			field := copied.Elem().Field(i)
+
+			if field.Type() == expressionType && !field.IsNil() {
+				field.Set(reflect.ValueOf(traceTree(field.Interface().(Expression), t)))
+			} else if field.Type() == reflect.SliceOf(expressionType) && !field.IsNil() {
+				items := reflect.MakeSlice(field.Type(), field.Len(), field.Len())
+				for j := 0; j < field.Len(); j++ {
+					items.Index(j).Set(reflect.ValueOf(traceTree(field.Index(j).Interface().(Expression), t)))
+				}
+				field.Set(items)
+			}
+		}
+
+		x = copied.Interface().(Expression)
+	}
+
+	return &tracedExpression{Expression: x, tracer: t}
+}
@@ -38,6 +38,10 @@ type ContextReference struct {
 }
 
 func (x *ContextReference) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	value, exists := scope.Get(x.Name)
 	if !exists {
 		return types.NewXErrorf("context has no property '%s'", x.Name)
@@ -64,6 +68,10 @@ type DotLookup struct {
 }
 
 func (x *DotLookup) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	containerVal := x.Container.Evaluate(env, scope, warnings)
 	if types.IsXError(containerVal) {
 		return containerVal
@@ -87,6 +95,10 @@ type ArrayLookup struct {
 }
 
 func (x *ArrayLookup) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	containerVal := x.Container.Evaluate(env, scope, warnings)
 	if types.IsXError(containerVal) {
 		return containerVal
@@ -116,6 +128,10 @@ type FunctionCall struct {
 }
 
 func (x *FunctionCall) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	funcVal := x.Func.Evaluate(env, scope, warnings)
 	if types.IsXError(funcVal) {
 		return funcVal
@@ -131,7 +147,7 @@ func (x *FunctionCall) Evaluate(env envs.Environment, scope *Scope, warnings *Wa
 		params[i] = x.Params[i].Evaluate(env, scope, warnings)
 	}
 
-	return asFunction.Call(env, params)
+	return scope.budget.check(asFunction.Call(env, params))
 }
 
 func (x *FunctionCall) Visit(v func(Expression)) {
@@ -157,6 +173,10 @@ type AnonFunction struct {
 }
 
 func (x *AnonFunction) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	// create an XFunction which wraps our body expression
 	fn := func(env envs.Environment, args ...types.XValue) types.XValue {
 		// create new context that includes the args
@@ -166,6 +186,11 @@ func (x *AnonFunction) Evaluate(env envs.Environment, scope *Scope, warnings *Wa
 		}
 		childScope := NewScope(types.NewXObject(argsMap), scope)
 
+		if err := childScope.budget.enter(); err != nil {
+			return err
+		}
+		defer childScope.budget.exit()
+
 		return x.Body.Evaluate(env, childScope, warnings)
 	}
 
@@ -187,7 +212,11 @@ type Concatenation struct {
 }
 
 func (x *Concatenation) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
-	return operators.Concatenate(env, x.Exp1.Evaluate(env, scope, warnings), x.Exp2.Evaluate(env, scope, warnings))
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
+	return scope.budget.check(operators.Concatenate(env, x.Exp1.Evaluate(env, scope, warnings), x.Exp2.Evaluate(env, scope, warnings)))
 }
 
 func (x *Concatenation) Visit(v func(Expression)) {
@@ -206,6 +235,10 @@ type Addition struct {
 }
 
 func (x *Addition) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	return operators.Add(env, x.Exp1.Evaluate(env, scope, warnings), x.Exp2.Evaluate(env, scope, warnings))
 }
 
@@ -225,6 +258,10 @@ type Subtraction struct {
 }
 
 func (x *Subtraction) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	return operators.Subtract(env, x.Exp1.Evaluate(env, scope, warnings), x.Exp2.Evaluate(env, scope, warnings))
 }
 
@@ -244,6 +281,10 @@ type Multiplication struct {
 }
 
 func (x *Multiplication) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	return operators.Multiply(env, x.Exp1.Evaluate(env, scope, warnings), x.Exp2.Evaluate(env, scope, warnings))
 }
 
@@ -263,6 +304,10 @@ type Division struct {
 }
 
 func (x *Division) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	return operators.Divide(env, x.Exp1.Evaluate(env, scope, warnings), x.Exp2.Evaluate(env, scope, warnings))
 }
 
@@ -282,6 +327,10 @@ type Exponent struct {
 }
 
 func (x *Exponent) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	return operators.Exponent(env, x.Expression.Evaluate(env, scope, warnings), x.Exponent.Evaluate(env, scope, warnings))
 }
 
@@ -300,6 +349,10 @@ type Negation struct {
 }
 
 func (x *Negation) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	return operators.Negate(env, x.Exp.Evaluate(env, scope, warnings))
 }
 
@@ -312,12 +365,37 @@ func (x *Negation) String() string {
 	return fmt.Sprintf("-%s", x.Exp.String())
 }
 
+type Not struct {
+	Exp Expression
+}
+
+func (x *Not) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
+	return operators.Not(env, x.Exp.Evaluate(env, scope, warnings))
+}
+
+func (x *Not) Visit(v func(Expression)) {
+	x.Exp.Visit(v)
+	v(x)
+}
+
+func (x *Not) String() string {
+	return fmt.Sprintf("!%s", x.Exp.String())
+}
+
 type Equality struct {
 	Exp1 Expression
 	Exp2 Expression
 }
 
 func (x *Equality) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	return operators.Equal(env, x.Exp1.Evaluate(env, scope, warnings), x.Exp2.Evaluate(env, scope, warnings))
 }
 
@@ -337,6 +415,10 @@ type InEquality struct {
 }
 
 func (x *InEquality) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	return operators.NotEqual(env, x.Exp1.Evaluate(env, scope, warnings), x.Exp2.Evaluate(env, scope, warnings))
 }
 
@@ -356,6 +438,10 @@ type LessThan struct {
 }
 
 func (x *LessThan) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	return operators.LessThan(env, x.Exp1.Evaluate(env, scope, warnings), x.Exp2.Evaluate(env, scope, warnings))
 }
 
@@ -375,6 +461,10 @@ type LessThanOrEqual struct {
 }
 
 func (x *LessThanOrEqual) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	return operators.LessThanOrEqual(env, x.Exp1.Evaluate(env, scope, warnings), x.Exp2.Evaluate(env, scope, warnings))
 }
 
@@ -394,6 +484,10 @@ type GreaterThan struct {
 }
 
 func (x *GreaterThan) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	return operators.GreaterThan(env, x.Exp1.Evaluate(env, scope, warnings), x.Exp2.Evaluate(env, scope, warnings))
 }
 
@@ -413,6 +507,10 @@ type GreaterThanOrEqual struct {
 }
 
 func (x *GreaterThanOrEqual) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	return operators.GreaterThanOrEqual(env, x.Exp1.Evaluate(env, scope, warnings), x.Exp2.Evaluate(env, scope, warnings))
 }
 
@@ -426,11 +524,139 @@ func (x *GreaterThanOrEqual) String() string {
 	return fmt.Sprintf("%s >= %s", x.Exp1.String(), x.Exp2.String())
 }
 
+type And struct {
+	Exp1 Expression
+	Exp2 Expression
+}
+
+func (x *And) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
+	val1 := x.Exp1.Evaluate(env, scope, warnings)
+
+	// only evaluate the second operand if the first doesn't decide the result
+	if b, xerr := types.ToXBoolean(val1); xerr != nil || !b.Native() {
+		return operators.And(env, val1, types.XBooleanFalse)
+	}
+
+	return operators.And(env, val1, x.Exp2.Evaluate(env, scope, warnings))
+}
+
+func (x *And) Visit(v func(Expression)) {
+	x.Exp1.Visit(v)
+	x.Exp2.Visit(v)
+	v(x)
+}
+
+func (x *And) String() string {
+	return fmt.Sprintf("%s && %s", x.Exp1.String(), x.Exp2.String())
+}
+
+type Or struct {
+	Exp1 Expression
+	Exp2 Expression
+}
+
+func (x *Or) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
+	val1 := x.Exp1.Evaluate(env, scope, warnings)
+
+	// only evaluate the second operand if the first doesn't decide the result
+	if b, xerr := types.ToXBoolean(val1); xerr != nil || b.Native() {
+		return operators.Or(env, val1, types.XBooleanFalse)
+	}
+
+	return operators.Or(env, val1, x.Exp2.Evaluate(env, scope, warnings))
+}
+
+func (x *Or) Visit(v func(Expression)) {
+	x.Exp1.Visit(v)
+	x.Exp2.Visit(v)
+	v(x)
+}
+
+func (x *Or) String() string {
+	return fmt.Sprintf("%s || %s", x.Exp1.String(), x.Exp2.String())
+}
+
+type Coalesce struct {
+	Exp1 Expression
+	Exp2 Expression
+}
+
+func (x *Coalesce) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
+	val1 := x.Exp1.Evaluate(env, scope, warnings)
+
+	// only evaluate the second operand if the first is null or an error
+	if !types.IsNil(val1) && !types.IsXError(val1) {
+		return val1
+	}
+
+	return operators.Coalesce(env, val1, x.Exp2.Evaluate(env, scope, warnings))
+}
+
+func (x *Coalesce) Visit(v func(Expression)) {
+	x.Exp1.Visit(v)
+	x.Exp2.Visit(v)
+	v(x)
+}
+
+func (x *Coalesce) String() string {
+	return fmt.Sprintf("%s ?? %s", x.Exp1.String(), x.Exp2.String())
+}
+
+type Ternary struct {
+	Test Expression
+	Exp1 Expression
+	Exp2 Expression
+}
+
+func (x *Ternary) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
+	test, xerr := types.ToXBoolean(x.Test.Evaluate(env, scope, warnings))
+	if xerr != nil {
+		return xerr
+	}
+
+	// only evaluate the branch that is taken
+	if test.Native() {
+		return x.Exp1.Evaluate(env, scope, warnings)
+	}
+	return x.Exp2.Evaluate(env, scope, warnings)
+}
+
+func (x *Ternary) Visit(v func(Expression)) {
+	x.Test.Visit(v)
+	x.Exp1.Visit(v)
+	x.Exp2.Visit(v)
+	v(x)
+}
+
+func (x *Ternary) String() string {
+	return fmt.Sprintf("%s ? %s : %s", x.Test.String(), x.Exp1.String(), x.Exp2.String())
+}
+
 type Parentheses struct {
 	Exp Expression
 }
 
 func (x *Parentheses) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	return x.Exp.Evaluate(env, scope, warnings)
 }
 
@@ -443,11 +669,94 @@ func (x *Parentheses) String() string {
 	return fmt.Sprintf("(%s)", x.Exp.String())
 }
 
+// ArrayLiteral is a literal array like [1, "x", true]
+type ArrayLiteral struct {
+	Items []Expression
+}
+
+func (x *ArrayLiteral) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
+	items := make([]types.XValue, len(x.Items))
+	for i := range x.Items {
+		items[i] = x.Items[i].Evaluate(env, scope, warnings)
+
+		if types.IsXError(items[i]) {
+			return items[i]
+		}
+	}
+
+	return scope.budget.check(types.NewXArray(items...))
+}
+
+func (x *ArrayLiteral) Visit(v func(Expression)) {
+	for _, i := range x.Items {
+		i.Visit(v)
+	}
+	v(x)
+}
+
+func (x *ArrayLiteral) String() string {
+	items := make([]string, len(x.Items))
+	for i := range x.Items {
+		items[i] = x.Items[i].String()
+	}
+
+	return fmt.Sprintf("[%s]", strings.Join(items, ", "))
+}
+
+// ObjectLiteral is a literal object like {"name": "Bob", "age": 23}
+type ObjectLiteral struct {
+	Keys   []string
+	Values []Expression
+}
+
+func (x *ObjectLiteral) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
+	properties := make(map[string]types.XValue, len(x.Keys))
+	for i := range x.Keys {
+		value := x.Values[i].Evaluate(env, scope, warnings)
+
+		if types.IsXError(value) {
+			return value
+		}
+
+		properties[x.Keys[i]] = value
+	}
+
+	return scope.budget.check(types.NewXObject(properties))
+}
+
+func (x *ObjectLiteral) Visit(v func(Expression)) {
+	for _, e := range x.Values {
+		e.Visit(v)
+	}
+	v(x)
+}
+
+func (x *ObjectLiteral) String() string {
+	props := make([]string, len(x.Keys))
+	for i := range x.Keys {
+		props[i] = fmt.Sprintf("%s: %s", types.NewXText(x.Keys[i]).Describe(), x.Values[i].String())
+	}
+
+	return fmt.Sprintf("{%s}", strings.Join(props, ", "))
+}
+
 type TextLiteral struct {
 	Value *types.XText
 }
 
 func (x *TextLiteral) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	return x.Value
 }
 
@@ -465,6 +774,10 @@ type NumberLiteral struct {
 }
 
 func (x *NumberLiteral) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	return x.Value
 }
 
@@ -482,6 +795,10 @@ type BooleanLiteral struct {
 }
 
 func (x *BooleanLiteral) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	return x.Value
 }
 
@@ -496,6 +813,10 @@ func (x *BooleanLiteral) String() string {
 type NullLiteral struct{}
 
 func (x *NullLiteral) Evaluate(env envs.Environment, scope *Scope, warnings *Warnings) types.XValue {
+	if err := scope.budget.operation(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
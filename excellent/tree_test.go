@@ -60,6 +60,45 @@ func TestParseTrees(t *testing.T) {
 				Params: []Expression{&TextLiteral{Value: types.NewXText("abc")}},
 			},
 		},
+		{
+			expression: `[1, "abc"]`,
+			parsed: &ArrayLiteral{Items: []Expression{
+				&NumberLiteral{Value: types.RequireXNumberFromString(`1`)},
+				&TextLiteral{Value: types.NewXText("abc")},
+			}},
+		},
+		{
+			expression: `{"a": x, "b c": []}`,
+			parsed: &ObjectLiteral{
+				Keys:   []string{"a", "b c"},
+				Values: []Expression{&ContextReference{Name: "x"}, &ArrayLiteral{Items: []Expression{}}},
+			},
+		},
+		{
+			expression: `!x && y || z`,
+			parsed: &Or{
+				Exp1: &And{
+					Exp1: &Not{Exp: &ContextReference{Name: "x"}},
+					Exp2: &ContextReference{Name: "y"},
+				},
+				Exp2: &ContextReference{Name: "z"},
+			},
+		},
+		{
+			expression: `x ?? y = 1 ? "a" : z ? "b" : "c"`,
+			parsed: &Ternary{
+				Test: &Coalesce{
+					Exp1: &ContextReference{Name: "x"},
+					Exp2: &Equality{Exp1: &ContextReference{Name: "y"}, Exp2: &NumberLiteral{Value: types.RequireXNumberFromString(`1`)}},
+				},
+				Exp1: &TextLiteral{Value: types.NewXText("a")},
+				Exp2: &Ternary{
+					Test: &ContextReference{Name: "z"},
+					Exp1: &TextLiteral{Value: types.NewXText("b")},
+					Exp2: &TextLiteral{Value: types.NewXText("c")},
+				},
+			},
+		},
 		{
 			expression: `child.run.status`,
 			parsed: &DotLookup{
@@ -112,6 +151,8 @@ func TestExpressionVisitAndString(t *testing.T) {
 		{&Exponent{Expression: one, Exponent: two}, []string{`1`, `2`, `1 ^ 2`}},
 		{&Negation{Exp: one}, []string{`1`, `-1`}},
 
+		{&Not{Exp: foo}, []string{`foo`, `!foo`}},
+
 		{&Equality{Exp1: one, Exp2: two}, []string{`1`, `2`, `1 = 2`}},
 		{&InEquality{Exp1: one, Exp2: two}, []string{`1`, `2`, `1 != 2`}},
 		{&LessThan{Exp1: one, Exp2: two}, []string{`1`, `2`, `1 < 2`}},
@@ -119,8 +160,18 @@ func TestExpressionVisitAndString(t *testing.T) {
 		{&GreaterThan{Exp1: one, Exp2: two}, []string{`1`, `2`, `1 > 2`}},
 		{&GreaterThanOrEqual{Exp1: one, Exp2: two}, []string{`1`, `2`, `1 >= 2`}},
 
+		{&And{Exp1: foo, Exp2: one}, []string{`foo`, `1`, `foo && 1`}},
+		{&Or{Exp1: foo, Exp2: one}, []string{`foo`, `1`, `foo || 1`}},
+		{&Coalesce{Exp1: foo, Exp2: abc}, []string{`foo`, `"abc"`, `foo ?? "abc"`}},
+		{&Ternary{Test: foo, Exp1: one, Exp2: two}, []string{`foo`, `1`, `2`, `foo ? 1 : 2`}},
+
 		{&Parentheses{Exp: abc}, []string{`"abc"`, `("abc")`}},
 
+		{&ArrayLiteral{Items: []Expression{}}, []string{`[]`}},
+		{&ArrayLiteral{Items: []Expression{one, abc}}, []string{`1`, `"abc"`, `[1, "abc"]`}},
+		{&ObjectLiteral{}, []string{`{}`}},
+		{&ObjectLiteral{Keys: []string{"a", `b "c"`}, Values: []Expression{one, foo}}, []string{`1`, `foo`, `{"a": 1, "b \"c\"": foo}`}},
+
 		{&TextLiteral{Value: types.XTextEmpty}, []string{`""`}},
 		{abc, []string{`"abc"`}},
 		{&TextLiteral{Value: types.NewXText(`don't say "hello"`)}, []string{`"don't say \"hello\""`}},
@@ -11,6 +11,7 @@ import (
 
 // XArray is an array of items.
 //
+//	@([1, "x", true]) -> [1, x, true]
 //	@(array(1, "x", true)) -> [1, x, true]
 //	@(array(1, "x", true)[1]) -> x
 //	@(count(array(1, "x", true))) -> 3
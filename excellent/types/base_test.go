@@ -109,6 +109,18 @@ func TestXValue(t *testing.T) {
 			rendered:  "2017-07-18T15:30:00.000000-05:00",
 			formatted: "18-07-2017 20:30",
 			asBool:    true,
+		}, {
+			value:     types.NewXDuration(0, 3, 90*time.Minute),
+			marshaled: `"P3DT1H30M"`,
+			rendered:  "P3DT1H30M",
+			formatted: "3d 1h 30m",
+			asBool:    true,
+		}, {
+			value:     types.XDurationZero,
+			marshaled: `"PT0S"`,
+			rendered:  "PT0S",
+			formatted: "0s",
+			asBool:    false,
 		}, {
 			value:     types.NewXArray(),
 			marshaled: `[]`,
@@ -232,6 +244,9 @@ func TestEquals(t *testing.T) {
 			false, // different value
 		},
 
+		{types.NewXDuration(1, 2, time.Hour), types.NewXDuration(1, 2, time.Hour), true},
+		{types.NewXDuration(1, 2, time.Hour), types.NewXDuration(0, 2, time.Hour), false},
+
 		{types.NewXError(fmt.Errorf("Error")), types.NewXError(fmt.Errorf("Error")), true},
 		{types.NewXError(fmt.Errorf("Error")), types.XDateTimeZero, false},
 
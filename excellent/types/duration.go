@@ -0,0 +1,264 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nyaruka/gocommon/jsonx"
+	"github.com/nyaruka/goflow/envs"
+)
+
+// XDuration is a length of time made up of months, days and a time part. Months and days are kept separate so that
+// adding a duration to a date or datetime respects calendar months and daylight savings changes.
+//
+// Durations can be parsed from ISO 8601 format, e.g. `P3DT12H`, or from shorthand like `2h30m` which supports the
+// units `w`, `d`, `h`, `m`, `s` and `ms`.
+//
+//	@(duration("2h30m")) -> PT2H30M
+//	@(duration("P1Y2M3D")) -> P1Y2M3D
+//	@(format(duration("P3DT2H30M"))) -> 3d 2h 30m
+//	@(json(duration("90m"))) -> "PT1H30M"
+//
+// @type duration
+type XDuration struct {
+	baseValue
+
+	months int
+	days   int
+	clock  time.Duration
+}
+
+// NewXDuration creates a new duration from the given number of months, days and time part
+func NewXDuration(months, days int, clock time.Duration) *XDuration {
+	return &XDuration{months: months, days: days, clock: clock}
+}
+
+// Describe returns a representation of this type for error messages
+func (x *XDuration) Describe() string { return "duration" }
+
+// Truthy determines truthiness for this type
+func (x *XDuration) Truthy() bool {
+	return !x.IsZero()
+}
+
+// Render returns the canonical text representation
+func (x *XDuration) Render() string {
+	if x.IsZero() {
+		return "PT0S"
+	}
+	if x.months <= 0 && x.days <= 0 && x.clock <= 0 {
+		return "-" + x.Negate().Render()
+	}
+
+	var sb strings.Builder
+	sb.WriteString("P")
+
+	years, months := x.months/12, x.months%12
+	writeComponent(&sb, int64(years), "Y")
+	writeComponent(&sb, int64(months), "M")
+	writeComponent(&sb, int64(x.days), "D")
+
+	if x.clock != 0 {
+		sb.WriteString("T")
+
+		hours := x.clock / time.Hour
+		minutes := (x.clock % time.Hour) / time.Minute
+		nanos := x.clock % time.Minute
+
+		writeComponent(&sb, int64(hours), "H")
+		writeComponent(&sb, int64(minutes), "M")
+
+		if nanos != 0 {
+			sb.WriteString(strconv.FormatFloat(nanos.Seconds(), 'f', -1, 64))
+			sb.WriteString("S")
+		}
+	}
+
+	return sb.String()
+}
+
+// Format returns the pretty text representation
+func (x *XDuration) Format(env envs.Environment) string {
+	if x.IsZero() {
+		return "0s"
+	}
+
+	parts := make([]string, 0, 6)
+	addPart := func(v int64, unit string) {
+		if v != 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", v, unit))
+		}
+	}
+
+	addPart(int64(x.months/12), "y")
+	addPart(int64(x.months%12), "mo")
+	addPart(int64(x.days), "d")
+	addPart(int64(x.clock/time.Hour), "h")
+	addPart(int64((x.clock%time.Hour)/time.Minute), "m")
+
+	if nanos := x.clock % time.Minute; nanos != 0 {
+		parts = append(parts, strconv.FormatFloat(nanos.Seconds(), 'f', -1, 64)+"s")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// MarshalJSON is called when a struct containing this type is marshaled
+func (x *XDuration) MarshalJSON() ([]byte, error) {
+	return jsonx.Marshal(x.Render())
+}
+
+// String returns the native string representation of this type
+func (x *XDuration) String() string {
+	return fmt.Sprintf(`XDuration(%d, %d, %s)`, x.months, x.days, x.clock)
+}
+
+// Months returns the months part of this duration
+func (x *XDuration) Months() int { return x.months }
+
+// Days returns the days part of this duration
+func (x *XDuration) Days() int { return x.days }
+
+// Clock returns the time part of this duration
+func (x *XDuration) Clock() time.Duration { return x.clock }
+
+// IsZero returns whether this is a zero length duration
+func (x *XDuration) IsZero() bool {
+	return x.months == 0 && x.days == 0 && x.clock == 0
+}
+
+// Equals determines equality for this type
+func (x *XDuration) Equals(o XValue) bool {
+	other := o.(*XDuration)
+
+	return x.months == other.months && x.days == other.days && x.clock == other.clock
+}
+
+// Add returns the sum of this duration and another
+func (x *XDuration) Add(other *XDuration) *XDuration {
+	return NewXDuration(x.months+other.months, x.days+other.days, x.clock+other.clock)
+}
+
+// Multiply returns this duration multiplied by the given factor
+func (x *XDuration) Multiply(factor int) *XDuration {
+	return NewXDuration(x.months*factor, x.days*factor, x.clock*time.Duration(factor))
+}
+
+// Negate returns the negation of this duration
+func (x *XDuration) Negate() *XDuration {
+	return x.Multiply(-1)
+}
+
+// AddTo adds this duration to the given time, adding months and days in the time's location. If adding months
+// gives a day that doesn't exist in the resulting month, e.g. Jan 31 + 1 month, the last day of that month is used.
+func (x *XDuration) AddTo(t time.Time) time.Time {
+	if x.months != 0 {
+		year, month, day := t.Date()
+		month += time.Month(x.months)
+		lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, t.Location()).Day()
+
+		t = time.Date(year, month, min(day, lastDay), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	}
+
+	return t.AddDate(0, 0, x.days).Add(x.clock)
+}
+
+// XDurationZero is the zero duration value
+var XDurationZero = NewXDuration(0, 0, 0)
+var _ XValue = XDurationZero
+
+// ToXDuration converts the given value to a duration or returns an error if that isn't possible
+func ToXDuration(env envs.Environment, x XValue) (*XDuration, *XError) {
+	if !IsNil(x) {
+		switch typed := x.(type) {
+		case *XError:
+			return XDurationZero, typed
+		case *XDuration:
+			return typed, nil
+		case *XText:
+			parsed, err := parseDuration(typed.Native())
+			if err == nil {
+				return parsed, nil
+			}
+		case *XObject:
+			if typed.hasDefault() {
+				return ToXDuration(env, typed.Default())
+			}
+		}
+	}
+
+	return XDurationZero, NewXErrorf("unable to convert %s to a duration", Describe(x))
+}
+
+var isoDurationRegex = regexp.MustCompile(`^(?i)(-)?P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+var shortDurationRegex = regexp.MustCompile(`^(-)?((?:\s*\d+(?:\.\d+)?\s*(?:ms|w|d|h|m|s))+)$`)
+var shortDurationPartRegex = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(ms|w|d|h|m|s)`)
+
+var shortDurationUnits = map[string]time.Duration{
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+}
+
+// parses a duration in ISO 8601 format or in shorthand like 2h30m
+func parseDuration(s string) (*XDuration, error) {
+	s = strings.TrimSpace(s)
+
+	if m := isoDurationRegex.FindStringSubmatch(s); m != nil && strings.Join(m[2:], "") != "" && !strings.HasSuffix(strings.ToUpper(s), "T") {
+		years, _ := strconv.Atoi(m[2])
+		months, _ := strconv.Atoi(m[3])
+		weeks, _ := strconv.Atoi(m[4])
+		days, _ := strconv.Atoi(m[5])
+		hours, _ := strconv.Atoi(m[6])
+		minutes, _ := strconv.Atoi(m[7])
+		seconds, _ := strconv.ParseFloat(m[8], 64)
+
+		clock := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds*float64(time.Second))
+		d := NewXDuration(years*12+months, weeks*7+days, clock)
+		if m[1] == "-" {
+			d = d.Negate()
+		}
+		return d, nil
+	}
+
+	if m := shortDurationRegex.FindStringSubmatch(s); m != nil {
+		days := 0
+		var clock time.Duration
+
+		for _, part := range shortDurationPartRegex.FindAllStringSubmatch(m[2], -1) {
+			value, _ := strconv.ParseFloat(part[1], 64)
+
+			switch part[2] {
+			case "w", "d":
+				if value != float64(int(value)) {
+					return nil, fmt.Errorf("fractional %s not supported in duration", part[2])
+				}
+				if part[2] == "w" {
+					value *= 7
+				}
+				days += int(value)
+			default:
+				clock += time.Duration(value * float64(shortDurationUnits[part[2]]))
+			}
+		}
+
+		d := NewXDuration(0, days, clock)
+		if m[1] == "-" {
+			d = d.Negate()
+		}
+		return d, nil
+	}
+
+	return nil, fmt.Errorf("%s is not a valid duration", s)
+}
+
+func writeComponent(sb *strings.Builder, v int64, unit string) {
+	if v != 0 {
+		sb.WriteString(strconv.FormatInt(v, 10))
+		sb.WriteString(unit)
+	}
+}
@@ -0,0 +1,100 @@
+package types_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nyaruka/gocommon/jsonx"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXDuration(t *testing.T) {
+	env := envs.NewBuilder().Build()
+
+	d1 := types.NewXDuration(14, 3, 4*time.Hour+5*time.Minute+6500*time.Millisecond)
+	assert.Equal(t, `duration`, d1.Describe())
+	assert.True(t, d1.Truthy())
+	assert.Equal(t, `P1Y2M3DT4H5M6.5S`, d1.Render())
+	assert.Equal(t, `1y 2mo 3d 4h 5m 6.5s`, d1.Format(env))
+	assert.Equal(t, `XDuration(14, 3, 4h5m6.5s)`, d1.String())
+	assert.Equal(t, 14, d1.Months())
+	assert.Equal(t, 3, d1.Days())
+	assert.Equal(t, 4*time.Hour+5*time.Minute+6500*time.Millisecond, d1.Clock())
+
+	assert.False(t, types.XDurationZero.Truthy())
+	assert.Equal(t, `PT0S`, types.XDurationZero.Render())
+	assert.Equal(t, `0s`, types.XDurationZero.Format(env))
+	assert.Equal(t, `-P1DT2H`, types.NewXDuration(0, -1, -2*time.Hour).Render())
+	assert.Equal(t, `P1MT-2H`, types.NewXDuration(1, 0, -2*time.Hour).Render())
+
+	marshaled, err := jsonx.Marshal(types.NewXDuration(0, 0, 90*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, `"PT1H30M"`, string(marshaled))
+
+	// test equality
+	assert.True(t, d1.Equals(types.NewXDuration(14, 3, 4*time.Hour+5*time.Minute+6500*time.Millisecond)))
+	assert.False(t, d1.Equals(types.NewXDuration(14, 3, 4*time.Hour)))
+
+	// test arithmetic
+	assert.Equal(t, types.NewXDuration(15, 5, 5*time.Hour), types.NewXDuration(1, 2, time.Hour).Add(types.NewXDuration(14, 3, 4*time.Hour)))
+	assert.Equal(t, types.NewXDuration(3, 6, 3*time.Hour), types.NewXDuration(1, 2, time.Hour).Multiply(3))
+	assert.Equal(t, types.NewXDuration(-1, -2, -time.Hour), types.NewXDuration(1, 2, time.Hour).Negate())
+
+	// adding months clamps to the end of the month
+	assert.Equal(t, time.Date(2019, 2, 28, 10, 0, 0, 0, time.UTC), types.NewXDuration(1, 0, 0).AddTo(time.Date(2019, 1, 31, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2020, 2, 29, 10, 0, 0, 0, time.UTC), types.NewXDuration(1, 0, 0).AddTo(time.Date(2020, 1, 31, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2019, 2, 28, 10, 0, 0, 0, time.UTC), types.NewXDuration(-1, 0, 0).AddTo(time.Date(2019, 3, 31, 10, 0, 0, 0, time.UTC)))
+
+	// adding days respects daylight savings changes
+	ny, _ := time.LoadLocation("America/New_York")
+	assert.Equal(t, time.Date(2019, 3, 10, 10, 0, 0, 0, ny), types.NewXDuration(0, 1, 0).AddTo(time.Date(2019, 3, 9, 10, 0, 0, 0, ny)))
+	assert.Equal(t, time.Date(2019, 3, 10, 11, 0, 0, 0, ny), types.NewXDuration(0, 0, 24*time.Hour).AddTo(time.Date(2019, 3, 9, 10, 0, 0, 0, ny)))
+}
+
+func TestToXDuration(t *testing.T) {
+	var tests = []struct {
+		value    types.XValue
+		expected *types.XDuration
+		hasError bool
+	}{
+		{nil, types.XDurationZero, true},
+		{types.NewXError(fmt.Errorf("Error")), types.XDurationZero, true},
+		{types.NewXNumberFromInt(123), types.XDurationZero, true},
+		{types.NewXDuration(1, 2, 3), types.NewXDuration(1, 2, 3), false},
+		{types.NewXText("P3D"), types.NewXDuration(0, 3, 0), false},
+		{types.NewXText("P1Y2M3W4DT5H6M7.5S"), types.NewXDuration(14, 25, 5*time.Hour+6*time.Minute+7500*time.Millisecond), false},
+		{types.NewXText("pt90m"), types.NewXDuration(0, 0, 90*time.Minute), false},
+		{types.NewXText("-P1M"), types.NewXDuration(-1, 0, 0), false},
+		{types.NewXText("2h30m"), types.NewXDuration(0, 0, 150*time.Minute), false},
+		{types.NewXText(" 1w 2d 3h "), types.NewXDuration(0, 9, 3*time.Hour), false},
+		{types.NewXText("1.5h"), types.NewXDuration(0, 0, 90*time.Minute), false},
+		{types.NewXText("250ms"), types.NewXDuration(0, 0, 250*time.Millisecond), false},
+		{types.NewXText("-3d"), types.NewXDuration(0, -3, 0), false},
+		{types.NewXText("P"), types.XDurationZero, true},
+		{types.NewXText("PT"), types.XDurationZero, true},
+		{types.NewXText("P1DT"), types.XDurationZero, true},
+		{types.NewXText("1.5d"), types.XDurationZero, true},
+		{types.NewXText("2 hours"), types.XDurationZero, true},
+		{types.NewXText(""), types.XDurationZero, true},
+		{types.NewXObject(map[string]types.XValue{
+			"__default__": types.NewXText("P2D"), // should use default
+			"foo":         types.NewXNumberFromInt(234),
+		}), types.NewXDuration(0, 2, 0), false},
+	}
+
+	env := envs.NewBuilder().Build()
+
+	for _, test := range tests {
+		result, err := types.ToXDuration(env, test.value)
+
+		if test.hasError {
+			assert.Error(t, err.Native(), "expected error for input %T{%s}", test.value, test.value)
+		} else {
+			assert.NoError(t, err.Native(), "unexpected error for input %T{%s}", test.value, test.value)
+			assert.Equal(t, test.expected, result, "result mismatch for input %T{%s}", test.value, test.value)
+		}
+	}
+}
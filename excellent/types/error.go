@@ -52,6 +52,9 @@ func (x *XError) Native() error {
 
 func (x *XError) Error() string { return x.Native().Error() }
 
+// Unwrap returns the native error so that errors.Is and errors.As can be used with XErrors
+func (x *XError) Unwrap() error { return x.Native() }
+
 // Equals determines equality for this type
 func (x *XError) Equals(o XValue) bool {
 	other := o.(*XError)
@@ -16,6 +16,7 @@ const serializeDefaultAs = "__default__"
 
 // XObject is an object with named properties.
 //
+//	@({"foo": 1, "bar": "x"}) -> {bar: x, foo: 1}
 //	@(object("foo", 1, "bar", "x")) -> {bar: x, foo: 1}
 //	@(object("foo", 1, "bar", "x").bar) -> x
 //	@(object("foo", 1, "bar", "x")["bar"]) -> x
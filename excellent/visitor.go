@@ -17,23 +17,43 @@ type visitor struct {
 	// tracks where we are in the context
 	currContext     []string
 	contextCallback func([]string)
+
+	// optionally tracks where each node starts in the expression
+	positions Positions
 }
 
 func (v *visitor) context(part string, reset bool) {
 	part = strings.ToLower(part)
 	if reset {
 		v.currContext = []string{part}
-	} else {
+	} else if v.currContext != nil {
 		v.currContext = append(v.currContext, part)
 	}
-	if v.contextCallback != nil {
+	if v.contextCallback != nil && v.currContext != nil {
 		v.contextCallback(v.currContext)
 	}
 }
 
 // Visit the top level parse tree
 func (v *visitor) Visit(tree antlr.ParseTree) any {
-	return tree.Accept(v)
+	result := tree.Accept(v)
+
+	// record the position of new nodes, i.e. the innermost rule that produced them
+	if v.positions != nil {
+		if x, isExpression := result.(Expression); isExpression {
+			if rule, isRule := tree.(antlr.ParserRuleContext); isRule {
+				v.position(x, rule.GetStart())
+			}
+		}
+	}
+
+	return result
+}
+
+func (v *visitor) position(x Expression, token antlr.Token) {
+	if _, seen := v.positions[x]; !seen {
+		v.positions[x] = token.GetStart()
+	}
 }
 
 // VisitParse handles our top level parser
@@ -57,17 +77,25 @@ func (v *visitor) VisitContextReference(ctx *gen.ContextReferenceContext) any {
 // VisitDotLookup deals with property lookups like foo.bar
 func (v *visitor) VisitDotLookup(ctx *gen.DotLookupContext) any {
 	container := toExpression(v.Visit(ctx.Atom()))
-	var lookup string
+	var lookupNode antlr.TerminalNode
 
 	if ctx.NAME() != nil {
-		lookup = ctx.NAME().GetText()
+		lookupNode = ctx.NAME()
 	} else {
-		lookup = ctx.INTEGER().GetText()
+		lookupNode = ctx.INTEGER()
 	}
 
+	lookup := lookupNode.GetText()
 	v.context(lookup, false)
 
-	return &DotLookup{Container: container, Lookup: lookup}
+	x := &DotLookup{Container: container, Lookup: lookup}
+
+	// the position of a dot lookup is that of the property name
+	if v.positions != nil {
+		v.position(x, lookupNode.GetSymbol())
+	}
+
+	return x
 }
 
 // VisitArrayLookup deals with lookups such as foo[5] or foo["key with spaces"]
@@ -106,6 +134,37 @@ func (v *visitor) VisitFunctionParameters(ctx *gen.FunctionParametersContext) an
 	return params
 }
 
+// VisitArrayLiteral deals with array literals like [1, "x", true]
+func (v *visitor) VisitArrayLiteral(ctx *gen.ArrayLiteralContext) any {
+	items := []Expression{}
+	if ctx.Parameters() != nil {
+		items, _ = v.Visit(ctx.Parameters()).([]Expression)
+	}
+
+	// lookups into a literal aren't lookups into the context
+	v.currContext = nil
+
+	return &ArrayLiteral{Items: items}
+}
+
+// VisitObjectLiteral deals with object literals like {"name": "Bob", "age": 23}
+func (v *visitor) VisitObjectLiteral(ctx *gen.ObjectLiteralContext) any {
+	var keys []string
+	var values []Expression
+
+	if ctx.Properties() != nil {
+		for _, p := range ctx.Properties().AllProperty() {
+			keys = append(keys, unquote(p.TEXT().GetText()))
+			values = append(values, toExpression(v.Visit(p.Expression())))
+		}
+	}
+
+	// lookups into a literal aren't lookups into the context
+	v.currContext = nil
+
+	return &ObjectLiteral{Keys: keys, Values: values}
+}
+
 // VisitAnonFunction deals with anonymous functions, e.g. (x) => 2 * x
 func (v *visitor) VisitAnonFunction(ctx *gen.AnonFunctionContext) any {
 	return &AnonFunction{
@@ -154,6 +213,39 @@ func (v *visitor) VisitMultiplicationOrDivision(ctx *gen.MultiplicationOrDivisio
 	return &Division{Exp1: exp1, Exp2: exp2}
 }
 
+// VisitAnd deals with logical ands like x && y
+func (v *visitor) VisitAnd(ctx *gen.AndContext) any {
+	return &And{
+		Exp1: toExpression(v.Visit(ctx.Expression(0))),
+		Exp2: toExpression(v.Visit(ctx.Expression(1))),
+	}
+}
+
+// VisitOr deals with logical ors like x || y
+func (v *visitor) VisitOr(ctx *gen.OrContext) any {
+	return &Or{
+		Exp1: toExpression(v.Visit(ctx.Expression(0))),
+		Exp2: toExpression(v.Visit(ctx.Expression(1))),
+	}
+}
+
+// VisitCoalesce deals with null coalescing like x ?? "default"
+func (v *visitor) VisitCoalesce(ctx *gen.CoalesceContext) any {
+	return &Coalesce{
+		Exp1: toExpression(v.Visit(ctx.Expression(0))),
+		Exp2: toExpression(v.Visit(ctx.Expression(1))),
+	}
+}
+
+// VisitTernary deals with conditionals like x > 5 ? "big" : "small"
+func (v *visitor) VisitTernary(ctx *gen.TernaryContext) any {
+	return &Ternary{
+		Test: toExpression(v.Visit(ctx.Expression(0))),
+		Exp1: toExpression(v.Visit(ctx.Expression(1))),
+		Exp2: toExpression(v.Visit(ctx.Expression(2))),
+	}
+}
+
 // VisitExponent deals with exponenets such as 5^5
 func (v *visitor) VisitExponent(ctx *gen.ExponentContext) any {
 	return &Exponent{
@@ -167,6 +259,11 @@ func (v *visitor) VisitNegation(ctx *gen.NegationContext) any {
 	return &Negation{Exp: toExpression(v.Visit(ctx.Expression()))}
 }
 
+// VisitNot deals with logical negations such as !x
+func (v *visitor) VisitNot(ctx *gen.NotContext) any {
+	return &Not{Exp: toExpression(v.Visit(ctx.Expression()))}
+}
+
 // VisitEquality deals with equality or inequality tests 5 = 5 and 5 != 5
 func (v *visitor) VisitEquality(ctx *gen.EqualityContext) any {
 	exp1 := toExpression(v.Visit(ctx.Expression(0)))
@@ -202,18 +299,7 @@ func (v *visitor) VisitParentheses(ctx *gen.ParenthesesContext) any {
 
 // VisitTextLiteral deals with string literals such as "asdf"
 func (v *visitor) VisitTextLiteral(ctx *gen.TextLiteralContext) any {
-	value := ctx.GetText()
-
-	// unquote, this takes care of escape sequences as well
-	unquoted, err := strconv.Unquote(value)
-
-	// if we had an error, just strip surrounding quotes. It's fairly common for text literals
-	// to contain escape sequences which aren't legal in go, e.g. a regex \w+
-	if err != nil {
-		unquoted = value[1 : len(value)-1]
-	}
-
-	return &TextLiteral{Value: types.NewXText(unquoted)}
+	return &TextLiteral{Value: types.NewXText(unquote(ctx.GetText()))}
 }
 
 // VisitNumberLiteral deals with numbers like 123 or 1.5
@@ -236,6 +322,18 @@ func (v *visitor) VisitNull(ctx *gen.NullContext) any {
 	return &NullLiteral{}
 }
 
+// unquotes a text literal, taking care of escape sequences as well
+func unquote(value string) string {
+	unquoted, err := strconv.Unquote(value)
+
+	// if we had an error, just strip surrounding quotes. It's fairly common for text literals
+	// to contain escape sequences which aren't legal in go, e.g. a regex \w+
+	if err != nil {
+		unquoted = value[1 : len(value)-1]
+	}
+	return unquoted
+}
+
 // convenience utility to convert the given value to an Expression
 func toExpression(val any) Expression {
 	asExp, isExp := val.(Expression)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/events"
+	"github.com/nyaruka/goflow/flows/inspect"
 
 	"golang.org/x/net/http/httpguts"
 )
@@ -55,7 +56,7 @@ type CallWebhookAction struct {
 	Method     string            `json:"method" validate:"required,http_method"`
 	URL        string            `json:"url" validate:"required" engine:"evaluated"`
 	Headers    map[string]string `json:"headers,omitempty" engine:"evaluated"`
-	Body       string            `json:"body,omitempty" engine:"evaluated"`
+	Body       string            `json:"body,omitempty" engine:"evaluated,escape=json"`
 	ResultName string            `json:"result_name,omitempty"`
 }
 
@@ -102,7 +103,7 @@ func (a *CallWebhookAction) Execute(run flows.Run, step flows.Step, logModifier
 	// substitute any body variables
 	if body != "" {
 		// webhook bodies aren't truncated like other templates
-		body, _ = run.EvaluateTemplateText(body, nil, false, logEvent)
+		body, _ = run.EvaluateTemplateText(body, inspect.FieldEscaping(a, "body"), false, logEvent)
 	}
 
 	return a.call(run, step, url, method, body, logEvent)
@@ -6,6 +6,7 @@ import (
 	"github.com/nyaruka/gocommon/uuids"
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/events"
+	"github.com/nyaruka/goflow/flows/inspect"
 )
 
 func init() {
@@ -19,7 +20,7 @@ const TypeSayMsg string = "say_msg"
 // a message with TTS or playing a pre-recorded audio file. It will generate an [event:ivr_created]
 // event if there is a valid audio URL or backdown text. This will contain a message which
 // the caller should handle as an IVR play command if it has an audio attachment, or otherwise
-// an IVR say command using the message text.
+// an IVR say command using the message text. Values inserted into the text are escaped so that it's valid SSML.
 //
 //	{
 //	  "uuid": "8eebd020-1af5-431c-b943-aa670fc74da9",
@@ -33,7 +34,7 @@ type SayMsgAction struct {
 	baseAction
 	voiceAction
 
-	Text     string `json:"text" validate:"required" engine:"localized,evaluated"`
+	Text     string `json:"text" validate:"required" engine:"localized,evaluated,escape=ssml"`
 	AudioURL string `json:"audio_url,omitempty"`
 }
 
@@ -50,7 +51,7 @@ func NewSayMsg(uuid flows.ActionUUID, text string, audioURL string) *SayMsgActio
 func (a *SayMsgAction) Execute(run flows.Run, step flows.Step, logModifier flows.ModifierCallback, logEvent flows.EventCallback) error {
 	// localize and evaluate the message text
 	localizedText, textLang := run.GetText(uuids.UUID(a.UUID()), "text", a.Text)
-	evaluatedText, _ := run.EvaluateTemplate(localizedText, logEvent)
+	evaluatedText, _ := run.EvaluateTemplateText(localizedText, inspect.FieldEscaping(a, "text"), true, logEvent)
 	evaluatedText = strings.TrimSpace(evaluatedText)
 
 	// localize the audio URL
@@ -529,6 +529,8 @@ func (c *Contact) QueryProperty(env envs.Environment, key string, propType conta
 			vals[i] = urnsWithScheme[i].URN().Path()
 		}
 		return vals
+	} else if propType == contactql.PropertyTypeResult {
+		return nil // results belong to runs rather than contacts
 	}
 
 	// try as a contact field
@@ -235,7 +235,7 @@ func TestNewFlow(t *testing.T) {
 					),
 				},
 				routers.NewSwitch(
-					waits.NewMsgWait(nil, hints.NewImageHint()),
+					waits.NewMsgWait(nil, hints.NewImageHint(), nil, nil),
 					"Response 1",
 					[]flows.Category{
 						routers.NewCategory(
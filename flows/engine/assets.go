@@ -2,6 +2,7 @@ package engine
 
 import (
 	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/contactql"
 	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/definition"
@@ -22,6 +23,7 @@ type sessionAssets struct {
 	locations   *flows.LocationAssets
 	optIns      *flows.OptInAssets
 	resthooks   *flows.ResthookAssets
+	schedules   *flows.ScheduleAssets
 	templates   *flows.TemplateAssets
 	topics      *flows.TopicAssets
 	users       *flows.UserAssets
@@ -67,6 +69,10 @@ func NewSessionAssets(env envs.Environment, source assets.Source, migrationConfi
 	if err != nil {
 		return nil, err
 	}
+	schedules, err := source.Schedules()
+	if err != nil {
+		return nil, err
+	}
 	templates, err := source.Templates()
 	if err != nil {
 		return nil, err
@@ -95,6 +101,7 @@ func NewSessionAssets(env envs.Environment, source assets.Source, migrationConfi
 		locations:   flows.NewLocationAssets(locations),
 		optIns:      flows.NewOptInAssets(optIns),
 		resthooks:   flows.NewResthookAssets(resthooks),
+		schedules:   flows.NewScheduleAssets(schedules),
 		templates:   flows.NewTemplateAssets(templates),
 		topics:      flows.NewTopicAssets(topics),
 		users:       flows.NewUserAssets(users),
@@ -112,6 +119,7 @@ func (s *sessionAssets) Labels() *flows.LabelAssets           { return s.labels
 func (s *sessionAssets) Locations() *flows.LocationAssets     { return s.locations }
 func (s *sessionAssets) OptIns() *flows.OptInAssets           { return s.optIns }
 func (s *sessionAssets) Resthooks() *flows.ResthookAssets     { return s.resthooks }
+func (s *sessionAssets) Schedules() *flows.ScheduleAssets     { return s.schedules }
 func (s *sessionAssets) Templates() *flows.TemplateAssets     { return s.templates }
 func (s *sessionAssets) Topics() *flows.TopicAssets           { return s.topics }
 func (s *sessionAssets) Users() *flows.UserAssets             { return s.users }
@@ -141,3 +149,26 @@ func (s *sessionAssets) ResolveFlow(name string) assets.Flow {
 	}
 	return f.Asset()
 }
+
+func (s *sessionAssets) ResolveResult(flow assets.Flow, key string) ([]string, bool) {
+	f, _ := s.Flows().Get(flow.UUID())
+	if f == nil {
+		return nil, false
+	}
+
+	categories := make([]string, 0)
+	found := false
+
+	for _, node := range f.Nodes() {
+		node.EnumerateResults(func(a flows.Action, r flows.Router, info *flows.ResultInfo) {
+			if info.Key == key {
+				categories = append(categories, info.Categories...)
+				found = true
+			}
+		})
+	}
+
+	return categories, found
+}
+
+var _ contactql.ResultResolver = (*sessionAssets)(nil)
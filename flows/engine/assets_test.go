@@ -6,6 +6,7 @@ import (
 
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/assets/static"
+	"github.com/nyaruka/goflow/contactql"
 	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/flows/engine"
 
@@ -36,6 +37,43 @@ var assetsJSON = `{
             "language": "eng",
             "type": "messaging",
             "nodes": []
+        },
+		{
+            "uuid": "50c3706e-fedb-42c0-8eab-dda3335714b7",
+            "name": "Registration",
+            "spec_version": "13.0",
+            "language": "eng",
+            "type": "messaging",
+            "nodes": [
+                {
+                    "uuid": "a58be63b-907d-4a1a-856b-0bb5579d7507",
+                    "actions": [
+                        {
+                            "uuid": "ad154980-7bf7-4ab8-8728-545fd6378912",
+                            "type": "set_run_result",
+                            "name": "Age",
+                            "value": "@input.text"
+                        }
+                    ],
+                    "router": {
+                        "type": "switch",
+                        "result_name": "Likes Cats",
+                        "operand": "@input.text",
+                        "cases": [
+                            {"uuid": "98503572-25bf-40ce-ad72-8836b6549a38", "type": "has_any_word", "arguments": ["yes"], "category_uuid": "c70fe86c-9aac-4cc2-a5cb-d35cbe3fed6e"}
+                        ],
+                        "categories": [
+                            {"uuid": "c70fe86c-9aac-4cc2-a5cb-d35cbe3fed6e", "name": "Yes", "exit_uuid": "97b9451c-2856-475b-af38-32af68100897"},
+                            {"uuid": "1ae9f4d2-d8cd-44df-8ad5-ec4d72b81ac1", "name": "Other", "exit_uuid": "ce1e7a1d-cc3f-44ab-9d8e-3ab4d0e7c22c"}
+                        ],
+                        "default_category_uuid": "1ae9f4d2-d8cd-44df-8ad5-ec4d72b81ac1"
+                    },
+                    "exits": [
+                        {"uuid": "97b9451c-2856-475b-af38-32af68100897"},
+                        {"uuid": "ce1e7a1d-cc3f-44ab-9d8e-3ab4d0e7c22c"}
+                    ]
+                }
+            ]
         }
 	],
 	"fields": [
@@ -120,6 +158,24 @@ func TestSessionAssets(t *testing.T) {
 	assert.Nil(t, sa.ResolveField("xxx"))
 	assert.Nil(t, sa.ResolveGroup("xxx"))
 	assert.Nil(t, sa.ResolveFlow("xxx"))
+
+	// and can resolve results against flow definitions
+	resultResolver := sa.(contactql.ResultResolver)
+	registration := sa.ResolveFlow("registration")
+
+	categories, found := resultResolver.ResolveResult(registration, "age")
+	assert.True(t, found)
+	assert.Equal(t, []string{}, categories)
+
+	categories, found = resultResolver.ResolveResult(registration, "likes_cats")
+	assert.True(t, found)
+	assert.Equal(t, []string{"Yes", "Other"}, categories)
+
+	_, found = resultResolver.ResolveResult(registration, "xxx")
+	assert.False(t, found)
+
+	_, found = resultResolver.ResolveResult(emptyFlow, "age")
+	assert.False(t, found)
 }
 
 func TestSessionAssetsWithSourceErrors(t *testing.T) {
@@ -138,7 +194,7 @@ func TestSessionAssetsWithSourceErrors(t *testing.T) {
 	_, err = sa.Flows().FindByName("Catch All")
 	assert.EqualError(t, err, "unable to load flow assets")
 
-	for _, errType := range []string{"channels", "classifiers", "fields", "globals", "groups", "labels", "locations", "optins", "resthooks", "templates", "users"} {
+	for _, errType := range []string{"channels", "classifiers", "fields", "globals", "groups", "labels", "locations", "optins", "resthooks", "schedules", "templates", "users"} {
 		source.currentErrType = errType
 		_, err = engine.NewSessionAssets(env, source, nil)
 		assert.EqualError(t, err, fmt.Sprintf("unable to load %s assets", errType), "error mismatch for type %s", errType)
@@ -197,6 +253,10 @@ func (s *testSource) Resthooks() ([]assets.Resthook, error) {
 	return nil, s.err("resthooks")
 }
 
+func (s *testSource) Schedules() ([]assets.Schedule, error) {
+	return nil, s.err("schedules")
+}
+
 func (s *testSource) OptIns() ([]assets.OptIn, error) {
 	return nil, s.err("optins")
 }
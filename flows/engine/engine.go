@@ -53,6 +53,8 @@ var _ flows.Engine = (*engine)(nil)
 // Builder is a builder for engine configs
 type Builder struct {
 	eng *engine
+
+	evaluationLimits *excellent.Limits
 }
 
 // NewBuilder creates a new engine builder
@@ -69,9 +71,24 @@ func NewBuilder() *Builder {
 				MaxResultChars:       640,
 			},
 		},
+		evaluationLimits: excellent.DefaultLimits(),
 	}
 }
 
+// WithEvaluator sets the evaluator used for templates, which allows its cache of parsed templates to be shared. The
+// engine's evaluation limits are applied to a copy of it.
+func (b *Builder) WithEvaluator(e *excellent.Evaluator) *Builder {
+	b.eng.evaluator = e
+	return b
+}
+
+// WithEvaluationLimits sets the limits on the resources used evaluating each expression in a template, where nil
+// means no limits. Defaults to excellent.DefaultLimits().
+func (b *Builder) WithEvaluationLimits(limits *excellent.Limits) *Builder {
+	b.evaluationLimits = limits
+	return b
+}
+
 // WithEmailServiceFactory sets the email service factory
 func (b *Builder) WithEmailServiceFactory(f EmailServiceFactory) *Builder {
 	b.eng.services.email = f
@@ -126,5 +143,16 @@ func (b *Builder) WithMaxResultChars(max int) *Builder {
 	return b
 }
 
+// WithTraceErrors sets whether errors from evaluating templates should include a trace of the evaluation. This is
+// intended for debugging flows as traces make error events much larger and more expensive to create.
+func (b *Builder) WithTraceErrors(trace bool) *Builder {
+	b.eng.options.TraceErrors = trace
+	return b
+}
+
 // Build returns the final engine
-func (b *Builder) Build() flows.Engine { return b.eng }
+func (b *Builder) Build() flows.Engine {
+	// limits are applied to a copy of the evaluator so that a shared evaluator isn't changed
+	b.eng.evaluator = b.eng.evaluator.WithLimits(b.evaluationLimits)
+	return b.eng
+}
@@ -4,6 +4,9 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent"
+	"github.com/nyaruka/goflow/excellent/types"
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/engine"
 	"github.com/nyaruka/goflow/services/webhooks"
@@ -19,6 +22,7 @@ func TestBuilder(t *testing.T) {
 		WithMaxTemplateChars(999).
 		WithMaxFieldChars(888).
 		WithMaxResultChars(777).
+		WithTraceErrors(true).
 		Build()
 
 	assert.Equal(t, 123, eng.Options().MaxStepsPerSprint)
@@ -26,8 +30,36 @@ func TestBuilder(t *testing.T) {
 	assert.Equal(t, 999, eng.Options().MaxTemplateChars)
 	assert.Equal(t, 888, eng.Options().MaxFieldChars)
 	assert.Equal(t, 777, eng.Options().MaxResultChars)
+	assert.True(t, eng.Options().TraceErrors)
 
-	_, err := eng.Services().Email(nil)
+	// engines apply the default evaluation limits unless others are set
+	_, _, err := eng.Evaluator().Template(envs.NewBuilder().Build(), types.NewXObject(nil), `@(repeat("x", 1000001))`, nil)
+	assert.EqualError(t, err, `error evaluating @(repeat("x", 1000001)): evaluation budget exceeded: text longer than 1000000 characters`)
+
+	// create engine with tighter evaluation limits
+	eng = engine.NewBuilder().
+		WithEvaluationLimits(&excellent.Limits{MaxValueLength: 5}).
+		Build()
+
+	_, _, err = eng.Evaluator().Template(envs.NewBuilder().Build(), types.NewXObject(nil), `@(repeat("x", 6))`, nil)
+	assert.EqualError(t, err, `error evaluating @(repeat("x", 6)): evaluation budget exceeded: text longer than 5 characters`)
+
+	// limits apply regardless of whether they're set before or after the evaluator, and don't change that evaluator
+	shared := excellent.NewEvaluator()
+
+	for _, b := range []*engine.Builder{
+		engine.NewBuilder().WithEvaluationLimits(&excellent.Limits{MaxValueLength: 5}).WithEvaluator(shared),
+		engine.NewBuilder().WithEvaluator(shared).WithEvaluationLimits(&excellent.Limits{MaxValueLength: 5}),
+	} {
+		_, _, err = b.Build().Evaluator().Template(envs.NewBuilder().Build(), types.NewXObject(nil), `@(repeat("x", 6))`, nil)
+		assert.EqualError(t, err, `error evaluating @(repeat("x", 6)): evaluation budget exceeded: text longer than 5 characters`)
+	}
+
+	output, _, err := shared.Template(envs.NewBuilder().Build(), types.NewXObject(nil), `@(repeat("x", 6))`, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "xxxxxx", output)
+
+	_, err = eng.Services().Email(nil)
 	assert.EqualError(t, err, "no email service factory configured")
 	_, err = eng.Services().Airtime(nil)
 	assert.EqualError(t, err, "no airtime service factory configured")
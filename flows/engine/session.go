@@ -14,6 +14,7 @@ import (
 	"github.com/nyaruka/goflow/flows/events"
 	"github.com/nyaruka/goflow/flows/inputs"
 	"github.com/nyaruka/goflow/flows/resumes"
+	"github.com/nyaruka/goflow/flows/routers/waits"
 	"github.com/nyaruka/goflow/flows/runs"
 	"github.com/nyaruka/goflow/flows/triggers"
 	"github.com/nyaruka/goflow/utils"
@@ -287,7 +288,17 @@ func (s *session) tryToResume(sprint *sprint, waitingRun flows.Run, resume flows
 	// ensure groups are correct
 	s.ensureQueryBasedGroups(logEvent)
 
+	// reminders are sent by the wait without leaving it
+	if reminder, isReminder := resume.(*resumes.ReminderResume); isReminder {
+		node.Router().Wait().(*waits.MsgWait).Remind(waitingRun, reminder.ReminderUUID(), logEvent)
+		s.status = flows.SessionStatusWaiting
+		return nil
+	}
+
+	// timeouts are only routed as such if the router has a timeout category, otherwise the router is used as normal,
+	// e.g. a timer wait without a timeout
 	_, isTimeout := resume.(*resumes.WaitTimeoutResume)
+	isTimeout = isTimeout && node.Router().AllowTimeout()
 
 	exit, operand, err := s.findResumeExit(sprint, waitingRun, isTimeout)
 	if err != nil {
@@ -295,6 +306,11 @@ func (s *session) tryToResume(sprint *sprint, waitingRun flows.Run, resume flows
 		return nil
 	}
 
+	// the router may have decided to wait again, e.g. to reprompt the contact
+	if s.status == flows.SessionStatusWaiting {
+		return nil
+	}
+
 	// off to the races again...
 	return s.continueUntilWait(sprint, waitingRun, node, exit, operand, step, nil)
 }
@@ -513,6 +529,11 @@ func (s *session) pickNodeExit(sprint *sprint, run flows.Run, node flows.Node, s
 		if err != nil {
 			return nil, "", fmt.Errorf("error routing from node[uuid=%s]: %w", node.UUID(), err)
 		}
+		// router didn't pick a category because it's gone back to waiting
+		if run.Status() == flows.RunStatusWaiting {
+			s.status = flows.SessionStatusWaiting
+			return nil, "", nil
+		}
 		// router didn't error.. but it failed to pick a category
 		if exitUUID == "" {
 			failRun(sprint, run, step, fmt.Errorf("router on node[uuid=%s] failed to pick a category", node.UUID()))
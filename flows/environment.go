@@ -9,6 +9,7 @@ import (
 	"github.com/nyaruka/gocommon/i18n"
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/utils"
 )
 
 type assetsEnvironment struct {
@@ -30,10 +31,27 @@ func NewAssetsEnvironment(e envs.Environment, la *LocationAssets) envs.Environme
 	return &assetsEnvironment{Environment: e, locationResolver: locationResolver}
 }
 
+func (e *assetsEnvironment) WeekStart() time.Weekday { return envs.WeekStartOf(e.Environment) }
+
 func (e *assetsEnvironment) LocationResolver() envs.LocationResolver {
 	return e.locationResolver
 }
 
+type assetScheduleResolver struct {
+	schedules *ScheduleAssets
+}
+
+// FindSchedule returns the schedule with the given UUID or name (case-insensitive)
+func (r *assetScheduleResolver) FindSchedule(uuidOrName string) envs.Schedule {
+	if s := r.schedules.Get(assets.ScheduleUUID(uuidOrName)); s != nil {
+		return s
+	}
+	if s := r.schedules.FindByName(uuidOrName); s != nil {
+		return s
+	}
+	return nil
+}
+
 type assetLocationResolver struct {
 	locations assets.LocationHierarchy
 }
@@ -81,6 +99,14 @@ func (r *assetLocationResolver) FindLocationsFuzzy(env envs.Environment, text st
 	return []*envs.Location{}
 }
 
+// FindLocationsByPoint returns locations with the given level whose boundaries contain the given point
+func (r *assetLocationResolver) FindLocationsByPoint(point utils.GeoPoint, level envs.LocationLevel) []*envs.Location {
+	if locations, ok := r.locations.(assets.PointLocationHierarchy); ok {
+		return locations.FindByPoint(point, level)
+	}
+	return []*envs.Location{}
+}
+
 func (r *assetLocationResolver) LookupLocation(path envs.LocationPath) *envs.Location {
 	return r.locations.FindByPath(path)
 }
@@ -100,6 +126,13 @@ func NewSessionEnvironment(s Session) envs.Environment {
 	}
 }
 
+func (e *sessionEnvironment) WeekStart() time.Weekday { return envs.WeekStartOf(e.Environment) }
+
+// ScheduleResolver returns a resolver for the session's schedule assets
+func (e *sessionEnvironment) ScheduleResolver() envs.ScheduleResolver {
+	return &assetScheduleResolver{e.session.Assets().Schedules()}
+}
+
 func (e *sessionEnvironment) Timezone() *time.Location {
 	contact := e.session.Contact()
 
@@ -136,3 +169,6 @@ func (e *sessionEnvironment) DefaultCountry() i18n.Country {
 func (e *sessionEnvironment) DefaultLocale() i18n.Locale {
 	return i18n.NewLocale(e.DefaultLanguage(), e.DefaultCountry())
 }
+
+var _ envs.WeekStartEnvironment = (*sessionEnvironment)(nil)
+var _ envs.ScheduleEnvironment = (*sessionEnvironment)(nil)
@@ -11,6 +11,7 @@ import (
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/engine"
 	"github.com/nyaruka/goflow/flows/triggers"
+	"github.com/nyaruka/goflow/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -93,6 +94,41 @@ func TestAssetsEnvironment(t *testing.T) {
 	matches := aenv.LocationResolver().FindLocationsFuzzy(env, "gisozi town", flows.LocationLevelWard, nil)
 	assert.Equal(t, 1, len(matches))
 	assert.Equal(t, "Gisozi", matches[0].Name())
+
+	_, isScheduleEnv := aenv.(envs.ScheduleEnvironment)
+	assert.False(t, isScheduleEnv)
+
+	senv, isScheduleEnv := session.MergedEnvironment().(envs.ScheduleEnvironment)
+	require.True(t, isScheduleEnv)
+	assert.Nil(t, senv.ScheduleResolver().FindSchedule("Office Hours"))
+}
+
+// a location hierarchy which doesn't support finding locations by point
+type namedLocations struct {
+	assets.LocationHierarchy
+}
+
+func TestAssetsEnvironmentPointLocations(t *testing.T) {
+	env := envs.NewBuilder().Build()
+	hierarchy, err := envs.ReadLocationHierarchy(env, []byte(`{
+		"name": "Rwanda",
+		"children": [
+			{"name": "Kigali City", "boundary": [[[29.95, -2.05], [30.25, -2.05], [30.25, -1.85], [29.95, -1.85]]]}
+		]
+	}`))
+	require.NoError(t, err)
+
+	kigali := utils.GeoPoint{Lat: -1.95, Lng: 30.06}
+
+	aenv := flows.NewAssetsEnvironment(env, flows.NewLocationAssets([]assets.LocationHierarchy{hierarchy}))
+	resolver := aenv.LocationResolver().(envs.PointLocationResolver)
+	matches := resolver.FindLocationsByPoint(kigali, flows.LocationLevelState)
+	assert.Equal(t, 1, len(matches))
+	assert.Equal(t, "Kigali City", matches[0].Name())
+
+	aenv = flows.NewAssetsEnvironment(env, flows.NewLocationAssets([]assets.LocationHierarchy{&namedLocations{hierarchy}}))
+	resolver = aenv.LocationResolver().(envs.PointLocationResolver)
+	assert.Equal(t, 0, len(resolver.FindLocationsByPoint(kigali, flows.LocationLevelState)))
 }
 
 const contactJSON = `{
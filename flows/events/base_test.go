@@ -18,6 +18,7 @@ import (
 	"github.com/nyaruka/gocommon/uuids"
 	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent"
 	"github.com/nyaruka/goflow/excellent/types"
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/events"
@@ -403,6 +404,29 @@ func TestEventMarshaling(t *testing.T) {
 				"type": "error"
 			}`,
 		},
+		{
+			events.NewErrorWithTrace(errors.New("error evaluating @(1 / x): context has no property 'x'"), []*excellent.TraceNode{
+				{Expression: "1 / x", Error: "context has no property 'x'", Children: []*excellent.TraceNode{
+					{Expression: "1", Value: "1"},
+					{Expression: "x", Error: "context has no property 'x'"},
+				}},
+			}),
+			`{
+				"created_on": "2018-10-18T14:20:30.000123456Z",
+				"text": "error evaluating @(1 / x): context has no property 'x'",
+				"trace": [
+					{
+						"expression": "1 / x",
+						"error": "context has no property 'x'",
+						"children": [
+							{"expression": "1", "value": "1"},
+							{"expression": "x", "error": "context has no property 'x'"}
+						]
+					}
+				],
+				"type": "error"
+			}`,
+		},
 		{
 			events.NewFailure(errors.New("503 is an failure")),
 			`{
@@ -507,13 +531,23 @@ func TestEventMarshaling(t *testing.T) {
 			}`,
 		},
 		{
-			events.NewMsgWait(&timeout, &expiresOn, hints.NewImageHint()),
+			events.NewMsgWait(&timeout, &expiresOn, hints.NewImageHint(), []*events.Reminder{{UUID: "4d5f4a4f-9b0a-4f3a-8d8a-6d3c3b7f1e2a", DelaySeconds: 300}}),
 			`{
 				"type": "msg_wait",
 				"created_on": "2018-10-18T14:20:30.000123456Z",
 				"timeout_seconds": 500,
 				"expires_on": "2022-02-03T13:45:30Z",
-				"hint": {"type": "image"}
+				"hint": {"type": "image"},
+				"reminders": [{"uuid": "4d5f4a4f-9b0a-4f3a-8d8a-6d3c3b7f1e2a", "delay_seconds": 300}]
+			}`,
+		},
+		{
+			events.NewTimerWait(time.Date(2022, 2, 1, 9, 0, 0, 0, time.UTC), &expiresOn),
+			`{
+				"type": "timer_wait",
+				"created_on": "2018-10-18T14:20:30.000123456Z",
+				"resume_on": "2022-02-01T09:00:00Z",
+				"expires_on": "2022-02-03T13:45:30Z"
 			}`,
 		},
 		{
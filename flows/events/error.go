@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/excellent"
 	"github.com/nyaruka/goflow/flows"
 )
 
@@ -14,7 +15,9 @@ func init() {
 // TypeError is the type of our error events
 const TypeError string = "error"
 
-// ErrorEvent events are created when an error occurs during flow execution.
+// ErrorEvent events are created when an error occurs during flow execution. If the engine is configured to trace
+// errors, which is intended only for debugging, errors from evaluating templates include a `trace` of how each
+// expression in the template was evaluated, with long values truncated.
 //
 //	{
 //	  "type": "error",
@@ -26,7 +29,8 @@ const TypeError string = "error"
 type ErrorEvent struct {
 	BaseEvent
 
-	Text string `json:"text" validate:"required"`
+	Text  string                 `json:"text" validate:"required"`
+	Trace []*excellent.TraceNode `json:"trace,omitempty"`
 }
 
 // NewError returns a new error event for the passed in error
@@ -34,6 +38,13 @@ func NewError(err error) *ErrorEvent {
 	return NewErrorf(err.Error())
 }
 
+// NewErrorWithTrace returns a new error event for the passed in error from evaluating a template with the given trace
+func NewErrorWithTrace(err error, trace []*excellent.TraceNode) *ErrorEvent {
+	event := NewError(err)
+	event.Trace = trace
+	return event
+}
+
 // NewErrorf returns a new error event for the passed in format string and args
 func NewErrorf(format string, a ...any) *ErrorEvent {
 	return &ErrorEvent{
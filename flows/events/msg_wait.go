@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/nyaruka/gocommon/uuids"
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/routers/waits/hints"
 	"github.com/nyaruka/goflow/utils"
@@ -19,7 +20,8 @@ const TypeMsgWait string = "msg_wait"
 
 // MsgWaitEvent events are created when a flow pauses waiting for a response from
 // a contact. If a timeout is set, then the caller should resume the flow after
-// the number of seconds in the timeout to resume it.
+// the number of seconds in the timeout to resume it. If reminders are set, then the
+// caller should resume the flow with a reminder resume after each reminder's delay.
 //
 //	{
 //	  "type": "msg_wait",
@@ -28,7 +30,10 @@ const TypeMsgWait string = "msg_wait"
 //	  "expires_on": "2022-02-02T13:27:30Z",
 //	  "hint": {
 //	     "type": "image"
-//	  }
+//	  },
+//	  "reminders": [
+//	     {"uuid": "4d5f4a4f-9b0a-4f3a-8d8a-6d3c3b7f1e2a", "delay_seconds": 3600}
+//	  ]
 //	}
 //
 // @event msg_wait
@@ -43,15 +48,26 @@ type MsgWaitEvent struct {
 	ExpiresOn *time.Time `json:"expires_on,omitempty"`
 
 	Hint flows.Hint `json:"hint,omitempty"`
+
+	// reminders the caller should trigger if the contact hasn't replied. Delays are relative for the same reason as
+	// the timeout.
+	Reminders []*Reminder `json:"reminders,omitempty"`
+}
+
+// Reminder is a scheduled reminder on a message wait
+type Reminder struct {
+	UUID         uuids.UUID `json:"uuid"          validate:"required,uuid4"`
+	DelaySeconds int        `json:"delay_seconds" validate:"required"`
 }
 
 // NewMsgWait returns a new msg wait with the passed in timeout
-func NewMsgWait(timeoutSeconds *int, expiresOn *time.Time, hint flows.Hint) *MsgWaitEvent {
+func NewMsgWait(timeoutSeconds *int, expiresOn *time.Time, hint flows.Hint, reminders []*Reminder) *MsgWaitEvent {
 	return &MsgWaitEvent{
 		BaseEvent:      NewBaseEvent(TypeMsgWait),
 		TimeoutSeconds: timeoutSeconds,
 		ExpiresOn:      expiresOn,
 		Hint:           hint,
+		Reminders:      reminders,
 	}
 }
 
@@ -65,6 +81,7 @@ type msgWaitEnvelope struct {
 	TimeoutSeconds *int            `json:"timeout_seconds,omitempty"`
 	ExpiresOn      *time.Time      `json:"expires_on,omitempty"`
 	Hint           json.RawMessage `json:"hint,omitempty"`
+	Reminders      []*Reminder     `json:"reminders,omitempty" validate:"omitempty,dive"`
 }
 
 // UnmarshalJSON unmarshals this event from the given JSON
@@ -77,6 +94,7 @@ func (e *MsgWaitEvent) UnmarshalJSON(data []byte) error {
 	e.BaseEvent = v.BaseEvent
 	e.TimeoutSeconds = v.TimeoutSeconds
 	e.ExpiresOn = v.ExpiresOn
+	e.Reminders = v.Reminders
 
 	var err error
 	if v.Hint != nil {
@@ -0,0 +1,46 @@
+package events
+
+import (
+	"time"
+
+	"github.com/nyaruka/goflow/flows"
+)
+
+func init() {
+	registerType(TypeTimerWait, func() flows.Event { return &TimerWaitEvent{} })
+}
+
+// TypeTimerWait is the type of our timer wait event
+const TypeTimerWait string = "timer_wait"
+
+// TimerWaitEvent events are created when a flow pauses until a point in time. The caller should resume the flow
+// with a timer resume at the time given by `resume_on`.
+//
+//	{
+//	  "type": "timer_wait",
+//	  "created_on": "2022-01-03T13:27:30Z",
+//	  "resume_on": "2022-01-06T09:00:00Z",
+//	  "expires_on": "2022-02-02T13:27:30Z"
+//	}
+//
+// @event timer_wait
+type TimerWaitEvent struct {
+	BaseEvent
+
+	// when the caller should resume the flow
+	ResumeOn time.Time `json:"resume_on" validate:"required"`
+
+	// when this wait expires and the whole run can be expired
+	ExpiresOn *time.Time `json:"expires_on,omitempty"`
+}
+
+// NewTimerWait returns a new timer wait event
+func NewTimerWait(resumeOn time.Time, expiresOn *time.Time) *TimerWaitEvent {
+	return &TimerWaitEvent{
+		BaseEvent: NewBaseEvent(TypeTimerWait),
+		ResumeOn:  resumeOn,
+		ExpiresOn: expiresOn,
+	}
+}
+
+var _ flows.Event = (*TimerWaitEvent)(nil)
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/excellent"
 	"github.com/nyaruka/goflow/excellent/types"
 )
 
@@ -56,3 +57,6 @@ var RunContextTopLevels = []string{
 func ContactQueryEscaping(s string) string {
 	return strconv.Quote(s)
 }
+
+// ContactQueryEscapingMode is the escaping mode used for templates which are contact queries
+var ContactQueryEscapingMode = excellent.NewEscapingMode(ContactQueryEscaping)
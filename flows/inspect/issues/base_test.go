@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/nyaruka/gocommon/i18n"
@@ -120,3 +121,49 @@ func TestIssues(t *testing.T) {
 	assert.Equal(t, i18n.NilLanguage, info.Issues[0].Language())
 	assert.Equal(t, "missing field dependency 'county'", info.Issues[0].Description())
 }
+
+func TestInvalidExpressionsInLegacyFlows(t *testing.T) {
+	// expressions migrated from legacy flows, e.g. @extra => @legacy_extra, should be considered valid
+	paths := []string{
+		"extra.json",
+		"legacy_favorites.json",
+		"legacy_registration.json",
+		"legacy_subflow.json",
+		"legacy_timeout.json",
+		"legacy_webhook.json",
+		"webhook_migrated.json",
+	}
+
+	legacyFlows := make([]json.RawMessage, 0)
+	for _, path := range paths {
+		path = filepath.Join("../../../test/testdata/runner", path)
+		assetsJSON, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		assets := &struct {
+			Flows []json.RawMessage `json:"flows"`
+		}{}
+		jsonx.MustUnmarshal(assetsJSON, assets)
+		legacyFlows = append(legacyFlows, assets.Flows...)
+	}
+
+	testsJSON, err := os.ReadFile("../../definition/legacy/testdata/flows.json")
+	require.NoError(t, err)
+
+	legacyTests := []struct {
+		Legacy json.RawMessage `json:"legacy"`
+	}{}
+	jsonx.MustUnmarshal(testsJSON, &legacyTests)
+	for _, tc := range legacyTests {
+		legacyFlows = append(legacyFlows, tc.Legacy)
+	}
+
+	for _, flowJSON := range legacyFlows {
+		flow, err := definition.ReadFlow(flowJSON, nil)
+		require.NoError(t, err)
+
+		for _, issue := range flow.Inspect(nil).Issues {
+			assert.NotEqual(t, issues.TypeInvalidExpression, issue.Type(), "unexpected issue in flow '%s': %s", flow.Name(), issue.Description())
+		}
+	}
+}
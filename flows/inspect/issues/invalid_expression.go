@@ -0,0 +1,63 @@
+package issues
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nyaruka/gocommon/i18n"
+	"github.com/nyaruka/goflow/excellent/tools"
+	"github.com/nyaruka/goflow/flows"
+)
+
+func init() {
+	registerType(TypeInvalidExpression, InvalidExpressionCheck)
+}
+
+// TypeInvalidExpression is our type for an expression which will error when evaluated
+const TypeInvalidExpression string = "invalid_expression"
+
+// InvalidExpression is an expression which will error when evaluated
+type InvalidExpression struct {
+	baseIssue
+
+	Expression string `json:"expression"`
+	Position   int    `json:"position"`
+}
+
+func newInvalidExpression(nodeUUID flows.NodeUUID, actionUUID flows.ActionUUID, language i18n.Language, expression string, position int, message string) *InvalidExpression {
+	return &InvalidExpression{
+		baseIssue: newBaseIssue(
+			TypeInvalidExpression,
+			nodeUUID,
+			actionUUID,
+			language,
+			fmt.Sprintf("invalid expression %s: %s", expression, message),
+		),
+		Expression: expression,
+		Position:   position,
+	}
+}
+
+// InvalidExpressionCheck checks for expressions which are invalid against the context, e.g. references to results that
+// no node in the flow creates or calls to functions with the wrong number of arguments
+func InvalidExpressionCheck(sa flows.SessionAssets, flow flows.Flow, tpls []flows.ExtractedTemplate, refs []flows.ExtractedReference, report func(flows.Issue)) {
+	resultKeys := make([]string, 0)
+	for _, node := range flow.Nodes() {
+		node.EnumerateResults(func(a flows.Action, r flows.Router, i *flows.ResultInfo) {
+			resultKeys = append(resultKeys, strings.ToLower(i.Key))
+		})
+	}
+
+	// field and global keys aren't checked here as missing dependencies already covers those
+	checker := tools.NewChecker(map[string][]string{"results": resultKeys})
+
+	for _, t := range tpls {
+		for _, issue := range checker.CheckTemplate(t.Template, flows.RunContextTopLevels) {
+			var actionUUID flows.ActionUUID
+			if t.Action != nil {
+				actionUUID = t.Action.UUID()
+			}
+			report(newInvalidExpression(t.Node.UUID(), actionUUID, t.Language, issue.Expression, issue.Position, issue.Message))
+		}
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 
+	"github.com/nyaruka/goflow/excellent"
 	"github.com/nyaruka/goflow/flows"
 )
 
@@ -14,6 +16,7 @@ type EngineField struct {
 	JSONName  string
 	Localized bool
 	Evaluated bool
+	Escaping  string
 	Getter    func(reflect.Value) reflect.Value
 }
 
@@ -45,13 +48,14 @@ func extractEngineFieldsFromType(ct reflect.Type, rt reflect.Type, loc []int, in
 			continue
 		}
 
-		localized, evaluated := parseEngineTag(ct, f)
+		localized, evaluated, escaping := parseEngineTag(ct, f)
 
 		include(&EngineField{
 			Type:      f.Type,
 			JSONName:  jsonName,
 			Localized: localized,
 			Evaluated: evaluated,
+			Escaping:  escaping,
 			Getter:    func(v reflect.Value) reflect.Value { return v.FieldByIndex(index) },
 		})
 	}
@@ -68,7 +72,7 @@ func jsonNameTag(f reflect.StructField) string {
 }
 
 // parses the engine tag on a field if it exists
-func parseEngineTag(st reflect.Type, f reflect.StructField) (localized bool, evaluated bool) {
+func parseEngineTag(st reflect.Type, f reflect.StructField) (localized bool, evaluated bool, escaping string) {
 	t := f.Type
 	tagVals := strings.Split(f.Tag.Get("engine"), ",")
 	localized = false
@@ -96,10 +100,50 @@ func parseEngineTag(st reflect.Type, f reflect.StructField) (localized bool, eva
 			if !(t.Kind() == reflect.String || (t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String) || (t.Kind() == reflect.Map && t.Elem().Kind() == reflect.String)) {
 				panic(fmt.Sprintf("engine:evaluated tag found on unsupported type %v", t))
 			}
+		} else if strings.HasPrefix(v, "escape=") {
+			escaping = strings.TrimPrefix(v, "escape=")
+
+			if excellent.LookupEscapingMode(escaping) == nil {
+				panic(fmt.Sprintf("engine:escape tag found with unknown escaping %s", escaping))
+			}
+		}
+	}
+
+	// escaping only makes sense for fields which are evaluated
+	if escaping != "" && !evaluated {
+		panic(fmt.Sprintf("engine:escape tag found on field %s which isn't evaluated", f.Name))
+	}
+
+	return localized, evaluated, escaping
+}
+
+type fieldKey struct {
+	t        reflect.Type
+	jsonName string
+}
+
+var fieldEscapings sync.Map
+
+// FieldEscaping gets the escaping set by the engine tag of the field with the given JSON name in the given struct,
+// or nil if the field has no escaping
+func FieldEscaping(s any, jsonName string) *excellent.EscapingMode {
+	t := reflect.TypeOf(s)
+	key := fieldKey{t, jsonName}
+
+	if cached, found := fieldEscapings.Load(key); found {
+		escaping, _ := cached.(*excellent.EscapingMode)
+		return escaping
+	}
+
+	var escaping *excellent.EscapingMode
+	for _, ef := range extractEngineFields(t, derefType(t)) {
+		if ef.JSONName == jsonName && ef.Escaping != "" {
+			escaping = excellent.LookupEscapingMode(ef.Escaping)
 		}
 	}
 
-	return localized, evaluated
+	fieldEscapings.Store(key, escaping)
+	return escaping
 }
 
 func walk(v reflect.Value, visitStruct func(reflect.Value), visitField func(reflect.Value, reflect.Value, *EngineField)) {
@@ -14,8 +14,11 @@ type badTagsStruct1 struct {
 	Valid2 []string          `json:"valid2" engine:"localized,evaluated"`
 	Valid3 map[string]string `json:"valid3" engine:"evaluated"`
 	Valid4 string
-	Bad1   int `engine:"evaluated"` // an int field can't be evaluated
-	Bad2   int `engine:"localized"` // or localized
+	Valid5 string `json:"valid5" engine:"evaluated,escape=json"`
+	Bad1   int    `engine:"evaluated"`             // an int field can't be evaluated
+	Bad2   int    `engine:"localized"`             // or localized
+	Bad3   string `engine:"escape=json"`           // or escaped without being evaluated
+	Bad4   string `engine:"evaluated,escape=xxxx"` // or escaped with an unknown escaping
 }
 
 func (s badTagsStruct1) LocalizationUUID() uuids.UUID {
@@ -30,27 +33,44 @@ func TestParseEngineTag(t *testing.T) {
 	typ1 := reflect.TypeOf(badTagsStruct1{})
 	typ2 := reflect.TypeOf(badTagsStruct2{})
 
-	assertTags := func(fieldIndex int, name string, localized bool, evaluated bool) {
+	assertTags := func(fieldIndex int, name string, localized bool, evaluated bool, escaping string) {
 		f := typ1.Field(fieldIndex)
 
 		assert.Equal(t, name, jsonNameTag(f))
 
-		actualLocalized, actualEvaluated := parseEngineTag(typ1, f)
+		actualLocalized, actualEvaluated, actualEscaping := parseEngineTag(typ1, f)
 		assert.Equal(t, localized, actualLocalized)
 		assert.Equal(t, evaluated, actualEvaluated)
+		assert.Equal(t, escaping, actualEscaping)
 	}
 
-	assertTags(0, "valid1", true, false)
-	assertTags(1, "valid2", true, true)
-	assertTags(2, "valid3", false, true)
-	assertTags(3, "", false, false)
+	assertTags(0, "valid1", true, false, "")
+	assertTags(1, "valid2", true, true, "")
+	assertTags(2, "valid3", false, true, "")
+	assertTags(3, "", false, false, "")
+	assertTags(4, "valid5", false, true, "json")
 
-	assert.Panics(t, func() { parseEngineTag(typ1, typ1.Field(4)) })
 	assert.Panics(t, func() { parseEngineTag(typ1, typ1.Field(5)) })
+	assert.Panics(t, func() { parseEngineTag(typ1, typ1.Field(6)) })
+	assert.Panics(t, func() { parseEngineTag(typ1, typ1.Field(7)) })
+	assert.Panics(t, func() { parseEngineTag(typ1, typ1.Field(8)) })
 
 	assert.Panics(t, func() { parseEngineTag(typ2, typ2.Field(0)) })
 }
 
+func TestFieldEscaping(t *testing.T) {
+	s := &struct {
+		Foo string `json:"foo" engine:"evaluated,escape=ssml"`
+		Bar string `json:"bar" engine:"evaluated"`
+	}{}
+
+	assert.Equal(t, "&lt;b&gt;", FieldEscaping(s, "foo").Escaping("<b>"))
+	assert.Equal(t, "&lt;b&gt;", FieldEscaping(s, "foo").Escaping("<b>")) // cached
+	assert.Nil(t, FieldEscaping(s, "bar"))
+	assert.Nil(t, FieldEscaping(s, "bar"))
+	assert.Nil(t, FieldEscaping(s, "xxx"))
+}
+
 type nestedFieldsStruct struct {
 	Foo string `json:"foo" engine:"localized,evaluated"`
 }
@@ -112,6 +112,7 @@ type SessionAssets interface {
 	Locations() *LocationAssets
 	OptIns() *OptInAssets
 	Resthooks() *ResthookAssets
+	Schedules() *ScheduleAssets
 	Templates() *TemplateAssets
 	Topics() *TopicAssets
 	Users() *UserAssets
@@ -327,6 +328,7 @@ type EngineOptions struct {
 	MaxTemplateChars     int
 	MaxFieldChars        int
 	MaxResultChars       int
+	TraceErrors          bool // only for debugging as traces make error events larger and more expensive
 }
 
 // Engine provides callers with session starting and resuming
@@ -424,7 +426,7 @@ type Run interface {
 	ReceivedInput() bool
 
 	EvaluateTemplateValue(string, EventCallback) (types.XValue, bool)
-	EvaluateTemplateText(string, excellent.Escaping, bool, EventCallback) (string, bool)
+	EvaluateTemplateText(string, *excellent.EscapingMode, bool, EventCallback) (string, bool)
 	EvaluateTemplate(string, EventCallback) (string, bool)
 	RootContext(envs.Environment) map[string]types.XValue
 
@@ -0,0 +1,84 @@
+package resumes
+
+import (
+	"encoding/json"
+
+	"github.com/nyaruka/gocommon/jsonx"
+	"github.com/nyaruka/gocommon/uuids"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/utils"
+)
+
+func init() {
+	registerType(TypeReminder, readReminderResume)
+}
+
+// TypeReminder is the type for resuming a session to send a reminder
+const TypeReminder string = "reminder"
+
+// ReminderResume is used when a session waiting for a message is resumed so that a reminder can be sent to the
+// contact. The session continues waiting after the reminder has been sent.
+//
+//	{
+//	  "type": "reminder",
+//	  "resumed_on": "2000-01-01T00:00:00.000000000-00:00",
+//	  "reminder_uuid": "4d5f4a4f-9b0a-4f3a-8d8a-6d3c3b7f1e2a"
+//	}
+//
+// @resume reminder
+type ReminderResume struct {
+	baseResume
+
+	reminderUUID uuids.UUID
+}
+
+// NewReminder creates a new reminder resume with the passed in values
+func NewReminder(env envs.Environment, contact *flows.Contact, reminderUUID uuids.UUID) *ReminderResume {
+	return &ReminderResume{
+		baseResume:   newBaseResume(TypeReminder, env, contact),
+		reminderUUID: reminderUUID,
+	}
+}
+
+// ReminderUUID returns the UUID of the reminder to be sent
+func (r *ReminderResume) ReminderUUID() uuids.UUID { return r.reminderUUID }
+
+var _ flows.Resume = (*ReminderResume)(nil)
+
+//------------------------------------------------------------------------------------------
+// JSON Encoding / Decoding
+//------------------------------------------------------------------------------------------
+
+type reminderResumeEnvelope struct {
+	baseResumeEnvelope
+
+	ReminderUUID uuids.UUID `json:"reminder_uuid" validate:"required,uuid4"`
+}
+
+func readReminderResume(sessionAssets flows.SessionAssets, data json.RawMessage, missing assets.MissingCallback) (flows.Resume, error) {
+	e := &reminderResumeEnvelope{}
+	if err := utils.UnmarshalAndValidate(data, e); err != nil {
+		return nil, err
+	}
+
+	r := &ReminderResume{reminderUUID: e.ReminderUUID}
+
+	if err := r.unmarshal(sessionAssets, &e.baseResumeEnvelope, missing); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// MarshalJSON marshals this resume into JSON
+func (r *ReminderResume) MarshalJSON() ([]byte, error) {
+	e := &reminderResumeEnvelope{ReminderUUID: r.reminderUUID}
+
+	if err := r.marshal(&e.baseResumeEnvelope); err != nil {
+		return nil, err
+	}
+
+	return jsonx.Marshal(e)
+}
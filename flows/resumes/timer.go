@@ -0,0 +1,69 @@
+package resumes
+
+import (
+	"encoding/json"
+
+	"github.com/nyaruka/gocommon/jsonx"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/utils"
+)
+
+func init() {
+	registerType(TypeTimer, readTimerResume)
+}
+
+// TypeTimer is the type for resuming a session when a timer wait has fired
+const TypeTimer string = "timer"
+
+// TimerResume is used when a session waiting on a timer is resumed because that timer has been reached.
+//
+//	{
+//	  "type": "timer",
+//	  "resumed_on": "2000-01-01T00:00:00.000000000-00:00"
+//	}
+//
+// @resume timer
+type TimerResume struct {
+	baseResume
+}
+
+// NewTimer creates a new timer resume with the passed in values
+func NewTimer(env envs.Environment, contact *flows.Contact) *TimerResume {
+	return &TimerResume{
+		baseResume: newBaseResume(TypeTimer, env, contact),
+	}
+}
+
+var _ flows.Resume = (*TimerResume)(nil)
+
+//------------------------------------------------------------------------------------------
+// JSON Encoding / Decoding
+//------------------------------------------------------------------------------------------
+
+func readTimerResume(sessionAssets flows.SessionAssets, data json.RawMessage, missing assets.MissingCallback) (flows.Resume, error) {
+	e := &baseResumeEnvelope{}
+	if err := utils.UnmarshalAndValidate(data, e); err != nil {
+		return nil, err
+	}
+
+	r := &TimerResume{}
+
+	if err := r.unmarshal(sessionAssets, e, missing); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// MarshalJSON marshals this resume into JSON
+func (r *TimerResume) MarshalJSON() ([]byte, error) {
+	e := &baseResumeEnvelope{}
+
+	if err := r.marshal(e); err != nil {
+		return nil, err
+	}
+
+	return jsonx.Marshal(e)
+}
@@ -30,6 +30,11 @@ func RegisteredTypes() map[string](func() flows.Router) {
 	return registeredTypes
 }
 
+// a wait which has localizable text of its own
+type localizablesEnumerator interface {
+	EnumerateLocalizables(func(uuids.UUID, string, []string, func([]string)))
+}
+
 // baseRouter is the base class for all router types
 type baseRouter struct {
 	type_      string
@@ -62,6 +67,9 @@ func (r *baseRouter) ResultName() string { return r.resultName }
 
 // EnumerateTemplates enumerates all expressions on this object and its children
 func (r *baseRouter) EnumerateTemplates(localization flows.Localization, include func(i18n.Language, string)) {
+	if te, ok := r.wait.(flows.TemplateEnumerator); ok {
+		te.EnumerateTemplates(localization, include)
+	}
 }
 
 // EnumerateDependencies enumerates all dependencies on this object
@@ -88,6 +96,10 @@ func (r *baseRouter) EnumerateLocalizables(include func(uuids.UUID, string, []st
 		}
 		include(cat.LocalizationUUID(), "name", []string{cat.Name()}, w)
 	}
+
+	if le, ok := r.wait.(localizablesEnumerator); ok {
+		le.EnumerateLocalizables(include)
+	}
 }
 
 func (r *baseRouter) validate(flow flows.Flow, exits []flows.Exit) error {
@@ -103,6 +115,11 @@ func (r *baseRouter) validate(flow flows.Flow, exits []flows.Exit) error {
 		}
 	}
 
+	// check wait reprompt category is valid
+	if reprompt := r.reprompt(); reprompt != nil && !r.isValidCategory(reprompt.CategoryUUID) {
+		return fmt.Errorf("reprompt category %s is not a valid category", reprompt.CategoryUUID)
+	}
+
 	if r.wait != nil && !flow.Type().Allows(r.wait) {
 		return fmt.Errorf("wait type '%s' is not allowed in a flow of type '%s'", r.wait.Type(), flow.Type())
 	}
@@ -110,6 +127,14 @@ func (r *baseRouter) validate(flow flows.Flow, exits []flows.Exit) error {
 	return nil
 }
 
+// gets the reprompt configuration of our wait if it has one
+func (r *baseRouter) reprompt() *waits.Reprompt {
+	if msgWait, ok := r.wait.(*waits.MsgWait); ok {
+		return msgWait.Reprompt()
+	}
+	return nil
+}
+
 func (r *baseRouter) isValidCategory(uuid flows.CategoryUUID) bool {
 	for _, c := range r.categories {
 		if c.UUID() == uuid {
@@ -3,8 +3,12 @@ package cases
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/nyaruka/gocommon/dates"
 	"github.com/nyaruka/gocommon/i18n"
@@ -25,51 +29,64 @@ import (
 // XTESTS is our map of test functions
 var XTESTS = map[string]*types.XFunction{}
 
+// a test along with the signature used to statically check calls to it
+type testWithSignature struct {
+	fn        types.XFunc
+	signature *functions.Signature
+}
+
 func init() {
-	builtin := map[string]types.XFunc{
-		"has_error": functions.OneArgFunction(HasError),
-
-		"has_only_text":   functions.TwoTextFunction(HasOnlyText),
-		"has_phrase":      functions.TwoTextFunction(HasPhrase),
-		"has_only_phrase": functions.TwoTextFunction(HasOnlyPhrase),
-		"has_any_word":    functions.TwoTextFunction(HasAnyWord),
-		"has_all_words":   functions.TwoTextFunction(HasAllWords),
-		"has_beginning":   functions.TwoTextFunction(HasBeginning),
-		"has_text":        functions.OneTextFunction(HasText),
-		"has_pattern":     functions.TwoTextFunction(HasPattern),
-
-		"has_number":         functions.OneTextFunction(HasNumber),
-		"has_number_between": functions.ThreeArgFunction(HasNumberBetween),
-		"has_number_lt":      functions.TextAndNumberFunction(HasNumberLT),
-		"has_number_lte":     functions.TextAndNumberFunction(HasNumberLTE),
-		"has_number_eq":      functions.TextAndNumberFunction(HasNumberEQ),
-		"has_number_gte":     functions.TextAndNumberFunction(HasNumberGTE),
-		"has_number_gt":      functions.TextAndNumberFunction(HasNumberGT),
-
-		"has_date":    functions.OneTextFunction(HasDate),
-		"has_date_lt": functions.TextAndDateFunction(HasDateLT),
-		"has_date_eq": functions.TextAndDateFunction(HasDateEQ),
-		"has_date_gt": functions.TextAndDateFunction(HasDateGT),
-
-		"has_time":  functions.OneTextFunction(HasTime),
-		"has_phone": functions.InitialTextFunction(0, 1, HasPhone),
-		"has_email": functions.OneTextFunction(HasEmail),
-		"has_group": functions.MinAndMaxArgsCheck(2, 3, HasGroup),
-
-		"has_category":   functions.ObjectAndTextsFunction(HasCategory),
-		"has_intent":     functions.ObjectTextAndNumberFunction(HasIntent),
-		"has_top_intent": functions.ObjectTextAndNumberFunction(HasTopIntent),
-
-		"has_state":    functions.OneTextFunction(HasState),
-		"has_district": functions.MinAndMaxArgsCheck(1, 2, HasDistrict),
-		"has_ward":     HasWard,
+	builtin := map[string]testWithSignature{
+		"has_error": {functions.OneArgFunction(HasError), functions.NewSignature(1, 1)},
+
+		"has_only_text":   {functions.TwoTextFunction(HasOnlyText), functions.NewSignature(2, 2, "text", "text")},
+		"has_phrase":      {functions.TwoTextFunction(HasPhrase), functions.NewSignature(2, 2, "text", "text")},
+		"has_only_phrase": {functions.TwoTextFunction(HasOnlyPhrase), functions.NewSignature(2, 2, "text", "text")},
+		"has_any_word":    {functions.TwoTextFunction(HasAnyWord), functions.NewSignature(2, 2, "text", "text")},
+		"has_all_words":   {functions.TwoTextFunction(HasAllWords), functions.NewSignature(2, 2, "text", "text")},
+		"has_beginning":   {functions.TwoTextFunction(HasBeginning), functions.NewSignature(2, 2, "text", "text")},
+		"has_text":        {functions.OneTextFunction(HasText), functions.NewSignature(1, 1, "text")},
+		"has_pattern":     {functions.TwoTextFunction(HasPattern), functions.NewSignature(2, 2, "text", "text")},
+
+		"has_number":         {functions.OneTextFunction(HasNumber), functions.NewSignature(1, 1, "text")},
+		"has_number_between": {functions.ThreeArgFunction(HasNumberBetween), functions.NewSignature(3, 3)},
+		"has_number_lt":      {functions.TextAndNumberFunction(HasNumberLT), functions.NewSignature(2, 2, "text", "number")},
+		"has_number_lte":     {functions.TextAndNumberFunction(HasNumberLTE), functions.NewSignature(2, 2, "text", "number")},
+		"has_number_eq":      {functions.TextAndNumberFunction(HasNumberEQ), functions.NewSignature(2, 2, "text", "number")},
+		"has_number_gte":     {functions.TextAndNumberFunction(HasNumberGTE), functions.NewSignature(2, 2, "text", "number")},
+		"has_number_gt":      {functions.TextAndNumberFunction(HasNumberGT), functions.NewSignature(2, 2, "text", "number")},
+
+		"has_date":    {functions.OneTextFunction(HasDate), functions.NewSignature(1, 1, "text")},
+		"has_date_lt": {functions.TextAndDateFunction(HasDateLT), functions.NewSignature(2, 2, "text", "datetime")},
+		"has_date_eq": {functions.TextAndDateFunction(HasDateEQ), functions.NewSignature(2, 2, "text", "datetime")},
+		"has_date_gt": {functions.TextAndDateFunction(HasDateGT), functions.NewSignature(2, 2, "text", "datetime")},
+
+		"has_date_between": {functions.ThreeArgFunction(HasDateBetween), functions.NewSignature(3, 3)},
+		"has_age_between":  {functions.MinAndMaxArgsCheck(3, 4, HasAgeBetween), functions.NewSignature(3, 4)},
+
+		"has_time":         {functions.OneTextFunction(HasTime), functions.NewSignature(1, 1, "text")},
+		"has_time_between": {functions.ThreeArgFunction(HasTimeBetween), functions.NewSignature(3, 3)},
+		"has_phone":        {functions.InitialTextFunction(0, 1, HasPhone), functions.NewSignature(1, 2, "text")},
+		"has_email":        {functions.OneTextFunction(HasEmail), functions.NewSignature(1, 1, "text")},
+		"has_group":        {functions.MinAndMaxArgsCheck(2, 3, HasGroup), functions.NewSignature(2, 3)},
+
+		"has_category":   {functions.ObjectAndTextsFunction(HasCategory), functions.NewSignature(2, -1, "object", "text")},
+		"has_intent":     {functions.ObjectTextAndNumberFunction(HasIntent), functions.NewSignature(3, 3, "object", "text", "number")},
+		"has_top_intent": {functions.ObjectTextAndNumberFunction(HasTopIntent), functions.NewSignature(3, 3, "object", "text", "number")},
+
+		"is_open":   {functions.DateTimeAndTextFunction(IsOpen), functions.NewSignature(2, 2, "datetime", "text")},
+		"next_open": {functions.DateTimeAndTextFunction(NextOpen), functions.NewSignature(2, 2, "datetime", "text")},
+
+		"has_state":    {functions.OneTextFunction(HasState), functions.NewSignature(1, 1, "text")},
+		"has_district": {functions.MinAndMaxArgsCheck(1, 2, HasDistrict), functions.NewSignature(1, 2)},
+		"has_ward":     {HasWard, functions.NewSignature(1, 3, "text", "text", "text")},
 
 		// for backward compatibility
-		"has_value": functions.OneTextFunction(HasText),
+		"has_value": {functions.OneTextFunction(HasText), functions.NewSignature(1, 1, "text")},
 	}
 
-	for name, fn := range builtin {
-		RegisterXTest(name, fn)
+	for name, f := range builtin {
+		RegisterXTestWithSignature(name, f.fn, f.signature)
 	}
 }
 
@@ -81,6 +98,14 @@ func RegisterXTest(name string, fn types.XFunc) {
 	functions.RegisterXFunction(name, fn)
 }
 
+// RegisterXTestWithSignature registers a new router test (and Excellent function) along with the signature used to
+// statically check calls to it
+func RegisterXTestWithSignature(name string, fn types.XFunc, s *functions.Signature) {
+	XTESTS[name] = types.NewXFunction(name, fn)
+
+	functions.RegisterXFunctionWithSignature(name, fn, s)
+}
+
 //------------------------------------------------------------------------------------------
 // Results
 //------------------------------------------------------------------------------------------
@@ -441,7 +466,7 @@ func HasNumberGT(env envs.Environment, text *types.XText, num *types.XNumber) ty
 //
 // @test has_date(text)
 func HasDate(env envs.Environment, text *types.XText) types.XValue {
-	return testDate(env, text, types.XDateTimeZero, isDateTest)
+	return testDate(env, text, types.XDateTimeZero, types.XDateTimeZero, isDateTest)
 }
 
 // HasDateLT tests whether `text` contains a date before the date `max`
@@ -453,7 +478,7 @@ func HasDate(env envs.Environment, text *types.XText) types.XValue {
 //
 // @test has_date_lt(text, max)
 func HasDateLT(env envs.Environment, text *types.XText, date *types.XDateTime) types.XValue {
-	return testDate(env, text, date, isDateLTTest)
+	return testDate(env, text, date, types.XDateTimeZero, isDateLTTest)
 }
 
 // HasDateEQ tests whether `text` a date equal to `date`
@@ -466,7 +491,7 @@ func HasDateLT(env envs.Environment, text *types.XText, date *types.XDateTime) t
 //
 // @test has_date_eq(text, date)
 func HasDateEQ(env envs.Environment, text *types.XText, date *types.XDateTime) types.XValue {
-	return testDate(env, text, date, isDateEQTest)
+	return testDate(env, text, date, types.XDateTimeZero, isDateEQTest)
 }
 
 // HasDateGT tests whether `text` a date after the date `min`
@@ -479,7 +504,99 @@ func HasDateEQ(env envs.Environment, text *types.XText, date *types.XDateTime) t
 //
 // @test has_date_gt(text, min)
 func HasDateGT(env envs.Environment, text *types.XText, date *types.XDateTime) types.XValue {
-	return testDate(env, text, date, isDateGTTest)
+	return testDate(env, text, date, types.XDateTimeZero, isDateGTTest)
+}
+
+// HasDateBetween tests whether `text` contains a date between `min` and `max` inclusive. The parsed date is included
+// in the extra of the result.
+//
+//	@(has_date_between("the date is 15/01/2017", "2017-01-01", "2017-01-31")) -> true
+//	@(has_date_between("the date is 15/01/2017", "2017-01-01", "2017-01-31").match) -> 2017-01-15T13:24:30.123456-05:00
+//	@(has_date_between("the date is 15/01/2017", "2017-01-01", "2017-01-31").extra.date) -> 2017-01-15
+//	@(has_date_between("the date is 15/01/2017", "2017-02-01", "2017-02-28")) -> false
+//	@(has_date_between("there is no date here, just a year 2017", "2017-01-01", "2017-12-31")) -> false
+//	@(has_date_between("the date is 15/01/2017", "not date", "2017-12-31")) -> ERROR
+//
+// @test has_date_between(text, min, max)
+func HasDateBetween(env envs.Environment, arg1 types.XValue, arg2 types.XValue, arg3 types.XValue) types.XValue {
+	text, xerr := types.ToXText(env, arg1)
+	if xerr != nil {
+		return xerr
+	}
+	min, xerr := types.ToXDateTime(env, arg2)
+	if xerr != nil {
+		return xerr
+	}
+	max, xerr := types.ToXDateTime(env, arg3)
+	if xerr != nil {
+		return xerr
+	}
+
+	value, date, ok := findDate(env, text, min, max, isDateBetweenTest)
+	if ok {
+		return NewTrueResultWithExtra(value, types.NewXObject(map[string]types.XValue{
+			"date": types.NewXDate(date),
+		}))
+	}
+
+	return FalseResult
+}
+
+// HasAgeBetween tests whether `text` contains a date, such as a date of birth, which makes for an age between `min`
+// and `max` inclusive. The age is the number of whole `unit`s which have passed between that date and today, where
+// `unit` can be "Y" for years (the default), "M" for months, "W" for weeks or "D" for days. The parsed date and the
+// computed age are included in the extra of the result.
+//
+//	@(has_age_between("I was born on 15/01/2000", 18, 45)) -> true
+//	@(has_age_between("I was born on 15/01/2000", 18, 45).match) -> 2000-01-15T13:24:30.123456-05:00
+//	@(has_age_between("I was born on 15/01/2000", 18, 45).extra.age) -> 18
+//	@(has_age_between("I was born on 15/01/2000", 18, 45).extra.date) -> 2000-01-15
+//	@(has_age_between("I was born on 15/01/2000", 20, 45)) -> false
+//	@(has_age_between("born 01/03/2018", 0, 6, "W").extra.age) -> 5
+//	@(has_age_between("born 01/03/2018", 0, 6, "M")) -> true
+//	@(has_age_between("I don't know", 18, 45)) -> false
+//	@(has_age_between("I was born on 15/01/2000", 18, 45, "X")) -> ERROR
+//	@(has_age_between("I don't know", 18, 45, "X")) -> ERROR
+//
+// @test has_age_between(text, min, max, unit)
+func HasAgeBetween(env envs.Environment, args ...types.XValue) types.XValue {
+	text, xerr := types.ToXText(env, args[0])
+	if xerr != nil {
+		return xerr
+	}
+	min, xerr := types.ToXNumber(env, args[1])
+	if xerr != nil {
+		return xerr
+	}
+	max, xerr := types.ToXNumber(env, args[2])
+	if xerr != nil {
+		return xerr
+	}
+	unit := types.NewXText("Y")
+	if len(args) == 4 {
+		if unit, xerr = types.ToXText(env, args[3]); xerr != nil {
+			return xerr
+		}
+	}
+	if !slices.Contains(ageUnits, unit.Native()) {
+		return types.NewXErrorf("unknown unit: %s, must be one of %s", unit.Native(), strings.Join(ageUnits, ", "))
+	}
+
+	value, date, ok := findDate(env, text, types.XDateTimeZero, types.XDateTimeZero, isDateTest)
+	if !ok {
+		return FalseResult
+	}
+
+	age := ageIn(date, dates.ExtractDate(env.Now()), unit.Native())
+
+	if isNumberBetween(decimal.NewFromInt(int64(age)), min.Native(), max.Native()) {
+		return NewTrueResultWithExtra(value, types.NewXObject(map[string]types.XValue{
+			"date": types.NewXDate(date),
+			"age":  types.NewXNumberFromInt(age),
+		}))
+	}
+
+	return FalseResult
 }
 
 // HasTime tests whether `text` contains a time.
@@ -500,6 +617,50 @@ func HasTime(env envs.Environment, text *types.XText) types.XValue {
 	return FalseResult
 }
 
+// HasTimeBetween tests whether `text` contains a time between `min` and `max` inclusive. If `min` is after `max`, the
+// range is taken to span midnight.
+//
+//	@(has_time_between("the time is 10:30", "09:00", "17:00")) -> true
+//	@(has_time_between("the time is 10:30", "09:00", "17:00").match) -> 10:30:00.000000
+//	@(has_time_between("the time is 10 PM", "09:00", "17:00")) -> false
+//	@(has_time_between("the time is 11 PM", "22:00", "06:00")) -> true
+//	@(has_time_between("there is no time here, just the number 25", "09:00", "17:00")) -> false
+//	@(has_time_between("the time is 10:30", "xx", "17:00")) -> ERROR
+//
+// @test has_time_between(text, min, max)
+func HasTimeBetween(env envs.Environment, arg1 types.XValue, arg2 types.XValue, arg3 types.XValue) types.XValue {
+	text, xerr := types.ToXText(env, arg1)
+	if xerr != nil {
+		return xerr
+	}
+	min, xerr := types.ToXTime(env, arg2)
+	if xerr != nil {
+		return xerr
+	}
+	max, xerr := types.ToXTime(env, arg3)
+	if xerr != nil {
+		return xerr
+	}
+
+	t, xerr := types.ToXTime(env, text)
+	if xerr != nil {
+		return FalseResult
+	}
+
+	value, lower, upper := t.Native(), min.Native(), max.Native()
+	var between bool
+	if lower.Compare(upper) <= 0 {
+		between = value.Compare(lower) >= 0 && value.Compare(upper) <= 0
+	} else {
+		between = value.Compare(lower) >= 0 || value.Compare(upper) <= 0
+	}
+
+	if between {
+		return NewTrueResult(t)
+	}
+	return FalseResult
+}
+
 var emailAddressRE = regexp.MustCompile(`([\pL\pN][-_+$~.\pL\pN]*)@([\pL\pN][-_\pL\pN]*)(\.[\pL\pN][-_\pL\pN]*)+`)
 
 // HasEmail tests whether an email is contained in `text`
@@ -595,12 +756,60 @@ func HasTopIntent(env envs.Environment, result *types.XObject, name *types.XText
 	return hasIntent(result, name, confidence, true)
 }
 
+// IsOpen tests whether the schedule with the given name or UUID is open at `datetime`. If the schedule doesn't have
+// its own timezone, the timezone of the environment is used.
+//
+//	@(is_open("2018-04-11 10:00", "Office Hours")) -> true
+//	@(is_open("2018-04-11 10:00", "Office Hours").match) -> 2018-04-11T10:00:00.000000-05:00
+//	@(is_open("2018-04-11 18:00", "Office Hours")) -> false
+//	@(is_open("2018-12-25 10:00", "Office Hours")) -> false
+//	@(is_open(now(), "Weekend Hours")) -> false
+//	@(is_open(now(), "Holiday Hours")) -> ERROR
+//
+// @test is_open(datetime, schedule)
+func IsOpen(env envs.Environment, datetime *types.XDateTime, schedule *types.XText) types.XValue {
+	s, xerr := findSchedule(env, schedule)
+	if xerr != nil {
+		return xerr
+	}
+
+	if s.IsOpen(env, datetime.Native()) {
+		return NewTrueResult(datetime)
+	}
+	return FalseResult
+}
+
+// NextOpen tests whether the schedule with the given name or UUID opens at or after `datetime` within the next year,
+// and if so, returns the date and time of that opening as the match.
+//
+//	@(next_open("2018-04-11 18:00", "Office Hours")) -> true
+//	@(next_open("2018-04-11 18:00", "Office Hours").match) -> 2018-04-12T09:00:00.000000-05:00
+//	@(next_open("2018-04-11 10:00", "Office Hours").match) -> 2018-04-11T10:00:00.000000-05:00
+//	@(next_open(now(), "Weekend Hours").match) -> 2018-04-14T10:00:00.000000-05:00
+//	@(next_open(now(), "Holiday Hours")) -> ERROR
+//
+// @test next_open(datetime, schedule)
+func NextOpen(env envs.Environment, datetime *types.XDateTime, schedule *types.XText) types.XValue {
+	s, xerr := findSchedule(env, schedule)
+	if xerr != nil {
+		return xerr
+	}
+
+	if next := s.NextOpen(env, datetime.Native()); next != nil {
+		return NewTrueResult(types.NewXDateTime(*next))
+	}
+	return FalseResult
+}
+
 // HasState tests whether a state name is contained in the `text`
 //
 //	@(has_state("Kigali").match) -> Rwanda > Kigali City
 //	@(has_state("¡Kigali!").match) -> Rwanda > Kigali City
 //	@(has_state("I live in Kigali").match) -> Rwanda > Kigali City
 //	@(has_state("Boston")) -> false
+//	@(has_state("geo:-1.9441,30.0619").match) -> Rwanda > Kigali City
+//
+// If `text` is a GPS point, e.g. a `geo` attachment, then the state whose boundary contains that point is matched.
 //
 // @test has_state(text)
 func HasState(env envs.Environment, text *types.XText) types.XValue {
@@ -609,6 +818,13 @@ func HasState(env envs.Environment, text *types.XText) types.XValue {
 		return types.NewXErrorf("can't find locations in environment which is not location enabled")
 	}
 
+	if state, isPoint := findLocationByPoint(locations, text.Native(), flows.LocationLevelState); isPoint {
+		if state != nil {
+			return NewTrueResult(types.NewXText(string(state.Path())))
+		}
+		return FalseResult
+	}
+
 	states := locations.FindLocationsFuzzy(env, text.Native(), flows.LocationLevelState, nil)
 	if len(states) > 0 {
 		return NewTrueResult(types.NewXText(string(states[0].Path())))
@@ -623,6 +839,9 @@ func HasState(env envs.Environment, text *types.XText) types.XValue {
 //	@(has_district("I live in Gasabo", "Kigali").match) -> Rwanda > Kigali City > Gasabo
 //	@(has_district("Gasabo", "Boston")) -> false
 //	@(has_district("Gasabo").match) -> Rwanda > Kigali City > Gasabo
+//	@(has_district("geo:-1.9441,30.0619", "Kigali").match) -> Rwanda > Kigali City > Gasabo
+//
+// If `text` is a GPS point, e.g. a `geo` attachment, then the district whose boundary contains that point is matched.
 //
 // @test has_district(text, state)
 func HasDistrict(env envs.Environment, args ...types.XValue) types.XValue {
@@ -647,6 +866,14 @@ func HasDistrict(env envs.Environment, args ...types.XValue) types.XValue {
 	}
 
 	states := locations.FindLocationsFuzzy(env, stateText.Native(), flows.LocationLevelState, nil)
+
+	if district, isPoint := findLocationByPoint(locations, text.Native(), flows.LocationLevelDistrict); isPoint {
+		if district != nil && (stateText.Empty() || slices.Contains(states, district.Parent())) {
+			return NewTrueResult(types.NewXText(string(district.Path())))
+		}
+		return FalseResult
+	}
+
 	if len(states) > 0 {
 		districts := locations.FindLocationsFuzzy(env, text.Native(), flows.LocationLevelDistrict, states[0])
 		if len(districts) > 0 {
@@ -675,6 +902,8 @@ func HasDistrict(env envs.Environment, args ...types.XValue) types.XValue {
 //	@(has_ward("Gasabo")) -> false
 //	@(has_ward("Gisozi").match) -> Rwanda > Kigali City > Gasabo > Gisozi
 //
+// If `text` is a GPS point, e.g. a `geo` attachment, then the ward whose boundary contains that point is matched.
+//
 // @test has_ward(text, district, state)
 func HasWard(env envs.Environment, args ...types.XValue) types.XValue {
 	if len(args) != 1 && len(args) != 3 {
@@ -707,6 +936,14 @@ func HasWard(env envs.Environment, args ...types.XValue) types.XValue {
 	}
 
 	states := locations.FindLocationsFuzzy(env, stateText.Native(), flows.LocationLevelState, nil)
+
+	if ward, isPoint := findLocationByPoint(locations, text.Native(), flows.LocationLevelWard); isPoint {
+		if ward != nil && (districtText.Empty() || slices.Contains(states, ward.Parent().Parent()) && slices.Contains(locations.FindLocationsFuzzy(env, districtText.Native(), flows.LocationLevelDistrict, ward.Parent().Parent()), ward.Parent())) {
+			return NewTrueResult(types.NewXText(string(ward.Path())))
+		}
+		return FalseResult
+	}
+
 	if len(states) > 0 {
 		districts := locations.FindLocationsFuzzy(env, districtText.Native(), flows.LocationLevelDistrict, states[0])
 		if len(districts) > 0 {
@@ -728,6 +965,22 @@ func HasWard(env envs.Environment, args ...types.XValue) types.XValue {
 	return FalseResult
 }
 
+// if the given text is a GPS point, finds the first location at the given level whose boundary contains it
+func findLocationByPoint(locations envs.LocationResolver, text string, level envs.LocationLevel) (*envs.Location, bool) {
+	point, err := utils.ParseGeoPoint(text)
+	if err != nil {
+		return nil, false
+	}
+
+	// resolvers which can't search by point can't match a point to anything
+	if resolver, ok := locations.(envs.PointLocationResolver); ok {
+		if matches := resolver.FindLocationsByPoint(point, level); len(matches) > 0 {
+			return matches[0], true
+		}
+	}
+	return nil, true
+}
+
 //------------------------------------------------------------------------------------------
 // Text Test Functions
 //------------------------------------------------------------------------------------------
@@ -849,15 +1102,20 @@ func hasOnlyPhraseTest(env envs.Environment, hays []string, pins []string) types
 type decimalTest func(value decimal.Decimal, test1 decimal.Decimal, test2 decimal.Decimal) bool
 
 func testNumber(env envs.Environment, str *types.XText, testNum1 *types.XNumber, testNum2 *types.XNumber, testFunc decimalTest) types.XValue {
-	// create a number finding regex based on current environment
-	pattern := regexp.MustCompile(fmt.Sprintf(`[-+]?([\pN\%[1]s]+(\%[2]s[\pN]+)?|(\W|^)\%[2]s[\pN]+)`, env.NumberFormat().DigitGroupingSymbol, env.NumberFormat().DecimalSymbol))
-
-	// look for number like things in the input and use the first one that we can actually parse
-	for _, value := range pattern.FindAllString(str.Native(), -1) {
-		num, err := ParseDecimal(value, env.NumberFormat())
-		if err == nil {
-			if testFunc(num, testNum1.Native(), testNum2.Native()) {
-				return NewTrueResult(types.NewXNumber(num))
+	// like parse_number, try the conventions of the contact's locale first and then the environment's number format
+	formats := []*envs.NumberFormat{env.NumberFormat()}
+	if localeFormat := envs.NumberFormatForLocale(env.DefaultLocale()); localeFormat != nil && *localeFormat != *env.NumberFormat() {
+		formats = []*envs.NumberFormat{localeFormat, env.NumberFormat()}
+	}
+
+	for _, format := range formats {
+		// look for number like things in the input and use the first one that we can actually parse
+		for _, value := range findNumbers(str.Native(), format) {
+			num, err := ParseDecimal(value, format)
+			if err == nil {
+				if testFunc(num, testNum1.Native(), testNum2.Native()) {
+					return NewTrueResult(types.NewXNumber(num))
+				}
 			}
 		}
 	}
@@ -865,6 +1123,32 @@ func testNumber(env envs.Environment, str *types.XText, testNum1 *types.XNumber,
 	return FalseResult
 }
 
+// finds the numbers in the given text which are valid in the given number format. Digits must be grouped in threes, and
+// numbers which run into other digits and separators, e.g. 2,5 in a format which groups with commas, are ambiguous and
+// so are ignored.
+func findNumbers(s string, format *envs.NumberFormat) []string {
+	group, decimal := regexp.QuoteMeta(format.DigitGroupingSymbol), regexp.QuoteMeta(format.DecimalSymbol)
+	pattern := regexp.MustCompile(fmt.Sprintf(`[-+]?(\pN{1,3}(%[1]s\pN{3})+(%[2]s\pN+)?|\pN+(%[2]s\pN+)?|(\W|^)%[2]s\pN+)`, group, decimal))
+
+	separators := []string{".", ",", format.DigitGroupingSymbol, format.DecimalSymbol}
+	isSeparator := func(r rune) bool { return slices.Contains(separators, string(r)) }
+
+	numbers := make([]string, 0, 1)
+	for _, match := range pattern.FindAllStringIndex(s, -1) {
+		before, size := utf8.DecodeLastRuneInString(s[:match[0]])
+		beforeThat, _ := utf8.DecodeLastRuneInString(s[:match[0]-size])
+		after, size := utf8.DecodeRuneInString(s[match[1]:])
+		afterThat, _ := utf8.DecodeRuneInString(s[match[1]+size:])
+
+		if unicode.IsNumber(before) || (isSeparator(before) && unicode.IsNumber(beforeThat)) || (isSeparator(after) && unicode.IsNumber(afterThat)) {
+			continue
+		}
+
+		numbers = append(numbers, s[match[0]:match[1]])
+	}
+	return numbers
+}
+
 func isNumberTest(value decimal.Decimal, _ decimal.Decimal, _ decimal.Decimal) bool {
 	return true
 }
@@ -897,43 +1181,85 @@ func isNumberBetween(value decimal.Decimal, test1 decimal.Decimal, test2 decimal
 // Date Test Functions
 //------------------------------------------------------------------------------------------
 
-type dateTest func(dates.Date, dates.Date) bool
+type dateTest func(value dates.Date, test1 dates.Date, test2 dates.Date) bool
 
-func testDate(env envs.Environment, str *types.XText, testDate *types.XDateTime, testFunc dateTest) types.XValue {
+func testDate(env envs.Environment, str *types.XText, testDate1 *types.XDateTime, testDate2 *types.XDateTime, testFunc dateTest) types.XValue {
+	if value, _, ok := findDate(env, str, testDate1, testDate2, testFunc); ok {
+		return NewTrueResult(value)
+	}
+
+	return FalseResult
+}
+
+// parses a date from the given text and if it passes the given test, returns it as both a datetime and a date
+func findDate(env envs.Environment, str *types.XText, testDate1 *types.XDateTime, testDate2 *types.XDateTime, testFunc dateTest) (*types.XDateTime, dates.Date, bool) {
 	// first parse with time filling which will be the test result
 	value, xerr := types.ToXDateTimeWithTimeFill(env, str)
+	if xerr != nil {
+		return nil, dates.ZeroDate, false
+	}
 
 	// but comparison should be against only the date portions
 	valueAsDate := dates.ExtractDate(value.In(env.Timezone()).Native())
-	testAsDate := dates.ExtractDate(testDate.In(env.Timezone()).Native())
-
-	if xerr != nil {
-		return FalseResult
-	}
+	test1AsDate := dates.ExtractDate(testDate1.In(env.Timezone()).Native())
+	test2AsDate := dates.ExtractDate(testDate2.In(env.Timezone()).Native())
 
-	if testFunc(valueAsDate, testAsDate) {
-		return NewTrueResult(value)
+	if testFunc(valueAsDate, test1AsDate, test2AsDate) {
+		return value, valueAsDate, true
 	}
 
-	return FalseResult
+	return nil, dates.ZeroDate, false
 }
 
-func isDateTest(value dates.Date, test dates.Date) bool {
+func isDateTest(value dates.Date, _ dates.Date, _ dates.Date) bool {
 	return true
 }
 
-func isDateLTTest(value dates.Date, test dates.Date) bool {
+func isDateLTTest(value dates.Date, test dates.Date, _ dates.Date) bool {
 	return value.Compare(test) < 0
 }
 
-func isDateEQTest(value dates.Date, test dates.Date) bool {
+func isDateEQTest(value dates.Date, test dates.Date, _ dates.Date) bool {
 	return value.Compare(test) == 0
 }
 
-func isDateGTTest(value dates.Date, test dates.Date) bool {
+func isDateGTTest(value dates.Date, test dates.Date, _ dates.Date) bool {
 	return value.Compare(test) > 0
 }
 
+func isDateBetweenTest(value dates.Date, test1 dates.Date, test2 dates.Date) bool {
+	return value.Compare(test1) >= 0 && value.Compare(test2) <= 0
+}
+
+// calculates the number of whole units between the given date and today
+// units which ages can be measured in, as days, weeks, months or years
+var ageUnits = []string{"D", "W", "M", "Y"}
+
+func ageIn(date dates.Date, today dates.Date, unit string) int {
+	switch unit {
+	case "D":
+		return daysBetween(date, today)
+	case "W":
+		return daysBetween(date, today) / 7
+	case "M":
+		months := (today.Year-date.Year)*12 + int(today.Month-date.Month)
+		if today.Day < date.Day {
+			months--
+		}
+		return months
+	}
+
+	years := today.Year - date.Year
+	if today.Month < date.Month || (today.Month == date.Month && today.Day < date.Day) {
+		years--
+	}
+	return years
+}
+
+func daysBetween(date1 dates.Date, date2 dates.Date) int {
+	return dates.DaysBetween(date2.Combine(dates.ZeroTimeOfDay, time.UTC), date1.Combine(dates.ZeroTimeOfDay, time.UTC))
+}
+
 //------------------------------------------------------------------------------------------
 // Result Test helpers
 //------------------------------------------------------------------------------------------
@@ -979,3 +1305,19 @@ func hasIntent(resultObj *types.XObject, name *types.XText, confidence *types.XN
 
 	return FalseResult
 }
+
+func findSchedule(env envs.Environment, schedule *types.XText) (envs.Schedule, *types.XError) {
+	var schedules envs.ScheduleResolver
+	if se, ok := env.(envs.ScheduleEnvironment); ok {
+		schedules = se.ScheduleResolver()
+	}
+	if schedules == nil {
+		return nil, types.NewXErrorf("can't find schedules in environment which is not schedule enabled")
+	}
+
+	s := schedules.FindSchedule(schedule.Native())
+	if s == nil {
+		return nil, types.NewXErrorf("no such schedule '%s'", schedule.Native())
+	}
+	return s, nil
+}
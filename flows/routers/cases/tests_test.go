@@ -11,6 +11,7 @@ import (
 	"github.com/nyaruka/goflow/assets/static"
 	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/excellent"
+	"github.com/nyaruka/goflow/excellent/functions"
 	"github.com/nyaruka/goflow/excellent/types"
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/engine"
@@ -27,6 +28,8 @@ var xn = types.RequireXNumberFromString
 var xd = types.NewXDateTime
 var xt = types.NewXTime
 var xa = types.NewXArray
+var xo = types.NewXObject
+var xdate = func(y, m, d int) types.XValue { return types.NewXDate(dates.NewDate(y, m, d)) }
 var xj = func(s string) types.XValue { return types.JSONToXValue([]byte(s)) }
 var result = cases.NewTrueResult
 var resultWithExtra = cases.NewTrueResultWithExtra
@@ -40,6 +43,10 @@ var dmy = envs.NewBuilder().
 	WithTimezone(kgl).
 	WithDefaultCountry(i18n.Country("RW")).
 	Build()
+var spa = envs.NewBuilder().
+	WithAllowedLanguages("spa").
+	WithDefaultCountry(i18n.Country("EC")).
+	Build()
 var ara = envs.NewBuilder().
 	WithInputCollation(envs.CollationArabicVariants).
 	Build()
@@ -63,12 +70,21 @@ var assetsJSON = `{
 				{
 					"name": "Kigali City",
 					"aliases": ["Kigali", "Kigari"],
+					"boundary": [
+						[[29.95, -2.05], [30.25, -2.05], [30.25, -1.85], [29.95, -1.85]]
+					],
 					"children": [
 						{
 							"name": "Gasabo",
+							"boundary": [
+								[[30.05, -2.0], [30.25, -2.0], [30.25, -1.85], [30.05, -1.85]]
+							],
 							"children": [
 								{
-									"name": "Gisozi"
+									"name": "Gisozi",
+									"boundary": [
+										[[30.05, -1.95], [30.15, -1.95], [30.15, -1.9], [30.05, -1.9]]
+									]
 								},
 								{
 									"name": "Ndera"
@@ -98,6 +114,21 @@ var assetsJSON = `{
 				}
 			]
 		}
+	],
+	"schedules": [
+		{
+			"uuid": "4b4a7b9c-0a7d-4a65-9a0b-7f3b0f1b0d3c",
+			"name": "Office Hours",
+			"intervals": [
+				{"days": ["mon", "tue", "wed", "thu", "fri"], "start": "09:00", "end": "17:00"}
+			],
+			"holidays": ["2018-04-13"]
+		},
+		{
+			"uuid": "a9b5f0c5-8b6c-4f5a-9d0c-6a1d7e3c2b1f",
+			"name": "Never",
+			"intervals": []
+		}
 	]
 }`
 
@@ -210,6 +241,12 @@ var testTests = []struct {
 	{"has_number", dmy, []types.XValue{xs("١٢٣٤")}, result(xn("1234"))}, // Arabic
 	{"has_number", dmy, []types.XValue{xs("۱۲۳۴")}, result(xn("1234"))}, // Eastern Arabic
 	{"has_number", dmy, []types.XValue{xs("٠.٥")}, result(xn("0.5"))},
+	{"has_number", dmy, []types.XValue{xs("1,234.5")}, result(xn("1234.5"))},
+	{"has_number", dmy, []types.XValue{xs("2,5")}, falseResult}, // ambiguous grouping
+	{"has_number", dmy, []types.XValue{xs("12345,678")}, falseResult},
+	{"has_number", spa, []types.XValue{xs("son 2,5")}, result(xn("2.5"))},
+	{"has_number", spa, []types.XValue{xs("son 1.234,5")}, result(xn("1234.5"))},
+	{"has_number", spa, []types.XValue{xs("son 1,234.5")}, result(xn("1234.5"))},
 	{"has_number", dmy, []types.XValue{xs("nothing here")}, falseResult},
 	{"has_number", dmy, []types.XValue{xs("lOO")}, falseResult}, // no longer do substitutions
 	{"has_number", dmy, []types.XValue{xs("one"), xs("two"), xs("three")}, ERROR},
@@ -220,6 +257,7 @@ var testTests = []struct {
 	{"has_number_lt", dmy, []types.XValue{xs("١٠"), xs("11")}, result(xn("10"))},
 	{"has_number_lt", dmy, []types.XValue{xs("nothing here"), xs("12")}, falseResult},
 	{"has_number_lt", dmy, []types.XValue{xs("too big 15"), xs("12")}, falseResult},
+	{"has_number_lt", spa, []types.XValue{xs("son 2,5"), xs("2.6")}, result(xn("2.5"))},
 	{"has_number_lt", dmy, []types.XValue{xs("one"), xs("two"), xs("three")}, ERROR},
 	{"has_number_lt", dmy, []types.XValue{xs("but foo"), falseResult}, ERROR},
 	{"has_number_lt", dmy, []types.XValue{nil, xs("but foo")}, ERROR},
@@ -238,6 +276,10 @@ var testTests = []struct {
 	{"has_number_eq", dmy, []types.XValue{xs("١٠"), xs("10")}, result(xn("10"))},
 	{"has_number_eq", dmy, []types.XValue{xs("nothing here"), xs("12")}, falseResult},
 	{"has_number_eq", dmy, []types.XValue{xs("wrong .51"), xs(".61")}, falseResult},
+	{"has_number_eq", spa, []types.XValue{xs("son 1.234,50"), xs("1234.5")}, result(xn("1234.50"))},
+	{"has_number_eq", spa, []types.XValue{xs("son 1,234.50"), xs("1234.5")}, result(xn("1234.50"))},
+	{"has_number_eq", spa, []types.XValue{xs("son 1.234,50"), xs("1.234")}, falseResult},
+	{"has_number_eq", spa, []types.XValue{xs("son 1.234,50"), xs("50")}, falseResult},
 	{"has_number_eq", dmy, []types.XValue{xs("one"), xs("two"), xs("three")}, ERROR},
 	{"has_number_eq", dmy, []types.XValue{}, ERROR},
 
@@ -301,12 +343,44 @@ var testTests = []struct {
 	{"has_date_gt", dmy, []types.XValue{xs("too"), xs("many"), xs("args")}, ERROR},
 	{"has_date_gt", dmy, []types.XValue{}, ERROR},
 
+	{"has_date_between", dmy, []types.XValue{xs("last date was 1.10.2017"), xs("1.10.2017"), xs("3.10.2017")}, resultWithExtra(xd(time.Date(2017, 10, 1, 15, 24, 30, 123456000, kgl)), xo(map[string]types.XValue{"date": xdate(2017, 10, 1)}))},
+	{"has_date_between", dmy, []types.XValue{xs("last date was 3.10.2017"), xs("1.10.2017"), xs("3.10.2017")}, resultWithExtra(xd(time.Date(2017, 10, 3, 15, 24, 30, 123456000, kgl)), xo(map[string]types.XValue{"date": xdate(2017, 10, 3)}))},
+	{"has_date_between", dmy, []types.XValue{xs("last date was 4.10.2017"), xs("1.10.2017"), xs("3.10.2017")}, falseResult},
+	{"has_date_between", dmy, []types.XValue{xs("no date at all"), xs("1.10.2017"), xs("3.10.2017")}, falseResult},
+	{"has_date_between", dmy, []types.XValue{xs("last date was 1.10.2017"), xs("xxx"), xs("3.10.2017")}, ERROR},
+	{"has_date_between", dmy, []types.XValue{xs("last date was 1.10.2017"), xs("1.10.2017"), ERROR}, ERROR},
+	{"has_date_between", dmy, []types.XValue{xs("too"), xs("few")}, ERROR},
+
+	{"has_age_between", dmy, []types.XValue{xs("born 11.4.2000"), xn("18"), xn("45")}, resultWithExtra(xd(time.Date(2000, 4, 11, 15, 24, 30, 123456000, kgl)), xo(map[string]types.XValue{"date": xdate(2000, 4, 11), "age": xn("18")}))},
+	{"has_age_between", dmy, []types.XValue{xs("born 12.4.2000"), xn("18"), xn("45")}, falseResult}, // turns 18 tomorrow
+	{"has_age_between", dmy, []types.XValue{xs("born 12.4.2000"), xn("18"), xn("45"), xs("M")}, falseResult},
+	{"has_age_between", dmy, []types.XValue{xs("born 12.4.1972"), xn("18"), xn("45")}, resultWithExtra(xd(time.Date(1972, 4, 12, 15, 24, 30, 123456000, kgl)), xo(map[string]types.XValue{"date": xdate(1972, 4, 12), "age": xn("45")}))},
+	{"has_age_between", dmy, []types.XValue{xs("born 11.4.1972"), xn("18"), xn("45")}, falseResult},
+	{"has_age_between", dmy, []types.XValue{xs("born 12.3.2018"), xn("0"), xn("1"), xs("M")}, resultWithExtra(xd(time.Date(2018, 3, 12, 15, 24, 30, 123456000, kgl)), xo(map[string]types.XValue{"date": xdate(2018, 3, 12), "age": xn("0")}))},
+	{"has_age_between", dmy, []types.XValue{xs("born 28.3.2018"), xn("2"), xn("2"), xs("W")}, resultWithExtra(xd(time.Date(2018, 3, 28, 15, 24, 30, 123456000, kgl)), xo(map[string]types.XValue{"date": xdate(2018, 3, 28), "age": xn("2")}))},
+	{"has_age_between", dmy, []types.XValue{xs("born 1.4.2018"), xn("10"), xn("10"), xs("D")}, resultWithExtra(xd(time.Date(2018, 4, 1, 15, 24, 30, 123456000, kgl)), xo(map[string]types.XValue{"date": xdate(2018, 4, 1), "age": xn("10")}))},
+	{"has_age_between", dmy, []types.XValue{xs("no date at all"), xn("18"), xn("45")}, falseResult},
+	{"has_age_between", dmy, []types.XValue{xs("born 11.4.2000"), xn("18"), xn("45"), xs("X")}, ERROR},
+	{"has_age_between", dmy, []types.XValue{xs("no date at all"), xn("18"), xn("45"), xs("X")}, ERROR},
+	{"has_age_between", dmy, []types.XValue{xs("born 11.4.2000"), xs("xx"), xn("45")}, ERROR},
+	{"has_age_between", dmy, []types.XValue{xs("born 11.4.2000"), xn("18")}, ERROR},
+
 	{"has_time", dmy, []types.XValue{xs("last time was 10:30")}, result(xt(dates.NewTimeOfDay(10, 30, 0, 0)))},
 	{"has_time", dmy, []types.XValue{xs("this isn't a valid time 59:77")}, falseResult},
 	{"has_time", dmy, []types.XValue{xs("no time at all")}, falseResult},
 	{"has_time", dmy, []types.XValue{xs("too"), xs("many"), xs("args")}, ERROR},
 	{"has_time", dmy, []types.XValue{}, ERROR},
 
+	{"has_time_between", dmy, []types.XValue{xs("last time was 10:30"), xs("09:00"), xs("17:00")}, result(xt(dates.NewTimeOfDay(10, 30, 0, 0)))},
+	{"has_time_between", dmy, []types.XValue{xs("last time was 17:00"), xs("09:00"), xs("17:00")}, result(xt(dates.NewTimeOfDay(17, 0, 0, 0)))},
+	{"has_time_between", dmy, []types.XValue{xs("last time was 17:01"), xs("09:00"), xs("17:00")}, falseResult},
+	{"has_time_between", dmy, []types.XValue{xs("last time was 23:30"), xs("22:00"), xs("06:00")}, result(xt(dates.NewTimeOfDay(23, 30, 0, 0)))},
+	{"has_time_between", dmy, []types.XValue{xs("last time was 05:30"), xs("22:00"), xs("06:00")}, result(xt(dates.NewTimeOfDay(5, 30, 0, 0)))},
+	{"has_time_between", dmy, []types.XValue{xs("last time was 12:00"), xs("22:00"), xs("06:00")}, falseResult},
+	{"has_time_between", dmy, []types.XValue{xs("no time at all"), xs("09:00"), xs("17:00")}, falseResult},
+	{"has_time_between", dmy, []types.XValue{xs("last time was 10:30"), xs("xx"), xs("17:00")}, ERROR},
+	{"has_time_between", dmy, []types.XValue{xs("last time was 10:30")}, ERROR},
+
 	{"has_email", dmy, []types.XValue{xs("my email is foo@bar.com.")}, result(xs("foo@bar.com"))},
 	{"has_email", dmy, []types.XValue{xs("my email is <foo~$1+spam@bar-2.com>")}, result(xs("foo~$1+spam@bar-2.com"))},
 	{"has_email", dmy, []types.XValue{xs("FOO@bar.whatzit")}, result(xs("FOO@bar.whatzit"))},
@@ -341,6 +415,25 @@ var testTests = []struct {
 	{"has_group", dmy, []types.XValue{xa(), ERROR}, ERROR},
 	{"has_group", dmy, []types.XValue{}, ERROR},
 
+	{"is_open", dmy, []types.XValue{xd(time.Date(2018, 4, 11, 9, 0, 0, 0, kgl)), xs("Office Hours")}, result(xd(time.Date(2018, 4, 11, 9, 0, 0, 0, kgl)))},
+	{"is_open", dmy, []types.XValue{xd(time.Date(2018, 4, 11, 9, 0, 0, 0, kgl)), xs("4b4a7b9c-0a7d-4a65-9a0b-7f3b0f1b0d3c")}, result(xd(time.Date(2018, 4, 11, 9, 0, 0, 0, kgl)))},
+	{"is_open", dmy, []types.XValue{xs("11-04-2018 16:59"), xs("office hours")}, result(xd(time.Date(2018, 4, 11, 16, 59, 0, 0, kgl)))},
+	{"is_open", dmy, []types.XValue{xd(time.Date(2018, 4, 11, 8, 59, 0, 0, kgl)), xs("Office Hours")}, falseResult},
+	{"is_open", dmy, []types.XValue{xd(time.Date(2018, 4, 11, 17, 0, 0, 0, kgl)), xs("Office Hours")}, falseResult},
+	{"is_open", dmy, []types.XValue{xd(time.Date(2018, 4, 13, 10, 0, 0, 0, kgl)), xs("Office Hours")}, falseResult}, // holiday
+	{"is_open", dmy, []types.XValue{xd(time.Date(2018, 4, 14, 10, 0, 0, 0, kgl)), xs("Office Hours")}, falseResult}, // weekend
+	{"is_open", dmy, []types.XValue{xd(time.Date(2018, 4, 11, 10, 0, 0, 0, kgl)), xs("Never")}, falseResult},
+	{"is_open", dmy, []types.XValue{xd(time.Date(2018, 4, 11, 10, 0, 0, 0, kgl)), xs("Unknown")}, ERROR},
+	{"is_open", dmy, []types.XValue{xs("xxx"), xs("Office Hours")}, ERROR},
+	{"is_open", dmy, []types.XValue{xs("Office Hours")}, ERROR},
+
+	{"next_open", dmy, []types.XValue{xd(time.Date(2018, 4, 11, 10, 0, 0, 0, kgl)), xs("Office Hours")}, result(xd(time.Date(2018, 4, 11, 10, 0, 0, 0, kgl)))},
+	{"next_open", dmy, []types.XValue{xd(time.Date(2018, 4, 11, 18, 0, 0, 0, kgl)), xs("Office Hours")}, result(xd(time.Date(2018, 4, 12, 9, 0, 0, 0, kgl)))},
+	{"next_open", dmy, []types.XValue{xd(time.Date(2018, 4, 12, 18, 0, 0, 0, kgl)), xs("Office Hours")}, result(xd(time.Date(2018, 4, 16, 9, 0, 0, 0, kgl)))}, // skips holiday and weekend
+	{"next_open", dmy, []types.XValue{xd(time.Date(2018, 4, 11, 10, 0, 0, 0, kgl)), xs("Never")}, falseResult},
+	{"next_open", dmy, []types.XValue{xd(time.Date(2018, 4, 11, 10, 0, 0, 0, kgl)), xs("Unknown")}, ERROR},
+	{"next_open", dmy, []types.XValue{}, ERROR},
+
 	{"has_state", dmy, []types.XValue{xs("Quebec")}, result(xs("Rwanda > Québec"))},
 	{"has_state", dmy, []types.XValue{xs("Québec")}, result(xs("Rwanda > Québec"))},
 	{"has_state", dmy, []types.XValue{xs("Je suis dans la province du Québec")}, result(xs("Rwanda > Québec"))},
@@ -355,18 +448,29 @@ var testTests = []struct {
 	{"has_state", dmy, []types.XValue{xs("غم ځپلې هلمند")}, falseResult},
 	{"has_state", dmy, []types.XValue{xs("\u063a\u0645 \u0681\u067e\u0644\u06d0 \u0647\u0644\u0645\u0646\u062f")}, falseResult},
 	{"has_state", dmy, []types.XValue{xs("xyz")}, falseResult},
+	{"has_state", dmy, []types.XValue{xs("geo:-1.93,30.1")}, result(xs("Rwanda > Kigali City"))},
+	{"has_state", dmy, []types.XValue{xs("-1.93, 30.1")}, result(xs("Rwanda > Kigali City"))},
+	{"has_state", dmy, []types.XValue{xs("geo:40.7,-74.0")}, falseResult},
 	{"has_state", dmy, []types.XValue{ERROR}, ERROR},
 
 	{"has_district", dmy, []types.XValue{xs("Gasabo"), xs("kigali")}, result(xs("Rwanda > Kigali City > Gasabo"))},
 	{"has_district", dmy, []types.XValue{xs("I live in gasabo"), xs("kigali")}, result(xs("Rwanda > Kigali City > Gasabo"))},
 	{"has_district", dmy, []types.XValue{xs("Gasabo")}, result(xs("Rwanda > Kigali City > Gasabo"))},
 	{"has_district", dmy, []types.XValue{xs("xyz"), xs("kigali")}, falseResult},
+	{"has_district", dmy, []types.XValue{xs("geo:-1.93,30.1"), xs("kigali")}, result(xs("Rwanda > Kigali City > Gasabo"))},
+	{"has_district", dmy, []types.XValue{xs("geo:-1.93,30.1")}, result(xs("Rwanda > Kigali City > Gasabo"))},
+	{"has_district", dmy, []types.XValue{xs("geo:-1.93,30.1"), xs("Québec")}, falseResult},
+	{"has_district", dmy, []types.XValue{xs("geo:40.7,-74.0")}, falseResult},
 	{"has_district", dmy, []types.XValue{ERROR}, ERROR},
 
 	{"has_ward", dmy, []types.XValue{xs("Gisozi"), xs("kigali"), xs("Gasabo")}, result(xs("Rwanda > Kigali City > Gasabo > Gisozi"))},
 	{"has_ward", dmy, []types.XValue{xs("I live in gisozi"), xs("kigali"), xs("Gasabo")}, result(xs("Rwanda > Kigali City > Gasabo > Gisozi"))},
 	{"has_ward", dmy, []types.XValue{xs("Gisozi")}, result(xs("Rwanda > Kigali City > Gasabo > Gisozi"))},
 	{"has_ward", dmy, []types.XValue{xs("xyz"), xs("kigali"), xs("Gasabo")}, falseResult},
+	{"has_ward", dmy, []types.XValue{xs("geo:-1.93,30.1"), xs("kigali"), xs("Gasabo")}, result(xs("Rwanda > Kigali City > Gasabo > Gisozi"))},
+	{"has_ward", dmy, []types.XValue{xs("geo:-1.93,30.1")}, result(xs("Rwanda > Kigali City > Gasabo > Gisozi"))},
+	{"has_ward", dmy, []types.XValue{xs("geo:-1.93,30.1"), xs("kigali"), xs("Nyarugenge")}, falseResult},
+	{"has_ward", dmy, []types.XValue{xs("geo:-1.99,30.2")}, falseResult},
 	{"has_ward", dmy, []types.XValue{ERROR}, ERROR},
 
 	{
@@ -563,6 +667,35 @@ func TestTests(t *testing.T) {
 	}
 }
 
+func TestSignatures(t *testing.T) {
+	env := envs.NewBuilder().Build()
+	args := func(n int) []types.XValue {
+		a := make([]types.XValue, n)
+		for i := range a {
+			a[i] = types.NewXText("x")
+		}
+		return a
+	}
+
+	for name, fn := range cases.XTESTS {
+		sig := functions.LookupSignature(name)
+		if assert.NotNil(t, sig, "missing signature for test %s", name) {
+			// check the signature doesn't allow fewer or more arguments than the test accepts
+			if sig.MinArgs > 0 {
+				assert.True(t, types.IsXError(fn.Call(env, args(sig.MinArgs-1))), "expected error for too few arguments to %s", name)
+			}
+			if sig.MaxArgs >= 0 {
+				assert.True(t, types.IsXError(fn.Call(env, args(sig.MaxArgs+1))), "expected error for too many arguments to %s", name)
+			}
+			for _, n := range []int{sig.MinArgs, max(sig.MinArgs, sig.MaxArgs)} {
+				if xerr, isErr := fn.Call(env, args(n)).(*types.XError); isErr {
+					assert.NotContains(t, xerr.Error(), "argument(s)", "unexpected error for %d arguments to %s", n, name)
+				}
+			}
+		}
+	}
+}
+
 func TestEvaluateTemplate(t *testing.T) {
 	ctx := types.NewXObject(map[string]types.XValue{
 		"int1":   types.NewXNumberFromInt(1),
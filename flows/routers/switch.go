@@ -13,6 +13,7 @@ import (
 	"github.com/nyaruka/goflow/flows/events"
 	"github.com/nyaruka/goflow/flows/inspect"
 	"github.com/nyaruka/goflow/flows/routers/cases"
+	"github.com/nyaruka/goflow/flows/routers/waits"
 	"github.com/nyaruka/goflow/utils"
 )
 
@@ -144,12 +145,40 @@ func (r *SwitchRouter) Route(run flows.Run, step flows.Step, log flows.EventCall
 
 		match = value.Native()
 		categoryUUID = r.defaultCategoryUUID
+
+		// if our wait can reprompt, do that until the contact runs out of attempts
+		if reprompt := r.reprompt(); reprompt != nil {
+			if !reprompt.Exhausted(run, step) {
+				r.wait.(*waits.MsgWait).Rewait(run, log)
+				return "", operandAsStr, nil
+			}
+
+			categoryUUID = reprompt.CategoryUUID
+		}
+	}
+
+	// record how many attempts it took the contact to get here
+	if reprompt := r.reprompt(); reprompt != nil {
+		extra = withAttempts(extra, reprompt.Attempts(run, step))
 	}
 
 	exit, err := r.routeToCategory(run, step, categoryUUID, match, operandAsStr, extra, log)
 	return exit, operandAsStr, err
 }
 
+// adds the number of attempts to the given result extra
+func withAttempts(extra *types.XObject, attempts int) *types.XObject {
+	properties := map[string]types.XValue{}
+	if extra != nil {
+		for _, p := range extra.Properties() {
+			properties[p], _ = extra.Get(p)
+		}
+	}
+	properties["attempts"] = types.NewXNumberFromInt(attempts)
+
+	return types.NewXObject(properties)
+}
+
 func (r *SwitchRouter) matchCase(run flows.Run, step flows.Step, operand types.XValue, log flows.EventCallback) (string, flows.CategoryUUID, *types.XObject, error) {
 	for _, c := range r.cases {
 		test := strings.ToLower(c.Type)
@@ -215,6 +244,8 @@ func (r *SwitchRouter) EnumerateTemplates(localization flows.Localization, inclu
 	include(i18n.NilLanguage, r.operand)
 
 	inspect.Templates(r.cases, localization, include)
+
+	r.baseRouter.EnumerateTemplates(localization, include)
 }
 
 // EnumerateDependencies enumerates all dependencies on this object and its children
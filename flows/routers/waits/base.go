@@ -3,10 +3,15 @@ package waits
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/nyaruka/gocommon/dates"
+	"github.com/nyaruka/gocommon/i18n"
+	"github.com/nyaruka/gocommon/urns"
+	"github.com/nyaruka/goflow/assets"
 	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/events"
 	"github.com/nyaruka/goflow/utils"
 )
 
@@ -63,6 +68,46 @@ func (w *baseWait) expiresOn(run flows.Run) *time.Time {
 	return nil
 }
 
+// sends a localized message to the contact from a wait, e.g. a reprompt
+func sendMsg(run flows.Run, localizable flows.Localizable, key, text string, log flows.EventCallback) {
+	localizedText, lang := run.GetText(localizable.LocalizationUUID(), key, text)
+	evaluatedText, _ := run.EvaluateTemplate(localizedText, log)
+	evaluatedText = strings.TrimSpace(evaluatedText)
+
+	if evaluatedText == "" {
+		log(events.NewErrorf("%s text evaluated to empty string, skipping", key))
+		return
+	}
+
+	locale := i18n.NewLocale(lang, run.Session().MergedEnvironment().DefaultCountry())
+
+	// in a voice flow the message is spoken on the current call
+	if run.Flow().Type() == flows.FlowTypeVoice {
+		call := run.Session().Trigger().Call()
+		log(events.NewIVRCreated(flows.NewIVRMsgOut(call.URN(), call.Channel(), evaluatedText, "", locale)))
+		return
+	}
+
+	content := &flows.MsgContent{Text: evaluatedText}
+
+	unsendableReason := flows.NilUnsendableReason
+	if run.Contact().Status() != flows.ContactStatusActive {
+		unsendableReason = flows.UnsendableReasonContactStatus
+	}
+
+	var msg *flows.MsgOut
+	destinations := run.Contact().ResolveDestinations(false)
+	if len(destinations) > 0 {
+		dest := destinations[0]
+		channelRef := assets.NewChannelReference(dest.Channel.UUID(), dest.Channel.Name())
+		msg = flows.NewMsgOut(dest.URN.URN(), channelRef, content, nil, flows.NilMsgTopic, locale, unsendableReason)
+	} else {
+		msg = flows.NewMsgOut(urns.NilURN, nil, content, nil, flows.NilMsgTopic, locale, flows.UnsendableReasonNoDestination)
+	}
+
+	log(events.NewMsgCreated(msg))
+}
+
 //------------------------------------------------------------------------------------------
 // JSON Encoding / Decoding
 //------------------------------------------------------------------------------------------
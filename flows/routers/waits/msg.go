@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/nyaruka/gocommon/i18n"
 	"github.com/nyaruka/gocommon/jsonx"
+	"github.com/nyaruka/gocommon/uuids"
 	"github.com/nyaruka/goflow/flows"
 	"github.com/nyaruka/goflow/flows/events"
+	"github.com/nyaruka/goflow/flows/inspect"
 	"github.com/nyaruka/goflow/flows/resumes"
 	"github.com/nyaruka/goflow/flows/routers/waits/hints"
 	"github.com/nyaruka/goflow/flows/triggers"
@@ -29,19 +32,33 @@ type MsgWait struct {
 	// an attachment of that type. In the case of other flow types this should be considered only a hint to the channel,
 	// which may or may not support prompting the contact for media of that type.
 	hint flows.Hint
+
+	// Message waits can re-prompt the contact when their reply only matches the default category of the router
+	reprompt *Reprompt
+
+	// Message waits can remind the contact to reply, without leaving the wait
+	reminders []*Reminder
 }
 
 // NewMsgWait creates a new message wait
-func NewMsgWait(timeout *Timeout, hint flows.Hint) *MsgWait {
+func NewMsgWait(timeout *Timeout, hint flows.Hint, reprompt *Reprompt, reminders []*Reminder) *MsgWait {
 	return &MsgWait{
-		baseWait: newBaseWait(TypeMsg, timeout),
-		hint:     hint,
+		baseWait:  newBaseWait(TypeMsg, timeout),
+		hint:      hint,
+		reprompt:  reprompt,
+		reminders: reminders,
 	}
 }
 
 // Hint returns the hint (optional)
 func (w *MsgWait) Hint() flows.Hint { return w.hint }
 
+// Reprompt returns the reprompt configuration (optional)
+func (w *MsgWait) Reprompt() *Reprompt { return w.reprompt }
+
+// Reminders returns the reminders (optional)
+func (w *MsgWait) Reminders() []*Reminder { return w.reminders }
+
 // AllowedFlowTypes returns the flow types which this wait is allowed to occur in
 func (w *MsgWait) AllowedFlowTypes() []flows.FlowType {
 	return []flows.FlowType{flows.FlowTypeMessaging, flows.FlowTypeMessagingOffline, flows.FlowTypeVoice}
@@ -56,15 +73,46 @@ func (w *MsgWait) Begin(run flows.Run, log flows.EventCallback) bool {
 		return false
 	}
 
+	w.begin(run, log)
+
+	return true
+}
+
+// Rewait sends the reprompt message and begins waiting again
+func (w *MsgWait) Rewait(run flows.Run, log flows.EventCallback) {
+	sendMsg(run, w.reprompt, "text", w.reprompt.Text, log)
+
+	w.begin(run, log)
+
+	run.SetStatus(flows.RunStatusWaiting)
+}
+
+// Remind sends the message of the given reminder and continues waiting
+func (w *MsgWait) Remind(run flows.Run, reminderUUID uuids.UUID, log flows.EventCallback) {
+	reminder := w.findReminder(reminderUUID)
+
+	sendMsg(run, reminder, "text", reminder.Text, log)
+
+	run.SetStatus(flows.RunStatusWaiting)
+}
+
+func (w *MsgWait) findReminder(uuid uuids.UUID) *Reminder {
+	for _, r := range w.reminders {
+		if r.UUID == uuid {
+			return r
+		}
+	}
+	return nil
+}
+
+func (w *MsgWait) begin(run flows.Run, log flows.EventCallback) {
 	var timeoutSeconds *int
 	if w.timeout != nil {
 		seconds := w.timeout.Seconds()
 		timeoutSeconds = &seconds
 	}
 
-	log(events.NewMsgWait(timeoutSeconds, w.expiresOn(run), w.hint))
-
-	return true
+	log(events.NewMsgWait(timeoutSeconds, w.expiresOn(run), w.hint, reminderSchedule(w.reminders)))
 }
 
 // Accept returns whether this wait accepts the given resume
@@ -74,10 +122,28 @@ func (w *MsgWait) Accepts(resume flows.Resume) bool {
 		return true
 	case resumes.TypeWaitTimeout:
 		return w.timeout != nil
+	case resumes.TypeReminder:
+		return w.findReminder(resume.(*resumes.ReminderResume).ReminderUUID()) != nil
 	}
 	return false
 }
 
+// EnumerateTemplates enumerates all expressions on this object
+func (w *MsgWait) EnumerateTemplates(localization flows.Localization, include func(i18n.Language, string)) {
+	if w.reprompt != nil {
+		inspect.Templates(w.reprompt, localization, include)
+	}
+	inspect.Templates(w.reminders, localization, include)
+}
+
+// EnumerateLocalizables enumerates all the localizable text on this object
+func (w *MsgWait) EnumerateLocalizables(include func(uuids.UUID, string, []string, func([]string))) {
+	if w.reprompt != nil {
+		inspect.LocalizableText(w.reprompt, include)
+	}
+	inspect.LocalizableText(w.reminders, include)
+}
+
 var _ flows.Wait = (*MsgWait)(nil)
 
 //------------------------------------------------------------------------------------------
@@ -87,7 +153,9 @@ var _ flows.Wait = (*MsgWait)(nil)
 type msgWaitEnvelope struct {
 	baseWaitEnvelope
 
-	Hint json.RawMessage `json:"hint,omitempty"`
+	Hint      json.RawMessage `json:"hint,omitempty"`
+	Reprompt  *Reprompt       `json:"reprompt,omitempty"  validate:"omitempty"`
+	Reminders []*Reminder     `json:"reminders,omitempty" validate:"omitempty,dive"`
 }
 
 func readMsgWait(data json.RawMessage) (flows.Wait, error) {
@@ -96,7 +164,16 @@ func readMsgWait(data json.RawMessage) (flows.Wait, error) {
 		return nil, err
 	}
 
-	w := &MsgWait{}
+	w := &MsgWait{reprompt: e.Reprompt, reminders: e.Reminders}
+
+	// reminders are only useful if they happen before the wait times out
+	if e.Timeout != nil {
+		for _, r := range e.Reminders {
+			if r.DelaySeconds >= e.Timeout.Seconds() {
+				return nil, fmt.Errorf("reminder delay of %d seconds isn't less than timeout of %d seconds", r.DelaySeconds, e.Timeout.Seconds())
+			}
+		}
+	}
 
 	var err error
 	if e.Hint != nil {
@@ -110,7 +187,7 @@ func readMsgWait(data json.RawMessage) (flows.Wait, error) {
 
 // MarshalJSON marshals this wait into JSON
 func (w *MsgWait) MarshalJSON() ([]byte, error) {
-	e := &msgWaitEnvelope{}
+	e := &msgWaitEnvelope{Reprompt: w.reprompt, Reminders: w.reminders}
 
 	if err := w.marshal(&e.baseWaitEnvelope); err != nil {
 		return nil, err
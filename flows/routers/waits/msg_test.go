@@ -4,7 +4,10 @@ import (
 	"testing"
 
 	"github.com/nyaruka/gocommon/jsonx"
+	"github.com/nyaruka/gocommon/uuids"
+	"github.com/nyaruka/goflow/envs"
 	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/events"
 	"github.com/nyaruka/goflow/flows/resumes"
 	"github.com/nyaruka/goflow/flows/routers/waits"
 	"github.com/nyaruka/goflow/flows/routers/waits/hints"
@@ -57,7 +60,7 @@ func TestMsgWait(t *testing.T) {
 	run := session.Runs()[0]
 
 	// no timeout or media
-	wait := waits.NewMsgWait(nil, nil)
+	wait := waits.NewMsgWait(nil, nil, nil, nil)
 	marshaled := jsonx.MustMarshal(wait)
 	assert.Equal(t, `{"type":"msg"}`, string(marshaled))
 
@@ -68,6 +71,8 @@ func TestMsgWait(t *testing.T) {
 	wait = waits.NewMsgWait(
 		waits.NewTimeout(5, flows.CategoryUUID("63fca57d-5ef6-4afd-9bcd-7bdcf653cea8")),
 		hints.NewImageHint(),
+		nil,
+		nil,
 	)
 
 	// test marsalling definition wait
@@ -110,3 +115,147 @@ func TestMsgWaitSkipIfInitial(t *testing.T) {
 	assert.Equal(t, 1, len(sprint.Events()))
 	assert.Equal(t, "msg_received", sprint.Events()[0].Type())
 }
+
+var repromptJSON = `{
+	"flows": [
+		{
+			"uuid": "615b8a0f-588c-4d20-a05f-363b0b4ce6f4",
+			"name": "Reprompt",
+			"spec_version": "13.0",
+			"language": "eng",
+			"type": "messaging",
+			"nodes": [
+				{
+					"uuid": "46d51f50-58de-49da-8d13-dadbf322685d",
+					"router": {
+						"type": "switch",
+						"wait": {
+							"type": "msg",
+							"reprompt": {
+								"uuid": "c8c3a8f1-5d5b-4bd4-a0a3-6c9b5e1d0e45",
+								"text": "Sorry, please reply with a number",
+								"max_attempts": 3,
+								"category_uuid": "e57e1bd4-b3ba-4a5c-a61a-ec9a9a5c7e6b"
+							}
+						},
+						"result_name": "Age",
+						"categories": [
+							{
+								"uuid": "c82e161f-fa2d-4e7d-a338-c27f6c349445",
+								"name": "Number",
+								"exit_uuid": "598ae7a5-2f81-48f1-afac-595262514aa1"
+							},
+							{
+								"uuid": "a9e3fc50-2f6f-4a3f-8d1d-1c0d1f3a4b5c",
+								"name": "Other",
+								"exit_uuid": "598ae7a5-2f81-48f1-afac-595262514aa1"
+							},
+							{
+								"uuid": "e57e1bd4-b3ba-4a5c-a61a-ec9a9a5c7e6b",
+								"name": "Exhausted",
+								"exit_uuid": "598ae7a5-2f81-48f1-afac-595262514aa1"
+							}
+						],
+						"operand": "@input.text",
+						"cases": [
+							{
+								"uuid": "9c0b6a1e-4a8b-4d8d-8e3b-0b0b5f6d3c2a",
+								"type": "has_number",
+								"category_uuid": "c82e161f-fa2d-4e7d-a338-c27f6c349445"
+							}
+						],
+						"default_category_uuid": "a9e3fc50-2f6f-4a3f-8d1d-1c0d1f3a4b5c"
+					},
+					"exits": [
+						{
+							"uuid": "598ae7a5-2f81-48f1-afac-595262514aa1"
+						}
+					]
+				}
+			]
+		}
+	]
+}`
+
+func TestMsgWaitReprompt(t *testing.T) {
+	reply := func(session flows.Session, text string) flows.Sprint {
+		msg := flows.NewMsgIn(flows.MsgUUID(uuids.New()), "tel:+12065551212", nil, text, nil)
+		sprint, err := session.Resume(resumes.NewMsg(nil, nil, msg))
+		require.NoError(t, err)
+		return sprint
+	}
+
+	// contact eventually replies with a number
+	_, session, _ := test.NewSessionBuilder().WithAssetsJSON([]byte(repromptJSON)).WithFlow("615b8a0f-588c-4d20-a05f-363b0b4ce6f4").MustBuild()
+
+	sprint := reply(session, "what?")
+	assert.Equal(t, flows.SessionStatusWaiting, session.Status())
+	assert.Equal(t, []string{"msg_received", "msg_created", "msg_wait"}, eventTypes(sprint.Events()))
+	assert.Equal(t, "Sorry, please reply with a number", sprint.Events()[1].(*events.MsgCreatedEvent).Msg.Text())
+
+	sprint = reply(session, "25")
+	assert.Equal(t, flows.SessionStatusCompleted, session.Status())
+	assert.Equal(t, []string{"msg_received", "run_result_changed"}, eventTypes(sprint.Events()))
+
+	result := session.Runs()[0].Results().Get("age")
+	assert.Equal(t, "Number", result.Category)
+	assert.JSONEq(t, `{"attempts": 2}`, string(result.Extra))
+
+	// contact never replies with a number
+	_, session, _ = test.NewSessionBuilder().WithAssetsJSON([]byte(repromptJSON)).WithFlow("615b8a0f-588c-4d20-a05f-363b0b4ce6f4").MustBuild()
+
+	reply(session, "what?")
+	reply(session, "huh?")
+	assert.Equal(t, flows.SessionStatusWaiting, session.Status())
+
+	sprint = reply(session, "no idea")
+	assert.Equal(t, flows.SessionStatusCompleted, session.Status())
+	assert.Equal(t, []string{"msg_received", "run_result_changed"}, eventTypes(sprint.Events()))
+
+	result = session.Runs()[0].Results().Get("age")
+	assert.Equal(t, "Exhausted", result.Category)
+	assert.JSONEq(t, `{"attempts": 3}`, string(result.Extra))
+
+	// the whole thing happens at a single step
+	assert.Equal(t, 1, len(session.Runs()[0].Path()))
+}
+
+func eventTypes(evts []flows.Event) []string {
+	types := make([]string, len(evts))
+	for i := range evts {
+		types[i] = evts[i].Type()
+	}
+	return types
+}
+
+func TestMsgWaitReminders(t *testing.T) {
+	// reminders must happen before the timeout
+	_, err := waits.ReadWait([]byte(`{
+		"type": "msg",
+		"timeout": {"seconds": 600, "category_uuid": "63fca57d-5ef6-4afd-9bcd-7bdcf653cea8"},
+		"reminders": [{"uuid": "4d5f4a4f-9b0a-4f3a-8d8a-6d3c3b7f1e2a", "delay_seconds": 600, "text": "Hello?"}]
+	}`))
+	assert.EqualError(t, err, "reminder delay of 600 seconds isn't less than timeout of 600 seconds")
+
+	// and have a delay and text
+	_, err = waits.ReadWait([]byte(`{"type": "msg", "reminders": [{"uuid": "4d5f4a4f-9b0a-4f3a-8d8a-6d3c3b7f1e2a"}]}`))
+	assert.EqualError(t, err, "field 'reminders[0].delay_seconds' is required, field 'reminders[0].text' is required")
+
+	wait, err := waits.ReadWait([]byte(`{"type": "msg", "reminders": [{"uuid": "4d5f4a4f-9b0a-4f3a-8d8a-6d3c3b7f1e2a", "delay_seconds": 300, "text": "Hello?"}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(wait.(*waits.MsgWait).Reminders()))
+	assert.Equal(t, `{"type":"msg","reminders":[{"uuid":"4d5f4a4f-9b0a-4f3a-8d8a-6d3c3b7f1e2a","delay_seconds":300,"text":"Hello?"}]}`, string(jsonx.MustMarshal(wait)))
+
+	// only accepts reminder resumes for its own reminders
+	assert.True(t, wait.Accepts(resumes.NewReminder(nil, nil, "4d5f4a4f-9b0a-4f3a-8d8a-6d3c3b7f1e2a")))
+	assert.False(t, wait.Accepts(resumes.NewReminder(nil, nil, "ad2b3c5b-4df0-47cc-a3c0-6b9e0c0f7e1a")))
+
+	// reminder schedule is included in the wait event
+	session, _, err := test.CreateTestSession("", envs.RedactionPolicyNone)
+	require.NoError(t, err)
+
+	log := test.NewEventLog()
+	assert.True(t, wait.Begin(session.Runs()[0], log.Log))
+	assert.Equal(t, 1, len(log.Events))
+	assert.Equal(t, []*events.Reminder{{UUID: "4d5f4a4f-9b0a-4f3a-8d8a-6d3c3b7f1e2a", DelaySeconds: 300}}, log.Events[0].(*events.MsgWaitEvent).Reminders)
+}
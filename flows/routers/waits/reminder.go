@@ -0,0 +1,35 @@
+package waits
+
+import (
+	"github.com/nyaruka/gocommon/uuids"
+	"github.com/nyaruka/goflow/flows/events"
+)
+
+// Reminder is a message which is sent to the contact if they haven't replied to a message wait after a delay. Sending
+// a reminder doesn't end the wait.
+type Reminder struct {
+	UUID         uuids.UUID `json:"uuid"          validate:"required,uuid4"`
+	DelaySeconds int        `json:"delay_seconds" validate:"required,min=1"`
+	Text         string     `json:"text"          validate:"required" engine:"localized,evaluated"`
+}
+
+// NewReminder creates a new reminder
+func NewReminder(uuid uuids.UUID, delaySeconds int, text string) *Reminder {
+	return &Reminder{UUID: uuid, DelaySeconds: delaySeconds, Text: text}
+}
+
+// LocalizationUUID gets the UUID which identifies this object for localization
+func (r *Reminder) LocalizationUUID() uuids.UUID { return r.UUID }
+
+// converts the given reminders into the schedule included in wait events
+func reminderSchedule(reminders []*Reminder) []*events.Reminder {
+	if len(reminders) == 0 {
+		return nil
+	}
+
+	schedule := make([]*events.Reminder, len(reminders))
+	for i, r := range reminders {
+		schedule[i] = &events.Reminder{UUID: r.UUID, DelaySeconds: r.DelaySeconds}
+	}
+	return schedule
+}
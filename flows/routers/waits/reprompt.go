@@ -0,0 +1,41 @@
+package waits
+
+import (
+	"github.com/nyaruka/gocommon/uuids"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/events"
+)
+
+// Reprompt configures a message wait to re-send a prompt and wait again when the router can't match the contact's
+// reply to anything but its default category. Once the contact has made the maximum number of attempts, the router
+// takes the exhausted category instead.
+type Reprompt struct {
+	UUID         uuids.UUID         `json:"uuid"          validate:"required,uuid4"`
+	Text         string             `json:"text"          validate:"required" engine:"localized,evaluated"`
+	MaxAttempts  int                `json:"max_attempts"  validate:"required,min=1"`
+	CategoryUUID flows.CategoryUUID `json:"category_uuid" validate:"required,uuid4"`
+}
+
+// NewReprompt creates a new reprompt
+func NewReprompt(uuid uuids.UUID, text string, maxAttempts int, categoryUUID flows.CategoryUUID) *Reprompt {
+	return &Reprompt{UUID: uuid, Text: text, MaxAttempts: maxAttempts, CategoryUUID: categoryUUID}
+}
+
+// LocalizationUUID gets the UUID which identifies this object for localization
+func (r *Reprompt) LocalizationUUID() uuids.UUID { return r.UUID }
+
+// Attempts returns the number of replies the contact has made at the given step
+func (r *Reprompt) Attempts(run flows.Run, step flows.Step) int {
+	attempts := 0
+	for _, e := range run.Events() {
+		if e.StepUUID() == step.UUID() && e.Type() == events.TypeMsgReceived {
+			attempts++
+		}
+	}
+	return attempts
+}
+
+// Exhausted returns whether the contact has used up all their attempts at the given step
+func (r *Reprompt) Exhausted(run flows.Run, step flows.Step) bool {
+	return r.Attempts(run, step) >= r.MaxAttempts
+}
@@ -0,0 +1,156 @@
+package waits
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nyaruka/gocommon/dates"
+	"github.com/nyaruka/gocommon/i18n"
+	"github.com/nyaruka/gocommon/jsonx"
+	"github.com/nyaruka/goflow/excellent/types"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/events"
+	"github.com/nyaruka/goflow/flows/resumes"
+	"github.com/nyaruka/goflow/utils"
+)
+
+func init() {
+	registerType(TypeTimer, readTimerWait)
+	utils.RegisterStructValidator(timerWaitValidation, timerWaitEnvelope{})
+}
+
+// TypeTimer is the type of our timer wait
+const TypeTimer string = "timer"
+
+// TimerWait is a wait which pauses the flow until a point in time which is either evaluated from an expression or
+// is a fixed delay from when the wait begins. Unlike message wait timeouts, a timer can't be interrupted by the
+// contact replying.
+type TimerWait struct {
+	baseWait
+
+	until        string
+	delaySeconds int
+}
+
+// NewTimerWait creates a new timer wait which will resume at the datetime evaluated from until, or if that is empty,
+// after the given number of seconds
+func NewTimerWait(until string, delaySeconds int) *TimerWait {
+	return &TimerWait{
+		baseWait:     newBaseWait(TypeTimer, nil),
+		until:        until,
+		delaySeconds: delaySeconds,
+	}
+}
+
+// Until returns the expression which evaluates to when this wait should resume
+func (w *TimerWait) Until() string { return w.until }
+
+// Delay returns the fixed delay after which this wait should resume
+func (w *TimerWait) Delay() time.Duration { return time.Second * time.Duration(w.delaySeconds) }
+
+// AllowedFlowTypes returns the flow types which this wait is allowed to occur in
+func (w *TimerWait) AllowedFlowTypes() []flows.FlowType {
+	return []flows.FlowType{flows.FlowTypeMessaging}
+}
+
+// Begin beings waiting at this wait
+func (w *TimerWait) Begin(run flows.Run, log flows.EventCallback) bool {
+	var resumeOn time.Time
+
+	if w.until != "" {
+		env := run.Session().MergedEnvironment()
+		value, _ := run.EvaluateTemplateValue(w.until, log)
+
+		until, xerr := types.ToXDateTimeWithTimeFill(env, value)
+		if xerr != nil {
+			log(events.NewError(xerr))
+			return false
+		}
+
+		resumeOn = until.Native()
+	} else {
+		resumeOn = dates.Now().Add(w.Delay())
+	}
+
+	// a timer in the past has already fired so don't wait at all
+	if !resumeOn.After(dates.Now()) {
+		return false
+	}
+
+	// the run shouldn't expire before the timer has had a chance to resume it
+	expiresOn := w.expiresOn(run)
+	if expiresOn != nil && expiresOn.Before(resumeOn) {
+		dt := resumeOn.Add(time.Minute)
+		expiresOn = &dt
+	}
+
+	log(events.NewTimerWait(resumeOn.UTC(), expiresOn))
+
+	return true
+}
+
+// Accepts returns whether this wait accepts the given resume
+func (w *TimerWait) Accepts(resume flows.Resume) bool {
+	switch resume.Type() {
+	case resumes.TypeTimer, resumes.TypeWaitTimeout, resumes.TypeRunExpiration:
+		return true
+	}
+	return false
+}
+
+// EnumerateTemplates enumerates all expressions on this object
+func (w *TimerWait) EnumerateTemplates(localization flows.Localization, include func(i18n.Language, string)) {
+	if w.until != "" {
+		include(i18n.NilLanguage, w.until)
+	}
+}
+
+var _ flows.Wait = (*TimerWait)(nil)
+
+//------------------------------------------------------------------------------------------
+// JSON Encoding / Decoding
+//------------------------------------------------------------------------------------------
+
+type timerWaitEnvelope struct {
+	baseWaitEnvelope
+
+	Until        string `json:"until,omitempty"`
+	DelaySeconds int    `json:"delay_seconds,omitempty" validate:"min=0"`
+}
+
+// validates that a timer wait has either an until expression or a delay but not both
+func timerWaitValidation(sl validator.StructLevel) {
+	e := sl.Current().Interface().(timerWaitEnvelope)
+	if (e.Until == "") == (e.DelaySeconds == 0) {
+		sl.ReportError(e.Until, "until", "Until", "mutually_exclusive", "delay_seconds")
+	}
+}
+
+func readTimerWait(data json.RawMessage) (flows.Wait, error) {
+	e := &timerWaitEnvelope{}
+	if err := utils.UnmarshalAndValidate(data, e); err != nil {
+		return nil, err
+	}
+
+	w := &TimerWait{
+		until:        e.Until,
+		delaySeconds: e.DelaySeconds,
+	}
+
+	return w, w.unmarshal(&e.baseWaitEnvelope)
+}
+
+// MarshalJSON marshals this wait into JSON
+func (w *TimerWait) MarshalJSON() ([]byte, error) {
+	e := &timerWaitEnvelope{
+		Until:        w.until,
+		DelaySeconds: w.delaySeconds,
+	}
+
+	if err := w.marshal(&e.baseWaitEnvelope); err != nil {
+		return nil, err
+	}
+
+	return jsonx.Marshal(e)
+}
@@ -0,0 +1,83 @@
+package waits_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nyaruka/gocommon/dates"
+	"github.com/nyaruka/gocommon/i18n"
+	"github.com/nyaruka/gocommon/jsonx"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows/events"
+	"github.com/nyaruka/goflow/flows/resumes"
+	"github.com/nyaruka/goflow/flows/routers/waits"
+	"github.com/nyaruka/goflow/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimerWait(t *testing.T) {
+	defer dates.SetNowSource(dates.DefaultNowSource)
+	dates.SetNowSource(dates.NewFixedNowSource(time.Date(2018, 10, 18, 14, 20, 30, 0, time.UTC)))
+
+	session, _, err := test.CreateTestSession("", envs.RedactionPolicyNone)
+	require.NoError(t, err)
+	run := session.Runs()[0]
+
+	// one of until or delay required
+	_, err = waits.ReadWait([]byte(`{"type": "timer"}`))
+	assert.EqualError(t, err, "field 'until' is mutually exclusive with 'delay_seconds'")
+
+	// but not both
+	_, err = waits.ReadWait([]byte(`{"type": "timer", "until": "@(now())", "delay_seconds": 60}`))
+	assert.EqualError(t, err, "field 'until' is mutually exclusive with 'delay_seconds'")
+
+	// read a timer with a fixed delay
+	wait, err := waits.ReadWait([]byte(`{"type": "timer", "delay_seconds": 3600}`))
+	assert.NoError(t, err)
+	assert.Equal(t, waits.TypeTimer, wait.Type())
+	assert.Equal(t, time.Hour, wait.(*waits.TimerWait).Delay())
+	assert.Equal(t, `{"type":"timer","delay_seconds":3600}`, string(jsonx.MustMarshal(wait)))
+
+	log := test.NewEventLog()
+	assert.True(t, wait.Begin(run, log.Log))
+	assert.Equal(t, 1, len(log.Events))
+	assert.Equal(t, "timer_wait", log.Events[0].Type())
+	assert.Equal(t, time.Date(2018, 10, 18, 15, 20, 30, 0, time.UTC), log.Events[0].(*events.TimerWaitEvent).ResumeOn)
+
+	// only accepts timer, timeout and expiration resumes
+	assert.True(t, wait.Accepts(resumes.NewTimer(nil, nil)))
+	assert.True(t, wait.Accepts(resumes.NewWaitTimeout(nil, nil)))
+	assert.True(t, wait.Accepts(resumes.NewRunExpiration(nil, nil)))
+	assert.False(t, wait.Accepts(resumes.NewMsg(nil, nil, nil)))
+
+	// read a timer with an until expression
+	wait = waits.NewTimerWait(`@(datetime_add(now(), 3, "D"))`, 0)
+	assert.Equal(t, `{"type":"timer","until":"@(datetime_add(now(), 3, \"D\"))"}`, string(jsonx.MustMarshal(wait)))
+
+	log = test.NewEventLog()
+	assert.True(t, wait.Begin(run, log.Log))
+	assert.Equal(t, 1, len(log.Events))
+	assert.Equal(t, time.Date(2018, 10, 21, 14, 20, 30, 0, time.UTC), log.Events[0].(*events.TimerWaitEvent).ResumeOn)
+
+	// expression is included in templates
+	var templates []string
+	wait.(*waits.TimerWait).EnumerateTemplates(nil, func(l i18n.Language, t string) { templates = append(templates, t) })
+	assert.Equal(t, []string{`@(datetime_add(now(), 3, "D"))`}, templates)
+
+	// a datetime in the past means we don't wait at all
+	wait = waits.NewTimerWait(`@(datetime_add(now(), -1, "D"))`, 0)
+
+	log = test.NewEventLog()
+	assert.False(t, wait.Begin(run, log.Log))
+	assert.Equal(t, 0, len(log.Events))
+
+	// as does an expression which isn't a datetime
+	wait = waits.NewTimerWait(`@("foo")`, 0)
+
+	log = test.NewEventLog()
+	assert.False(t, wait.Begin(run, log.Log))
+	assert.Equal(t, 1, len(log.Events))
+	assert.Equal(t, "error", log.Events[0].Type())
+}
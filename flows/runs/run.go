@@ -307,9 +307,19 @@ func (r *run) nodeContext(env envs.Environment) map[string]types.XValue {
 func (r *run) EvaluateTemplateValue(template string, log flows.EventCallback) (types.XValue, bool) {
 	ctx := types.NewXObject(r.RootContext(r.session.MergedEnvironment()))
 
-	value, warnings, err := r.session.Engine().Evaluator().TemplateValue(r.session.MergedEnvironment(), ctx, template)
+	var value types.XValue
+	var warnings []string
+	var traces []*excellent.TraceNode
+	var err error
+
+	if r.session.Engine().Options().TraceErrors {
+		value, warnings, traces, err = r.session.Engine().Evaluator().TraceTemplateValue(r.session.MergedEnvironment(), ctx, template)
+	} else {
+		value, warnings, err = r.session.Engine().Evaluator().TemplateValue(r.session.MergedEnvironment(), ctx, template)
+	}
+
 	if err != nil {
-		log(events.NewError(err))
+		log(events.NewErrorWithTrace(err, traces))
 	}
 	for _, w := range warnings {
 		log(events.NewWarning(w))
@@ -318,12 +328,22 @@ func (r *run) EvaluateTemplateValue(template string, log flows.EventCallback) (t
 }
 
 // EvaluateTemplateText evaluates the given template as text in the context of this run
-func (r *run) EvaluateTemplateText(template string, escaping excellent.Escaping, truncate bool, log flows.EventCallback) (string, bool) {
+func (r *run) EvaluateTemplateText(template string, escaping *excellent.EscapingMode, truncate bool, log flows.EventCallback) (string, bool) {
 	ctx := types.NewXObject(r.RootContext(r.session.MergedEnvironment()))
 
-	value, warnings, err := r.session.Engine().Evaluator().Template(r.session.MergedEnvironment(), ctx, template, escaping)
+	var value string
+	var warnings []string
+	var traces []*excellent.TraceNode
+	var err error
+
+	if r.session.Engine().Options().TraceErrors {
+		value, warnings, traces, err = r.session.Engine().Evaluator().TraceTemplate(r.session.MergedEnvironment(), ctx, template, escaping)
+	} else {
+		value, warnings, err = r.session.Engine().Evaluator().Template(r.session.MergedEnvironment(), ctx, template, escaping)
+	}
+
 	if err != nil {
-		log(events.NewError(err))
+		log(events.NewErrorWithTrace(err, traces))
 	}
 	for _, w := range warnings {
 		log(events.NewWarning(w))
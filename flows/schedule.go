@@ -0,0 +1,167 @@
+package flows
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nyaruka/gocommon/dates"
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/envs"
+)
+
+// how far ahead we'll look for the next opening of a schedule
+const scheduleMaxLookaheadDays = 366
+
+// Schedule represents a set of opening hours such as business hours
+type Schedule struct {
+	assets.Schedule
+
+	timezone *time.Location
+}
+
+// NewSchedule creates a new schedule from the given asset
+func NewSchedule(asset assets.Schedule) *Schedule {
+	var timezone *time.Location
+	if asset.Timezone() != "" {
+		timezone, _ = time.LoadLocation(asset.Timezone())
+	}
+
+	return &Schedule{Schedule: asset, timezone: timezone}
+}
+
+// Asset returns the underlying asset
+func (s *Schedule) Asset() assets.Schedule { return s.Schedule }
+
+// Reference returns a reference to this schedule
+func (s *Schedule) Reference() *assets.ScheduleReference {
+	if s == nil {
+		return nil
+	}
+	return assets.NewScheduleReference(s.UUID(), s.Name())
+}
+
+// Location returns the timezone of this schedule, falling back to the timezone of the given environment if the
+// schedule doesn't have its own
+func (s *Schedule) Location(env envs.Environment) *time.Location {
+	if s.timezone != nil {
+		return s.timezone
+	}
+	return env.Timezone()
+}
+
+// IsOpen returns whether this schedule is open at the given time
+func (s *Schedule) IsOpen(env envs.Environment, t time.Time) bool {
+	t = t.In(s.Location(env))
+	date, tod := dates.ExtractDate(t), dates.ExtractTimeOfDay(t)
+
+	prevDate := dates.ExtractDate(t.AddDate(0, 0, -1))
+
+	for _, interval := range s.Intervals() {
+		start, end := interval.Start(), interval.End()
+
+		if isEndOfDay(end) || start.Compare(end) < 0 {
+			if s.opensOn(interval, date) && tod.Compare(start) >= 0 && (isEndOfDay(end) || tod.Compare(end) < 0) {
+				return true
+			}
+		} else {
+			// interval spans midnight so is open from its start on one day until its end on the next
+			if s.opensOn(interval, date) && tod.Compare(start) >= 0 {
+				return true
+			}
+			if s.opensOn(interval, prevDate) && tod.Compare(end) < 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NextOpen returns the time at or after the given time when this schedule is next open, or nil if it doesn't open
+// again within the next year
+func (s *Schedule) NextOpen(env envs.Environment, t time.Time) *time.Time {
+	if s.IsOpen(env, t) {
+		return &t
+	}
+
+	tz := s.Location(env)
+	t = t.In(tz)
+
+	for d := 0; d <= scheduleMaxLookaheadDays; d++ {
+		date := dates.ExtractDate(time.Date(t.Year(), t.Month(), t.Day()+d, 0, 0, 0, 0, tz))
+
+		var next *time.Time
+		for _, interval := range s.Intervals() {
+			if !s.opensOn(interval, date) {
+				continue
+			}
+
+			start := date.Combine(interval.Start(), tz)
+			if start.After(t) && (next == nil || start.Before(*next)) {
+				next = &start
+			}
+		}
+		if next != nil {
+			return next
+		}
+	}
+
+	return nil
+}
+
+// whether the given interval opens on the given date
+func (s *Schedule) opensOn(interval assets.ScheduleInterval, date dates.Date) bool {
+	return slices.Contains(interval.Days(), date.Weekday()) && !s.isHoliday(date)
+}
+
+func (s *Schedule) isHoliday(date dates.Date) bool {
+	for _, h := range s.Holidays() {
+		if h.Equal(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// an interval which ends at midnight is open until the end of the day
+func isEndOfDay(tod dates.TimeOfDay) bool {
+	return tod.Equal(dates.ZeroTimeOfDay)
+}
+
+var _ assets.Schedule = (*Schedule)(nil)
+var _ envs.Schedule = (*Schedule)(nil)
+
+// ScheduleAssets provides access to all schedule assets
+type ScheduleAssets struct {
+	all    []*Schedule
+	byUUID map[assets.ScheduleUUID]*Schedule
+}
+
+// NewScheduleAssets creates a new set of schedule assets
+func NewScheduleAssets(schedules []assets.Schedule) *ScheduleAssets {
+	s := &ScheduleAssets{
+		byUUID: make(map[assets.ScheduleUUID]*Schedule, len(schedules)),
+	}
+	for _, asset := range schedules {
+		schedule := NewSchedule(asset)
+		s.all = append(s.all, schedule)
+		s.byUUID[schedule.UUID()] = schedule
+	}
+	return s
+}
+
+// Get returns the schedule with the given UUID
+func (s *ScheduleAssets) Get(uuid assets.ScheduleUUID) *Schedule {
+	return s.byUUID[uuid]
+}
+
+// FindByName looks for a schedule with the given name (case-insensitive)
+func (s *ScheduleAssets) FindByName(name string) *Schedule {
+	name = strings.ToLower(name)
+	for _, schedule := range s.all {
+		if strings.ToLower(schedule.Name()) == name {
+			return schedule
+		}
+	}
+	return nil
+}
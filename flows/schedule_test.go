@@ -0,0 +1,113 @@
+package flows_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nyaruka/goflow/assets"
+	"github.com/nyaruka/goflow/assets/static"
+	"github.com/nyaruka/goflow/envs"
+	"github.com/nyaruka/goflow/flows"
+	"github.com/nyaruka/goflow/flows/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedules(t *testing.T) {
+	kgl, _ := time.LoadLocation("Africa/Kigali")
+	nyc, _ := time.LoadLocation("America/New_York")
+	env := envs.NewBuilder().WithTimezone(kgl).Build()
+
+	source, err := static.NewSource([]byte(`{
+		"schedules": [
+			{
+				"uuid": "4b4a7b9c-0a7d-4a65-9a0b-7f3b0f1b0d3c",
+				"name": "Office Hours",
+				"intervals": [
+					{"days": ["mon", "tue", "wed", "thu", "fri"], "start": "09:00", "end": "12:00"},
+					{"days": ["mon", "tue", "wed", "thu", "fri"], "start": "13:00", "end": "17:00"}
+				],
+				"holidays": ["2024-12-25"]
+			},
+			{
+				"uuid": "1e5a4a8f-4bd7-4d84-9a2a-1d7c5e3fc3a8",
+				"name": "Late Shift",
+				"timezone": "America/New_York",
+				"intervals": [
+					{"days": ["sat"], "start": "20:00", "end": "00:00"}
+				]
+			},
+			{
+				"uuid": "6c0e3b6f-2f8e-4a5e-8a3a-3c2e7d9f1b4a",
+				"name": "Night Shift",
+				"intervals": [
+					{"days": ["fri"], "start": "22:00", "end": "06:00"}
+				],
+				"holidays": ["2024-12-20"]
+			},
+			{
+				"uuid": "a9b5f0c5-8b6c-4f5a-9d0c-6a1d7e3c2b1f",
+				"name": "Never",
+				"intervals": []
+			}
+		]
+	}`))
+	require.NoError(t, err)
+
+	sa, err := engine.NewSessionAssets(env, source, nil)
+	require.NoError(t, err)
+
+	office := sa.Schedules().Get("4b4a7b9c-0a7d-4a65-9a0b-7f3b0f1b0d3c")
+	assert.Equal(t, "Office Hours", office.Name())
+	assert.Equal(t, assets.NewScheduleReference("4b4a7b9c-0a7d-4a65-9a0b-7f3b0f1b0d3c", "Office Hours"), office.Reference())
+	assert.Equal(t, office, sa.Schedules().FindByName("office hours"))
+	assert.Nil(t, sa.Schedules().Get("xyz"))
+	assert.Nil(t, sa.Schedules().FindByName("xyz"))
+
+	// schedule without a timezone uses the environment's timezone
+	assert.Equal(t, kgl, office.Location(env))
+
+	assert.True(t, office.IsOpen(env, time.Date(2024, 12, 24, 9, 0, 0, 0, kgl)))
+	assert.True(t, office.IsOpen(env, time.Date(2024, 12, 24, 9, 0, 0, 0, time.UTC))) // 11:00 in Kigali
+	assert.False(t, office.IsOpen(env, time.Date(2024, 12, 24, 12, 30, 0, 0, kgl)))   // lunch
+	assert.False(t, office.IsOpen(env, time.Date(2024, 12, 24, 17, 0, 0, 0, kgl)))    // closing time
+	assert.False(t, office.IsOpen(env, time.Date(2024, 12, 25, 10, 0, 0, 0, kgl)))    // holiday
+	assert.False(t, office.IsOpen(env, time.Date(2024, 12, 28, 10, 0, 0, 0, kgl)))    // weekend
+
+	next := func(s *flows.Schedule, dt time.Time) string {
+		n := s.NextOpen(env, dt)
+		require.NotNil(t, n)
+		return n.Format(time.RFC3339)
+	}
+
+	assert.Equal(t, "2024-12-24T10:00:00+02:00", next(office, time.Date(2024, 12, 24, 10, 0, 0, 0, kgl)))
+	assert.Equal(t, "2024-12-24T13:00:00+02:00", next(office, time.Date(2024, 12, 24, 12, 30, 0, 0, kgl)))
+	assert.Equal(t, "2024-12-26T09:00:00+02:00", next(office, time.Date(2024, 12, 24, 17, 30, 0, 0, kgl)))
+	assert.Equal(t, "2024-12-30T09:00:00+02:00", next(office, time.Date(2024, 12, 27, 18, 0, 0, 0, kgl)))
+
+	// schedule with its own timezone ignores the environment's timezone
+	late := sa.Schedules().FindByName("Late Shift")
+	assert.Equal(t, nyc, late.Location(env))
+
+	assert.True(t, late.IsOpen(env, time.Date(2024, 12, 28, 23, 59, 0, 0, nyc))) // open until end of day
+	assert.False(t, late.IsOpen(env, time.Date(2024, 12, 29, 0, 0, 0, 0, nyc)))
+	assert.Equal(t, "2024-12-28T20:00:00-05:00", next(late, time.Date(2024, 12, 28, 12, 0, 0, 0, kgl)))
+
+	// schedule with an interval which spans midnight
+	night := sa.Schedules().FindByName("Night Shift")
+	assert.False(t, night.IsOpen(env, time.Date(2024, 12, 27, 21, 59, 0, 0, kgl)))
+	assert.True(t, night.IsOpen(env, time.Date(2024, 12, 27, 22, 0, 0, 0, kgl)))
+	assert.True(t, night.IsOpen(env, time.Date(2024, 12, 28, 5, 59, 0, 0, kgl)))  // open until end on the next day
+	assert.False(t, night.IsOpen(env, time.Date(2024, 12, 28, 6, 0, 0, 0, kgl)))  // closing time
+	assert.False(t, night.IsOpen(env, time.Date(2024, 12, 28, 23, 0, 0, 0, kgl))) // only opens on fridays
+	assert.False(t, night.IsOpen(env, time.Date(2024, 12, 29, 1, 0, 0, 0, kgl)))
+	assert.False(t, night.IsOpen(env, time.Date(2024, 12, 21, 1, 0, 0, 0, kgl))) // opened on a holiday
+	assert.Equal(t, "2024-12-27T22:00:00+02:00", next(night, time.Date(2024, 12, 20, 23, 0, 0, 0, kgl)))
+	assert.Equal(t, "2024-12-28T01:00:00+02:00", next(night, time.Date(2024, 12, 28, 1, 0, 0, 0, kgl)))
+	assert.Equal(t, "2025-01-03T22:00:00+02:00", next(night, time.Date(2024, 12, 28, 7, 0, 0, 0, kgl)))
+
+	// schedule which is never open
+	never := sa.Schedules().FindByName("Never")
+	assert.False(t, never.IsOpen(env, time.Date(2024, 12, 24, 10, 0, 0, 0, kgl)))
+	assert.Nil(t, never.NextOpen(env, time.Date(2024, 12, 24, 10, 0, 0, 0, kgl)))
+}
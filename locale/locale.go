@@ -0,0 +1,103 @@
+// Package locale provides access to the gettext catalogs in this directory, which are organized as
+// <locale>/<domain>.po, e.g. es/contactql.po
+package locale
+
+import (
+	"embed"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nyaruka/gocommon/i18n"
+	"github.com/nyaruka/goflow/utils/po"
+)
+
+// SrcLocale is the locale of the source text that catalogs translate from
+const SrcLocale = "en_US"
+
+//go:embed */*.po
+var catalogs embed.FS
+
+var cache sync.Map
+
+// the names of the locales which have catalogs, read once since the catalogs are embedded
+var locales = readLocales()
+
+func readLocales() []string {
+	entries, _ := fs.ReadDir(catalogs, ".")
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
+// Locales returns the names of the locales which have catalogs, e.g. en_US, es, pt_BR
+func Locales() []string {
+	return slices.Clone(locales)
+}
+
+// Match returns the name of the catalog locale to use for the given locale, e.g. pt_BR for por-BR, or the source
+// locale if there is no catalog for its language
+func Match(locale i18n.Locale) string {
+	lang, country := locale.Split()
+	lang2 := lang.ISO639_1()
+	if lang2 == "" {
+		return SrcLocale
+	}
+
+	candidates := []string{lang2 + "_" + string(country), lang2}
+
+	for _, candidate := range candidates {
+		if slices.Contains(locales, candidate) {
+			return candidate
+		}
+	}
+
+	// fallback to a catalog for the same language but a different country
+	for _, name := range locales {
+		if strings.HasPrefix(name, lang2+"_") {
+			return name
+		}
+	}
+
+	return SrcLocale
+}
+
+// Load loads the catalog for the given locale name and domain
+func Load(locale, domain string) (*po.PO, error) {
+	key := locale + "/" + domain
+	if cached, ok := cache.Load(key); ok {
+		return cached.(*po.PO), nil
+	}
+
+	f, err := catalogs.Open(path.Join(locale, domain+".po"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	catalog, err := po.ReadPO(f)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.Store(key, catalog)
+	return catalog, nil
+}
+
+// GetText returns a function which translates text into the given locale using the given domain, falling back to
+// the source text for text which isn't translated or if there is no catalog
+func GetText(locale i18n.Locale, domain string) func(string) string {
+	catalog, err := Load(Match(locale), domain)
+	if err != nil {
+		return func(s string) string { return s }
+	}
+
+	return func(s string) string { return catalog.GetText("", s) }
+}
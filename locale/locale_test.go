@@ -0,0 +1,59 @@
+package locale_test
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/nyaruka/goflow/locale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocales(t *testing.T) {
+	assert.Equal(t, []string{"cs", "en_US", "es", "fr", "mn", "pt_BR", "ru"}, locale.Locales())
+
+	assert.Equal(t, "en_US", locale.Match("eng-US"))
+	assert.Equal(t, "en_US", locale.Match("eng"))
+	assert.Equal(t, "es", locale.Match("spa-EC"))
+	assert.Equal(t, "es", locale.Match("spa"))
+	assert.Equal(t, "pt_BR", locale.Match("por-BR"))
+	assert.Equal(t, "pt_BR", locale.Match("por-PT"))
+	assert.Equal(t, "en_US", locale.Match("kin-RW"))
+	assert.Equal(t, "en_US", locale.Match(""))
+
+	es := locale.GetText("spa-EC", "contactql")
+	assert.Equal(t, "todos los contactos", es("all contacts"))
+	assert.Equal(t, "xyz", es("xyz"))
+
+	// untranslated text and missing domains fall back to the source text
+	assert.Equal(t, "all contacts", locale.GetText("rus", "contactql")("all contacts"))
+	assert.Equal(t, "all contacts", locale.GetText("spa", "xxx")("all contacts"))
+}
+
+func TestCatalogsConsistent(t *testing.T) {
+	for _, domain := range []string{"flows", "contactql"} {
+		src, err := locale.Load(locale.SrcLocale, domain)
+		require.NoError(t, err)
+
+		for _, name := range locale.Locales() {
+			// not every locale has a catalog for every domain
+			catalog, err := locale.Load(name, domain)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			require.NoError(t, err, "error loading %s/%s", name, domain)
+
+			msgIDs := make([]string, len(catalog.Entries))
+			for i, e := range catalog.Entries {
+				msgIDs[i] = e.MsgID
+			}
+			for _, e := range src.Entries {
+				assert.Contains(t, msgIDs, e.MsgID, "missing msgid in %s/%s", name, domain)
+			}
+		}
+	}
+
+	_, err := locale.Load("xx", "contactql")
+	assert.Error(t, err)
+}
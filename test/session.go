@@ -257,9 +257,15 @@ var sessionAssets = `{
                 {
                     "name": "Kigali City",
                     "aliases": ["Kigali", "Kigari"],
+                    "boundary": [
+                        [[29.95, -2.05], [30.25, -2.05], [30.25, -1.85], [29.95, -1.85]]
+                    ],
                     "children": [
                         {
                             "name": "Gasabo",
+                            "boundary": [
+                                [[30.05, -2.0], [30.25, -2.0], [30.25, -1.85], [30.05, -1.85]]
+                            ],
                             "children": [
                                 {
                                     "name": "Gisozi"
@@ -271,6 +277,9 @@ var sessionAssets = `{
                         },
                         {
                             "name": "Nyarugenge",
+                            "boundary": [
+                                [[29.95, -2.0], [30.05, -2.0], [30.05, -1.9], [29.95, -1.9]]
+                            ],
                             "children": []
                         }
                     ]
@@ -286,6 +295,24 @@ var sessionAssets = `{
             ]
         }
     ],
+    "schedules": [
+        {
+            "uuid": "4b4a7b9c-0a7d-4a65-9a0b-7f3b0f1b0d3c",
+            "name": "Office Hours",
+            "intervals": [
+                {"days": ["mon", "tue", "wed", "thu", "fri"], "start": "09:00", "end": "17:00"}
+            ],
+            "holidays": ["2018-12-25"]
+        },
+        {
+            "uuid": "1e5a4a8f-4bd7-4d84-9a2a-1d7c5e3fc3a8",
+            "name": "Weekend Hours",
+            "timezone": "America/Guayaquil",
+            "intervals": [
+                {"days": ["sat", "sun"], "start": "10:00", "end": "14:00"}
+            ]
+        }
+    ],
     "users": [
         {
             "email": "bob@nyaruka.com",
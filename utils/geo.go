@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// the mean radius of the earth in meters
+const earthRadius = 6_371_008.8
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+var geoPointRegex = regexp.MustCompile(`^\s*([-+]?\d+(?:\.\d+)?)\s*,\s*([-+]?\d+(?:\.\d+)?)\s*$`)
+
+// GeoPoint is a point on the earth's surface described by a latitude and longitude in degrees
+type GeoPoint struct {
+	Lat float64
+	Lng float64
+}
+
+// ParseGeoPoint parses a point from text like 1.234,-5.678 or a geo attachment like geo:1.234,-5.678
+func ParseGeoPoint(s string) (GeoPoint, error) {
+	contentType, url := Attachment(strings.TrimSpace(s)).ToParts()
+	if contentType != "" && contentType != "geo" {
+		return GeoPoint{}, fmt.Errorf("%s is not a geo attachment", s)
+	}
+
+	m := geoPointRegex.FindStringSubmatch(url)
+	if m == nil {
+		return GeoPoint{}, fmt.Errorf("%s is not a valid geo point", s)
+	}
+
+	lat, _ := strconv.ParseFloat(m[1], 64)
+	lng, _ := strconv.ParseFloat(m[2], 64)
+	p := GeoPoint{Lat: lat, Lng: lng}
+
+	if !p.IsValid() {
+		return GeoPoint{}, fmt.Errorf("%s is not a valid geo point", s)
+	}
+	return p, nil
+}
+
+// IsValid returns whether the latitude and longitude of this point are within range
+func (p GeoPoint) IsValid() bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lng >= -180 && p.Lng <= 180
+}
+
+// DistanceTo returns the great-circle distance in meters between this point and another
+func (p GeoPoint) DistanceTo(other GeoPoint) float64 {
+	lat1, lat2 := toRadians(p.Lat), toRadians(other.Lat)
+	dLat, dLng := lat2-lat1, toRadians(other.Lng-p.Lng)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+// Geohash encodes this point as a geohash with the given number of characters
+func (p GeoPoint) Geohash(precision int) string {
+	latRange, lngRange := [2]float64{-90, 90}, [2]float64{-180, 180}
+
+	var sb strings.Builder
+	bit, ch, even := 0, 0, true
+
+	for sb.Len() < precision {
+		// bits alternate between longitude and latitude, starting with longitude
+		rng, value := &latRange, p.Lat
+		if even {
+			rng, value = &lngRange, p.Lng
+		}
+
+		mid := (rng[0] + rng[1]) / 2
+		if value >= mid {
+			ch |= 1 << (4 - bit)
+			rng[0] = mid
+		} else {
+			rng[1] = mid
+		}
+		even = !even
+
+		if bit < 4 {
+			bit++
+		} else {
+			sb.WriteByte(geohashAlphabet[ch])
+			bit, ch = 0, 0
+		}
+	}
+
+	return sb.String()
+}
+
+// String returns the text representation of this point
+func (p GeoPoint) String() string {
+	return strconv.FormatFloat(p.Lat, 'f', -1, 64) + "," + strconv.FormatFloat(p.Lng, 'f', -1, 64)
+}
+
+// GeoPolygon is a closed area described by the points of its boundary
+type GeoPolygon []GeoPoint
+
+// Contains returns whether the given point is inside this polygon
+func (g GeoPolygon) Contains(p GeoPoint) bool {
+	inside := false
+
+	// count how many edges a ray cast east from the point crosses
+	for i, j := 0, len(g)-1; i < len(g); j, i = i, i+1 {
+		a, b := g[i], g[j]
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) && p.Lng < (b.Lng-a.Lng)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
+
+func toRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
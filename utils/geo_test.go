@@ -0,0 +1,65 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/goflow/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGeoPoint(t *testing.T) {
+	tcs := []struct {
+		input    string
+		expected utils.GeoPoint
+		err      string
+	}{
+		{"1.5,-2.25", utils.GeoPoint{Lat: 1.5, Lng: -2.25}, ""},
+		{" -1.95 , 30.06 ", utils.GeoPoint{Lat: -1.95, Lng: 30.06}, ""},
+		{"geo:-1.95,30.06", utils.GeoPoint{Lat: -1.95, Lng: 30.06}, ""},
+		{"GEO:+45,90", utils.GeoPoint{Lat: 45, Lng: 90}, ""},
+		{"image/jpeg:http://example.com/test.jpg", utils.GeoPoint{}, "image/jpeg:http://example.com/test.jpg is not a geo attachment"},
+		{"geo:95,30", utils.GeoPoint{}, "geo:95,30 is not a valid geo point"},
+		{"45,181", utils.GeoPoint{}, "45,181 is not a valid geo point"},
+		{"geo:", utils.GeoPoint{}, "geo: is not a valid geo point"},
+		{"Kigali", utils.GeoPoint{}, "Kigali is not a valid geo point"},
+	}
+
+	for _, tc := range tcs {
+		actual, err := utils.ParseGeoPoint(tc.input)
+		if tc.err != "" {
+			assert.EqualError(t, err, tc.err, "error mismatch for input %s", tc.input)
+		} else {
+			assert.NoError(t, err, "unexpected error for input %s", tc.input)
+			assert.Equal(t, tc.expected, actual, "point mismatch for input %s", tc.input)
+		}
+	}
+}
+
+func TestGeoPoint(t *testing.T) {
+	london := utils.GeoPoint{Lat: 51.5074, Lng: -0.1278}
+	paris := utils.GeoPoint{Lat: 48.8566, Lng: 2.3522}
+
+	assert.Equal(t, "51.5074,-0.1278", london.String())
+	assert.InDelta(t, 343_556, london.DistanceTo(paris), 100)
+	assert.InDelta(t, 343_556, paris.DistanceTo(london), 100)
+	assert.Equal(t, 0.0, london.DistanceTo(london))
+
+	assert.Equal(t, "u4pruydqqvj", utils.GeoPoint{Lat: 57.64911, Lng: 10.40744}.Geohash(11))
+	assert.Equal(t, "gcpvj", london.Geohash(5))
+	assert.Equal(t, "", london.Geohash(0))
+}
+
+func TestGeoPolygon(t *testing.T) {
+	square := utils.GeoPolygon{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 10}, {Lat: 10, Lng: 10}, {Lat: 10, Lng: 0}}
+	assert.True(t, square.Contains(utils.GeoPoint{Lat: 5, Lng: 5}))
+	assert.False(t, square.Contains(utils.GeoPoint{Lat: 15, Lng: 5}))
+	assert.False(t, square.Contains(utils.GeoPoint{Lat: 5, Lng: -5}))
+
+	// an L shape to check concave polygons
+	ell := utils.GeoPolygon{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 10}, {Lat: 5, Lng: 10}, {Lat: 5, Lng: 5}, {Lat: 10, Lng: 5}, {Lat: 10, Lng: 0}}
+	assert.True(t, ell.Contains(utils.GeoPoint{Lat: 2, Lng: 8}))
+	assert.True(t, ell.Contains(utils.GeoPoint{Lat: 8, Lng: 2}))
+	assert.False(t, ell.Contains(utils.GeoPoint{Lat: 8, Lng: 8}))
+
+	assert.False(t, utils.GeoPolygon{}.Contains(utils.GeoPoint{Lat: 0, Lng: 0}))
+}
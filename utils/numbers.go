@@ -0,0 +1,368 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nyaruka/gocommon/i18n"
+)
+
+// the largest number we can spell out
+const maxSpellable = 999_999_999_999_999
+
+type numberSpeller func(n int64) string
+
+var numberSpellers = map[i18n.Language]numberSpeller{
+	"eng": spellEnglish,
+	"spa": spellSpanish,
+	"fra": spellFrench,
+	"por": spellPortuguese,
+}
+
+// SpellNumber spells out the given whole number in words in the given language
+func SpellNumber(n int64, lang i18n.Language) (string, error) {
+	if lang == i18n.NilLanguage {
+		lang = "eng"
+	}
+
+	speller := numberSpellers[lang]
+	if speller == nil {
+		return "", fmt.Errorf("spelling numbers not supported for language '%s'", lang)
+	}
+
+	if n < -maxSpellable || n > maxSpellable {
+		return "", fmt.Errorf("number %d is too large to spell", n)
+	}
+
+	if n < 0 {
+		minus := map[i18n.Language]string{"eng": "minus", "spa": "menos", "fra": "moins", "por": "menos"}[lang]
+		return minus + " " + speller(-n), nil
+	}
+	return speller(n), nil
+}
+
+// FormatOrdinal formats the given whole number as an ordinal, e.g. 1st, in the given language
+func FormatOrdinal(n int64, lang i18n.Language) (string, error) {
+	num := strconv.FormatInt(n, 10)
+
+	switch lang {
+	case "eng", i18n.NilLanguage:
+		abs := n
+		if abs < 0 {
+			abs = -abs
+		}
+		if abs%100 >= 11 && abs%100 <= 13 {
+			return num + "th", nil
+		}
+		switch abs % 10 {
+		case 1:
+			return num + "st", nil
+		case 2:
+			return num + "nd", nil
+		case 3:
+			return num + "rd", nil
+		}
+		return num + "th", nil
+	case "spa":
+		return num + ".º", nil
+	case "fra":
+		if n == 1 {
+			return num + "er", nil
+		}
+		return num + "e", nil
+	case "por":
+		return num + "º", nil
+	}
+
+	return "", fmt.Errorf("formatting ordinals not supported for language '%s'", lang)
+}
+
+// splits a number into its scale groups, e.g. 1234567 -> [1, 234, 567]
+func numberGroups(n int64) []int {
+	groups := make([]int, 0, 5)
+	for n > 0 {
+		groups = append([]int{int(n % 1000)}, groups...)
+		n /= 1000
+	}
+	return groups
+}
+
+//------------------------------------------------------------------------------------------
+// English
+//------------------------------------------------------------------------------------------
+
+var engOnes = []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+var engTens = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+var engScales = []string{"", "thousand", "million", "billion", "trillion"}
+
+func spellEnglish(n int64) string {
+	if n == 0 {
+		return engOnes[0]
+	}
+
+	groups := numberGroups(n)
+	words := make([]string, 0, len(groups)*2)
+
+	for i, g := range groups {
+		if g == 0 {
+			continue
+		}
+		words = append(words, spellEnglishBelow1000(g))
+		if scale := engScales[len(groups)-1-i]; scale != "" {
+			words = append(words, scale)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func spellEnglishBelow1000(n int) string {
+	words := make([]string, 0, 3)
+	if n >= 100 {
+		words = append(words, engOnes[n/100], "hundred")
+		n %= 100
+	}
+	if n >= 20 {
+		tens := engTens[n/10]
+		if n%10 != 0 {
+			tens += "-" + engOnes[n%10]
+		}
+		words = append(words, tens)
+	} else if n > 0 {
+		words = append(words, engOnes[n])
+	}
+	return strings.Join(words, " ")
+}
+
+//------------------------------------------------------------------------------------------
+// Spanish
+//------------------------------------------------------------------------------------------
+
+var spaOnes = []string{"cero", "uno", "dos", "tres", "cuatro", "cinco", "seis", "siete", "ocho", "nueve", "diez", "once", "doce", "trece", "catorce", "quince", "dieciséis", "diecisiete", "dieciocho", "diecinueve",
+	"veinte", "veintiuno", "veintidós", "veintitrés", "veinticuatro", "veinticinco", "veintiséis", "veintisiete", "veintiocho", "veintinueve"}
+var spaTens = []string{"", "", "", "treinta", "cuarenta", "cincuenta", "sesenta", "setenta", "ochenta", "noventa"}
+var spaHundreds = []string{"", "ciento", "doscientos", "trescientos", "cuatrocientos", "quinientos", "seiscientos", "setecientos", "ochocientos", "novecientos"}
+
+func spellSpanish(n int64) string {
+	if n == 0 {
+		return spaOnes[0]
+	}
+
+	// in Spanish, a billón is a million millions, so large numbers are built from millions
+	words := make([]string, 0, 6)
+	scales := []struct {
+		size             int64
+		singular, plural string
+	}{
+		{1_000_000_000_000, "un billón", "billones"},
+		{1_000_000, "un millón", "millones"},
+	}
+
+	for _, scale := range scales {
+		if count := n / scale.size; count > 0 {
+			if count == 1 {
+				words = append(words, scale.singular)
+			} else {
+				words = append(words, spanishApocope(spellSpanishBelowMillion(int(count))), scale.plural)
+			}
+			n %= scale.size
+		}
+	}
+	if n > 0 {
+		words = append(words, spellSpanishBelowMillion(int(n)))
+	}
+	return strings.Join(words, " ")
+}
+
+func spellSpanishBelowMillion(n int) string {
+	words := make([]string, 0, 3)
+	if thousands := n / 1000; thousands > 0 {
+		if thousands > 1 {
+			words = append(words, spanishApocope(spellSpanishBelow1000(thousands)))
+		}
+		words = append(words, "mil")
+		n %= 1000
+	}
+	if n > 0 {
+		words = append(words, spellSpanishBelow1000(n))
+	}
+	return strings.Join(words, " ")
+}
+
+func spellSpanishBelow1000(n int) string {
+	if n == 100 {
+		return "cien"
+	}
+
+	words := make([]string, 0, 4)
+	if n >= 100 {
+		words = append(words, spaHundreds[n/100])
+		n %= 100
+	}
+	if n >= 30 {
+		words = append(words, spaTens[n/10])
+		if n%10 != 0 {
+			words = append(words, "y", spaOnes[n%10])
+		}
+	} else if n > 0 {
+		words = append(words, spaOnes[n])
+	}
+	return strings.Join(words, " ")
+}
+
+// shortens a trailing uno to un as it precedes a noun like mil or millones
+func spanishApocope(s string) string {
+	if strings.HasSuffix(s, "veintiuno") {
+		return strings.TrimSuffix(s, "veintiuno") + "veintiún"
+	}
+	if strings.HasSuffix(s, "uno") {
+		return strings.TrimSuffix(s, "uno") + "un"
+	}
+	return s
+}
+
+//------------------------------------------------------------------------------------------
+// French
+//------------------------------------------------------------------------------------------
+
+var fraOnes = []string{"zéro", "un", "deux", "trois", "quatre", "cinq", "six", "sept", "huit", "neuf", "dix", "onze", "douze", "treize", "quatorze", "quinze", "seize", "dix-sept", "dix-huit", "dix-neuf"}
+var fraTens = []string{"", "", "vingt", "trente", "quarante", "cinquante", "soixante", "soixante", "quatre-vingt", "quatre-vingt"}
+var fraScales = []struct{ singular, plural string }{{"", ""}, {"mille", "mille"}, {"un million", "millions"}, {"un milliard", "milliards"}, {"un billion", "billions"}}
+
+func spellFrench(n int64) string {
+	if n == 0 {
+		return fraOnes[0]
+	}
+
+	groups := numberGroups(n)
+	words := make([]string, 0, len(groups)*2)
+
+	for i, g := range groups {
+		if g == 0 {
+			continue
+		}
+
+		scale := len(groups) - 1 - i
+		if g == 1 && scale > 0 {
+			words = append(words, fraScales[scale].singular)
+			continue
+		}
+
+		// cents and vingts only take an s when nothing follows them, and mille counts as following
+		words = append(words, spellFrenchBelow1000(g, scale != 1))
+		if scale > 0 {
+			words = append(words, fraScales[scale].plural)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func spellFrenchBelow1000(n int, final bool) string {
+	words := make([]string, 0, 3)
+	if hundreds := n / 100; hundreds > 0 {
+		if hundreds > 1 {
+			words = append(words, fraOnes[hundreds])
+		}
+		if n%100 == 0 && hundreds > 1 && final {
+			words = append(words, "cents")
+		} else {
+			words = append(words, "cent")
+		}
+		n %= 100
+	}
+	if n > 0 {
+		words = append(words, spellFrenchBelow100(n, final))
+	}
+	return strings.Join(words, " ")
+}
+
+func spellFrenchBelow100(n int, final bool) string {
+	if n < 20 {
+		return fraOnes[n]
+	}
+
+	tens, ones := n/10, n%10
+
+	// 70-79 and 90-99 are built on 60 and 80 plus 10-19
+	if tens == 7 || tens == 9 {
+		ones += 10
+	}
+
+	if ones == 0 {
+		if tens == 8 && final {
+			return "quatre-vingts"
+		}
+		return fraTens[tens]
+	}
+	if (ones == 1 || ones == 11) && tens != 8 && tens != 9 {
+		return fraTens[tens] + " et " + fraOnes[ones]
+	}
+	return fraTens[tens] + "-" + fraOnes[ones]
+}
+
+//------------------------------------------------------------------------------------------
+// Portuguese
+//------------------------------------------------------------------------------------------
+
+var porOnes = []string{"zero", "um", "dois", "três", "quatro", "cinco", "seis", "sete", "oito", "nove", "dez", "onze", "doze", "treze", "catorze", "quinze", "dezesseis", "dezessete", "dezoito", "dezenove"}
+var porTens = []string{"", "", "vinte", "trinta", "quarenta", "cinquenta", "sessenta", "setenta", "oitenta", "noventa"}
+var porHundreds = []string{"", "cento", "duzentos", "trezentos", "quatrocentos", "quinhentos", "seiscentos", "setecentos", "oitocentos", "novecentos"}
+var porScales = []struct{ singular, plural string }{{"", ""}, {"mil", "mil"}, {"um milhão", "milhões"}, {"um bilhão", "bilhões"}, {"um trilhão", "trilhões"}}
+
+func spellPortuguese(n int64) string {
+	if n == 0 {
+		return porOnes[0]
+	}
+
+	groups := numberGroups(n)
+	words := make([]string, 0, len(groups)*2)
+
+	for i, g := range groups {
+		if g == 0 {
+			continue
+		}
+
+		// the last group is joined with e if it's less than 100 or a round number of hundreds
+		if i == len(groups)-1 && len(words) > 0 && (g < 100 || g%100 == 0) {
+			words = append(words, "e")
+		}
+
+		scale := len(groups) - 1 - i
+		if g == 1 && scale > 0 {
+			words = append(words, porScales[scale].singular)
+			continue
+		}
+
+		words = append(words, spellPortugueseBelow1000(g))
+		if scale > 0 {
+			words = append(words, porScales[scale].plural)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func spellPortugueseBelow1000(n int) string {
+	if n == 100 {
+		return "cem"
+	}
+
+	words := make([]string, 0, 5)
+	if n >= 100 {
+		words = append(words, porHundreds[n/100])
+		n %= 100
+	}
+	if n > 0 {
+		if len(words) > 0 {
+			words = append(words, "e")
+		}
+		if n < 20 {
+			words = append(words, porOnes[n])
+		} else {
+			words = append(words, porTens[n/10])
+			if n%10 != 0 {
+				words = append(words, "e", porOnes[n%10])
+			}
+		}
+	}
+	return strings.Join(words, " ")
+}
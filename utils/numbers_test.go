@@ -0,0 +1,118 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/gocommon/i18n"
+	"github.com/nyaruka/goflow/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpellNumber(t *testing.T) {
+	tcs := []struct {
+		num      int64
+		lang     i18n.Language
+		expected string
+	}{
+		{0, "eng", "zero"},
+		{7, "eng", "seven"},
+		{15, "eng", "fifteen"},
+		{42, "eng", "forty-two"},
+		{100, "eng", "one hundred"},
+		{123, "eng", "one hundred twenty-three"},
+		{1001, "eng", "one thousand one"},
+		{2500000, "eng", "two million five hundred thousand"},
+		{1000000000000, "eng", "one trillion"},
+		{-12, "eng", "minus twelve"},
+		{42, i18n.NilLanguage, "forty-two"},
+
+		{0, "spa", "cero"},
+		{16, "spa", "dieciséis"},
+		{21, "spa", "veintiuno"},
+		{31, "spa", "treinta y uno"},
+		{100, "spa", "cien"},
+		{101, "spa", "ciento uno"},
+		{555, "spa", "quinientos cincuenta y cinco"},
+		{1000, "spa", "mil"},
+		{21000, "spa", "veintiún mil"},
+		{31000, "spa", "treinta y un mil"},
+		{100000, "spa", "cien mil"},
+		{1000000, "spa", "un millón"},
+		{21000000, "spa", "veintiún millones"},
+		{1000000000, "spa", "mil millones"},
+		{2000000000000, "spa", "dos billones"},
+		{-5, "spa", "menos cinco"},
+
+		{0, "fra", "zéro"},
+		{17, "fra", "dix-sept"},
+		{21, "fra", "vingt et un"},
+		{71, "fra", "soixante et onze"},
+		{75, "fra", "soixante-quinze"},
+		{80, "fra", "quatre-vingts"},
+		{81, "fra", "quatre-vingt-un"},
+		{91, "fra", "quatre-vingt-onze"},
+		{200, "fra", "deux cents"},
+		{201, "fra", "deux cent un"},
+		{1000, "fra", "mille"},
+		{80000, "fra", "quatre-vingt mille"},
+		{200000, "fra", "deux cent mille"},
+		{1000000, "fra", "un million"},
+		{200000000, "fra", "deux cents millions"},
+
+		{0, "por", "zero"},
+		{16, "por", "dezesseis"},
+		{21, "por", "vinte e um"},
+		{100, "por", "cem"},
+		{123, "por", "cento e vinte e três"},
+		{1001, "por", "mil e um"},
+		{1100, "por", "mil e cem"},
+		{1123, "por", "mil cento e vinte e três"},
+		{2000000, "por", "dois milhões"},
+		{1000000, "por", "um milhão"},
+	}
+
+	for _, tc := range tcs {
+		actual, err := utils.SpellNumber(tc.num, tc.lang)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, actual, "spelling mismatch for %d in %s", tc.num, tc.lang)
+	}
+
+	_, err := utils.SpellNumber(12, "kin")
+	assert.EqualError(t, err, "spelling numbers not supported for language 'kin'")
+
+	_, err = utils.SpellNumber(1_000_000_000_000_000, "eng")
+	assert.EqualError(t, err, "number 1000000000000000 is too large to spell")
+}
+
+func TestFormatOrdinal(t *testing.T) {
+	tcs := []struct {
+		num      int64
+		lang     i18n.Language
+		expected string
+	}{
+		{1, "eng", "1st"},
+		{2, "eng", "2nd"},
+		{3, "eng", "3rd"},
+		{4, "eng", "4th"},
+		{11, "eng", "11th"},
+		{12, "eng", "12th"},
+		{13, "eng", "13th"},
+		{21, "eng", "21st"},
+		{102, "eng", "102nd"},
+		{111, "eng", "111th"},
+		{3, i18n.NilLanguage, "3rd"},
+		{1, "spa", "1.º"},
+		{1, "fra", "1er"},
+		{2, "fra", "2e"},
+		{3, "por", "3º"},
+	}
+
+	for _, tc := range tcs {
+		actual, err := utils.FormatOrdinal(tc.num, tc.lang)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, actual, "ordinal mismatch for %d in %s", tc.num, tc.lang)
+	}
+
+	_, err := utils.FormatOrdinal(12, "kin")
+	assert.EqualError(t, err, "formatting ordinals not supported for language 'kin'")
+}
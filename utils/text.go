@@ -3,8 +3,12 @@ package utils
 import (
 	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/blevesearch/segment"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 )
 
 var snakedChars = regexp.MustCompile(`[^\p{L}\d_]+`)
@@ -96,3 +100,27 @@ func Indent(s string, prefix string) string {
 	}
 	return output.String()
 }
+
+// Latin letters which don't decompose into an ASCII letter plus combining marks
+var transliterations = map[rune]string{
+	'ß': "ss", 'Æ': "AE", 'æ': "ae", 'Ø': "O", 'ø': "o", 'Œ': "OE", 'œ': "oe", 'Đ': "D", 'đ': "d",
+	'Ł': "L", 'ł': "l", 'Þ': "TH", 'þ': "th", 'Ð': "D", 'ð': "d", 'ı': "i", 'Ħ': "H", 'ħ': "h",
+}
+
+var removeMarks = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+// Transliterate removes accents from the given text and replaces other Latin letters with their closest ASCII
+// equivalents. Characters which have no ASCII equivalent are left unchanged.
+func Transliterate(s string) string {
+	stripped, _, _ := transform.String(removeMarks, s)
+
+	var sb strings.Builder
+	for _, r := range stripped {
+		if repl, found := transliterations[r]; found {
+			sb.WriteString(repl)
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
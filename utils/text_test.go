@@ -148,3 +148,10 @@ func TestIndent(t *testing.T) {
 	assert.Equal(t, "  x\n\n  y", utils.Indent("x\n\ny", "  "))
 	assert.Equal(t, ">>>x", utils.Indent("x", ">>>"))
 }
+
+func TestTransliterate(t *testing.T) {
+	assert.Equal(t, "", utils.Transliterate(""))
+	assert.Equal(t, "Creme Brulee", utils.Transliterate("Crème Brûlée"))
+	assert.Equal(t, "Strasse AEro", utils.Transliterate("Straße Ærø"))
+	assert.Equal(t, "Привет 😀", utils.Transliterate("Привет 😀"))
+}